@@ -0,0 +1,386 @@
+// antha/AnthaStandardLibrary/Packages/calibration/calibration.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+// Package calibration fits the results of liquid handling calibration runs,
+// in which a design of policy variants is used to dispense known volumes
+// which are then weighed or measured with a dye, and turns them into
+// policy rules choosing the best variant for each volume range
+package calibration
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/doe"
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/platereader/dataset"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+// Design is a set of policy variants under test along with the runs of the
+// design they were made from
+type Design struct {
+	Names    []string
+	Policies []wtype.LHPolicy
+	Runs     []doe.Run
+}
+
+// NewDesign makes the policy variants for a set of design runs in the same
+// way as liquidhandling.PolicyMakerfromRuns, so names are prepend followed
+// by the run number
+func NewDesign(basepolicy string, runs []doe.Run, prepend string) Design {
+	policies, names := liquidhandling.PolicyMakerfromRuns(basepolicy, runs, prepend, false)
+
+	return Design{
+		Names:    names,
+		Policies: policies,
+		Runs:     runs,
+	}
+}
+
+// DesignFromFile reads a DX or JMP design file from the antha path as
+// liquidhandling.PolicyMakerfromDesign does
+func DesignFromFile(basepolicy, dxorjmp, filename, prepend string) (Design, error) {
+	policies, names, runs, err := liquidhandling.PolicyMakerfromDesign(basepolicy, dxorjmp, filename, prepend)
+
+	if err != nil {
+		return Design{}, err
+	}
+
+	return Design{
+		Names:    names,
+		Policies: policies,
+		Runs:     runs,
+	}, nil
+}
+
+func (d Design) index(name string) int {
+	for i, n := range d.Names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// factors returns just those settings of the named variant which the design
+// varies, so rules made from it don't override anything else
+func (d Design) factors(name string) (wtype.LHPolicy, error) {
+	i := d.index(name)
+
+	if i < 0 || i >= len(d.Policies) {
+		return nil, fmt.Errorf("policy %s is not part of the design", name)
+	}
+
+	policy := d.Policies[i]
+
+	if i >= len(d.Runs) {
+		return wtype.DupLHPolicy(policy), nil
+	}
+
+	ret := make(wtype.LHPolicy, len(d.Runs[i].Factordescriptors))
+
+	for _, desc := range d.Runs[i].Factordescriptors {
+		if v, ok := policy[desc]; ok {
+			ret[desc] = v
+		}
+	}
+
+	return ret, nil
+}
+
+// Transfer is a single dispense made during a calibration run
+type Transfer struct {
+	Policy string       // name of the policy variant used
+	Well   string       // well dispensed into
+	Volume wunit.Volume // volume requested
+}
+
+// Gravimetric converts the mass of liquid in g dispensed into each well to
+// a volume given its density in g/ml
+func Gravimetric(masses map[string]float64, density float64) (map[string]wunit.Volume, error) {
+	if density <= 0.0 {
+		return nil, fmt.Errorf("density must be positive, got %f", density)
+	}
+
+	ret := make(map[string]wunit.Volume, len(masses))
+
+	for well, m := range masses {
+		ret[well] = wunit.NewVolume(m/density, "ml")
+	}
+
+	return ret, nil
+}
+
+// Absorbance works out the volume of dye dispensed into each of wells from
+// plate reader data, for instance as parsed from MARS or SpectraMax output.
+// Readings are blank corrected and converted to volumes using a line through
+// the origin fitted to the standards, wells known to hold the volumes given
+func Absorbance(data dataset.AbsorbanceData, wells []string, standards map[string]wunit.Volume, blanks []string, wavelength int, readingtype string) (map[string]wunit.Volume, error) {
+	if len(standards) == 0 {
+		return nil, fmt.Errorf("at least one standard is needed to convert absorbance to volume")
+	}
+
+	// least squares slope through the origin
+
+	sxy, sxx := 0.0, 0.0
+
+	for well, v := range standards {
+		a, err := data.BlankCorrect([]string{well}, blanks, wavelength, readingtype)
+
+		if err != nil {
+			return nil, err
+		}
+
+		x := v.ConvertToString("ul")
+		sxy += x * a
+		sxx += x * x
+	}
+
+	if sxx == 0.0 || sxy == 0.0 {
+		return nil, fmt.Errorf("cannot fit standards: no absorbance or volume in standard wells")
+	}
+
+	slope := sxy / sxx
+
+	ret := make(map[string]wunit.Volume, len(wells))
+
+	for _, well := range wells {
+		a, err := data.BlankCorrect([]string{well}, blanks, wavelength, readingtype)
+
+		if err != nil {
+			return nil, err
+		}
+
+		ret[well] = wunit.NewVolume(a/slope, "ul")
+	}
+
+	return ret, nil
+}
+
+// VolumeRange is a range of transfer volumes in ul. In fitting, a volume on
+// the boundary between adjacent ranges belongs to the upper one so the upper
+// bound is exclusive, except for the last range given which includes it
+type VolumeRange struct {
+	Lower float64
+	Upper float64
+}
+
+func (vr VolumeRange) contains(v float64, last bool) bool {
+	if last {
+		return v >= vr.Lower && v <= vr.Upper
+	}
+	return v >= vr.Lower && v < vr.Upper
+}
+
+func (vr VolumeRange) String() string {
+	return fmt.Sprintf("%g-%gul", vr.Lower, vr.Upper)
+}
+
+// Fit summarises how well one policy variant performed over a volume range.
+// Accuracy is the mean error of measured against requested volume and CV
+// the coefficient of variation of the ratio between them, both in percent
+type Fit struct {
+	Policy   string
+	Range    VolumeRange
+	N        int
+	Accuracy float64
+	CV       float64
+}
+
+// Score is what is minimised in choosing between policies: the combined
+// systematic and random error
+func (f Fit) Score() float64 {
+	return math.Hypot(f.Accuracy, f.CV)
+}
+
+// FitTransfers works out the accuracy and CV of each policy variant in each
+// volume range from the volumes measured in each well. Transfers whose
+// wells weren't measured are ignored, as are volume ranges with fewer than
+// two transfers for a policy since no CV can be estimated
+func FitTransfers(transfers []Transfer, measured map[string]wunit.Volume, ranges []VolumeRange) []Fit {
+	type key struct {
+		Policy string
+		Range  int
+	}
+
+	ratios := make(map[key][]float64)
+
+	for _, t := range transfers {
+		m, ok := measured[t.Well]
+
+		if !ok {
+			continue
+		}
+
+		v := t.Volume.ConvertToString("ul")
+
+		if v <= 0.0 {
+			continue
+		}
+
+		for i, r := range ranges {
+			if r.contains(v, i == len(ranges)-1) {
+				k := key{Policy: t.Policy, Range: i}
+				ratios[k] = append(ratios[k], m.ConvertToString("ul")/v)
+				break
+			}
+		}
+	}
+
+	fits := make([]Fit, 0, len(ratios))
+
+	for k, rs := range ratios {
+		if len(rs) < 2 {
+			continue
+		}
+
+		mean, sd := meanAndSD(rs)
+
+		fits = append(fits, Fit{
+			Policy:   k.Policy,
+			Range:    ranges[k.Range],
+			N:        len(rs),
+			Accuracy: 100.0 * (mean - 1.0),
+			CV:       100.0 * sd / mean,
+		})
+	}
+
+	sort.Slice(fits, func(i, j int) bool {
+		if fits[i].Range.Lower != fits[j].Range.Lower {
+			return fits[i].Range.Lower < fits[j].Range.Lower
+		}
+		return fits[i].Policy < fits[j].Policy
+	})
+
+	return fits
+}
+
+func meanAndSD(xs []float64) (float64, float64) {
+	mean := 0.0
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+
+	ss := 0.0
+	for _, x := range xs {
+		ss += (x - mean) * (x - mean)
+	}
+
+	return mean, math.Sqrt(ss / float64(len(xs)-1))
+}
+
+// Best returns the best fitting policy for each volume range in the order
+// the ranges are given, leaving out ranges with no fits
+func Best(fits []Fit, ranges []VolumeRange) []Fit {
+	ret := make([]Fit, 0, len(ranges))
+
+	for _, r := range ranges {
+		var best *Fit
+		for i := range fits {
+			f := &fits[i]
+			if f.Range != r {
+				continue
+			}
+			if best == nil || f.Score() < best.Score() {
+				best = f
+			}
+		}
+
+		if best != nil {
+			ret = append(ret, *best)
+		}
+	}
+
+	return ret
+}
+
+// Calibrate fits the measurements made from a calibration run and returns
+// a rule set which uses the best performing variant in the design for each
+// volume range when handling liquidclass, along with the fits it was
+// chosen from. Each rule has a category condition on LIQUIDCLASS and a
+// numeric condition on VOLUME
+func Calibrate(liquidclass string, design Design, transfers []Transfer, measured map[string]wunit.Volume, ranges []VolumeRange) (*wtype.LHPolicyRuleSet, []Fit, error) {
+	if len(ranges) == 0 {
+		return nil, nil, fmt.Errorf("no volume ranges to calibrate %s over", liquidclass)
+	}
+
+	fits := FitTransfers(transfers, measured, ranges)
+	last := ranges[len(ranges)-1]
+
+	rs := wtype.NewLHPolicyRuleSet()
+
+	for _, best := range Best(fits, ranges) {
+		policy, err := design.factors(best.Policy)
+
+		if err != nil {
+			return nil, fits, err
+		}
+
+		policy["DESCRIPTION"] = fmt.Sprintf("%s calibrated for %s: %s accuracy %.2f%% CV %.2f%% (n=%d)", liquidclass, best.Range, best.Policy, best.Accuracy, best.CV, best.N)
+
+		rule := wtype.NewLHPolicyRule(fmt.Sprintf("%s_%s", liquidclass, best.Range))
+
+		if err := rule.AddCategoryConditionOn("LIQUIDCLASS", liquidclass); err != nil {
+			return nil, fits, err
+		}
+
+		// numeric conditions include both bounds, so stop short of the
+		// upper bound of all but the last range: a volume on a boundary
+		// was fitted in the range above it and must only match that rule
+
+		upper := best.Range.Upper
+		if best.Range != last {
+			upper = math.Nextafter(upper, best.Range.Lower)
+		}
+
+		if err := rule.AddNumericConditionOn("VOLUME", best.Range.Lower, upper); err != nil {
+			return nil, fits, err
+		}
+
+		rs.AddRule(rule, policy)
+	}
+
+	if len(rs.Rules) == 0 {
+		return nil, fits, fmt.Errorf("not enough measurements to calibrate %s", liquidclass)
+	}
+
+	return rs, fits, nil
+}
+
+// WritePolicies writes a rule set in the JSON format read by
+// liquidhandling.LoadLHPoliciesFromFile
+func WritePolicies(w io.Writer, rs *wtype.LHPolicyRuleSet) error {
+	b, err := json.MarshalIndent(rs, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	return err
+}
@@ -0,0 +1,272 @@
+package calibration
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/doe"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+// absorbance proportional to volume, 0.1 per ul over a blank of 0.05
+type testReader map[string]float64
+
+func (r testReader) BlankCorrect(wellnames []string, blanknames []string, wavelength int, readingtypekeyword string) (float64, error) {
+	sum, blank := 0.0, 0.0
+	for _, w := range wellnames {
+		sum += r[w]
+	}
+	for _, w := range blanknames {
+		blank += r[w]
+	}
+	return sum/float64(len(wellnames)) - blank/float64(len(blanknames)), nil
+}
+
+func (r testReader) AbsorbanceReading(wellname string, wavelength int, readingtypekeyword string) (float64, error) {
+	return r[wellname], nil
+}
+
+func (r testReader) FindOptimalWavelength(wellname string, blankname string, readingtypekeyword string) (int, error) {
+	return 600, nil
+}
+
+func makeDesign() Design {
+	runs := []doe.Run{
+		{RunNumber: 1, Factordescriptors: []string{"ASPSPEED"}, Setpoints: []interface{}{1.0}},
+		{RunNumber: 2, Factordescriptors: []string{"ASPSPEED"}, Setpoints: []interface{}{3.0}},
+	}
+
+	return NewDesign("default", runs, "cal")
+}
+
+// run 1 is accurate at low volume, run 2 at high volume
+func makeResults() ([]Transfer, map[string]wunit.Volume) {
+	transfers := make([]Transfer, 0, 12)
+	measured := make(map[string]wunit.Volume, 12)
+
+	add := func(policy string, vol float64, got ...float64) {
+		for _, g := range got {
+			well := fmt.Sprintf("A%d", len(transfers)+1)
+			transfers = append(transfers, Transfer{Policy: policy, Well: well, Volume: wunit.NewVolume(vol, "ul")})
+			measured[well] = wunit.NewVolume(g, "ul")
+		}
+	}
+
+	add("cal1", 2.0, 2.0, 2.02, 1.98)
+	add("cal2", 2.0, 1.5, 1.7, 1.6)
+	add("cal1", 50.0, 45.0, 46.0, 44.0)
+	add("cal2", 50.0, 50.0, 50.5, 49.5)
+
+	return transfers, measured
+}
+
+func TestCalibrate(t *testing.T) {
+	design := makeDesign()
+	transfers, measured := makeResults()
+	ranges := []VolumeRange{{Lower: 0.0, Upper: 9.99}, {Lower: 10.0, Upper: 200.0}}
+
+	rs, fits, err := Calibrate("water", design, transfers, measured, ranges)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fits) != 4 {
+		t.Errorf("expected 4 fits, got %d", len(fits))
+	}
+
+	expected := map[string]float64{"water_0-9.99ul": 1.0, "water_10-200ul": 3.0}
+
+	if len(rs.Rules) != len(expected) {
+		t.Fatalf("expected %d rules, got %d", len(expected), len(rs.Rules))
+	}
+
+	for name, speed := range expected {
+		rule, ok := rs.Rules[name]
+		if !ok {
+			t.Errorf("no rule %s", name)
+			continue
+		}
+
+		if len(rule.Conditions) != 2 {
+			t.Errorf("rule %s: expected LIQUIDCLASS and VOLUME conditions, got %v", name, rule.Conditions)
+		}
+
+		if got := rs.Policies[name]["ASPSPEED"]; got != speed {
+			t.Errorf("rule %s: expected ASPSPEED %f, got %v", name, speed, got)
+		}
+
+		// only the factors in the design should be set
+
+		if _, ok := rs.Policies[name]["TOUCHOFF"]; ok {
+			t.Errorf("rule %s sets settings not in the design", name)
+		}
+	}
+}
+
+func TestCalibrateBoundaries(t *testing.T) {
+	design := makeDesign()
+	transfers, measured := makeResults()
+	ranges := []VolumeRange{{Lower: 0.0, Upper: 10.0}, {Lower: 10.0, Upper: 200.0}}
+
+	rs, _, err := Calibrate("water", design, transfers, measured, ranges)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a volume on a boundary gets the policy of the range above it, as in
+	// fitting, and the upper bound of the last range is included
+
+	for _, test := range []struct {
+		Volume float64
+		Speed  float64
+	}{
+		{Volume: 9.99, Speed: 1.0},
+		{Volume: 10.0, Speed: 3.0},
+		{Volume: 200.0, Speed: 3.0},
+	} {
+		ins := liquidhandling.NewBlowInstruction()
+		ins.What = []string{"water"}
+		ins.Volume = []wunit.Volume{wunit.NewVolume(test.Volume, "ul")}
+
+		var matched []string
+		for name, rule := range rs.Rules {
+			if ins.Check(rule) {
+				matched = append(matched, name)
+			}
+		}
+
+		if len(matched) != 1 {
+			t.Errorf("%g ul: expected one rule to match, got %v", test.Volume, matched)
+		}
+
+		if got := liquidhandling.GetPolicyFor(rs, ins)["ASPSPEED"]; got != test.Speed {
+			t.Errorf("%g ul: expected ASPSPEED %f, got %v", test.Volume, test.Speed, got)
+		}
+	}
+}
+
+func TestFitTransfers(t *testing.T) {
+	transfers, measured := makeResults()
+
+	fits := FitTransfers(transfers, measured, []VolumeRange{{Lower: 0.0, Upper: 9.99}})
+
+	if len(fits) != 2 {
+		t.Fatalf("expected 2 fits, got %d", len(fits))
+	}
+
+	// cal1 2.0, 2.02, 1.98 from 2ul
+
+	f := fits[0]
+
+	if f.Policy != "cal1" || f.N != 3 {
+		t.Fatalf("expected 3 transfers with cal1, got %d with %s", f.N, f.Policy)
+	}
+
+	if f.Accuracy < -1e-6 || f.Accuracy > 1e-6 {
+		t.Errorf("expected no bias, got %f", f.Accuracy)
+	}
+
+	if f.CV < 0.99 || f.CV > 1.01 {
+		t.Errorf("expected CV of 1%%, got %f", f.CV)
+	}
+
+	if fits[1].Accuracy > -19.99 || fits[1].Accuracy < -20.01 {
+		t.Errorf("expected accuracy of -20%%, got %f", fits[1].Accuracy)
+	}
+}
+
+func TestFitTransfersBoundaries(t *testing.T) {
+	var transfers []Transfer
+	measured := make(map[string]wunit.Volume)
+
+	for i, v := range []float64{10.0, 10.0, 200.0, 200.0} {
+		well := fmt.Sprintf("A%d", i+1)
+		transfers = append(transfers, Transfer{Policy: "cal1", Well: well, Volume: wunit.NewVolume(v, "ul")})
+		measured[well] = wunit.NewVolume(v, "ul")
+	}
+
+	// 10ul is only in the upper range, 200ul is in it since it is the last
+
+	ranges := []VolumeRange{{Lower: 0.0, Upper: 10.0}, {Lower: 10.0, Upper: 200.0}}
+
+	fits := FitTransfers(transfers, measured, ranges)
+
+	if len(fits) != 1 {
+		t.Fatalf("expected 1 fit, got %d", len(fits))
+	}
+
+	if fits[0].Range != ranges[1] || fits[0].N != 4 {
+		t.Errorf("expected 4 transfers in %s, got %d in %s", ranges[1], fits[0].N, fits[0].Range)
+	}
+}
+
+func TestAbsorbance(t *testing.T) {
+	data := testReader{"A1": 0.05, "B1": 0.55, "B2": 1.05, "C1": 0.3}
+
+	vols, err := Absorbance(data, []string{"C1"}, map[string]wunit.Volume{"B1": wunit.NewVolume(5.0, "ul"), "B2": wunit.NewVolume(10.0, "ul")}, []string{"A1"}, 600, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := vols["C1"].ConvertToString("ul"); v < 2.4999 || v > 2.5001 {
+		t.Errorf("expected 2.5 ul, got %f", v)
+	}
+}
+
+func TestGravimetric(t *testing.T) {
+	vols, err := Gravimetric(map[string]float64{"A1": 0.0099}, 0.99)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := vols["A1"].ConvertToString("ul"); v < 9.9999 || v > 10.0001 {
+		t.Errorf("expected 10 ul, got %f", v)
+	}
+
+	if _, err := Gravimetric(nil, 0.0); err == nil {
+		t.Errorf("expected error with zero density")
+	}
+}
+
+func TestWritePoliciesRoundTrip(t *testing.T) {
+	transfers, measured := makeResults()
+
+	rs, _, err := Calibrate("water", makeDesign(), transfers, measured, []VolumeRange{{Lower: 0.0, Upper: 9.99}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := WritePolicies(&buf, rs); err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "calibration")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fn := filepath.Join(dir, "policies.json")
+	if err := ioutil.WriteFile(fn, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	old := os.Getenv("ANTHA_LHPOLICIES_FILE")
+	defer os.Setenv("ANTHA_LHPOLICIES_FILE", old)
+	os.Setenv("ANTHA_LHPOLICIES_FILE", fn)
+
+	loaded, err := liquidhandling.LoadLHPoliciesFromFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !loaded.IsEqualTo(rs) {
+		t.Errorf("policies differ after writing and reading back")
+	}
+}
@@ -57,36 +57,16 @@ func Blankcorrect(blank wtype.Absorbance, sample wtype.Absorbance) (blankcorrect
 	return
 }
 
+// EstimatePathLength returns the vertical path length through volume of
+// liquid in a well of the given plate type, i.e. the height of the liquid at
+// the centre of the well. Plate types calibrated with measured heights take
+// account of the meniscus
 func EstimatePathLength(plate *wtype.LHPlate, volume wunit.Volume) (pathlength wunit.Length, err error) {
-
-	if plate.Welltype.Bottom == 0 /* i.e. flat */ && plate.Welltype.Shape().LengthUnit == "mm" {
-		wellarea, err := plate.Welltype.CalculateMaxCrossSectionArea()
-		if err != nil {
-
-			return pathlength, err
-		}
-		wellvol, err := plate.Welltype.CalculateMaxVolume()
-		if err != nil {
-			return pathlength, err
-		}
-
-		if volume.Unit().PrefixedSymbol() == "ul" && wellvol.Unit().PrefixedSymbol() == "ul" && wellarea.Unit().PrefixedSymbol() == "mm^2" || wellarea.Unit().PrefixedSymbol() == "mm" /* mm generated previously - wrong and needs fixing */ {
-			ratio := volume.RawValue() / wellvol.RawValue()
-
-			wellheightinmm := wellvol.RawValue() / wellarea.RawValue()
-
-			pathlengthinmm := wellheightinmm * ratio
-
-			pathlength = wunit.NewLength(pathlengthinmm, "mm")
-
-		} else {
-			fmt.Println(volume.Unit().PrefixedSymbol(), wellvol.Unit().PrefixedSymbol(), wellarea.Unit().PrefixedSymbol(), wellarea.ToString())
-		}
-	} else {
-		err = fmt.Errorf("Can't yet estimate pathlength for this welltype shape unit ", plate.Welltype.Shape().LengthUnit, "or non flat bottom type")
+	if plate == nil || plate.Welltype == nil {
+		return pathlength, fmt.Errorf("Can't estimate pathlength without a well type")
 	}
 
-	return
+	return plate.Welltype.HeightForVolume(volume)
 }
 
 func PathlengthCorrect(pathlength wunit.Length, reading wtype.Absorbance) (pathlengthcorrected wtype.Absorbance) {
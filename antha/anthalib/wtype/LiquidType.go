@@ -60,6 +60,7 @@ const (
 	LTMegaMix
 	LTSolvent
 	LTSmartMix
+	LTMultiDispense
 )
 
 func LiquidTypeFromString(s PolicyName) (LiquidType, error) {
@@ -139,6 +140,8 @@ func LiquidTypeFromString(s PolicyName) (LiquidType, error) {
 		return LTSolvent, nil
 	case "SmartMix":
 		return LTSmartMix, nil
+	case "multidispense":
+		return LTMultiDispense, nil
 	case "default":
 		return LTDefault, nil
 	default:
@@ -211,6 +214,8 @@ func LiquidTypeName(lt LiquidType) PolicyName {
 		return "MegaMix"
 	case LTSmartMix:
 		return "SmartMix"
+	case LTMultiDispense:
+		return "multidispense"
 	default:
 		return "nil"
 	}
@@ -0,0 +1,97 @@
+// wtype/accuracy.go: Part of the Antha language
+// Copyright (C) 2017 the Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package wtype
+
+import (
+	"sort"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+// AccuracyPoint is the performance of a pipetting channel, adaptor or tip
+// when moving Volume ul, typically from the manufacturer's specification:
+// Accuracy is the systematic error and CV the coefficient of variation,
+// both in percent of the volume moved
+type AccuracyPoint struct {
+	Volume   float64
+	Accuracy float64
+	CV       float64
+}
+
+// AccuracyCurve describes how accuracy and CV vary with volume
+type AccuracyCurve []AccuracyPoint
+
+func (ac AccuracyCurve) sorted() AccuracyCurve {
+	s := make(AccuracyCurve, len(ac))
+	copy(s, ac)
+	sort.Slice(s, func(i, j int) bool { return s[i].Volume < s[j].Volume })
+	return s
+}
+
+// At returns the expected accuracy and CV in percent when moving v.
+// Between points these are interpolated; above the largest volume they are
+// taken to be those at the largest. Below the smallest volume the absolute
+// errors are assumed to stay the same, so the relative errors grow in
+// inverse proportion to the volume. ok is false if the curve is empty
+func (ac AccuracyCurve) At(v wunit.Volume) (accuracy, cv float64, ok bool) {
+	if len(ac) == 0 {
+		return 0.0, 0.0, false
+	}
+
+	s := ac.sorted()
+	ul := v.ConvertToString("ul")
+
+	first, last := s[0], s[len(s)-1]
+
+	if ul <= first.Volume {
+		if ul <= 0.0 {
+			return first.Accuracy, first.CV, true
+		}
+		scale := first.Volume / ul
+		return first.Accuracy * scale, first.CV * scale, true
+	}
+
+	if ul >= last.Volume {
+		return last.Accuracy, last.CV, true
+	}
+
+	for i := 1; i < len(s); i++ {
+		if ul <= s[i].Volume {
+			a := interpolate(s[i-1].Volume, s[i-1].Accuracy, s[i].Volume, s[i].Accuracy, ul)
+			c := interpolate(s[i-1].Volume, s[i-1].CV, s[i].Volume, s[i].CV, ul)
+			return a, c, true
+		}
+	}
+
+	return last.Accuracy, last.CV, true
+}
+
+// Dup returns a copy of the curve
+func (ac AccuracyCurve) Dup() AccuracyCurve {
+	if ac == nil {
+		return nil
+	}
+	r := make(AccuracyCurve, len(ac))
+	copy(r, ac)
+	return r
+}
@@ -0,0 +1,48 @@
+package wtype
+
+import (
+	"math"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+func TestAccuracyCurveAt(t *testing.T) {
+	// deliberately out of order
+	ac := AccuracyCurve{
+		{Volume: 20.0, Accuracy: 1.0, CV: 0.3},
+		{Volume: 2.0, Accuracy: 5.0, CV: 1.5},
+		{Volume: 10.0, Accuracy: 1.0, CV: 0.5},
+	}
+
+	type atTest struct {
+		Volume   float64
+		Accuracy float64
+		CV       float64
+	}
+
+	tests := []atTest{
+		{Volume: 2.0, Accuracy: 5.0, CV: 1.5},
+		{Volume: 6.0, Accuracy: 3.0, CV: 1.0},
+		{Volume: 15.0, Accuracy: 1.0, CV: 0.4},
+		{Volume: 200.0, Accuracy: 1.0, CV: 0.3},
+		// absolute errors stay the same below the curve
+		{Volume: 1.0, Accuracy: 10.0, CV: 3.0},
+	}
+
+	for _, test := range tests {
+		a, cv, ok := ac.At(wunit.NewVolume(test.Volume, "ul"))
+
+		if !ok {
+			t.Fatal("expected curve to give a value")
+		}
+
+		if math.Abs(a-test.Accuracy) > 1e-9 || math.Abs(cv-test.CV) > 1e-9 {
+			t.Errorf("%.1f ul: expected %f, %f got %f, %f", test.Volume, test.Accuracy, test.CV, a, cv)
+		}
+	}
+
+	if _, _, ok := (AccuracyCurve{}).At(wunit.NewVolume(1.0, "ul")); ok {
+		t.Errorf("expected empty curve to give nothing")
+	}
+}
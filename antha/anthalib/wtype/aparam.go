@@ -75,6 +75,7 @@ func MakePolicyItems() AParamSet {
 	alhpis["BLOWOUTVOLUME"] = AParam{Name: "BLOWOUTVOLUME", Type: typemap["float64"], Desc: "how much to blow out"}
 	alhpis["BLOWOUTVOLUMEUNIT"] = AParam{Name: "BLOWOUTVOLUMEUNIT", Type: typemap["string"], Desc: "volume unit for blowout volume"}
 	alhpis["CAN_MULTI"] = AParam{Name: "CAN_MULTI", Type: typemap["bool"], Desc: "is multichannel operation allowed?"}
+	alhpis["CAN_MULTIDISPENSE"] = AParam{Name: "CAN_MULTIDISPENSE", Type: typemap["bool"], Desc: "is one aspirate followed by several dispenses permitted?"}
 	alhpis["DSPENTRYSPEED"] = AParam{Name: "DSPENTRYSPEED", Type: typemap["float64"], Desc: "allows slow moves into liquids"}
 	alhpis["DSPREFERENCE"] = AParam{Name: "DSPREFERENCE", Type: typemap["int"], Desc: "where to be when dispensing: 0 well bottom"}
 	alhpis["DSPSPEED"] = AParam{Name: "DSPSPEED", Type: typemap["float64"], Desc: "dispense pipetting rate"}
@@ -82,7 +83,9 @@ func MakePolicyItems() AParamSet {
 	alhpis["DSP_WAIT"] = AParam{Name: "DSP_WAIT", Type: typemap["float64"], Desc: "wait time in seconds post dispense"}
 	alhpis["EXTRA_ASP_VOLUME"] = AParam{Name: "EXTRA_ASP_VOLUME", Type: typemap["Volume"], Desc: "additional volume to take up when aspirating"}
 	alhpis["EXTRA_DISP_VOLUME"] = AParam{Name: "EXTRA_DISP_VOLUME", Type: typemap["Volume"], Desc: "additional volume to dispense"}
+	alhpis["MULTIDISPENSE_EXCESS"] = AParam{Name: "MULTIDISPENSE_EXCESS", Type: typemap["Volume"], Desc: "additional volume aspirated when multi-dispensing, disposed of in the waste after the last dispense; if set, transfers are only multi-dispensed when a waste is mounted"}
 	alhpis["JUSTBLOWOUT"] = AParam{Name: "JUSTBLOWOUT", Type: typemap["bool"], Desc: "shortcut to get single transfer"}
+	alhpis["LLF_BELOW_SURFACE"] = AParam{Name: "LLF_BELOW_SURFACE", Type: typemap["float64"], Desc: "mm below the liquid surface to aspirate from when USE_LLF is set"}
 	alhpis["OFFSETZADJUST"] = AParam{Name: "OFFSETZADJUST", Type: typemap["float64"], Desc: "Added to z offset"}
 	alhpis["POST_MIX"] = AParam{Name: "POST_MIX", Type: typemap["int"], Desc: "number of mix cycles to do after dispense"}
 	alhpis["POST_MIX_RATE"] = AParam{Name: "POST_MIX_RATE", Type: typemap["float64"], Desc: "pipetting rate when post mixing"}
@@ -97,6 +100,8 @@ func MakePolicyItems() AParamSet {
 	alhpis["PRE_MIX_Y"] = AParam{Name: "PRE_MIX_Y", Type: typemap["float64"], Desc: "y offset from centre of well (mm) when pre-mixing"}
 	alhpis["PRE_MIX_Z"] = AParam{Name: "PRE_MIX_Z", Type: typemap["float64"], Desc: "z offset from centre of well (mm) when pre-mixing"}
 	alhpis["RESET_OVERRIDE"] = AParam{Name: "RESET_OVERRIDE", Type: typemap["bool"], Desc: "Do not generate reset commands"}
+	alhpis["USE_LLF"] = AParam{Name: "USE_LLF", Type: typemap["bool"], Desc: "work out aspirate and dispense heights from the liquid level and follow it where needed"}
+	alhpis["TIP_TYPES"] = AParam{Name: "TIP_TYPES", Type: typemap["string"], Desc: "comma-separated list of tip types which may be used, any loaded type if unset"}
 	alhpis["TIP_REUSE_LIMIT"] = AParam{Name: "TIP_REUSE_LIMIT", Type: typemap["int"], Desc: "number of times tips can be reused for asp/dsp cycles"}
 	alhpis["TOUCHOFF"] = AParam{Name: "TOUCHOFF", Type: typemap["bool"], Desc: "whether to move to TOUCHOFFSET after dispense"}
 	alhpis["TOUCHOFFSET"] = AParam{Name: "TOUCHOFFSET", Type: typemap["float64"], Desc: "mm above wb to touch off at"}
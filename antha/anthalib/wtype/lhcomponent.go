@@ -25,6 +25,7 @@ package wtype
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/antha-lang/antha/antha/anthalib/wunit"
@@ -56,6 +57,9 @@ type LHComponent struct {
 	Extra              map[string]interface{}
 	Loc                string // refactor to PlateLocation
 	Destination        string
+	VolErr             float64                        // standard uncertainty in Vol, in Vunit
+	ConcErr            float64                        // standard uncertainty in Conc, in Cunit
+	Solutes            map[string]SoluteConcentration // what a mixture contains, see SoluteConcentrations
 }
 
 func (cmp *LHComponent) Matches(cmp2 *LHComponent) bool {
@@ -500,6 +504,14 @@ func (lhc *LHComponent) Dup() *LHComponent {
 
 	c.Loc = lhc.Loc
 	c.Destination = lhc.Destination
+	c.VolErr = lhc.VolErr
+	c.ConcErr = lhc.ConcErr
+	if lhc.Solutes != nil {
+		c.Solutes = make(map[string]SoluteConcentration, len(lhc.Solutes))
+		for k, v := range lhc.Solutes {
+			c.Solutes[k] = v
+		}
+	}
 	c.ParentID = lhc.ParentID
 	c.DaughterID = lhc.DaughterID
 	return c
@@ -587,6 +599,10 @@ func (cmp *LHComponent) Mix(cmp2 *LHComponent) {
 	cmp.Type = mergeTypes(cmp, cmp2)
 	// add cmp2 to cmp
 
+	// concentrations and uncertainties depend on volumes before mixing
+	cmp.mixSolutes(cmp2)
+	cmp.VolErr = math.Hypot(cmp.VolErr, cmp2.volErrIn(cmp.Vunit))
+
 	vcmp := wunit.NewVolume(cmp.Vol, cmp.Vunit)
 	vcmp2 := wunit.NewVolume(cmp2.Vol, cmp2.Vunit)
 	vcmp.Add(vcmp2)
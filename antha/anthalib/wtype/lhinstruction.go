@@ -1,6 +1,7 @@
 package wtype
 
 import (
+	"fmt"
 	"github.com/antha-lang/antha/antha/anthalib/wunit"
 	"strings"
 )
@@ -12,9 +13,10 @@ const (
 	LHIMIX
 	LHIWAI
 	LHIPRM
+	LHISPL
 )
 
-var InsNames = []string{"END", "MIX", "WAIT", "PROMPT"}
+var InsNames = []string{"END", "MIX", "WAIT", "PROMPT", "SPLIT"}
 
 func InsType(i int) string {
 
@@ -50,6 +52,7 @@ type LHInstruction struct {
 	OutPlate         *LHPlate
 	Message          string
 	PassThrough      map[string]*LHComponent // 1:1 pass through, only applies to prompts
+	Results          []*LHComponent          // 1:n daughters, only applies to splits
 }
 
 func (lhi *LHInstruction) GetPlateType() string {
@@ -82,6 +85,15 @@ func NewLHPromptInstruction() *LHInstruction {
 	return lhi
 }
 
+// NewLHSplitInstruction makes an instruction to distribute samples of
+// one component into several new wells. Components[i] is the sample
+// giving rise to Results[i]
+func NewLHSplitInstruction() *LHInstruction {
+	lhi := newLHInstruction()
+	lhi.Type = LHISPL
+	return lhi
+}
+
 func (inst *LHInstruction) InsType() string {
 	return InsType(inst.Type)
 }
@@ -104,6 +116,46 @@ func (inst *LHInstruction) AddComponent(cmp *LHComponent) {
 	inst.Components = append(inst.Components, cmp)
 }
 
+// AddSplitProduct adds a daughter component made by sampling cmp
+// from the source of a split
+func (inst *LHInstruction) AddSplitProduct(smp, cmp *LHComponent) {
+	inst.Components = append(inst.Components, smp)
+	inst.Results = append(inst.Results, cmp)
+}
+
+// SplitToMixes expands a split instruction into one mix per daughter
+// component: the planner deals with these as simple transfers from the
+// source which may later be merged into multi-dispenses
+func (inst *LHInstruction) SplitToMixes() ([]*LHInstruction, error) {
+	if inst.Type != LHISPL {
+		return nil, fmt.Errorf("cannot split instruction of type %s", inst.InsType())
+	}
+
+	if len(inst.Components) != len(inst.Results) {
+		return nil, fmt.Errorf("split instruction %s has %d samples but %d products", inst.ID, len(inst.Components), len(inst.Results))
+	}
+
+	ret := make([]*LHInstruction, 0, len(inst.Results))
+
+	for i, res := range inst.Results {
+		mix := NewLHMixInstruction()
+		mix.BlockID = inst.BlockID
+		mix.SName = inst.SName
+		mix.ContainerType = inst.ContainerType
+		mix.Platetype = inst.Platetype
+		mix.PlateID = inst.PlateID
+		mix.PlateName = inst.PlateName
+		mix.OutPlate = inst.OutPlate
+		mix.Majorlayoutgroup = inst.Majorlayoutgroup
+		mix.SetGeneration(inst.Generation())
+		mix.AddComponent(inst.Components[i])
+		mix.AddProduct(res)
+		ret = append(ret, mix)
+	}
+
+	return ret, nil
+}
+
 func (ins *LHInstruction) Generation() int {
 	return ins.gen
 }
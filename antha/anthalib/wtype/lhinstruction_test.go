@@ -0,0 +1,60 @@
+package wtype
+
+import (
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+func TestSplitToMixes(t *testing.T) {
+	src := NewLHComponent()
+	src.CName = "water"
+	src.Type = LTWater
+	src.SetVolume(wunit.NewVolume(100.0, "ul"))
+
+	plate := makeplatefortest()
+
+	ins := NewLHSplitInstruction()
+	ins.OutPlate = plate
+	ins.Platetype = plate.Type
+	ins.PlateID = plate.ID
+
+	for i := 0; i < 4; i++ {
+		smp, err := src.Sample(wunit.NewVolume(10.0, "ul"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := NewLHComponent()
+		res.Mix(smp)
+		ins.AddSplitProduct(smp, res)
+	}
+
+	mixes, err := ins.SplitToMixes()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mixes) != 4 {
+		t.Fatalf("expected 4 mixes, got %d", len(mixes))
+	}
+
+	for i, mix := range mixes {
+		if mix.Type != LHIMIX {
+			t.Errorf("mix %d has type %s", i, mix.InsType())
+		}
+		if mix.Result != ins.Results[i] || mix.ProductID != ins.Results[i].ID {
+			t.Errorf("mix %d does not make split product %d", i, i)
+		}
+		if len(mix.Components) != 1 || mix.Components[0] != ins.Components[i] {
+			t.Errorf("mix %d should move exactly sample %d", i, i)
+		}
+		if mix.OutPlate != plate || mix.PlateID != plate.ID {
+			t.Errorf("mix %d has lost the destination plate", i)
+		}
+	}
+
+	if _, err := NewLHMixInstruction().SplitToMixes(); err == nil {
+		t.Error("expected error splitting a mix instruction")
+	}
+}
@@ -58,8 +58,17 @@ type LHPlate struct {
 	WellXStart  float64            // offset (mm) to first well in X direction
 	WellYStart  float64            // offset (mm) to first well in Y direction
 	WellZStart  float64            // offset (mm) to bottom of well in Z direction
+	Skirt       string             `gotopb:"-"` // one of the Skirt constants, or empty if not known
+	Barcode     string             `gotopb:"-"` // barcode of this physical plate, if it has one
 }
 
+// Plate skirts
+const (
+	SkirtNone = "none"
+	SkirtSemi = "semi"
+	SkirtFull = "full"
+)
+
 func (plate LHPlate) OutputLayout() {
 	for x := 0; x < plate.WellsX(); x += 1 {
 		for y := 0; y < plate.WellsY(); y += 1 {
@@ -81,6 +90,12 @@ func (plate LHPlate) OutputLayout() {
 			}
 			//}
 			fmt.Printf(" %-6.2f%s", well.Currvol(), well.Vunit)
+			if well.WContents.VolErr > 0.0 {
+				fmt.Printf(" ± %.2f%s", well.WContents.VolErr, well.Vunit)
+			}
+			if r := well.WContents.ConcentrationReport(); r != "" {
+				fmt.Print(" ", r)
+			}
 			fmt.Println()
 			fmt.Println()
 		}
@@ -571,6 +586,8 @@ func (lhp *LHPlate) Dup() *LHPlate {
 		logger.Fatal(fmt.Sprintln("Can't dup nonexistent plate"))
 	}
 	ret := NewLHPlate(lhp.Type, lhp.Mnfr, lhp.WlsY, lhp.WlsX, lhp.Height, lhp.Hunit, lhp.Welltype, lhp.WellXOffset, lhp.WellYOffset, lhp.WellXStart, lhp.WellYStart, lhp.WellZStart)
+	ret.Skirt = lhp.Skirt
+	ret.Barcode = lhp.Barcode
 
 	ret.PlateName = lhp.PlateName
 
@@ -598,6 +615,8 @@ func (lhp *LHPlate) DupKeepIDs() *LHPlate {
 		logger.Fatal(fmt.Sprintln("Can't dup nonexistent plate"))
 	}
 	ret := NewLHPlate(lhp.Type, lhp.Mnfr, lhp.WlsY, lhp.WlsX, lhp.Height, lhp.Hunit, lhp.Welltype, lhp.WellXOffset, lhp.WellYOffset, lhp.WellXStart, lhp.WellYStart, lhp.WellZStart)
+	ret.Skirt = lhp.Skirt
+	ret.Barcode = lhp.Barcode
 	ret.ID = lhp.ID
 
 	ret.PlateName = lhp.PlateName
@@ -637,6 +656,7 @@ func (p *LHPlate) UnProtectAllWells() {
 
 func New_Plate(platetype *LHPlate) *LHPlate {
 	new_plate := NewLHPlate(platetype.Type, platetype.Mnfr, platetype.WlsY, platetype.WlsX, platetype.Height, platetype.Hunit, platetype.Welltype, platetype.WellXOffset, platetype.WellYOffset, platetype.WellXStart, platetype.WellYStart, platetype.WellZStart)
+	new_plate.Skirt = platetype.Skirt
 	//	Initialize_Wells(new_plate)
 	return new_plate
 }
@@ -67,7 +67,7 @@ func (plhp *LHPolicy) UnmarshalJSON(data []byte) error {
 	m := make(map[string]interface{})
 	*plhp = make(map[string]interface{})
 	lhp := *plhp
-	items := MakePolicyItems()
+	schema := GetPolicySchema()
 
 	err := json.Unmarshal(data, &m)
 
@@ -76,50 +76,19 @@ func (plhp *LHPolicy) UnmarshalJSON(data []byte) error {
 	}
 
 	for k, v := range m {
-		alhpi, ok := items[k]
+		item, ok := schema[k]
 
 		if !ok {
 			return fmt.Errorf("Policy item %s unknown", k)
 		}
 
-		switch alhpi.Type.Name() {
-		case "float64":
-			tv, ok := v.(float64)
-			if !ok {
-				return fmt.Errorf("Wrong type for %s: should be %s got %s", k, alhpi.Type.Name(), reflect.TypeOf(v))
-			}
-			lhp[k] = tv
-		case "int":
-			tv, ok := v.(int)
-
-			if !ok {
-				tv2, ok2 := v.(float64)
-				if ok2 {
-					tv = int(tv2)
-				} else {
-					return fmt.Errorf("Wrong type for %s: should be %s got %s", k, alhpi.Type.Name(), reflect.TypeOf(v))
-				}
-			}
-			lhp[k] = tv
-		case "string":
-			tv, ok := v.(string)
-			if !ok {
-				return fmt.Errorf("Wrong type for %s: should be %s got %s", k, alhpi.Type.Name(), reflect.TypeOf(v))
-			}
-			lhp[k] = tv
-		case "bool":
-			tv, ok := v.(bool)
-			if !ok {
-				return fmt.Errorf("Wrong type for %s: should be %s got %s", k, alhpi.Type.Name(), reflect.TypeOf(v))
-			}
-			lhp[k] = tv
-		case "wunit.Volume":
-			tv, ok := v.(wunit.Volume)
-			if !ok {
-				return fmt.Errorf("Wrong type for %s: should be %s got %s", k, alhpi.Type.Name(), reflect.TypeOf(v))
-			}
-			lhp[k] = tv
+		tv, err := item.Convert(v)
+
+		if err != nil {
+			return err
 		}
+
+		lhp[k] = tv
 	}
 
 	return nil
@@ -129,7 +98,8 @@ func (lhp LHPolicy) IsEqualTo(lh2 LHPolicy) bool {
 	for k, v := range lhp {
 		v2 := lh2[k]
 
-		if v2 != v {
+		// volumes hold pointers so compare what they point to
+		if !reflect.DeepEqual(v2, v) {
 			return false
 		}
 
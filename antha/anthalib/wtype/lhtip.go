@@ -32,6 +32,8 @@ type LHTip struct {
 	Dirty  bool
 	MaxVol wunit.Volume
 	MinVol wunit.Volume
+	// expected accuracy and CV using this tip, if known
+	Accuracy AccuracyCurve `gotopb:"-"`
 }
 
 /*
@@ -53,7 +55,7 @@ func (tip *LHTip) GetParams() *LHChannelParameter {
 		return nil
 	}
 
-	lhcp := LHChannelParameter{Name: tip.Type + "Params", Minvol: tip.MinVol, Maxvol: tip.MaxVol, Multi: 1, Independent: false, Orientation: LHVChannel}
+	lhcp := LHChannelParameter{Name: tip.Type + "Params", Minvol: tip.MinVol, Maxvol: tip.MaxVol, Multi: 1, Independent: false, Orientation: LHVChannel, Accuracy: tip.Accuracy}
 	return &lhcp
 }
 
@@ -67,6 +69,7 @@ func (tip *LHTip) IsNil() bool {
 func (tip *LHTip) Dup() *LHTip {
 	t := NewLHTip(tip.Mnfr, tip.Type, tip.MinVol.RawValue(), tip.MaxVol.RawValue(), tip.MinVol.Unit().PrefixedSymbol())
 	t.Dirty = tip.Dirty
+	t.Accuracy = tip.Accuracy.Dup()
 	return t
 }
 
@@ -43,6 +43,7 @@ type LHTipbox struct {
 	TipXStart  float64
 	TipYStart  float64
 	TipZStart  float64
+	Barcode    string `gotopb:"-"` // barcode of this physical tipbox, if it has one
 }
 
 func NewLHTipbox(nrows, ncols int, height float64, manufacturer, boxtype string, tiptype *LHTip, well *LHWell, tipxoffset, tipyoffset, tipxstart, tipystart, tipzstart float64) *LHTipbox {
@@ -144,6 +145,7 @@ func (tb *LHTipbox) dup(keepIDs bool) *LHTipbox {
 	if keepIDs {
 		tb2.ID = tb.ID
 	}
+	tb2.Barcode = tb.Barcode
 
 	for i := 0; i < len(tb.Tips); i++ {
 		for j := 0; j < len(tb.Tips[i]); j++ {
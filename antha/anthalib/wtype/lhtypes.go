@@ -83,6 +83,8 @@ type LHChannelParameter struct {
 	Independent bool
 	Orientation int
 	Head        int
+	// expected accuracy and CV using this channel, if known
+	Accuracy AccuracyCurve `gotopb:"-"`
 }
 
 func (lhcprm *LHChannelParameter) Equals(prm2 *LHChannelParameter) bool {
@@ -145,6 +147,7 @@ func (lhcp LHChannelParameter) MarshalJSON() ([]byte, error) {
 		Independent bool
 		Orientation int
 		Head        int
+		Accuracy    AccuracyCurve `json:",omitempty"`
 	}{
 		lhcp.ID,
 		lhcp.Name,
@@ -156,11 +159,13 @@ func (lhcp LHChannelParameter) MarshalJSON() ([]byte, error) {
 		lhcp.Independent,
 		lhcp.Orientation,
 		lhcp.Head,
+		lhcp.Accuracy,
 	})
 }
 
 func (lhcp *LHChannelParameter) Dup() *LHChannelParameter {
 	r := NewLHChannelParameter(lhcp.Name, lhcp.Platform, lhcp.Minvol, lhcp.Maxvol, lhcp.Minspd, lhcp.Maxspd, lhcp.Multi, lhcp.Independent, lhcp.Orientation, lhcp.Head)
+	r.Accuracy = lhcp.Accuracy.Dup()
 
 	return r
 }
@@ -37,6 +37,7 @@ const (
 	LHWBFLAT = iota
 	LHWBU
 	LHWBV
+	LHWBCONICAL
 )
 
 func BottomType(well *LHWell) (desc string) {
@@ -50,6 +51,9 @@ func BottomType(well *LHWell) (desc string) {
 	if well.Bottom == 2 {
 		desc = "V bottomed"
 	}
+	if well.Bottom == 3 {
+		desc = "conical bottomed"
+	}
 	return
 }
 
@@ -399,13 +403,15 @@ func (lhw *LHWell) AreaForVolume() wunit.Area {
 	return ret
 }
 
-func (lhw *LHWell) HeightForVolume() wunit.Length {
+// SetBottomWidth sets the width of the flat tip of a conical bottom
+func (lhw *LHWell) SetBottomWidth(w float64) {
 	if lhw == nil {
-		return wunit.ZeroLength()
+		return
 	}
-	ret := wunit.NewLength(0.0, "m")
-
-	return ret
+	if lhw.Extra == nil {
+		lhw.Extra = make(map[string]interface{})
+	}
+	lhw.Extra["bottomwidth"] = w
 }
 
 func (lhw *LHWell) SetAfVFunc(f string) {
@@ -746,7 +752,7 @@ func (w *LHWell) UpdateContentID(IDBefore string, after *LHComponent) bool {
 
 // CheckExtraKey checks if the key is a reserved name
 func (w LHWell) CheckExtraKey(s string) error {
-	reserved := []string{"protected", "afvfunc", "temporary", "autoallocated", "UserAllocated"}
+	reserved := []string{"protected", "afvfunc", "temporary", "autoallocated", "UserAllocated", "volumeheight", "bottomwidth"}
 
 	if wutil.StrInStrArray(s, reserved) {
 		return fmt.Errorf("%s is a system key used by plates", s)
@@ -0,0 +1,58 @@
+package wtype
+
+import (
+	"math"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+type heighttest struct {
+	Bottom   int
+	Volume   float64
+	Expected float64
+}
+
+func maketestwell(bottom int) *LHWell {
+	// 10 x 10 box, 100 mm^2 cross section with a 3mm bottom section
+	return NewLHWell("test", "", "A1", "ul", 1000, 10, NewShape("box", "mm", 10, 10, 20), bottom, 10, 10, 20, 3, "mm")
+}
+
+func TestHeightForVolume(t *testing.T) {
+	tests := []heighttest{
+		{Bottom: LHWBFLAT, Volume: 0.0, Expected: 0.0},
+		{Bottom: LHWBFLAT, Volume: 100.0, Expected: 1.0},
+		{Bottom: LHWBFLAT, Volume: 250.0, Expected: 2.5},
+		// V: bottom holds 100ul, as a pyramid volume goes as h^3
+		{Bottom: LHWBV, Volume: 100.0, Expected: 3.0},
+		{Bottom: LHWBV, Volume: 12.5, Expected: 1.5},
+		{Bottom: LHWBV, Volume: 200.0, Expected: 4.0},
+		// U: bottom is a spherical cap of radius 6.805 mm whose base is the
+		// cross section, holding 164.137ul
+		{Bottom: LHWBU, Volume: 164.1372, Expected: 3.0},
+		{Bottom: LHWBU, Volume: 50.0, Expected: 1.5927},
+		{Bottom: LHWBU, Volume: 250.0, Expected: 3.8586},
+	}
+
+	for _, test := range tests {
+		well := maketestwell(test.Bottom)
+
+		h, err := well.HeightForVolume(wunit.NewVolume(test.Volume, "ul"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if math.Abs(h.ConvertToString("mm")-test.Expected) > 0.0001 {
+			t.Errorf("bottom type %d, %f ul: expected height %f mm got %f mm", test.Bottom, test.Volume, test.Expected, h.ConvertToString("mm"))
+		}
+	}
+}
+
+func TestLiquidHeightUnknownShape(t *testing.T) {
+	well := NewLHWell("test", "", "A1", "ul", 1000, 10, NewShape("trap", "mm", 10, 10, 20), LHWBFLAT, 10, 10, 20, 0, "mm")
+
+	if _, err := well.LiquidHeight(); err == nil {
+		t.Errorf("expected error for well shape with no geometry")
+	}
+}
@@ -0,0 +1,228 @@
+// wtype/policyschema.go: Part of the Antha language
+// Copyright (C) 2017 the Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package wtype
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+// PolicyItemSchema describes the values a policy item may take: its type,
+// the unit numeric values are in and the range they must lie in
+type PolicyItemSchema struct {
+	AParam
+	Unit string
+	Min  float64
+	Max  float64
+}
+
+// units and limits for policy items, any not listed are unbounded
+var policyItemLimits = map[string]PolicyItemSchema{
+	"ASPENTRYSPEED":        {Unit: "mm/s", Min: 0.0, Max: math.Inf(1)},
+	"ASPREFERENCE":         {Min: 0, Max: 2},
+	"ASPSPEED":             {Unit: "ml/min", Min: 0.0, Max: math.Inf(1)},
+	"ASPZOFFSET":           {Unit: "mm", Min: math.Inf(-1), Max: math.Inf(1)},
+	"ASP_WAIT":             {Unit: "s", Min: 0.0, Max: math.Inf(1)},
+	"BLOWOUTOFFSET":        {Unit: "mm", Min: math.Inf(-1), Max: math.Inf(1)},
+	"BLOWOUTREFERENCE":     {Min: 0, Max: 2},
+	"BLOWOUTVOLUME":        {Min: 0.0, Max: math.Inf(1)},
+	"DEFAULTPIPETTESPEED":  {Unit: "ml/min", Min: 0.0, Max: math.Inf(1)},
+	"DSPENTRYSPEED":        {Unit: "mm/s", Min: 0.0, Max: math.Inf(1)},
+	"DSPREFERENCE":         {Min: 0, Max: 2},
+	"DSPSPEED":             {Unit: "ml/min", Min: 0.0, Max: math.Inf(1)},
+	"DSPZOFFSET":           {Unit: "mm", Min: math.Inf(-1), Max: math.Inf(1)},
+	"DSP_WAIT":             {Unit: "s", Min: 0.0, Max: math.Inf(1)},
+	"EXTRA_ASP_VOLUME":     {Unit: "ul", Min: 0.0, Max: math.Inf(1)},
+	"EXTRA_DISP_VOLUME":    {Unit: "ul", Min: 0.0, Max: math.Inf(1)},
+	"LLF_BELOW_SURFACE":    {Unit: "mm", Min: 0.0, Max: math.Inf(1)},
+	"MULTIDISPENSE_EXCESS": {Unit: "ul", Min: 0.0, Max: math.Inf(1)},
+	"OFFSETZADJUST":        {Unit: "mm", Min: math.Inf(-1), Max: math.Inf(1)},
+	"POST_MIX":             {Min: 0, Max: math.Inf(1)},
+	"POST_MIX_RATE":        {Unit: "ml/min", Min: 0.0, Max: math.Inf(1)},
+	"POST_MIX_VOLUME":      {Unit: "ul", Min: 0.0, Max: math.Inf(1)},
+	"POST_MIX_X":           {Unit: "mm", Min: math.Inf(-1), Max: math.Inf(1)},
+	"POST_MIX_Y":           {Unit: "mm", Min: math.Inf(-1), Max: math.Inf(1)},
+	"POST_MIX_Z":           {Unit: "mm", Min: math.Inf(-1), Max: math.Inf(1)},
+	"PRE_MIX":              {Min: 0, Max: math.Inf(1)},
+	"PRE_MIX_RATE":         {Unit: "ml/min", Min: 0.0, Max: math.Inf(1)},
+	"PRE_MIX_VOLUME":       {Unit: "ul", Min: 0.0, Max: math.Inf(1)},
+	"PRE_MIX_X":            {Unit: "mm", Min: math.Inf(-1), Max: math.Inf(1)},
+	"PRE_MIX_Y":            {Unit: "mm", Min: math.Inf(-1), Max: math.Inf(1)},
+	"PRE_MIX_Z":            {Unit: "mm", Min: math.Inf(-1), Max: math.Inf(1)},
+	"PTZOFFSET":            {Unit: "mm", Min: math.Inf(-1), Max: math.Inf(1)},
+	"PTZREFERENCE":         {Min: 0, Max: 2},
+	"TIP_REUSE_LIMIT":      {Min: 0, Max: math.Inf(1)},
+	"TOUCHOFFSET":          {Unit: "mm", Min: math.Inf(-1), Max: math.Inf(1)},
+}
+
+// GetPolicySchema returns the schema for each known policy item
+func GetPolicySchema() map[string]PolicyItemSchema {
+	items := MakePolicyItems()
+	ret := make(map[string]PolicyItemSchema, len(items))
+
+	for name, item := range items {
+		s, ok := policyItemLimits[name]
+		if !ok {
+			s = PolicyItemSchema{Min: math.Inf(-1), Max: math.Inf(1)}
+		}
+		s.AParam = item
+		ret[name] = s
+	}
+
+	return ret
+}
+
+// PolicyTypeError is returned when a policy item is given a value of the
+// wrong type
+type PolicyTypeError struct {
+	Item  string
+	Want  string
+	Value interface{}
+}
+
+func (e PolicyTypeError) Error() string {
+	return fmt.Sprintf("wrong type for %s: should be %s got %v (%T)", e.Item, e.Want, e.Value, e.Value)
+}
+
+// PolicyRangeError is returned when a policy item is given a value outside
+// its permitted range
+type PolicyRangeError struct {
+	Item  string
+	Value float64
+	Min   float64
+	Max   float64
+}
+
+func (e PolicyRangeError) Error() string {
+	switch {
+	case math.IsInf(e.Max, 1):
+		return fmt.Sprintf("value %g for %s out of range: must be at least %g", e.Value, e.Item, e.Min)
+	case math.IsInf(e.Min, -1):
+		return fmt.Sprintf("value %g for %s out of range: must be at most %g", e.Value, e.Item, e.Max)
+	default:
+		return fmt.Sprintf("value %g for %s out of range: must be between %g and %g", e.Value, e.Item, e.Min, e.Max)
+	}
+}
+
+// Range returns a description of the values permitted, or an empty string
+// if any value of the right type is allowed
+func (s PolicyItemSchema) Range() string {
+	switch {
+	case math.IsInf(s.Min, -1) && math.IsInf(s.Max, 1):
+		return ""
+	case math.IsInf(s.Max, 1):
+		return fmt.Sprintf(">= %g", s.Min)
+	case math.IsInf(s.Min, -1):
+		return fmt.Sprintf("<= %g", s.Max)
+	default:
+		return fmt.Sprintf("%g-%g", s.Min, s.Max)
+	}
+}
+
+func (s PolicyItemSchema) checkRange(v float64) error {
+	if v < s.Min || v > s.Max {
+		return PolicyRangeError{Item: s.Name, Value: v, Min: s.Min, Max: s.Max}
+	}
+	return nil
+}
+
+// Convert checks that v is a valid value for this policy item and returns it
+// as the type the item needs. Numbers are accepted for ints provided they
+// are whole, and volumes may be given as strings such as "5 ul" or as
+// numbers in the schema's unit
+func (s PolicyItemSchema) Convert(v interface{}) (interface{}, error) {
+	typeErr := PolicyTypeError{Item: s.Name, Want: s.TypeName(), Value: v}
+
+	if s.Type == nil {
+		return nil, typeErr
+	}
+
+	switch s.Type.Kind() {
+	case reflect.Float64:
+		f, ok := toFloat(v)
+		if !ok {
+			return nil, typeErr
+		}
+		return f, s.checkRange(f)
+	case reflect.Int:
+		f, ok := toFloat(v)
+		if !ok || f != math.Trunc(f) {
+			return nil, typeErr
+		}
+		return int(f), s.checkRange(f)
+	case reflect.String:
+		str, ok := v.(string)
+		if !ok {
+			return nil, typeErr
+		}
+		return str, nil
+	case reflect.Bool:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, typeErr
+		}
+		return b, nil
+	}
+
+	if s.Type == reflect.TypeOf(wunit.Volume{}) {
+		var vol wunit.Volume
+		switch tv := v.(type) {
+		case wunit.Volume:
+			vol = tv
+		case string:
+			pv, err := wunit.ParseVolume(tv)
+			if err != nil {
+				return nil, typeErr
+			}
+			vol = pv
+		default:
+			f, ok := toFloat(v)
+			if !ok {
+				return nil, typeErr
+			}
+			vol = wunit.NewVolume(f, s.Unit)
+		}
+		return vol, s.checkRange(vol.ConvertToString(s.Unit))
+	}
+
+	return nil, typeErr
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch tv := v.(type) {
+	case float64:
+		return tv, true
+	case int:
+		return float64(tv), true
+	case int64:
+		return float64(tv), true
+	case json.Number:
+		f, err := tv.Float64()
+		return f, err == nil
+	}
+	return 0.0, false
+}
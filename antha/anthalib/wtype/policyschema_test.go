@@ -0,0 +1,103 @@
+package wtype
+
+import (
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+func TestPolicySchemaCoversPolicyItems(t *testing.T) {
+	schema := GetPolicySchema()
+
+	for name := range MakePolicyItems() {
+		if _, ok := schema[name]; !ok {
+			t.Errorf("no schema for policy item %s", name)
+		}
+	}
+
+	for name := range policyItemLimits {
+		if _, ok := schema[name]; !ok {
+			t.Errorf("limits given for unknown policy item %s", name)
+		}
+	}
+}
+
+func TestPolicySchemaConvert(t *testing.T) {
+	schema := GetPolicySchema()
+
+	type convertTest struct {
+		Item     string
+		Value    interface{}
+		Expected interface{}
+		Err      bool
+	}
+
+	tests := []convertTest{
+		{Item: "ASPSPEED", Value: 3.0, Expected: 3.0},
+		{Item: "ASPSPEED", Value: 3, Expected: 3.0},
+		{Item: "ASPSPEED", Value: -1.0, Err: true},
+		{Item: "ASPSPEED", Value: "fast", Err: true},
+		{Item: "POST_MIX", Value: 3.0, Expected: 3},
+		{Item: "POST_MIX", Value: 3.5, Err: true},
+		{Item: "DSPREFERENCE", Value: 3, Err: true},
+		{Item: "TOUCHOFF", Value: true, Expected: true},
+		{Item: "TOUCHOFF", Value: "true", Err: true},
+		{Item: "DESCRIPTION", Value: 5.0, Err: true},
+	}
+
+	for _, test := range tests {
+		v, err := schema[test.Item].Convert(test.Value)
+
+		if test.Err {
+			if err == nil {
+				t.Errorf("%s = %v: expected error, got %v", test.Item, test.Value, v)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s = %v: %s", test.Item, test.Value, err)
+		} else if v != test.Expected {
+			t.Errorf("%s = %v: expected %v (%T), got %v (%T)", test.Item, test.Value, test.Expected, test.Expected, v, v)
+		}
+	}
+}
+
+func TestPolicySchemaConvertVolume(t *testing.T) {
+	s := GetPolicySchema()["EXTRA_ASP_VOLUME"]
+
+	for _, v := range []interface{}{"2 ul", "2ul", 2.0, wunit.NewVolume(0.002, "ml")} {
+		c, err := s.Convert(v)
+		if err != nil {
+			t.Errorf("%v: %s", v, err)
+			continue
+		}
+
+		vol, ok := c.(wunit.Volume)
+		if !ok {
+			t.Errorf("%v: expected volume got %T", v, c)
+		} else if !vol.EqualTo(wunit.NewVolume(2.0, "ul")) {
+			t.Errorf("%v: expected 2 ul got %s", v, vol)
+		}
+	}
+
+	if _, err := s.Convert("-2 ul"); err == nil {
+		t.Errorf("expected error for negative volume")
+	}
+}
+
+func TestLHPolicyUnmarshalVolume(t *testing.T) {
+	var p LHPolicy
+
+	if err := p.UnmarshalJSON([]byte(`{"EXTRA_ASP_VOLUME": "5 ul", "POST_MIX": 2}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := p["EXTRA_ASP_VOLUME"].(wunit.Volume); !ok {
+		t.Errorf("expected EXTRA_ASP_VOLUME to be a volume, got %T", p["EXTRA_ASP_VOLUME"])
+	}
+
+	if _, ok := p["POST_MIX"].(int); !ok {
+		t.Errorf("expected POST_MIX to be an int, got %T", p["POST_MIX"])
+	}
+}
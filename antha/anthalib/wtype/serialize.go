@@ -64,10 +64,12 @@ type SLHPlate struct {
 	WellXStart  float64 // offset (mm) to first well in X direction
 	WellYStart  float64 // offset (mm) to first well in Y direction
 	WellZStart  float64 // offset (mm) to bottom of well in Z direction
+	Skirt       string
+	Barcode     string
 }
 
 func (p *LHPlate) ToSLHPLate() SLHPlate {
-	return SLHPlate{ID: p.ID, Inst: p.Inst, Loc: p.Loc, Name: p.PlateName, Type: p.Type, Mnfr: p.Mnfr, WellsX: p.WlsX, WellsY: p.WlsY, Nwells: p.Nwells, Height: p.Height, Hunit: p.Hunit, Welltype: p.Welltype, Wellcoords: p.Wellcoords, WellXOffset: p.WellXOffset, WellYOffset: p.WellYOffset, WellXStart: p.WellXStart, WellYStart: p.WellYStart, WellZStart: p.WellZStart}
+	return SLHPlate{ID: p.ID, Inst: p.Inst, Loc: p.Loc, Name: p.PlateName, Type: p.Type, Mnfr: p.Mnfr, WellsX: p.WlsX, WellsY: p.WlsY, Nwells: p.Nwells, Height: p.Height, Hunit: p.Hunit, Welltype: p.Welltype, Wellcoords: p.Wellcoords, WellXOffset: p.WellXOffset, WellYOffset: p.WellYOffset, WellXStart: p.WellXStart, WellYStart: p.WellYStart, WellZStart: p.WellZStart, Skirt: p.Skirt, Barcode: p.Barcode}
 }
 
 func (slhp SLHPlate) FillPlate(plate *LHPlate) {
@@ -89,6 +91,8 @@ func (slhp SLHPlate) FillPlate(plate *LHPlate) {
 	plate.WellXStart = slhp.WellXStart
 	plate.WellYStart = slhp.WellYStart
 	plate.WellZStart = slhp.WellZStart
+	plate.Skirt = slhp.Skirt
+	plate.Barcode = slhp.Barcode
 	makeRows(plate)
 	makeCols(plate)
 	plate.HWells = make(map[string]*LHWell, len(plate.Wellcoords))
@@ -0,0 +1,152 @@
+// wtype/solutes.go: Part of the Antha language
+// Copyright (C) 2017 the Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package wtype
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+// SoluteConcentration is the concentration of something dissolved in a
+// component along with its standard uncertainty, both in Unit
+type SoluteConcentration struct {
+	Conc float64
+	Err  float64
+	Unit string
+}
+
+func (sc SoluteConcentration) String() string {
+	return fmt.Sprintf("%.4g ± %.2g %s", sc.Conc, sc.Err, sc.Unit)
+}
+
+// SoluteConcentrations returns the concentration of everything dissolved
+// in the component. For mixtures these are tracked through each mix,
+// otherwise a component with a concentration is taken to be a solution of
+// itself
+func (lhc *LHComponent) SoluteConcentrations() map[string]SoluteConcentration {
+	if len(lhc.Solutes) != 0 {
+		return lhc.Solutes
+	}
+
+	if lhc.HasConcentration() {
+		return map[string]SoluteConcentration{
+			lhc.CName: {Conc: lhc.Conc, Err: lhc.ConcErr, Unit: lhc.Cunit},
+		}
+	}
+
+	return nil
+}
+
+// ConcentrationReport describes the concentrations of everything dissolved
+// in the component as value ± error, or returns an empty string if there
+// are none
+func (lhc *LHComponent) ConcentrationReport() string {
+	solutes := lhc.SoluteConcentrations()
+
+	names := make([]string, 0, len(solutes))
+	for name := range solutes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	s := make([]string, 0, len(names))
+	for _, name := range names {
+		s = append(s, fmt.Sprintf("%s: %s", name, solutes[name]))
+	}
+
+	return strings.Join(s, "; ")
+}
+
+// VolumeError returns the standard uncertainty in the component's volume
+func (lhc *LHComponent) VolumeError() wunit.Volume {
+	if lhc.Vunit == "" {
+		return wunit.NewVolume(lhc.VolErr, "ul")
+	}
+	return wunit.NewVolume(lhc.VolErr, lhc.Vunit)
+}
+
+func (lhc *LHComponent) volErrIn(unit string) float64 {
+	if unit == "" || lhc.Vunit == "" || unit == lhc.Vunit || lhc.VolErr == 0.0 {
+		return lhc.VolErr
+	}
+	return lhc.VolumeError().ConvertToString(unit)
+}
+
+// mixSolutes works out the concentrations of what is dissolved in cmp once
+// cmp2 is added to it. Each solute is diluted by the fraction of the total
+// its component makes up, the uncertainty in which comes from those of
+// both volumes; solutes common to both are summed
+func (cmp *LHComponent) mixSolutes(cmp2 *LHComponent) {
+	s1 := cmp.SoluteConcentrations()
+	s2 := cmp2.SoluteConcentrations()
+
+	if len(s1) == 0 && len(s2) == 0 {
+		return
+	}
+
+	v1 := cmp.Volume().ConvertToString("ul")
+	v2 := cmp2.Volume().ConvertToString("ul")
+	e1 := cmp.volErrIn("ul")
+	e2 := cmp2.volErrIn("ul")
+
+	total := v1 + v2
+
+	if total <= 0.0 {
+		return
+	}
+
+	mixed := make(map[string]SoluteConcentration, len(s1)+len(s2))
+
+	dilute := func(solutes map[string]SoluteConcentration, v, ev, w, ew float64) {
+		f := v / total
+		ef := math.Hypot(w*ev, v*ew) / (total * total)
+
+		for name, sc := range solutes {
+			d := SoluteConcentration{
+				Conc: sc.Conc * f,
+				Err:  math.Hypot(sc.Err*f, sc.Conc*ef),
+				Unit: sc.Unit,
+			}
+
+			if prev, ok := mixed[name]; ok {
+				if prev.Unit != d.Unit {
+					// can't combine these without knowing more
+					continue
+				}
+				d.Conc += prev.Conc
+				d.Err = math.Hypot(d.Err, prev.Err)
+			}
+
+			mixed[name] = d
+		}
+	}
+
+	dilute(s1, v1, e1, v2, e2)
+	dilute(s2, v2, e2, v1, e1)
+
+	cmp.Solutes = mixed
+}
@@ -0,0 +1,67 @@
+package wtype
+
+import (
+	"math"
+	"testing"
+)
+
+func makeTestSolution(name string, vol, volErr, conc float64) *LHComponent {
+	c := NewLHComponent()
+	c.CName = name
+	c.Vol = vol
+	c.VolErr = volErr
+	if conc != 0.0 {
+		c.Conc = conc
+		c.Cunit = "mM"
+	}
+	return c
+}
+
+func TestMixDilutesSolutes(t *testing.T) {
+	water := makeTestSolution("water", 95.0, 1.0, 0.0)
+	dna := makeTestSolution("dna", 5.0, 0.1, 10.0)
+
+	water.Mix(dna)
+
+	sc, ok := water.SoluteConcentrations()["dna"]
+
+	if !ok {
+		t.Fatalf("expected dna in mixture, got %v", water.SoluteConcentrations())
+	}
+
+	if math.Abs(sc.Conc-0.5) > 1e-9 {
+		t.Errorf("expected 0.5 mM dna, got %f", sc.Conc)
+	}
+
+	// fraction 5/100 with error hypot(95*0.1, 5*1)/100^2
+	expected := 10.0 * math.Hypot(9.5, 5.0) / 10000.0
+	if math.Abs(sc.Err-expected) > 1e-9 {
+		t.Errorf("expected error %g mM, got %g", expected, sc.Err)
+	}
+
+	if expected := math.Hypot(1.0, 0.1); math.Abs(water.VolErr-expected) > 1e-9 {
+		t.Errorf("expected volume error %g, got %g", expected, water.VolErr)
+	}
+}
+
+func TestMixSumsSolutes(t *testing.T) {
+	a := makeTestSolution("salt", 50.0, 0.0, 10.0)
+	b := makeTestSolution("salt", 50.0, 0.0, 20.0)
+	b.ConcErr = 2.0
+
+	a.Mix(b)
+
+	sc := a.SoluteConcentrations()["salt"]
+
+	if math.Abs(sc.Conc-15.0) > 1e-9 {
+		t.Errorf("expected 15 mM salt, got %f", sc.Conc)
+	}
+
+	if math.Abs(sc.Err-1.0) > 1e-9 {
+		t.Errorf("expected error of 1 mM, got %f", sc.Err)
+	}
+
+	if r := a.ConcentrationReport(); r != "salt: 15 ± 1 mM" {
+		t.Errorf("unexpected report %q", r)
+	}
+}
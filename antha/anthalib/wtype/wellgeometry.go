@@ -0,0 +1,432 @@
+// wtype/wellgeometry.go: Part of the Antha language
+// Copyright (C) 2017 the Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package wtype
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+// well body shapes
+const (
+	CylindricalBody = "cylinder"
+	CuboidBody      = "cuboid"
+)
+
+// key under which calibration data are stored in the well's Extra map
+const volumeHeightKey = "volumeheight"
+
+// VolumeHeight is a single measurement of the height of the liquid surface
+// in a well (mm above the well bottom, at the centre of the well) holding a
+// given volume (ul)
+type VolumeHeight struct {
+	Volume float64
+	Height float64
+}
+
+// WellGeometry describes the inside of a well as a cylindrical or cuboid body
+// sitting on a bottom section. All lengths are in mm and volumes in ul.
+//
+// Width is the diameter of a cylindrical body or the x dimension of a cuboid
+// one, Length its y dimension. Bottom is one of LHWBFLAT, LHWBU, LHWBV or
+// LHWBCONICAL; BottomHeight is the height of the bottom section and
+// BottomWidth the width of the flat tip of a conical bottom.
+//
+// If Calibration holds two or more measurements they are used in preference
+// to the geometric model within the range measured.
+type WellGeometry struct {
+	Body         string
+	Width        float64
+	Length       float64
+	Depth        float64
+	Bottom       int
+	BottomHeight float64
+	BottomWidth  float64
+	Calibration  []VolumeHeight
+}
+
+// Area is the cross-sectional area of the body
+func (g WellGeometry) Area() float64 {
+	if g.Body == CylindricalBody {
+		return math.Pi * (g.Width / 2.0) * (g.Width / 2.0)
+	}
+	return g.Width * g.Length
+}
+
+func (g WellGeometry) bottomHeight() float64 {
+	if g.Bottom == LHWBFLAT {
+		return 0.0
+	}
+	return math.Max(0.0, math.Min(g.BottomHeight, g.Depth))
+}
+
+// bottomVolume is the volume of the bottom section up to height h
+func (g WellGeometry) bottomVolume(h float64) float64 {
+	a := g.Area()
+	hb := g.bottomHeight()
+
+	if hb <= 0.0 {
+		return 0.0
+	}
+
+	switch g.Bottom {
+	case LHWBV:
+		// cone or pyramid: cross section goes as h^2
+		return a * h * h * h / (3.0 * hb * hb)
+	case LHWBCONICAL:
+		// truncated cone or pyramid, from BottomWidth to Width
+		w := g.Width
+		b := math.Max(0.0, math.Min(g.BottomWidth, w))
+		if w == b {
+			return a * h
+		}
+		s := b + (w-b)*h/hb
+		return a * hb * (s*s*s - b*b*b) / (3.0 * w * w * (w - b))
+	case LHWBU:
+		// spherical cap of height hb whose base has the same area as
+		// the body's cross section: the bottom of a sphere of radius R
+		r := math.Sqrt(a / math.Pi)
+		R := (r*r + hb*hb) / (2.0 * hb)
+		return math.Pi * h * h * (3.0*R - h) / 3.0
+	}
+
+	return a * h
+}
+
+// modelVolumeForHeight is the volume held below height h according to the
+// geometric model alone
+func (g WellGeometry) modelVolumeForHeight(h float64) float64 {
+	if h <= 0.0 {
+		return 0.0
+	}
+
+	hb := g.bottomHeight()
+
+	if h <= hb {
+		return g.bottomVolume(h)
+	}
+
+	return g.bottomVolume(hb) + g.Area()*(h-hb)
+}
+
+// modelHeightForVolume inverts modelVolumeForHeight
+func (g WellGeometry) modelHeightForVolume(v float64) float64 {
+	if v <= 0.0 {
+		return 0.0
+	}
+
+	hb := g.bottomHeight()
+	vb := g.bottomVolume(hb)
+
+	if v >= vb {
+		return hb + (v-vb)/g.Area()
+	}
+
+	// volume is monotonic in height so bisect
+
+	lo, hi := 0.0, hb
+
+	for i := 0; i < 100 && hi-lo > 1e-9; i++ {
+		mid := (lo + hi) / 2.0
+		if g.bottomVolume(mid) < v {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return (lo + hi) / 2.0
+}
+
+// sortedCalibration returns a copy of cal sorted by volume, or an error
+// unless there are at least two points with height strictly increasing with
+// volume
+func sortedCalibration(cal []VolumeHeight) ([]VolumeHeight, error) {
+	if len(cal) < 2 {
+		return nil, fmt.Errorf("need at least 2 calibration points but got %d", len(cal))
+	}
+
+	ret := make([]VolumeHeight, len(cal))
+	copy(ret, cal)
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Volume < ret[j].Volume })
+
+	for i, vh := range ret {
+		if vh.Volume < 0.0 || vh.Height < 0.0 {
+			return nil, fmt.Errorf("invalid calibration point: volume %f height %f", vh.Volume, vh.Height)
+		}
+		if i > 0 && (vh.Volume <= ret[i-1].Volume || vh.Height <= ret[i-1].Height) {
+			return nil, fmt.Errorf("calibration points must increase in both volume and height: %f ul at %f mm follows %f ul at %f mm", vh.Volume, vh.Height, ret[i-1].Volume, ret[i-1].Height)
+		}
+	}
+
+	return ret, nil
+}
+
+// calibration returns the calibration data sorted by volume, or nil if
+// there are none usable
+func (g WellGeometry) calibration() []VolumeHeight {
+	cal, err := sortedCalibration(g.Calibration)
+	if err != nil {
+		return nil
+	}
+	return cal
+}
+
+// HeightForVolume returns the height in mm of the surface of v ul of liquid
+// above the well bottom. Within the range of any calibration data heights are
+// interpolated from the measurements, outside it the geometric model is
+// offset to agree with the nearest measurement
+func (g WellGeometry) HeightForVolume(v float64) float64 {
+	if v <= 0.0 {
+		return 0.0
+	}
+
+	cal := g.calibration()
+
+	if cal == nil {
+		return g.modelHeightForVolume(v)
+	}
+
+	first, last := cal[0], cal[len(cal)-1]
+
+	if v <= first.Volume {
+		h := g.modelHeightForVolume(v) - g.modelHeightForVolume(first.Volume) + first.Height
+		return math.Max(0.0, h)
+	}
+
+	if v >= last.Volume {
+		return g.modelHeightForVolume(v) - g.modelHeightForVolume(last.Volume) + last.Height
+	}
+
+	for i := 1; i < len(cal); i++ {
+		if v <= cal[i].Volume {
+			return interpolate(cal[i-1].Volume, cal[i-1].Height, cal[i].Volume, cal[i].Height, v)
+		}
+	}
+
+	return last.Height
+}
+
+// VolumeForHeight returns the volume in ul held in the well when the liquid
+// surface is h mm above the bottom
+func (g WellGeometry) VolumeForHeight(h float64) float64 {
+	if h <= 0.0 {
+		return 0.0
+	}
+
+	cal := g.calibration()
+
+	if cal == nil {
+		return g.modelVolumeForHeight(h)
+	}
+
+	// heights are monotonic in volume so bisect on the forward function
+
+	lo, hi := 0.0, math.Max(g.modelVolumeForHeight(h), cal[len(cal)-1].Volume)
+
+	if hi <= 0.0 {
+		hi = 1.0
+	}
+
+	for i := 0; i < 100 && g.HeightForVolume(hi) < h; i++ {
+		hi *= 2.0
+	}
+
+	for i := 0; i < 100 && hi-lo > 1e-9; i++ {
+		mid := (lo + hi) / 2.0
+		if g.HeightForVolume(mid) < h {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return (lo + hi) / 2.0
+}
+
+// MaxVolume is the volume held by the well when full to the brim
+func (g WellGeometry) MaxVolume() float64 {
+	return g.VolumeForHeight(g.Depth)
+}
+
+func interpolate(x0, y0, x1, y1, x float64) float64 {
+	if x1 == x0 {
+		return y0
+	}
+	return y0 + (y1-y0)*(x-x0)/(x1-x0)
+}
+
+func bodyFor(shapename string) (string, error) {
+	switch strings.ToLower(shapename) {
+	case "circle", "cylinder", "round", "sphere":
+		return CylindricalBody, nil
+	case "square", "rectangle", "box":
+		return CuboidBody, nil
+	}
+
+	return "", fmt.Errorf("no geometry for well shape %s", shapename)
+}
+
+// Geometry returns a description of the inside of this well built from its
+// shape, bottom type and dimensions along with any calibration data set
+// with SetVolumeHeightCalibration
+func (lhw *LHWell) Geometry() (*WellGeometry, error) {
+	if lhw == nil {
+		return nil, fmt.Errorf("nil well has no geometry")
+	}
+
+	sh := lhw.Shape()
+
+	body, err := bodyFor(sh.ShapeName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if sh.LengthUnit != "mm" || (lhw.Bottom != LHWBFLAT && lhw.Dunit != "mm") {
+		return nil, fmt.Errorf("can't make geometry for well %s of type %s: length units must be mm", lhw.Crds, lhw.Platetype)
+	}
+
+	g := WellGeometry{
+		Body:         body,
+		Width:        sh.W,
+		Length:       sh.H,
+		Depth:        sh.D,
+		Bottom:       lhw.Bottom,
+		BottomHeight: lhw.Bottomh,
+	}
+
+	if g.Area() <= 0.0 {
+		return nil, fmt.Errorf("can't make geometry for well %s of type %s: cross-sectional area is zero", lhw.Crds, lhw.Platetype)
+	}
+
+	if lhw.Bottom == LHWBCONICAL {
+		if bw, ok := lhw.Extra["bottomwidth"].(float64); ok {
+			g.BottomWidth = bw
+		}
+	}
+
+	cal, err := lhw.GetVolumeHeightCalibration()
+
+	if err != nil {
+		return nil, err
+	}
+
+	g.Calibration = cal
+
+	return &g, nil
+}
+
+// SetVolumeHeightCalibration stores measured (volume, height) pairs for this
+// well, which are then used to convert between volume and liquid height.
+// There must be at least two, with height strictly increasing with volume
+func (lhw *LHWell) SetVolumeHeightCalibration(cal []VolumeHeight) error {
+	if lhw == nil {
+		return fmt.Errorf("cannot calibrate nil well")
+	}
+
+	cal, err := sortedCalibration(cal)
+
+	if err != nil {
+		return fmt.Errorf("cannot calibrate %s: %s", lhw.Platetype, err)
+	}
+
+	// stored as a string so as to survive serialisation, like afvfunc
+
+	b, err := json.Marshal(cal)
+
+	if err != nil {
+		return err
+	}
+
+	if lhw.Extra == nil {
+		lhw.Extra = make(map[string]interface{})
+	}
+
+	lhw.Extra[volumeHeightKey] = string(b)
+	return nil
+}
+
+// GetVolumeHeightCalibration returns any calibration data set for this well
+func (lhw *LHWell) GetVolumeHeightCalibration() ([]VolumeHeight, error) {
+	if lhw == nil {
+		return nil, nil
+	}
+
+	s, ok := lhw.Extra[volumeHeightKey].(string)
+
+	if !ok {
+		return nil, nil
+	}
+
+	var cal []VolumeHeight
+
+	if err := json.Unmarshal([]byte(s), &cal); err != nil {
+		return nil, fmt.Errorf("corrupt volume-height calibration for %s: %s", lhw.Platetype, err)
+	}
+
+	return cal, nil
+}
+
+// CalibrateWellGeometry sets measured (volume, height) pairs on the plate's
+// well type and all its wells
+func (p *LHPlate) CalibrateWellGeometry(cal []VolumeHeight) error {
+	if err := p.checkExtra("cannot calibrate well geometry"); err != nil {
+		return err
+	}
+
+	if err := p.Welltype.SetVolumeHeightCalibration(cal); err != nil {
+		return err
+	}
+
+	for _, w := range p.Wellcoords {
+		if err := w.SetVolumeHeightCalibration(cal); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LiquidHeight returns the height of the liquid surface above the bottom of
+// the well given its current contents
+func (lhw *LHWell) LiquidHeight() (wunit.Length, error) {
+	return lhw.HeightForVolume(lhw.CurrentVolume())
+}
+
+// HeightForVolume returns the height above the bottom of the well of the
+// surface of a given volume of liquid
+func (lhw *LHWell) HeightForVolume(v wunit.Volume) (wunit.Length, error) {
+	g, err := lhw.Geometry()
+
+	if err != nil {
+		return wunit.NewLength(0.0, "mm"), err
+	}
+
+	return wunit.NewLength(g.HeightForVolume(v.ConvertToString("ul")), "mm"), nil
+}
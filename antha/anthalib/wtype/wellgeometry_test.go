@@ -0,0 +1,139 @@
+package wtype
+
+import (
+	"math"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+func TestGeometryRoundTrip(t *testing.T) {
+	for _, bottom := range []int{LHWBFLAT, LHWBU, LHWBV, LHWBCONICAL} {
+		well := maketestwell(bottom)
+		well.SetBottomWidth(4.0)
+
+		g, err := well.Geometry()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		last := 0.0
+		for _, h := range []float64{0.5, 1.0, 2.0, 2.9, 3.0, 3.1, 10.0, 20.0} {
+			v := g.VolumeForHeight(h)
+
+			if v <= last {
+				t.Errorf("bottom type %d: volume not increasing with height at %f mm", bottom, h)
+			}
+			last = v
+
+			if h2 := g.HeightForVolume(v); math.Abs(h2-h) > 0.0001 {
+				t.Errorf("bottom type %d: %f mm -> %f ul -> %f mm", bottom, h, v, h2)
+			}
+		}
+
+		// anything other than a flat bottom holds less than the full prism
+
+		if bottom != LHWBFLAT && g.MaxVolume() >= 2000.0 {
+			t.Errorf("bottom type %d: expected less than 2000 ul when full, got %f", bottom, g.MaxVolume())
+		}
+	}
+}
+
+func TestGeometryCylinder(t *testing.T) {
+	well := NewLHWell("test", "", "A1", "ul", 1000, 10, NewShape("cylinder", "mm", 10, 10, 20), LHWBFLAT, 10, 10, 20, 0, "mm")
+
+	h, err := well.HeightForVolume(wunit.NewVolume(25.0*math.Pi, "ul"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if math.Abs(h.ConvertToString("mm")-1.0) > 0.0001 {
+		t.Errorf("expected 1 mm got %s", h.ToString())
+	}
+}
+
+func TestGeometryCalibration(t *testing.T) {
+	p := makeplatefortest()
+
+	// measured heights are a little higher than the model says, e.g. due
+	// to the meniscus
+
+	cal := []VolumeHeight{{Volume: 100.0, Height: 5.0}, {Volume: 50.0, Height: 3.5}, {Volume: 150.0, Height: 6.0}}
+
+	if err := p.CalibrateWellGeometry(cal); err != nil {
+		t.Fatal(err)
+	}
+
+	well := p.Wellcoords["B2"]
+
+	tests := []heighttest{
+		{Volume: 50.0, Expected: 3.5},
+		{Volume: 75.0, Expected: 4.25},
+		{Volume: 125.0, Expected: 5.5},
+	}
+
+	for _, test := range tests {
+		h, err := well.HeightForVolume(wunit.NewVolume(test.Volume, "ul"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if math.Abs(h.ConvertToString("mm")-test.Expected) > 0.0001 {
+			t.Errorf("%f ul: expected height %f mm got %f mm", test.Volume, test.Expected, h.ConvertToString("mm"))
+		}
+	}
+
+	// beyond the measurements the model takes over from the last point
+
+	g, err := well.Geometry()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := 6.0 + g.modelHeightForVolume(200.0) - g.modelHeightForVolume(150.0)
+
+	if h := g.HeightForVolume(200.0); math.Abs(h-expected) > 0.0001 {
+		t.Errorf("200 ul: expected height %f mm got %f mm", expected, h)
+	}
+
+	// and new plates of the type pick up the calibration
+
+	if c, _ := p.Dup().Wellcoords["H12"].GetVolumeHeightCalibration(); len(c) != len(cal) {
+		t.Errorf("expected calibration to be copied with plate, got %v", c)
+	}
+}
+
+func TestCalibrationValidation(t *testing.T) {
+	bad := map[string][]VolumeHeight{
+		"one point":          {{Volume: 50.0, Height: 3.0}},
+		"all empty":          {{Volume: 0.0, Height: 0.0}, {Volume: 0.0, Height: 1.0}},
+		"repeated volume":    {{Volume: 50.0, Height: 3.0}, {Volume: 50.0, Height: 3.5}, {Volume: 100.0, Height: 5.0}},
+		"height decreasing":  {{Volume: 50.0, Height: 3.0}, {Volume: 100.0, Height: 2.0}},
+		"negative volume":    {{Volume: -10.0, Height: 0.0}, {Volume: 100.0, Height: 5.0}},
+		"height not growing": {{Volume: 50.0, Height: 3.0}, {Volume: 100.0, Height: 3.0}},
+	}
+
+	for name, cal := range bad {
+		if err := maketestwell(LHWBFLAT).SetVolumeHeightCalibration(cal); err == nil {
+			t.Errorf("%s: expected calibration to be rejected", name)
+		}
+	}
+
+	// bad data which get in some other way are ignored in favour of the
+	// model, and finding a volume terminates
+
+	g := WellGeometry{
+		Body:        CuboidBody,
+		Width:       10.0,
+		Length:      10.0,
+		Depth:       20.0,
+		Bottom:      LHWBFLAT,
+		Calibration: []VolumeHeight{{Volume: 0.0, Height: 0.0}, {Volume: 0.0, Height: 0.0}},
+	}
+
+	if v := g.VolumeForHeight(2.0); math.Abs(v-200.0) > 0.0001 {
+		t.Errorf("expected 200 ul at 2 mm from the model, got %f", v)
+	}
+}
@@ -204,6 +204,7 @@ func NewAntha(root *AnthaRoot) *Antha {
 	}
 
 	p.intrinsics = map[string]string{
+		"Aliquot":       "execute.Aliquot",
 		"Centrifuge":    "execute.Centrifuge",
 		"Electroshock":  "execute.Electroshock",
 		"Errorf":        "execute.Errorf",
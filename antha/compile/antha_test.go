@@ -63,6 +63,37 @@ func TestTypeSugaring(t *testing.T) {
 	}
 }
 
+func TestIntrinsicSugaring(t *testing.T) {
+	nodeSizes := make(map[ast.Node]int)
+	cfg := &Config{}
+	compiler := &compiler{}
+	fset := token.NewFileSet()
+	compiler.init(cfg, fset, nodeSizes)
+
+	root := NewAnthaRoot("")
+	antha := NewAntha(root)
+	expr, err := parser.ParseExpr("func() { Aliquot(water, vol, 8, plate) }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	desired, err := parser.ParseExpr("func() { execute.Aliquot(_ctx, water, vol, 8, plate) }")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ast.Inspect(expr, antha.inspectIntrinsics)
+	var buf1, buf2 bytes.Buffer
+	if err := compiler.Fprint(&buf1, fset, expr); err != nil {
+		t.Fatal(err)
+	}
+	if err := compiler.Fprint(&buf2, fset, desired); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf1.Bytes(), buf2.Bytes()) {
+		t.Errorf("wanted\n'''%s'''\ngot\n'''%s'''\n", buf2.String(), buf1.String())
+	}
+}
+
 func TestRelativeToGoPath(t *testing.T) {
 	type TestCase struct {
 		GoPath   []string
@@ -23,6 +23,8 @@ type InventoryItem struct {
 	CreatedAt *google_protobuf1.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
 	// History of this inventory item
 	FromItems []*InventoryItem `protobuf:"bytes,4,rep,name=from_items,json=fromItems" json:"from_items,omitempty"`
+	// Barcode of the physical item, if it has one
+	Barcode string `protobuf:"bytes,12,opt,name=barcode" json:"barcode,omitempty"`
 	// Types that are valid to be assigned to Item:
 	//	*InventoryItem_Tipbox
 	//	*InventoryItem_Tipwaste
@@ -108,6 +110,13 @@ func (m *InventoryItem) GetFromItems() []*InventoryItem {
 	return nil
 }
 
+func (m *InventoryItem) GetBarcode() string {
+	if m != nil {
+		return m.Barcode
+	}
+	return ""
+}
+
 func (m *InventoryItem) GetTipbox() *Tipbox {
 	if x, ok := m.GetItem().(*InventoryItem_Tipbox); ok {
 		return x.Tipbox
@@ -655,64 +664,65 @@ func init() {
 func init() { proto.RegisterFile("github.com/antha-lang/antha/api/v1/inventory.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 944 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x95, 0xeb, 0x6e, 0xdb, 0x36,
-	0x18, 0x86, 0x7d, 0xb6, 0xf5, 0xa5, 0xe9, 0x81, 0xd8, 0x41, 0x33, 0xd6, 0x36, 0xf3, 0x0e, 0x08,
-	0x3a, 0x4c, 0x46, 0x9d, 0x62, 0x48, 0x87, 0x01, 0x43, 0x0e, 0xc5, 0x5c, 0x60, 0x5e, 0x3d, 0x35,
-	0x5d, 0x7f, 0x0a, 0xb4, 0x45, 0xdb, 0x44, 0x44, 0x52, 0x90, 0x28, 0x37, 0xbe, 0x89, 0xed, 0xf2,
-	0x76, 0x0b, 0xdb, 0xcf, 0xdd, 0xc1, 0xc0, 0x83, 0x6c, 0xa7, 0x8b, 0x52, 0xf9, 0x1f, 0x65, 0xbf,
-	0xcf, 0x4b, 0xf2, 0xfb, 0x5e, 0x92, 0x30, 0x98, 0x53, 0xb9, 0xc8, 0x26, 0xde, 0x54, 0xb0, 0x3e,
-	0xe6, 0x72, 0x81, 0xbf, 0x8b, 0x30, 0x9f, 0x9b, 0x61, 0x1f, 0xc7, 0xb4, 0xbf, 0x7c, 0xda, 0xa7,
-	0x7c, 0x49, 0xb8, 0x14, 0xc9, 0xca, 0x8b, 0x13, 0x21, 0x05, 0xfa, 0x54, 0x24, 0x73, 0x4f, 0x2b,
-	0x02, 0x25, 0x36, 0x43, 0x6f, 0xf9, 0xb4, 0xfb, 0xd9, 0x5c, 0x88, 0x79, 0x44, 0xfa, 0x5a, 0x36,
-	0xc9, 0x66, 0x7d, 0xcc, 0x2d, 0xd3, 0x7d, 0xfc, 0xfe, 0x5f, 0x92, 0x32, 0x92, 0x4a, 0xcc, 0x62,
-	0x2b, 0xf0, 0x4a, 0x2c, 0x64, 0x2a, 0x44, 0x12, 0x5a, 0xfd, 0x51, 0x09, 0x7d, 0x2c, 0xa2, 0x15,
-	0x17, 0x8c, 0xe2, 0xc8, 0x42, 0xcf, 0x4a, 0x40, 0x8c, 0xe0, 0x34, 0x4b, 0x08, 0x23, 0x5c, 0x1a,
-	0xaa, 0xf7, 0x47, 0x0b, 0xf6, 0x5f, 0xe6, 0x35, 0x78, 0x29, 0x09, 0x43, 0x77, 0xa1, 0x46, 0x43,
-	0xb7, 0x7a, 0x50, 0x3d, 0x74, 0xfc, 0x1a, 0x0d, 0xd1, 0x18, 0x3a, 0x8c, 0x48, 0x1c, 0x62, 0x89,
-	0xdd, 0xda, 0x41, 0xfd, 0x70, 0x6f, 0xf0, 0xcc, 0x2b, 0x28, 0x92, 0x77, 0xcd, 0xc9, 0x1b, 0x59,
-	0xec, 0x05, 0x97, 0xc9, 0xca, 0x5f, 0xbb, 0xa0, 0xe7, 0x00, 0xd3, 0x84, 0x60, 0x49, 0xc2, 0x00,
-	0x4b, 0xb7, 0x7e, 0x50, 0x3d, 0xdc, 0x1b, 0x74, 0x3d, 0x53, 0x44, 0x2f, 0x2f, 0xa2, 0x77, 0x91,
-	0x17, 0xd1, 0x77, 0xac, 0xfa, 0x44, 0xa2, 0x17, 0x00, 0xb3, 0x44, 0xb0, 0x80, 0x4a, 0xc2, 0x52,
-	0xb7, 0xa1, 0x97, 0xf3, 0x4d, 0xb9, 0xe5, 0xf8, 0x8e, 0x22, 0xd5, 0x28, 0x45, 0xcf, 0xa1, 0x25,
-	0x69, 0x3c, 0x11, 0x57, 0x6e, 0x53, 0xcf, 0xfe, 0xb8, 0xd0, 0xe2, 0x42, 0xcb, 0x86, 0x15, 0xdf,
-	0x02, 0xe8, 0x27, 0xe8, 0x48, 0x1a, 0xbf, 0xc3, 0xa9, 0x24, 0x6e, 0x4b, 0xc3, 0x5f, 0xdc, 0x06,
-	0x6b, 0xe1, 0xb0, 0xe2, 0xaf, 0x21, 0xf4, 0x3d, 0x34, 0xe3, 0x08, 0x4b, 0xe2, 0xb6, 0x35, 0xfd,
-	0xa8, 0x90, 0x1e, 0x2b, 0xd5, 0xb0, 0xe2, 0x1b, 0x39, 0xfa, 0x05, 0xf6, 0x43, 0x32, 0xbd, 0x0c,
-	0x62, 0x91, 0x52, 0x49, 0x05, 0x77, 0x3b, 0x9a, 0xff, 0xba, 0x90, 0x3f, 0x27, 0xd3, 0xcb, 0xb1,
-	0x15, 0x0f, 0x2b, 0xfe, 0x9d, 0x70, 0xeb, 0x1b, 0x9d, 0x82, 0x33, 0x15, 0x2c, 0x16, 0x9c, 0x70,
-	0xe9, 0x3a, 0xda, 0xa9, 0x57, 0xe8, 0x74, 0x96, 0x2b, 0x87, 0x15, 0x7f, 0x83, 0xa1, 0x33, 0x00,
-	0xbd, 0xb4, 0x40, 0xae, 0x62, 0xe2, 0xc2, 0x07, 0x4c, 0xf4, 0x76, 0x2e, 0x56, 0xb1, 0xda, 0x92,
-	0x13, 0xe7, 0x1f, 0xe8, 0x08, 0x1a, 0x5c, 0x70, 0xe2, 0xee, 0x69, 0xfc, 0x61, 0x21, 0xfe, 0xab,
-	0xe0, 0x8a, 0xd4, 0xe2, 0xee, 0x6f, 0xb0, 0x7f, 0x2d, 0x5c, 0xe8, 0x3e, 0xd4, 0x2f, 0xc9, 0xca,
-	0xa6, 0x56, 0x0d, 0xd1, 0x13, 0x68, 0x2e, 0x71, 0x94, 0x11, 0xb7, 0xa6, 0x8d, 0x3f, 0xfa, 0x5f,
-	0xbe, 0x4e, 0xf8, 0xca, 0x37, 0x92, 0x1f, 0x6a, 0xc7, 0xd5, 0xd3, 0x16, 0x34, 0x54, 0xa8, 0x7a,
-	0x2d, 0x68, 0xa8, 0xa9, 0x7a, 0x9f, 0x43, 0xcb, 0xf4, 0x1e, 0x21, 0x68, 0xe8, 0x0d, 0x1a, 0x73,
-	0x3d, 0xee, 0x3d, 0x82, 0x4e, 0xde, 0xdc, 0x1b, 0xff, 0x7f, 0x02, 0x77, 0xb6, 0xcb, 0x8f, 0xba,
-	0xd0, 0x59, 0xf7, 0xcd, 0xe8, 0xd6, 0xdf, 0xbd, 0x31, 0x34, 0x75, 0x6d, 0x6e, 0x32, 0x42, 0x47,
-	0xd0, 0x7c, 0x47, 0xa2, 0x28, 0xb5, 0x47, 0xaf, 0xb8, 0x3e, 0x6f, 0x49, 0x14, 0xf9, 0x46, 0xdb,
-	0xfb, 0xb3, 0x0a, 0x0d, 0xf5, 0x8d, 0x4e, 0xde, 0x9b, 0xf6, 0xb6, 0xb8, 0xbc, 0x4a, 0x42, 0xca,
-	0x71, 0x74, 0xa6, 0xee, 0xa1, 0xcd, 0xea, 0xd0, 0xf9, 0x76, 0x50, 0x4c, 0x2d, 0x4b, 0x1f, 0xb8,
-	0x35, 0xd8, 0xfb, 0xb7, 0x06, 0xce, 0x3a, 0x45, 0x37, 0x6e, 0x14, 0x41, 0x83, 0x63, 0x66, 0xda,
-	0xe5, 0xf8, 0x7a, 0x8c, 0x7e, 0x84, 0xd6, 0x52, 0x44, 0x19, 0x23, 0xf6, 0x92, 0xf8, 0xaa, 0x70,
-	0xe2, 0xd1, 0xe6, 0x62, 0xf3, 0x2d, 0xa3, 0x22, 0xbe, 0xa4, 0xe9, 0x54, 0x6d, 0x64, 0xe5, 0x36,
-	0x76, 0x30, 0xd8, 0x60, 0xe8, 0x18, 0x1a, 0x0c, 0xa7, 0xa9, 0xbd, 0x26, 0xca, 0xe1, 0x9a, 0x50,
-	0x6b, 0xc7, 0x4c, 0x64, 0x5c, 0xda, 0x5b, 0xa2, 0xe4, 0xda, 0x0d, 0x83, 0x4e, 0x01, 0xd6, 0xc5,
-	0x4b, 0xdd, 0xb6, 0xee, 0x7d, 0x89, 0xf3, 0xe9, 0x6f, 0x51, 0xbd, 0xbf, 0x5a, 0xe0, 0xac, 0x0f,
-	0x1d, 0x3a, 0x86, 0x7a, 0x48, 0x99, 0x4d, 0x41, 0x71, 0x07, 0xc7, 0x8b, 0x55, 0x4a, 0xa7, 0x79,
-	0x0c, 0x14, 0xa2, 0x42, 0xa4, 0x62, 0x15, 0x28, 0xbc, 0xb6, 0x13, 0xde, 0x56, 0xdc, 0x39, 0x65,
-	0xaa, 0x15, 0x3c, 0x63, 0x81, 0x49, 0x72, 0x7d, 0xa7, 0x20, 0xf2, 0x8c, 0xa9, 0x28, 0xa7, 0xea,
-	0xb6, 0x61, 0xf8, 0x2a, 0xb0, 0x81, 0xd8, 0xa9, 0x9f, 0x0c, 0x5f, 0xfd, 0x6e, 0x32, 0xf1, 0x10,
-	0x40, 0xef, 0x25, 0x5d, 0xe0, 0x98, 0xe8, 0xae, 0x3a, 0xbe, 0xa3, 0x7e, 0x79, 0xad, 0x7e, 0x40,
-	0x6f, 0xe0, 0x63, 0xe3, 0x1f, 0x64, 0x51, 0x20, 0x45, 0x80, 0x13, 0x82, 0x03, 0xc6, 0x06, 0xb6,
-	0x87, 0x5f, 0x16, 0xef, 0x7b, 0xfd, 0x1a, 0xfb, 0x0f, 0x8c, 0xc3, 0x9b, 0xe8, 0x42, 0x9c, 0x24,
-	0x04, 0x8f, 0xd8, 0x00, 0xbd, 0x85, 0x4f, 0xae, 0xd9, 0x2e, 0x08, 0x9d, 0x2f, 0x64, 0xc0, 0x98,
-	0x7d, 0x03, 0x4a, 0xf9, 0xa2, 0x8d, 0xef, 0x50, 0xf3, 0x23, 0x86, 0x46, 0x70, 0x2f, 0x21, 0x29,
-	0x0d, 0x33, 0x1c, 0xe5, 0x85, 0xe9, 0xec, 0x50, 0x98, 0xbb, 0x39, 0x6c, 0xab, 0xf3, 0x33, 0xec,
-	0xe9, 0xea, 0x88, 0xd9, 0x2c, 0x25, 0xf9, 0xb3, 0x50, 0xb6, 0xd9, 0xba, 0xb0, 0xaf, 0x34, 0xb9,
-	0x31, 0x4a, 0xe8, 0x9c, 0x72, 0xfb, 0x34, 0xec, 0x66, 0xa4, 0x49, 0x75, 0x2b, 0x30, 0x3e, 0x4b,
-	0xf4, 0xeb, 0xe0, 0xf8, 0x7a, 0x8c, 0x5e, 0x03, 0xd2, 0xe6, 0x13, 0x21, 0xa5, 0x60, 0xb6, 0x98,
-	0xee, 0xdf, 0xed, 0x1d, 0x36, 0x7e, 0x5f, 0x19, 0x9c, 0x6a, 0xde, 0xd4, 0x12, 0x7d, 0x0b, 0x0f,
-	0xb6, 0x4d, 0x4d, 0x3e, 0xfe, 0x69, 0xeb, 0x69, 0xef, 0x6d, 0xd4, 0x3a, 0x26, 0x93, 0x96, 0x7e,
-	0x44, 0x8e, 0xfe, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x94, 0x48, 0x88, 0x76, 0x61, 0x0a, 0x00, 0x00,
+	// 956 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x95, 0xeb, 0x6e, 0xdb, 0x36,
+	0x14, 0xc7, 0xe3, 0x9b, 0x6c, 0x9d, 0x24, 0xbd, 0x10, 0xbb, 0x70, 0xc6, 0xda, 0x66, 0xde, 0x05,
+	0x41, 0x87, 0xc9, 0xa8, 0x53, 0x0c, 0xe9, 0x30, 0x60, 0xc8, 0xa5, 0x98, 0x0b, 0xcc, 0xab, 0xa7,
+	0xa6, 0xeb, 0x47, 0x81, 0xb6, 0x68, 0x9b, 0x88, 0x48, 0x0a, 0x12, 0xe5, 0xc6, 0x4f, 0xb1, 0xd7,
+	0xd8, 0x1b, 0xed, 0x15, 0xb6, 0x8f, 0x7b, 0x83, 0x81, 0xa4, 0x24, 0x3b, 0x5d, 0x9c, 0xda, 0xfd,
+	0x46, 0xda, 0xe7, 0xf7, 0x17, 0xcf, 0xff, 0xfc, 0x45, 0x41, 0x6f, 0xca, 0xd4, 0x2c, 0x1b, 0x79,
+	0x63, 0xc9, 0xbb, 0x44, 0xa8, 0x19, 0xf9, 0x2e, 0x22, 0x62, 0x6a, 0x97, 0x5d, 0x12, 0xb3, 0xee,
+	0xfc, 0x49, 0x97, 0x89, 0x39, 0x15, 0x4a, 0x26, 0x0b, 0x2f, 0x4e, 0xa4, 0x92, 0xe8, 0x53, 0x99,
+	0x4c, 0x3d, 0x53, 0x11, 0xe8, 0x62, 0xbb, 0xf4, 0xe6, 0x4f, 0xda, 0x9f, 0x4d, 0xa5, 0x9c, 0x46,
+	0xb4, 0x6b, 0xca, 0x46, 0xd9, 0xa4, 0x4b, 0x44, 0xce, 0xb4, 0x1f, 0xbd, 0xfb, 0x97, 0x62, 0x9c,
+	0xa6, 0x8a, 0xf0, 0x38, 0x2f, 0xf0, 0x36, 0x38, 0xc8, 0x58, 0xca, 0x24, 0xcc, 0xeb, 0x8f, 0x36,
+	0xa8, 0x8f, 0x65, 0xb4, 0x10, 0x92, 0x33, 0x12, 0xe5, 0xd0, 0xd3, 0x0d, 0x20, 0x4e, 0x49, 0x9a,
+	0x25, 0x94, 0x53, 0xa1, 0x2c, 0xd5, 0xf9, 0xd3, 0x81, 0xfd, 0x17, 0x85, 0x07, 0x2f, 0x14, 0xe5,
+	0xe8, 0x0e, 0x54, 0x59, 0x88, 0x2b, 0x07, 0x95, 0x43, 0xd7, 0xaf, 0xb2, 0x10, 0x0d, 0xa1, 0xc5,
+	0xa9, 0x22, 0x21, 0x51, 0x04, 0x57, 0x0f, 0x6a, 0x87, 0xbb, 0xbd, 0xa7, 0xde, 0x1a, 0x93, 0xbc,
+	0x6b, 0x4a, 0xde, 0x20, 0xc7, 0x9e, 0x0b, 0x95, 0x2c, 0xfc, 0x52, 0x05, 0x3d, 0x03, 0x18, 0x27,
+	0x94, 0x28, 0x1a, 0x06, 0x44, 0xe1, 0xda, 0x41, 0xe5, 0x70, 0xb7, 0xd7, 0xf6, 0xac, 0x89, 0x5e,
+	0x61, 0xa2, 0x77, 0x51, 0x98, 0xe8, 0xbb, 0x79, 0xf5, 0x89, 0x42, 0xcf, 0x01, 0x26, 0x89, 0xe4,
+	0x01, 0x53, 0x94, 0xa7, 0xb8, 0x6e, 0x8e, 0xf3, 0xcd, 0x66, 0xc7, 0xf1, 0x5d, 0x4d, 0xea, 0x55,
+	0x8a, 0x30, 0x34, 0x47, 0x24, 0x19, 0xcb, 0x90, 0xe2, 0x3d, 0xd3, 0x68, 0xb1, 0x45, 0xcf, 0xc0,
+	0x51, 0x2c, 0x1e, 0xc9, 0x2b, 0xdc, 0x30, 0xe7, 0x7a, 0xb4, 0x56, 0xfc, 0xc2, 0x94, 0xf5, 0x77,
+	0xfc, 0x1c, 0x40, 0x3f, 0x41, 0x4b, 0xb1, 0xf8, 0x2d, 0x49, 0x15, 0xc5, 0x8e, 0x81, 0xbf, 0xb8,
+	0x0d, 0x36, 0x85, 0xfd, 0x1d, 0xbf, 0x84, 0xd0, 0xf7, 0xd0, 0x88, 0x23, 0xa2, 0x28, 0x6e, 0x1a,
+	0xfa, 0xe1, 0x5a, 0x7a, 0xa8, 0xab, 0xfa, 0x3b, 0xbe, 0x2d, 0x47, 0xbf, 0xc0, 0x7e, 0x48, 0xc7,
+	0x97, 0x41, 0x2c, 0x53, 0xa6, 0x98, 0x14, 0xb8, 0x65, 0xf8, 0xaf, 0xd7, 0xf2, 0xe7, 0x74, 0x7c,
+	0x39, 0xcc, 0x8b, 0xfb, 0x3b, 0xfe, 0x5e, 0xb8, 0xb2, 0x47, 0xa7, 0xe0, 0x8e, 0x25, 0x8f, 0xa5,
+	0xa0, 0x42, 0x61, 0xd7, 0x28, 0x75, 0xd6, 0x2a, 0x9d, 0x15, 0x95, 0xfd, 0x1d, 0x7f, 0x89, 0xa1,
+	0x33, 0x00, 0x73, 0xb4, 0x40, 0x2d, 0x62, 0x8a, 0xe1, 0x3d, 0x22, 0xa6, 0x9d, 0x8b, 0x45, 0xac,
+	0x5b, 0x72, 0xe3, 0x62, 0x83, 0x8e, 0xa0, 0x2e, 0xa4, 0xa0, 0x78, 0xd7, 0xe0, 0x0f, 0xd6, 0xe2,
+	0xbf, 0x4a, 0xa1, 0x49, 0x53, 0xdc, 0xfe, 0x0d, 0xf6, 0xaf, 0xc5, 0x0e, 0xdd, 0x83, 0xda, 0x25,
+	0x5d, 0xe4, 0x79, 0xd6, 0x4b, 0xf4, 0x18, 0x1a, 0x73, 0x12, 0x65, 0x14, 0x57, 0x8d, 0xf0, 0x47,
+	0xff, 0x4b, 0xde, 0x89, 0x58, 0xf8, 0xb6, 0xe4, 0x87, 0xea, 0x71, 0xe5, 0xd4, 0x81, 0xba, 0x8e,
+	0x5b, 0xc7, 0x81, 0xba, 0x7e, 0x54, 0xe7, 0x73, 0x70, 0xec, 0xec, 0x11, 0x82, 0xba, 0x69, 0xd0,
+	0x8a, 0x9b, 0x75, 0xe7, 0x21, 0xb4, 0x8a, 0xe1, 0xde, 0xf8, 0xff, 0x63, 0xd8, 0x5b, 0xb5, 0x1f,
+	0xb5, 0xa1, 0x55, 0xce, 0xcd, 0xd6, 0x95, 0xfb, 0xce, 0x10, 0x1a, 0xc6, 0x9b, 0x9b, 0x84, 0xd0,
+	0x11, 0x34, 0xde, 0xd2, 0x28, 0x4a, 0xf3, 0x97, 0x72, 0xbd, 0x3f, 0x6f, 0x68, 0x14, 0xf9, 0xb6,
+	0xb6, 0xf3, 0x47, 0x05, 0xea, 0x7a, 0x8f, 0x4e, 0xde, 0x79, 0xec, 0x6d, 0x71, 0x79, 0x99, 0x84,
+	0x4c, 0x90, 0xe8, 0x4c, 0xdf, 0x50, 0xcb, 0xd3, 0xa1, 0xf3, 0xd5, 0xa0, 0x58, 0x2f, 0x37, 0x7e,
+	0x15, 0x4b, 0xb0, 0xf3, 0x6f, 0x15, 0xdc, 0x32, 0x45, 0x37, 0x36, 0x8a, 0xa0, 0x2e, 0x08, 0xb7,
+	0xe3, 0x72, 0x7d, 0xb3, 0x46, 0x3f, 0x82, 0x33, 0x97, 0x51, 0xc6, 0x69, 0x7e, 0x7d, 0x7c, 0xb5,
+	0xf6, 0xc1, 0x83, 0xe5, 0x95, 0xe7, 0xe7, 0x8c, 0x8e, 0xf8, 0x9c, 0xa5, 0x63, 0xdd, 0xc8, 0x02,
+	0xd7, 0xb7, 0x10, 0x58, 0x62, 0xe8, 0x18, 0xea, 0x9c, 0xa4, 0x29, 0x6e, 0x6c, 0x81, 0x1b, 0x42,
+	0x9f, 0x9d, 0x70, 0x99, 0x09, 0x85, 0x9d, 0x2d, 0xd8, 0x9c, 0x41, 0xa7, 0x00, 0xa5, 0x79, 0x29,
+	0x6e, 0x1e, 0xd4, 0x6e, 0x7d, 0xb5, 0x4a, 0x67, 0xfd, 0x15, 0xaa, 0xf3, 0x97, 0x03, 0x6e, 0xf9,
+	0xd2, 0xa1, 0x63, 0xa8, 0x85, 0x8c, 0xe3, 0xca, 0x7b, 0x26, 0x38, 0x9c, 0x2d, 0x52, 0x36, 0x2e,
+	0x62, 0xa0, 0x11, 0x1d, 0x22, 0x1d, 0xab, 0x40, 0xe3, 0xd5, 0xad, 0xf0, 0xa6, 0xe6, 0xce, 0x19,
+	0xd7, 0xa3, 0x10, 0x19, 0x0f, 0x6c, 0x92, 0x6b, 0x5b, 0x05, 0x51, 0x64, 0x5c, 0x47, 0x39, 0xd5,
+	0xb7, 0x0d, 0x27, 0x57, 0x41, 0x1e, 0x88, 0xad, 0xe6, 0xc9, 0xc9, 0xd5, 0xef, 0x36, 0x13, 0x0f,
+	0x00, 0x4c, 0x2f, 0xe9, 0x8c, 0xc4, 0xd4, 0x4c, 0xd5, 0xf5, 0x5d, 0xfd, 0xcb, 0x2b, 0xfd, 0x03,
+	0x7a, 0x0d, 0x1f, 0x5b, 0xfd, 0x20, 0x8b, 0x02, 0x25, 0x03, 0x92, 0x50, 0x12, 0x70, 0xde, 0xcb,
+	0x67, 0xf8, 0xe5, 0xfa, 0xbe, 0xcb, 0xef, 0xb4, 0x7f, 0xdf, 0x2a, 0xbc, 0x8e, 0x2e, 0xe4, 0x49,
+	0x42, 0xc9, 0x80, 0xf7, 0xd0, 0x1b, 0xf8, 0xe4, 0x9a, 0xec, 0x8c, 0xb2, 0xe9, 0x4c, 0x05, 0x9c,
+	0xe3, 0xe6, 0xe6, 0xba, 0x68, 0xa9, 0xdb, 0x37, 0xfc, 0x80, 0xa3, 0x01, 0xdc, 0x4d, 0x68, 0xca,
+	0xc2, 0x8c, 0x44, 0x85, 0x31, 0xad, 0x2d, 0x8c, 0xb9, 0x53, 0xc0, 0xb9, 0x3b, 0x3f, 0xc3, 0xae,
+	0x71, 0x47, 0x4e, 0x26, 0x29, 0x2d, 0x3e, 0x0b, 0x9b, 0x0e, 0xdb, 0x18, 0xfb, 0xd2, 0x90, 0x4b,
+	0xa1, 0x84, 0x4d, 0x99, 0xc0, 0xf0, 0x01, 0x42, 0x86, 0xd4, 0xb7, 0x02, 0x17, 0x93, 0xc4, 0x7c,
+	0x1d, 0x5c, 0xdf, 0xac, 0xd1, 0x2b, 0x40, 0x46, 0x7c, 0x24, 0x95, 0x92, 0x3c, 0x37, 0x13, 0xff,
+	0xdd, 0xdc, 0xa2, 0xf1, 0x7b, 0x5a, 0xe0, 0xd4, 0xf0, 0xd6, 0x4b, 0xf4, 0x2d, 0xdc, 0x5f, 0x15,
+	0xb5, 0xf9, 0xf8, 0xa7, 0x69, 0x1e, 0x7b, 0x77, 0x59, 0x6d, 0x62, 0x32, 0x72, 0xcc, 0x47, 0xe4,
+	0xe8, 0xbf, 0x01, 0x00, 0xbe, 0xb9, 0x88, 0xe3, 0x7b, 0x0a, 0x00, 0x00,
 }
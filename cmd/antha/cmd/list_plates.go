@@ -31,7 +31,7 @@ import (
 
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	"github.com/antha-lang/antha/antha/anthalib/wunit"
-	"github.com/antha-lang/antha/inventory/testinventory"
+	"github.com/antha-lang/antha/inventory"
 	"github.com/ghodss/yaml"
 	"github.com/mgutz/ansi"
 	"github.com/spf13/cobra"
@@ -77,10 +77,24 @@ func listPlates(cmd *cobra.Command, args []string) error {
 		return ansi.Color(x, "red")
 	}
 
-	ctx := testinventory.NewContext(context.Background())
+	q, err := inventory.ParsePlateQuery(viper.GetString("query"))
+	if err != nil {
+		return err
+	}
+
+	ctx, done, err := makeInventoryContext(context.Background(), viper.GetString("inventoryServer"), GetStringSlice("inventory"))
+	if err != nil {
+		return err
+	}
+	defer done() // nolint: errcheck
+
+	plates, err := inventory.Plates(ctx, q)
+	if err != nil {
+		return err
+	}
 
 	var ps simplePlates
-	for _, p := range testinventory.GetPlates(ctx) {
+	for _, p := range plates {
 		ps = append(ps, simplePlate{
 			Type:          p.Type,
 			WellsX:        p.WellsX(),
@@ -140,4 +154,9 @@ func listPlates(cmd *cobra.Command, args []string) error {
 func init() {
 	c := listPlatesCmd
 	listCmd.AddCommand(c)
+
+	flags := c.Flags()
+	flags.String("query", "", "Only list plates meeting these constraints, e.g., wells=96,minWellVolume=200ul,skirt=full,sbs; plates whose skirt is not known never match a skirt")
+	flags.String("inventoryServer", "", "Address of an inventory server (see antha serve inventory) to list the plates of instead of the built-in inventory")
+	flags.StringSlice("inventory", nil, "Directories of plate definitions to list on top of the built-in ones; use multiple flags for multiple directories, later ones taking precedence")
 }
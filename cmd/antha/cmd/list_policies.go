@@ -28,6 +28,8 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
 	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
 	"github.com/ghodss/yaml"
 	"github.com/mgutz/ansi"
@@ -69,6 +71,10 @@ func listPolicies(cmd *cobra.Command, args []string) error {
 		return ansi.Color(x, "red")
 	}
 
+	if liquidtype := viper.GetString("explain"); liquidtype != "" {
+		return explainPolicy(liquidtype, viper.GetString("volume"))
+	}
+
 	var ps simplePolicies
 	for name, p := range liquidhandling.MakePolicies() {
 
@@ -132,7 +138,145 @@ func listPolicies(cmd *cobra.Command, args []string) error {
 	}
 }
 
+type explainedSetting struct {
+	Name   string
+	Value  interface{}
+	Source string
+}
+
+type explainedRule struct {
+	Name       string
+	Layer      string
+	Source     string
+	Priority   int
+	Conditions []string
+	Sets       []string
+}
+
+type explainedPolicy struct {
+	LiquidType string
+	Volume     string
+	Settings   []explainedSetting
+	Rules      []explainedRule
+}
+
+func describeCondition(c wtype.LHVariableCondition) string {
+	switch cond := c.Condition.(type) {
+	case wtype.LHNumericCondition:
+		return fmt.Sprintf("%s in [%g, %g]", c.TestVariable, cond.Lower, cond.Upper)
+	case wtype.LHCategoryCondition:
+		return fmt.Sprintf("%s = %s", c.TestVariable, cond.Category)
+	default:
+		return fmt.Sprintf("%s %v", c.TestVariable, c.Condition)
+	}
+}
+
+// explainPolicy shows the policy used to aspirate volume of liquidtype and
+// where each setting came from
+func explainPolicy(liquidtype, volume string) error {
+	resolver, err := liquidhandling.DefaultPolicyResolver()
+	if err != nil {
+		return err
+	}
+
+	ins := liquidhandling.NewSuckInstruction()
+	ins.What = append(ins.What, liquidtype)
+
+	if volume != "" {
+		v, err := wunit.ParseVolume(volume)
+		if err != nil {
+			return err
+		}
+		// numeric conditions on volume are in ul
+		ins.Volume = append(ins.Volume, wunit.NewVolume(v.ConvertToString("ul"), "ul"))
+	}
+
+	resolved := resolver.Resolve(ins)
+
+	ep := explainedPolicy{
+		LiquidType: liquidtype,
+		Volume:     volume,
+	}
+
+	for k, v := range resolved.Policy {
+		ep.Settings = append(ep.Settings, explainedSetting{
+			Name:   k,
+			Value:  v,
+			Source: resolved.Sources[k].String(),
+		})
+	}
+
+	sort.Slice(ep.Settings, func(i, j int) bool {
+		return ep.Settings[i].Name < ep.Settings[j].Name
+	})
+
+	for _, m := range resolved.Matches {
+		er := explainedRule{
+			Name:     m.Rule,
+			Layer:    m.Layer,
+			Source:   m.Source,
+			Priority: m.Priority,
+		}
+		for _, c := range m.Conditions {
+			er.Conditions = append(er.Conditions, describeCondition(c))
+		}
+		for k := range m.Policy {
+			er.Sets = append(er.Sets, k)
+		}
+		sort.Strings(er.Sets)
+		ep.Rules = append(ep.Rules, er)
+	}
+
+	output := viper.GetString("output")
+	switch output {
+	case jsonOutput:
+		bs, err := json.MarshalIndent(ep, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Println(string(bs))
+		return err
+	case yamlOutput:
+		bs, err := yaml.Marshal(ep)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Print(string(bs))
+		return err
+	case textOutput:
+		red := func(x string) string {
+			return ansi.Color(x, "red")
+		}
+
+		var lines []string
+		lines = append(lines, red("Policy for")+" "+liquidtype+" "+volume)
+
+		for _, s := range ep.Settings {
+			lines = append(lines, fmt.Sprintf("%s: %v\t%s", red(s.Name), s.Value, s.Source))
+		}
+
+		lines = append(lines, "", red("Matching rules, in the order applied"))
+
+		for _, r := range ep.Rules {
+			when := "always"
+			if len(r.Conditions) != 0 {
+				when = "when " + strings.Join(r.Conditions, " and ")
+			}
+			lines = append(lines, fmt.Sprintf("%s %s (%s) priority %d %s sets %s", red(r.Name), r.Layer, r.Source, r.Priority, when, strings.Join(r.Sets, ", ")))
+		}
+
+		_, err := fmt.Println(strings.Join(lines, "\n"))
+		return err
+	default:
+		return fmt.Errorf("unknown output format %q", output)
+	}
+}
+
 func init() {
 	c := listPoliciesCmd
+	flags := c.Flags()
 	listCmd.AddCommand(c)
+
+	flags.String("explain", "", "Show the effective policy for this liquid type and where each setting comes from")
+	flags.String("volume", "", "Volume to use with --explain, e.g. 5ul")
 }
@@ -41,9 +41,11 @@ var listPolicyCommandsCmd = &cobra.Command{
 }
 
 type simplePolicyCommand struct {
-	Name string
-	Type string
-	Desc string
+	Name  string
+	Type  string
+	Unit  string
+	Range string
+	Desc  string
 }
 
 type simplePolicyCommands []simplePolicyCommand
@@ -66,11 +68,13 @@ func listPolicyCommands(cmd *cobra.Command, args []string) error {
 	}
 
 	var cs simplePolicyCommands
-	for _, c := range wtype.GetPolicyConsequents() {
+	for _, c := range wtype.GetPolicySchema() {
 		cs = append(cs, simplePolicyCommand{
-			Name: c.Name,
-			Type: c.TypeName(),
-			Desc: c.Desc,
+			Name:  c.Name,
+			Type:  c.TypeName(),
+			Unit:  c.Unit,
+			Range: c.Range(),
+			Desc:  c.Desc,
 		})
 	}
 
@@ -94,10 +98,10 @@ func listPolicyCommands(cmd *cobra.Command, args []string) error {
 		return err
 	case textOutput:
 		var lines []string
-		lines = append(lines, "name,type,description")
+		lines = append(lines, "name,type,unit,range,description")
 
 		for _, c := range cs {
-			lines = append(lines, fmt.Sprintf("%s,%s,%s", c.Name, c.Type, c.Desc))
+			lines = append(lines, fmt.Sprintf("%s,%s,%s,%s,%s", c.Name, c.Type, c.Unit, c.Range, c.Desc))
 		}
 
 		_, err := fmt.Println(strings.Join(lines, "\n"))
@@ -0,0 +1,73 @@
+// new_labware.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/antha-lang/antha/inventory/labware"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var newLabwareCmd = &cobra.Command{
+	Use:   "labware <dimensions.csv|dimensions.json> <definitions.yaml>",
+	Short: "Create inventory plate definitions from SBS dimension sheets",
+	Long: `Create inventory plate definitions from SBS dimension sheets
+
+Reads plate types as vendor drawings describe them: footprint, A1 offsets,
+well spacing and well geometry, and any risers the plates go on. Checks that
+the wells fit and writes definitions of the plates, and of them on each of
+their risers, for use with antha run --inventory.`,
+	RunE: newLabware,
+}
+
+func newLabware(cmd *cobra.Command, args []string) error {
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		return err
+	}
+
+	switch len(args) {
+	case 0:
+		return fmt.Errorf("no dimension sheet given")
+	case 1:
+		return fmt.Errorf("no output file given")
+	}
+
+	plates, err := labware.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	f, err := labware.Import(plates)
+	if err != nil {
+		return err
+	}
+
+	return f.Save(args[1])
+}
+
+func init() {
+	c := newLabwareCmd
+	newCmd.AddCommand(c)
+}
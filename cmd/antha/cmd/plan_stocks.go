@@ -0,0 +1,141 @@
+// plan_stocks.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/microArch/planner"
+	"github.com/ghodss/yaml"
+	"github.com/mgutz/ansi"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var planStocksCmd = &cobra.Command{
+	Use:   "stocks <targets.json|targets.yaml>",
+	Short: "Propose stock concentrations and dilutions for a set of target wells",
+	RunE:  planStocks,
+}
+
+func planStocks(cmd *cobra.Command, args []string) error {
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return fmt.Errorf("expected one file of target wells")
+	}
+
+	bs, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	// yaml is a superset of json
+	var req planner.StockRequest
+	if err := yaml.Unmarshal(bs, &req); err != nil {
+		return fmt.Errorf("cannot read targets from %s: %s", args[0], err)
+	}
+
+	if mv := viper.GetString("minVolume"); mv != "" {
+		vol, err := wunit.ParseVolume(mv)
+		if err != nil {
+			return err
+		}
+		req.MinVolume = vol
+	}
+
+	if md := viper.GetFloat64("maxDilution"); md != 0.0 {
+		req.MaxDilution = md
+	}
+
+	design, err := planner.DesignStocks(req)
+	if err != nil {
+		return err
+	}
+
+	output := viper.GetString("output")
+	switch output {
+	case jsonOutput:
+		bs, err := json.MarshalIndent(design, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Println(string(bs))
+		return err
+	case yamlOutput:
+		bs, err := yaml.Marshal(design)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Print(string(bs))
+		return err
+	case textOutput:
+		red := func(x string) string {
+			return ansi.Color(x, "red")
+		}
+
+		var lines []string
+
+		lines = append(lines, red("Stocks"))
+		for _, s := range design.Stocks {
+			lines = append(lines, fmt.Sprintf("  %s %s, at least %s", s.Component, s.Concentration, s.Volume))
+		}
+
+		if len(design.Dilutions) != 0 {
+			lines = append(lines, red("Dilutions"))
+			for _, d := range design.Dilutions {
+				lines = append(lines, fmt.Sprintf("  %s (%s): %s of %s + %s diluent", d.Name, d.Concentration, d.SourceVolume, d.From, d.DiluentVolume))
+			}
+		}
+
+		lines = append(lines, red("Transfers"))
+		for _, t := range design.Transfers {
+			lines = append(lines, fmt.Sprintf("  %s <- %s %s", t.Well, t.Volume, t.Source))
+		}
+
+		lines = append(lines, red("Reasoning"))
+		for _, r := range design.Reasoning {
+			lines = append(lines, "  "+r)
+		}
+
+		_, err := fmt.Println(strings.Join(lines, "\n"))
+		return err
+	default:
+		return fmt.Errorf("unknown output format %q", output)
+	}
+}
+
+func init() {
+	c := planStocksCmd
+	flags := c.Flags()
+	planCmd.AddCommand(c)
+
+	flags.String("minVolume", "", "Smallest volume the robot can transfer, e.g. 0.5ul, overriding the targets file")
+	flags.Float64("maxDilution", 0.0, fmt.Sprintf("Largest dilution to make in one step (default %g)", planner.DefaultMaxDilution))
+}
@@ -26,10 +26,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/url"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/antha-lang/antha/cmd/antha/frontend"
 	"github.com/antha-lang/antha/cmd/antha/pretty"
@@ -37,6 +39,10 @@ import (
 	"github.com/antha-lang/antha/execute"
 	"github.com/antha-lang/antha/execute/executeutil"
 	"github.com/antha-lang/antha/inject"
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/fileinventory"
+	"github.com/antha-lang/antha/inventory/remoteinventory"
+	"github.com/antha-lang/antha/inventory/stock"
 	"github.com/antha-lang/antha/inventory/testinventory"
 	"github.com/antha-lang/antha/target"
 	"github.com/antha-lang/antha/target/auto"
@@ -68,6 +74,20 @@ func makeMixerOpt(ctx context.Context) (mixer.Opt, error) {
 	}
 	opt.InputPlateType = GetStringSlice("inputPlateType")
 	opt.OutputPlateType = GetStringSlice("outputPlateType")
+	if s := viper.GetString("inputPlateQuery"); s != "" {
+		q, err := inventory.ParsePlateQuery(s)
+		if err != nil {
+			return opt, err
+		}
+		opt.InputPlateQuery = &q
+	}
+	if s := viper.GetString("outputPlateQuery"); s != "" {
+		q, err := inventory.ParsePlateQuery(s)
+		if err != nil {
+			return opt, err
+		}
+		opt.OutputPlateQuery = &q
+	}
 	opt.TipType = GetStringSlice("tipType")
 
 	for _, fn := range GetStringSlice("inputPlates") {
@@ -92,11 +112,54 @@ func makeMixerOpt(ctx context.Context) (mixer.Opt, error) {
 	opt.LegacyVolume = viper.GetBool("legacyVolumeTracking")
 
 	opt.FixVolumes = viper.GetBool("fixVolumes")
+	opt.OptimizeLayout = viper.GetBool("optimizeLayout")
+	opt.InsertDilutions = viper.GetBool("insertDilutions")
+	opt.OrderShortfalls = viper.GetBool("orderShortfalls")
+	opt.ChannelScorer = viper.GetString("channelScorer")
+	opt.MaxTransferError = viper.GetFloat64("maxTransferError")
+
+	if bs := GetStringSlice("barcode"); len(bs) != 0 {
+		opt.ScannedBarcodes = make(map[string]string)
+		for _, b := range bs {
+			kv := strings.SplitN(b, "=", 2)
+			if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+				return opt, fmt.Errorf("invalid barcode %q: expecting position=barcode", b)
+			}
+			opt.ScannedBarcodes[kv[0]] = kv[1]
+		}
+	}
 
 	return opt, nil
 }
 
-func makeContext() (context.Context, error) {
+// makeInventoryContext adds the inventory served at server, or failing that
+// the built-in one, to ctx, with any definitions in dirs on top of it. The
+// returned function closes any connection to the server.
+func makeInventoryContext(ctx context.Context, server string, dirs []string) (context.Context, func() error, error) {
+	done := func() error { return nil }
+	if len(server) != 0 {
+		c, err := remoteinventory.Dial(server)
+		if err != nil {
+			return nil, nil, err
+		}
+		ctx = inventory.NewContext(ctx, c)
+		done = c.Close
+	} else {
+		ctx = testinventory.NewContext(ctx)
+	}
+	if len(dirs) == 0 {
+		return ctx, done, nil
+	}
+	ctx, err := fileinventory.NewContext(ctx, inventory.GetInventory(ctx), dirs...)
+	if err != nil {
+		done() // nolint: errcheck
+		return nil, nil, err
+	}
+	return ctx, done, nil
+}
+
+// makeContext returns a context with the element library and inv
+func makeContext(inv inventory.Inventory) (context.Context, error) {
 	ctx := inject.NewContext(context.Background())
 	for _, desc := range library {
 		obj := desc.Constructor()
@@ -108,12 +171,14 @@ func makeContext() (context.Context, error) {
 			return nil, fmt.Errorf("error adding protocol %q: %s", desc.Name, err)
 		}
 	}
-	return testinventory.NewContext(ctx), nil
+	return inventory.NewContext(ctx, inv), nil
 }
 
 type runOpt struct {
 	MixerOpt               mixer.Opt
-	Drivers                []string
+	Drivers                []auto.Endpoint
+	Inventory              inventory.Inventory
+	StockFile              string
 	BundleFile             string
 	ParametersFile         string
 	WorkflowFile           string
@@ -173,9 +238,7 @@ func (a *runOpt) Run() error {
 	opt := auto.Opt{
 		MaybeArgs: []interface{}{mixerOpt},
 	}
-	for _, uri := range a.Drivers {
-		opt.Endpoints = append(opt.Endpoints, auto.Endpoint{URI: uri})
-	}
+	opt.Endpoints = append(opt.Endpoints, a.Drivers...)
 	t, err := auto.New(opt)
 	if err != nil {
 		return err
@@ -187,11 +250,19 @@ func (a *runOpt) Run() error {
 	}
 	defer fe.Shutdown() // nolint: errcheck
 
-	ctx, err := makeContext()
+	ctx, err := makeContext(a.Inventory)
 	if err != nil {
 		return err
 	}
 
+	var ledger *stock.Ledger
+	if len(a.StockFile) != 0 {
+		if ledger, err = stock.Load(a.StockFile); err != nil {
+			return err
+		}
+		ctx = stock.NewContext(ctx, ledger)
+	}
+
 	rout, err := execute.Run(ctx, execute.Opt{
 		Target:   t.Target,
 		Workflow: wdesc,
@@ -202,6 +273,14 @@ func (a *runOpt) Run() error {
 		return err
 	}
 
+	// keep the stock reserved by the plan
+	if ledger != nil {
+		if err := ledger.Save(a.StockFile); err != nil {
+			return err
+		}
+		reportReservations(os.Stdout, a.StockFile, rout.Insts)
+	}
+
 	// if option is set, add liquid handling instruction output
 	if a.MixInstructionFileName != "" {
 		countFiles := 1
@@ -263,20 +342,50 @@ func (a *runOpt) Run() error {
 	return nil
 }
 
+// reportReservations says which mixes hold stock in the ledger and how to
+// settle their reservations
+func reportReservations(w io.Writer, stockFile string, insts []target.Inst) {
+	for _, inst := range insts {
+		mix, ok := inst.(*target.Mix)
+		if !ok || len(mix.Reservations) == 0 {
+			continue
+		}
+		holder := mix.Reservations[0].Holder
+		fmt.Fprintf(w, "Stock reserved in %s for mix %s; once it has run use antha stock consume --stock %s %s, or if it won't be run antha stock release --stock %s %s\n", stockFile, holder, stockFile, holder, stockFile, holder)
+	}
+}
+
 func runWorkflow(cmd *cobra.Command, args []string) error {
 	if err := viper.BindPFlags(cmd.Flags()); err != nil {
 		return err
 	}
 
-	ctx := testinventory.NewContext(context.Background())
+	ctx, done, err := makeInventoryContext(context.Background(), viper.GetString("inventoryServer"), GetStringSlice("inventory"))
+	if err != nil {
+		return err
+	}
+	defer done() // nolint: errcheck
 
-	var drivers []string
+	var drivers []auto.Endpoint
 	for idx, uri := range GetStringSlice("driver") {
 		u, err := url.Parse(uri)
 		if err != nil {
 			return err
 		}
 
+		// writer+scheme://... writes up the instructions for the driver
+		// at scheme://... instead of sending them to it
+		var ep auto.Endpoint
+		if i := strings.Index(u.Scheme, "+"); i >= 0 {
+			ep.Writer = u.Scheme[:i]
+			if _, ok := auto.Writers[ep.Writer]; !ok {
+				return fmt.Errorf("unknown writer %q in %s, expected one of %s", ep.Writer, uri, strings.Join(auto.WriterNames(), ", "))
+			}
+			ep.Format = u.Query().Get("format")
+			u.Scheme = u.Scheme[i+1:]
+			u.RawQuery = ""
+		}
+
 		switch u.Scheme {
 		case "go":
 			p := u.Host + u.Path
@@ -289,16 +398,16 @@ func runWorkflow(cmd *cobra.Command, args []string) error {
 			} else if err := s.Start(); err != nil {
 				return fmt.Errorf("cannot start package %s: %s", p, err)
 			}
-			uri, err := s.URI()
+			ep.URI, err = s.URI()
 			if err != nil {
 				return fmt.Errorf("cannot parse port for package %s: %s", p, err)
 			}
-			drivers = append(drivers, uri)
 		case "tcp":
-			drivers = append(drivers, u.Host)
+			ep.URI = u.Host
 		default:
-			drivers = append(drivers, u.String())
+			ep.URI = u.String()
 		}
+		drivers = append(drivers, ep)
 	}
 
 	mopt, err := makeMixerOpt(ctx)
@@ -309,6 +418,8 @@ func runWorkflow(cmd *cobra.Command, args []string) error {
 	opt := &runOpt{
 		MixerOpt:               mopt,
 		Drivers:                drivers,
+		Inventory:              inventory.GetInventory(ctx),
+		StockFile:              viper.GetString("stock"),
 		BundleFile:             viper.GetString("bundle"),
 		ParametersFile:         viper.GetString("parameters"),
 		WorkflowFile:           viper.GetString("workflow"),
@@ -330,19 +441,30 @@ func init() {
 	flags.Bool("printInstructions", false, "Output the raw instructions sent to the driver")
 	flags.Bool("useDriverTipTracking", false, "If the driver has tip tracking available, use it")
 	flags.Bool("withMulti", false, "Allow use of new multichannel planning - deprecated")
+	flags.Float64("maxTransferError", 0.0, "Largest expected error in percent allowed by the errorbudget channel scorer")
 	flags.Float64("residualVolumeWeight", 0.0, "Residual volume weight")
 	flags.Int("maxPlates", 0, "Maximum number of plates")
 	flags.Int("maxWells", 0, "Maximum number of wells on a plate")
+	flags.String("channelScorer", "", "How to choose channels and tips: default, accuracy (smallest expected error) or errorbudget (quickest within maxTransferError)")
 	flags.String("bundle", "", "Input bundle with parameters and workflow together (overrides parameter and workflow arguments)")
 	flags.String("makeTestBundle", "", "Generate json format bundle for testing and put it here")
 	flags.String("mixInstructionFileName", "", "Name of instructions files to output to for mixes")
+	flags.String("inputPlateQuery", "", "Search the inventory for input plate types meeting these constraints instead of using inputPlateType, e.g., wells=96,minWellVolume=200ul,skirt=full,sbs; plates whose skirt is not known never match a skirt")
+	flags.String("inventoryServer", "", "Address of an inventory server (see antha serve inventory) to use instead of the built-in inventory")
+	flags.String("outputPlateQuery", "", "Search the inventory for output plate types meeting these constraints instead of using outputPlateType (see inputPlateQuery)")
 	flags.String("parameters", "parameters.json", "Parameters to workflow")
+	flags.String("stock", "", "Stock ledger (JSON or YAML) to reserve the inputs of the plan from; the reservations are saved back to it")
 	flags.String("workflow", "workflow.json", "Workflow definition file")
+	flags.StringSlice("barcode", nil, "Barcode scanned at a deck position, as position=barcode, to check against the plan before running; use multiple flags for multiple positions")
 	flags.StringSlice("component", nil, "Uris of remote components ({tcp,go}://...); use multiple flags for multiple components")
-	flags.StringSlice("driver", nil, "Uris of remote drivers ({tcp,go}://...); use multiple flags for multiple drivers")
+	flags.StringSlice("driver", nil, "Uris of remote drivers ({tcp,go}://...); use multiple flags for multiple drivers. Prefix a liquid handler's scheme with worklist+ or bench+ to write its instructions up instead of running them, choosing a format with ?format=")
 	flags.StringSlice("inputPlateType", nil, "Default input plate types (in order of preference)")
 	flags.StringSlice("inputPlates", nil, "File containing input plates")
+	flags.StringSlice("inventory", nil, "Directories of plate, tipbox, tipwaste and component definitions to use on top of the built-in ones; use multiple flags for multiple directories, later ones taking precedence")
 	flags.StringSlice("outputPlateType", nil, "Default output plate types (in order of preference)")
 	flags.StringSlice("tipType", nil, "Names of permitted tip types")
 	flags.Bool("fixVolumes", true, "Make all volumes sufficient for later uses")
+	flags.Bool("optimizeLayout", false, "Rearrange the deck to minimise head travel")
+	flags.Bool("insertDilutions", false, "Make intermediate dilutions for volumes too small to transfer")
+	flags.Bool("orderShortfalls", false, "If the stock ledger has too little of an input, order more rather than failing")
 }
@@ -0,0 +1,68 @@
+// serve_inventory.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/remoteinventory"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var serveInventoryCmd = &cobra.Command{
+	Use:   "inventory",
+	Short: "Serve an inventory for antha run --inventoryServer",
+	RunE:  serveInventory,
+}
+
+func serveInventory(cmd *cobra.Command, args []string) error {
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		return err
+	}
+
+	ctx, _, err := makeInventoryContext(context.Background(), "", GetStringSlice("inventory"))
+	if err != nil {
+		return err
+	}
+
+	lis, err := net.Listen("tcp", viper.GetString("listen"))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Serving inventory on %s\n", lis.Addr())
+	return remoteinventory.Serve(lis, inventory.GetInventory(ctx))
+}
+
+func init() {
+	c := serveInventoryCmd
+	flags := c.Flags()
+	serveCmd.AddCommand(c)
+
+	flags.String("listen", ":50052", "Address to listen on")
+	flags.StringSlice("inventory", nil, "Directories of plate, tipbox, tipwaste and component definitions to serve on top of the built-in ones; use multiple flags for multiple directories, later ones taking precedence")
+}
@@ -0,0 +1,102 @@
+// stock.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/antha-lang/antha/inventory/stock"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var stockCmd = &cobra.Command{
+	Use:   "stock",
+	Short: "Settle the reservations antha run makes in a stock ledger",
+}
+
+var stockConsumeCmd = &cobra.Command{
+	Use:   "consume <holder>",
+	Short: "Take the stock reserved for a mix out of its lots once the mix has been run",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return settleStock(cmd, args, "consumed", (*stock.Ledger).Consume)
+	},
+}
+
+var stockReleaseCmd = &cobra.Command{
+	Use:   "release <holder>",
+	Short: "Cancel the stock reserved for a mix which will not be run",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return settleStock(cmd, args, "released", (*stock.Ledger).Release)
+	},
+}
+
+// settleStock consumes or releases the reservations of the holder named in
+// args and saves the ledger
+func settleStock(cmd *cobra.Command, args []string, done string, settle func(*stock.Ledger, string)) error {
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		return err
+	}
+
+	if len(args) != 1 {
+		return fmt.Errorf("expected the holder of the reservations")
+	}
+	holder := args[0]
+
+	fn := viper.GetString("stock")
+	if fn == "" {
+		return fmt.Errorf("no stock ledger given")
+	}
+
+	l, err := stock.Load(fn)
+	if err != nil {
+		return err
+	}
+
+	rs := l.Reservations(holder)
+	if len(rs) == 0 {
+		return fmt.Errorf("no reservations held by %s in %s", holder, fn)
+	}
+
+	settle(l, holder)
+
+	if err := l.Save(fn); err != nil {
+		return err
+	}
+
+	for _, r := range rs {
+		fmt.Printf("%s %s\n", done, r)
+	}
+	return nil
+}
+
+func init() {
+	c := stockCmd
+	flags := c.PersistentFlags()
+	RootCmd.AddCommand(c)
+
+	flags.String("stock", "", "Stock ledger (JSON or YAML) holding the reservations")
+
+	c.AddCommand(stockConsumeCmd)
+	c.AddCommand(stockReleaseCmd)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	"github.com/antha-lang/antha/ast"
 	"github.com/antha-lang/antha/graph"
+	"github.com/antha-lang/antha/inventory/stock"
 	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
 	"github.com/antha-lang/antha/target"
 )
@@ -73,10 +74,16 @@ func (a *ir) addImplicitMixInsts() error {
 		return nil
 	}
 
+	var shortfalls stock.Shortfalls
+	for _, mix := range mixes {
+		shortfalls = append(shortfalls, mix.Shortfalls...)
+	}
+
 	if len(mixes) != 0 {
 		a.initializers = append(a.initializers,
 			&target.Order{
-				Mixes: mixes,
+				Mixes:      mixes,
+				Shortfalls: shortfalls,
 			},
 			&target.PlatePrep{
 				Mixes: mixes,
@@ -0,0 +1,321 @@
+// driver/bench/bench.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+// Package bench provides an in-process liquid handling driver which, rather
+// than controlling a robot, writes the instructions sent to it up as a
+// protocol to be carried out by hand at the bench: how to prepare each
+// plate, any master mixes worth making up and a checklist of the transfers
+// to pipette, grouped by what is being pipetted
+package bench
+
+import (
+	"fmt"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/driver/offline"
+	"github.com/antha-lang/antha/microArch/driver"
+	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+var (
+	_ liquidhandling.ExtendedLiquidhandlingDriver = &Driver{}
+)
+
+// Formats a protocol can be written in
+const (
+	Markdown = "markdown"
+	HTML     = "html" // a single page which prints cleanly
+)
+
+// DefaultMasterMixExcess is how much more of each master mix is made up than
+// is dispensed, as a fraction of what is dispensed
+const DefaultMasterMixExcess = 0.1
+
+// Opt are options for a bench Driver
+type Opt struct {
+	Title           string            // Title of the protocol; defaults to "Bench protocol"
+	Format          string            // Markdown or HTML; defaults to Markdown
+	PlateLabels     map[string]string // Labels to use for plates by plate name; defaults to the name
+	MasterMixExcess float64           // Excess of master mixes to make up; defaults to DefaultMasterMixExcess
+	NoMasterMixes   bool              // Pipette every reagent separately
+}
+
+// A Location is a well of a labelled plate
+type Location struct {
+	Label string
+	Well  string
+}
+
+func (l Location) String() string {
+	if l.Well == "" {
+		return l.Label
+	}
+	return fmt.Sprintf("%s %s", l.Label, l.Well)
+}
+
+type plate struct {
+	label    string
+	ptype    string
+	position string
+	wells    []WellPrep
+}
+
+// a transfer from one well to another, in ul
+type transfer struct {
+	from    Location
+	to      Location
+	reagent string
+	volume  float64
+	// whether the source was filled when the plates were prepared, rather
+	// than by an earlier transfer
+	bulk   bool
+	premix Mix
+	mix    Mix
+	// messages for whoever is pipetting, to be read first
+	notes []Note
+}
+
+// A Driver turns the instructions sent to it into a protocol, returned by
+// GetOutputFile. As with any other driver the protocol is started again
+// whenever the plates are removed from the deck
+type Driver struct {
+	offline.Base
+	opt       Opt
+	plates    map[string]*plate
+	order     []string
+	contents  map[Location]string
+	transfers []transfer
+	// the last transfer made by each channel, mixes of sources and
+	// messages waiting for the next
+	last   map[int]int
+	premix map[int]Mix
+	notes  []Note
+}
+
+// NewDriver creates a bench driver standing in for a robot with the given
+// properties, which are what instructions are planned for
+func NewDriver(props *liquidhandling.LHProperties, opt Opt) *Driver {
+	if opt.Title == "" {
+		opt.Title = "Bench protocol"
+	}
+	if opt.Format == "" {
+		opt.Format = Markdown
+	}
+	if opt.MasterMixExcess == 0.0 {
+		opt.MasterMixExcess = DefaultMasterMixExcess
+	}
+
+	d := &Driver{
+		Base: offline.Base{Props: props, Output: "bench protocols"},
+		opt:  opt,
+	}
+	d.reset()
+
+	return d
+}
+
+func (d *Driver) reset() {
+	d.plates = make(map[string]*plate)
+	d.order = nil
+	d.contents = make(map[Location]string)
+	d.ResetChannels()
+	d.transfers = nil
+	d.last = make(map[int]int)
+	d.premix = make(map[int]Mix)
+	d.notes = nil
+}
+
+func (d *Driver) location(pos, well string) Location {
+	label := pos
+	if p, ok := d.plates[pos]; ok {
+		label = p.label
+	}
+	return Location{Label: label, Well: well}
+}
+
+// reagent is what a well holds: whatever it was prepared with or, for wells
+// filled by transfers, what the well is
+func (d *Driver) reagent(l Location) (string, bool) {
+	if c, ok := d.contents[l]; ok {
+		return c, true
+	}
+	return fmt.Sprintf("contents of %s", l), false
+}
+
+// Dispense implements a LiquidhandlingDriver: every dispense is a step of
+// the protocol
+func (d *Driver) Dispense(volume []float64, blowout []bool, head int, multi int, platetype []string, what []string, llf []bool) driver.CommandStatus {
+	for i, v := range volume {
+		if v <= 0.0 {
+			continue
+		}
+		ch, st := d.Holding(i)
+		if !st.OK {
+			return st
+		}
+		from := d.location(ch.Source, ch.SourceWell)
+		reagent, bulk := d.reagent(from)
+
+		t := transfer{
+			from:    from,
+			to:      d.location(ch.Position, ch.Well),
+			reagent: reagent,
+			volume:  v,
+			bulk:    bulk,
+			premix:  d.premix[i],
+			notes:   d.notes,
+		}
+		delete(d.premix, i)
+		d.notes = nil
+
+		d.last[i] = len(d.transfers)
+		d.transfers = append(d.transfers, t)
+	}
+	return offline.OK()
+}
+
+// Mix implements a LiquidhandlingDriver. Mixing a well a channel has just
+// dispensed into is written up after that step; mixing anywhere else is
+// taken to be of a source and written up before the channel's next step
+func (d *Driver) Mix(head int, volume []float64, platetype []string, cycles []int, multi int, what []string, blowout []bool) driver.CommandStatus {
+	for i, v := range volume {
+		if v <= 0.0 || i >= len(cycles) || i >= len(d.Channels) {
+			continue
+		}
+		ch := d.Channel(i)
+		m := Mix{Cycles: cycles[i], Volume: v}
+
+		if t, ok := d.last[i]; ok && d.transfers[t].to == d.location(ch.Position, ch.Well) {
+			d.transfers[t].mix = m
+			continue
+		}
+
+		d.premix[i] = m
+	}
+	return offline.OK()
+}
+
+// AddPlateTo implements a LiquidhandlingDriver. Plates are written up with
+// whatever they hold as they are added, which is what they need to be
+// prepared with; tip boxes and tip waste are left out
+func (d *Driver) AddPlateTo(position string, p interface{}, name string) driver.CommandStatus {
+	lp, ok := p.(*wtype.LHPlate)
+	if !ok {
+		return offline.OK()
+	}
+
+	label := name
+	if l, ok := d.opt.PlateLabels[name]; ok {
+		label = l
+	}
+
+	if _, seen := d.plates[position]; !seen {
+		d.order = append(d.order, position)
+	}
+
+	pl := &plate{
+		label:    label,
+		ptype:    lp.Type,
+		position: position,
+	}
+
+	for _, wc := range lp.AllWellPositions(false) {
+		w, ok := lp.Wellcoords[wc]
+		if !ok || w.Empty() {
+			continue
+		}
+		cname := w.Contents().CName
+		pl.wells = append(pl.wells, WellPrep{
+			Well:      wc,
+			Component: cname,
+			Volume:    w.CurrentVolume().ConvertToString("ul"),
+		})
+		d.contents[Location{Label: label, Well: wc}] = cname
+	}
+
+	d.plates[position] = pl
+
+	return offline.OK()
+}
+
+// RemoveAllPlates implements a LiquidhandlingDriver; this starts a new
+// protocol
+func (d *Driver) RemoveAllPlates() driver.CommandStatus {
+	d.reset()
+	return offline.OK()
+}
+
+// RemovePlateAt implements a LiquidhandlingDriver
+func (d *Driver) RemovePlateAt(position string) driver.CommandStatus {
+	delete(d.plates, position)
+	for i, pos := range d.order {
+		if pos == position {
+			d.order = append(d.order[:i], d.order[i+1:]...)
+			break
+		}
+	}
+	return offline.OK()
+}
+
+// Message implements a LiquidhandlingDriver: messages are written up as
+// notes to read before the step which follows them
+func (d *Driver) Message(level int, title, text string, showcancel bool) driver.CommandStatus {
+	d.notes = append(d.notes, Note{Title: title, Text: text})
+	return offline.OK()
+}
+
+// Protocol returns the protocol for the instructions sent since the plates
+// were last removed
+func (d *Driver) Protocol() *Protocol {
+	return d.protocol()
+}
+
+// GetOutputFile implements an ExtendedLiquidhandlingDriver and returns the
+// protocol in the format asked for
+func (d *Driver) GetOutputFile() (string, driver.CommandStatus) {
+	var out []byte
+	var err error
+
+	switch d.opt.Format {
+	case Markdown:
+		out, err = d.protocol().Markdown()
+	case HTML:
+		out, err = d.protocol().HTML()
+	default:
+		err = fmt.Errorf("unknown bench protocol format %q", d.opt.Format)
+	}
+
+	if err != nil {
+		return "", offline.Fail(err.Error())
+	}
+
+	return string(out), offline.OK()
+}
+
+// OutputFileName is the name given to the protocol in the files produced
+// for a mix
+func (d *Driver) OutputFileName() string {
+	if d.opt.Format == HTML {
+		return "protocol.html"
+	}
+	return "protocol.md"
+}
@@ -0,0 +1,215 @@
+package bench
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/testinventory"
+)
+
+func setUpDeck(t *testing.T, d *Driver) {
+	ctx := testinventory.NewContext(context.Background())
+
+	in, err := inventory.NewPlate(ctx, "pcrplate_skirted")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for well, contents := range map[string]string{"A1": "water", "B1": "buffer", "C1": "dna"} {
+		c := wtype.NewLHComponent()
+		c.CName = contents
+		c.SetVolume(wunit.NewVolume(100.0, "ul"))
+		in.Wellcoords[well].Add(c)
+	}
+
+	out, err := inventory.NewPlate(ctx, "pcrplate_skirted")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if st := d.AddPlateTo("position_4", in, "Input_plate_1"); !st.OK {
+		t.Fatal(st.Msg)
+	}
+	if st := d.AddPlateTo("position_7", out, "Output_plate_1"); !st.OK {
+		t.Fatal(st.Msg)
+	}
+}
+
+func pipetteOne(d *Driver, fromPos, fromWell, toPos, toWell string, vol float64) {
+	d.Move([]string{fromPos}, []string{fromWell}, nil, nil, nil, nil, nil, 0)
+	d.Aspirate([]float64{vol}, nil, 0, 1, nil, nil, nil)
+	d.Move([]string{toPos}, []string{toWell}, nil, nil, nil, nil, nil, 0)
+	d.Dispense([]float64{vol}, nil, 0, 1, nil, nil, nil)
+	d.UnloadTips([]int{0}, 0, 1, nil, nil, nil)
+}
+
+// three wells of water and buffer, the first with dna in too, which is then
+// moved on to another well
+func pipette(d *Driver) {
+	for _, w := range []string{"A1", "B1", "C1"} {
+		pipetteOne(d, "position_4", "A1", "position_7", w, 10.0)
+	}
+	for _, w := range []string{"A1", "B1", "C1"} {
+		pipetteOne(d, "position_4", "B1", "position_7", w, 5.0)
+	}
+	pipetteOne(d, "position_4", "C1", "position_7", "A1", 2.0)
+	pipetteOne(d, "position_7", "A1", "position_7", "A2", 4.0)
+}
+
+func TestMarkdown(t *testing.T) {
+	d := NewDriver(nil, Opt{
+		Title:       "Test",
+		PlateLabels: map[string]string{"Input_plate_1": "Sources"},
+	})
+
+	d.RemoveAllPlates()
+	setUpDeck(t, d)
+	pipette(d)
+
+	out, st := d.GetOutputFile()
+	if !st.OK {
+		t.Fatal(st.Msg)
+	}
+
+	expected := `# Test
+
+## Plates
+
+| Plate | Type | Deck position |
+|---|---|---|
+| Sources | pcrplate_skirted | position_4 |
+| Output_plate_1 | pcrplate_skirted | position_7 |
+
+## Plate preparation
+
+### Sources
+
+| Well | Contents | Volume (ul) |
+|---|---|---|
+| A1 | water | 100 |
+| B1 | buffer | 100 |
+| C1 | dna | 100 |
+
+## Master mixes
+
+### Master mix 1
+
+Makes enough for 3 wells plus 10% excess.
+
+| Reagent | Per well (ul) | Total (ul) |
+|---|---|---|
+| water | 10 | 33 |
+| buffer | 5 | 16.5 |
+| **Total** | 15 | 49.5 |
+
+## Pipetting
+
+### Master mix 1
+
+- [ ] 15 ul from Master mix 1 to Output_plate_1 A1
+- [ ] 15 ul from Master mix 1 to Output_plate_1 B1
+- [ ] 15 ul from Master mix 1 to Output_plate_1 C1
+
+### dna
+
+- [ ] 2 ul from Sources C1 to Output_plate_1 A1
+
+### contents of Output_plate_1 A1
+
+- [ ] 4 ul from Output_plate_1 A1 to Output_plate_1 A2
+`
+	if out != expected {
+		t.Errorf("expected protocol\n%s\ngot\n%s", expected, out)
+	}
+
+	if d.OutputFileName() != "protocol.md" {
+		t.Errorf("expected protocol.md, got %s", d.OutputFileName())
+	}
+}
+
+func TestNoMasterMixes(t *testing.T) {
+	d := NewDriver(nil, Opt{NoMasterMixes: true})
+
+	setUpDeck(t, d)
+	pipette(d)
+
+	p := d.Protocol()
+
+	if len(p.MasterMixes) != 0 {
+		t.Errorf("expected no master mixes, got %v", p.MasterMixes)
+	}
+
+	var reagents []string
+	for _, rs := range p.Steps {
+		reagents = append(reagents, rs.Reagent)
+	}
+
+	if r := strings.Join(reagents, ","); r != "water,buffer,dna,contents of Output_plate_1 A1" {
+		t.Errorf("expected steps grouped by reagent in order of use, got %s", r)
+	}
+
+	if n := len(p.Steps[0].Steps); n != 3 {
+		t.Errorf("expected 3 transfers of water, got %d", n)
+	}
+}
+
+func TestHTML(t *testing.T) {
+	d := NewDriver(nil, Opt{Format: HTML})
+
+	setUpDeck(t, d)
+	pipette(d)
+
+	out, st := d.GetOutputFile()
+	if !st.OK {
+		t.Fatal(st.Msg)
+	}
+
+	for _, s := range []string{
+		"<h1>Bench protocol</h1>",
+		"<tr><td>A1</td><td>water</td><td>100</td></tr>",
+		"<h3>Master mix 1</h3>",
+		`<li><label><input type="checkbox"> 2 ul from Input_plate_1 C1 to Output_plate_1 A1</label></li>`,
+		"@media print",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected protocol to contain %q, got\n%s", s, out)
+		}
+	}
+
+	if d.OutputFileName() != "protocol.html" {
+		t.Errorf("expected protocol.html, got %s", d.OutputFileName())
+	}
+}
+
+func TestMixAndMessage(t *testing.T) {
+	d := NewDriver(nil, Opt{NoMasterMixes: true})
+
+	setUpDeck(t, d)
+
+	d.Message(0, "Thaw", "take the dna out of the freezer", false)
+	d.Move([]string{"position_4"}, []string{"C1"}, nil, nil, nil, nil, nil, 0)
+	d.Mix(0, []float64{50.0}, nil, []int{3}, 1, nil, nil)
+	d.Aspirate([]float64{2.0}, nil, 0, 1, nil, nil, nil)
+	d.Move([]string{"position_7"}, []string{"A1"}, nil, nil, nil, nil, nil, 0)
+	d.Dispense([]float64{2.0}, nil, 0, 1, nil, nil, nil)
+	d.Mix(0, []float64{10.0}, nil, []int{5}, 1, nil, nil)
+	d.Message(0, "", "seal the plate", false)
+
+	out, st := d.GetOutputFile()
+	if !st.OK {
+		t.Fatal(st.Msg)
+	}
+
+	for _, s := range []string{
+		"- **Thaw:** take the dna out of the freezer\n- [ ] mix Input_plate_1 C1 3 times with 50 ul, then 2 ul from Input_plate_1 C1 to Output_plate_1 A1, then mix Output_plate_1 A1 5 times with 10 ul\n",
+		"## Notes\n\n- seal the plate\n",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected protocol to contain %q, got\n%s", s, out)
+		}
+	}
+}
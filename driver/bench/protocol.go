@@ -0,0 +1,274 @@
+// driver/bench/protocol.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package bench
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A Protocol is a set of instructions written up to be carried out by hand.
+// All volumes are in ul
+type Protocol struct {
+	Title       string
+	Plates      []PlatePrep
+	MasterMixes []MasterMix
+	Steps       []ReagentSteps
+	Notes       []Note // to read once everything has been pipetted
+}
+
+// A PlatePrep is what to put in a plate before starting
+type PlatePrep struct {
+	Label    string
+	Type     string
+	Position string // deck position the plate was planned for
+	Wells    []WellPrep
+}
+
+// A WellPrep is what to put in a well before starting
+type WellPrep struct {
+	Well      string
+	Component string
+	Volume    float64
+}
+
+// An Ingredient is one reagent of a master mix
+type Ingredient struct {
+	Reagent string
+	PerWell float64 // volume dispensed to each well
+	Total   float64 // volume to make up, including excess
+}
+
+// A MasterMix is a set of reagents which go into the same wells in the same
+// volumes, and so can be mixed up front and dispensed together
+type MasterMix struct {
+	Name         string
+	Ingredients  []Ingredient
+	PerWell      float64 // volume of the mix dispensed to each well
+	Total        float64 // volume of the mix to make up
+	Excess       float64 // fraction made up beyond what is dispensed
+	Destinations []Location
+}
+
+// A Mix is mixing a well by pipetting up and down, none if Cycles is zero
+type Mix struct {
+	Cycles int
+	Volume float64
+}
+
+// A Note is a message for whoever is pipetting
+type Note struct {
+	Title string
+	Text  string
+}
+
+// A Step is a single transfer to pipette
+type Step struct {
+	From   Location
+	To     Location
+	Volume float64
+	PreMix Mix    // mixing of the source before aspirating
+	Mix    Mix    // mixing of the destination after dispensing
+	Notes  []Note // to read before starting the step
+}
+
+// ReagentSteps are the transfers of one reagent or master mix, in the order
+// they are to be pipetted
+type ReagentSteps struct {
+	Reagent string
+	Steps   []Step
+}
+
+// formatVolume writes v to no more than six decimal places, which hides
+// rounding from unit conversion
+func formatVolume(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 6, 64)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// a reagent going into a well
+type addition struct {
+	to      Location
+	reagent string
+}
+
+// a reagent going into some wells in the same volume
+type part struct {
+	reagent string
+	volume  float64
+	wells   []Location
+}
+
+// findMasterMixes groups the reagents which were set out on the plates to
+// start with, and which go into two or more wells in the same volumes as
+// each other, into master mixes. Which mix covers which additions is
+// returned alongside
+func findMasterMixes(transfers []transfer, excess float64) ([]MasterMix, map[addition]int) {
+	var additions []addition
+	volumes := make(map[addition]float64)
+
+	for _, t := range transfers {
+		if !t.bulk {
+			continue
+		}
+		a := addition{to: t.to, reagent: t.reagent}
+		if _, seen := volumes[a]; !seen {
+			additions = append(additions, a)
+		}
+		volumes[a] += t.volume
+	}
+
+	// which wells get each reagent at each volume
+	var parts []*part
+	partOf := make(map[string]*part)
+
+	for _, a := range additions {
+		v := volumes[a]
+		key := a.reagent + "\x00" + formatVolume(v)
+		p, ok := partOf[key]
+		if !ok {
+			p = &part{reagent: a.reagent, volume: v}
+			partOf[key] = p
+			parts = append(parts, p)
+		}
+		p.wells = append(p.wells, a.to)
+	}
+
+	// parts going to exactly the same wells can be mixed
+	var groups [][]*part
+	groupOf := make(map[string]int)
+
+	for _, p := range parts {
+		if len(p.wells) < 2 {
+			continue
+		}
+		wells := make([]string, 0, len(p.wells))
+		for _, w := range p.wells {
+			wells = append(wells, w.String())
+		}
+		sort.Strings(wells)
+		key := strings.Join(wells, "\x00")
+
+		g, ok := groupOf[key]
+		if !ok {
+			g = len(groups)
+			groupOf[key] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], p)
+	}
+
+	var mixes []MasterMix
+	covered := make(map[addition]int)
+
+	for _, g := range groups {
+		if len(g) < 2 {
+			continue
+		}
+
+		n := float64(len(g[0].wells))
+		mm := MasterMix{
+			Name:         "Master mix " + strconv.Itoa(len(mixes)+1),
+			Excess:       excess,
+			Destinations: g[0].wells,
+		}
+
+		for _, p := range g {
+			total := p.volume * n * (1.0 + excess)
+			mm.Ingredients = append(mm.Ingredients, Ingredient{Reagent: p.reagent, PerWell: p.volume, Total: total})
+			mm.PerWell += p.volume
+			mm.Total += total
+
+			for _, w := range p.wells {
+				covered[addition{to: w, reagent: p.reagent}] = len(mixes)
+			}
+		}
+
+		mixes = append(mixes, mm)
+	}
+
+	return mixes, covered
+}
+
+func (d *Driver) protocol() *Protocol {
+	p := &Protocol{Title: d.opt.Title}
+
+	for _, pos := range d.order {
+		pl := d.plates[pos]
+		p.Plates = append(p.Plates, PlatePrep{
+			Label:    pl.label,
+			Type:     pl.ptype,
+			Position: pl.position,
+			Wells:    pl.wells,
+		})
+	}
+
+	covered := make(map[addition]int)
+	if !d.opt.NoMasterMixes {
+		p.MasterMixes, covered = findMasterMixes(d.transfers, d.opt.MasterMixExcess)
+	}
+
+	// steps are grouped by what is pipetted, in the order each is first
+	// used; master mixes go into each well once, when the first of their
+	// reagents would have
+	groupOf := make(map[string]int)
+	mixed := make(map[addition]bool)
+
+	add := func(reagent string, s Step) {
+		g, ok := groupOf[reagent]
+		if !ok {
+			g = len(p.Steps)
+			groupOf[reagent] = g
+			p.Steps = append(p.Steps, ReagentSteps{Reagent: reagent})
+		}
+		p.Steps[g].Steps = append(p.Steps[g].Steps, s)
+	}
+
+	// notes go with the next step written, which for transfers covered by
+	// a master mix may be a later one
+	var notes []Note
+
+	for _, t := range d.transfers {
+		notes = append(notes, t.notes...)
+
+		if m, ok := covered[addition{to: t.to, reagent: t.reagent}]; ok && t.bulk {
+			mm := p.MasterMixes[m]
+			a := addition{to: t.to, reagent: mm.Name}
+			if !mixed[a] {
+				mixed[a] = true
+				add(mm.Name, Step{From: Location{Label: mm.Name}, To: t.to, Volume: mm.PerWell, PreMix: t.premix, Mix: t.mix, Notes: notes})
+				notes = nil
+			}
+			continue
+		}
+
+		add(t.reagent, Step{From: t.from, To: t.to, Volume: t.volume, PreMix: t.premix, Mix: t.mix, Notes: notes})
+		notes = nil
+	}
+
+	p.Notes = append(notes, d.notes...)
+
+	return p
+}
@@ -0,0 +1,215 @@
+// driver/bench/render.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package bench
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// cell escapes s for a Markdown table
+func cell(s string) string {
+	return strings.Replace(s, "|", `\|`, -1)
+}
+
+func percent(f float64) string {
+	return formatVolume(f * 100.0)
+}
+
+func mixText(l Location, m Mix) string {
+	return fmt.Sprintf("mix %s %d times with %s ul", l, m.Cycles, formatVolume(m.Volume))
+}
+
+// stepText describes a step in words, mixing included
+func stepText(s Step) string {
+	text := fmt.Sprintf("%s ul from %s to %s", formatVolume(s.Volume), s.From, s.To)
+	if s.PreMix.Cycles != 0 {
+		text = mixText(s.From, s.PreMix) + ", then " + text
+	}
+	if s.Mix.Cycles != 0 {
+		text += ", then " + mixText(s.To, s.Mix)
+	}
+	return text
+}
+
+func noteText(n Note) string {
+	if n.Title == "" {
+		return n.Text
+	}
+	return fmt.Sprintf("**%s:** %s", n.Title, n.Text)
+}
+
+// Markdown writes the protocol as a Markdown document
+func (p *Protocol) Markdown() ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "# %s\n\n", p.Title)
+
+	fmt.Fprintf(&buf, "## Plates\n\n")
+	fmt.Fprintf(&buf, "| Plate | Type | Deck position |\n|---|---|---|\n")
+	for _, pl := range p.Plates {
+		fmt.Fprintf(&buf, "| %s | %s | %s |\n", cell(pl.Label), cell(pl.Type), cell(pl.Position))
+	}
+
+	fmt.Fprintf(&buf, "\n## Plate preparation\n")
+	for _, pl := range p.Plates {
+		if len(pl.Wells) == 0 {
+			continue
+		}
+		fmt.Fprintf(&buf, "\n### %s\n\n", pl.Label)
+		fmt.Fprintf(&buf, "| Well | Contents | Volume (ul) |\n|---|---|---|\n")
+		for _, w := range pl.Wells {
+			fmt.Fprintf(&buf, "| %s | %s | %s |\n", w.Well, cell(w.Component), formatVolume(w.Volume))
+		}
+	}
+
+	if len(p.MasterMixes) != 0 {
+		fmt.Fprintf(&buf, "\n## Master mixes\n")
+	}
+	for _, mm := range p.MasterMixes {
+		fmt.Fprintf(&buf, "\n### %s\n\n", mm.Name)
+		fmt.Fprintf(&buf, "Makes enough for %d wells plus %s%% excess.\n\n", len(mm.Destinations), percent(mm.Excess))
+		fmt.Fprintf(&buf, "| Reagent | Per well (ul) | Total (ul) |\n|---|---|---|\n")
+		for _, in := range mm.Ingredients {
+			fmt.Fprintf(&buf, "| %s | %s | %s |\n", cell(in.Reagent), formatVolume(in.PerWell), formatVolume(in.Total))
+		}
+		fmt.Fprintf(&buf, "| **Total** | %s | %s |\n", formatVolume(mm.PerWell), formatVolume(mm.Total))
+	}
+
+	fmt.Fprintf(&buf, "\n## Pipetting\n")
+	for _, rs := range p.Steps {
+		fmt.Fprintf(&buf, "\n### %s\n\n", rs.Reagent)
+		for _, s := range rs.Steps {
+			for _, n := range s.Notes {
+				fmt.Fprintf(&buf, "- %s\n", noteText(n))
+			}
+			fmt.Fprintf(&buf, "- [ ] %s\n", stepText(s))
+		}
+	}
+
+	if len(p.Notes) != 0 {
+		fmt.Fprintf(&buf, "\n## Notes\n\n")
+	}
+	for _, n := range p.Notes {
+		fmt.Fprintf(&buf, "- %s\n", noteText(n))
+	}
+
+	return buf.Bytes(), nil
+}
+
+var htmlProtocol = template.Must(template.New("protocol").Funcs(template.FuncMap{
+	"vol":     formatVolume,
+	"percent": percent,
+	"step":    stepText,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #999; padding: 0.2em 0.6em; text-align: left; }
+ul.steps { list-style: none; padding-left: 0; }
+ul.steps li { margin: 0.2em 0; }
+ul.steps li.note { font-style: italic; }
+@media print {
+	body { margin: 0; font-size: 10pt; }
+	section { page-break-inside: avoid; }
+}
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<h2>Plates</h2>
+<table>
+<tr><th>Plate</th><th>Type</th><th>Deck position</th></tr>
+{{- range .Plates}}
+<tr><td>{{.Label}}</td><td>{{.Type}}</td><td>{{.Position}}</td></tr>
+{{- end}}
+</table>
+<h2>Plate preparation</h2>
+{{- range .Plates}}{{if .Wells}}
+<section>
+<h3>{{.Label}}</h3>
+<table>
+<tr><th>Well</th><th>Contents</th><th>Volume (ul)</th></tr>
+{{- range .Wells}}
+<tr><td>{{.Well}}</td><td>{{.Component}}</td><td>{{vol .Volume}}</td></tr>
+{{- end}}
+</table>
+</section>
+{{- end}}{{end}}
+{{- if .MasterMixes}}
+<h2>Master mixes</h2>
+{{- range .MasterMixes}}
+<section>
+<h3>{{.Name}}</h3>
+<p>Makes enough for {{len .Destinations}} wells plus {{percent .Excess}}% excess.</p>
+<table>
+<tr><th>Reagent</th><th>Per well (ul)</th><th>Total (ul)</th></tr>
+{{- range .Ingredients}}
+<tr><td>{{.Reagent}}</td><td>{{vol .PerWell}}</td><td>{{vol .Total}}</td></tr>
+{{- end}}
+<tr><th>Total</th><th>{{vol .PerWell}}</th><th>{{vol .Total}}</th></tr>
+</table>
+</section>
+{{- end}}
+{{- end}}
+<h2>Pipetting</h2>
+{{- range .Steps}}
+<section>
+<h3>{{.Reagent}}</h3>
+<ul class="steps">
+{{- range .Steps}}
+{{- range .Notes}}
+<li class="note">{{if .Title}}<strong>{{.Title}}:</strong> {{end}}{{.Text}}</li>
+{{- end}}
+<li><label><input type="checkbox"> {{step .}}</label></li>
+{{- end}}
+</ul>
+</section>
+{{- end}}
+{{- if .Notes}}
+<h2>Notes</h2>
+<ul>
+{{- range .Notes}}
+<li>{{if .Title}}<strong>{{.Title}}:</strong> {{end}}{{.Text}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</body>
+</html>
+`))
+
+// HTML writes the protocol as a single HTML page, laid out to be printed
+// and ticked off by hand
+func (p *Protocol) HTML() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlProtocol.Execute(&buf, p); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
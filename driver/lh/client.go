@@ -13,6 +13,7 @@ import (
 	liquidhandling "github.com/antha-lang/antha/microArch/driver/liquidhandling"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 )
 
 type Driver struct {
@@ -21,6 +22,8 @@ type Driver struct {
 	d liquidhandling.ExtendedLiquidhandlingDriver
 }
 
+var _ liquidhandling.BarcodeScanner = &Driver{}
+
 func NewDriver(address string) *Driver {
 	var d Driver
 	conn, err := grpc.Dial(address, grpc.WithInsecure())
@@ -90,7 +93,7 @@ func EncodeLHProperties(arg liquidhandling.LHProperties) *pb.LHPropertiesMessage
 	return &ret
 }
 func DecodeLHProperties(arg *pb.LHPropertiesMessage) liquidhandling.LHProperties {
-	ret := liquidhandling.LHProperties{(string)(arg.Arg_1), (int)(arg.Arg_2), (map[string]*wtype.LHPosition)(DecodeMapstringPtrToLHPositionMessage(arg.Arg_3)), (map[string]interface{})(DecodeMapstringinterfaceMessage(arg.Arg_4)), (map[string]string)(DecodeMapstringstringMessage(arg.Arg_5)), (map[string]string)(DecodeMapstringstringMessage(arg.Arg_6)), (map[string]*wtype.LHPlate)(DecodeMapstringPtrToLHPlateMessage(arg.Arg_7)), (map[string]*wtype.LHTipbox)(DecodeMapstringPtrToLHTipboxMessage(arg.Arg_8)), (map[string]*wtype.LHTipwaste)(DecodeMapstringPtrToLHTipwasteMessage(arg.Arg_9)), (map[string]*wtype.LHPlate)(DecodeMapstringPtrToLHPlateMessage(arg.Arg_10)), (map[string]*wtype.LHPlate)(DecodeMapstringPtrToLHPlateMessage(arg.Arg_11)), (map[string]string)(DecodeMapstringstringMessage(arg.Arg_12)), (string)(arg.Arg_13), (string)(arg.Arg_14), (string)(arg.Arg_15), (string)(arg.Arg_16), ([]*wtype.LHHead)(DecodeArrayOfPtrToLHHead(arg.Arg_17)), ([]*wtype.LHHead)(DecodeArrayOfPtrToLHHead(arg.Arg_18)), ([]*wtype.LHAdaptor)(DecodeArrayOfPtrToLHAdaptor(arg.Arg_19)), ([]*wtype.LHTip)(DecodeArrayOfPtrToLHTip(arg.Arg_20)), ([]string)(DecodeArrayOfstring(arg.Arg_21)), ([]string)(DecodeArrayOfstring(arg.Arg_22)), ([]string)(DecodeArrayOfstring(arg.Arg_23)), ([]string)(DecodeArrayOfstring(arg.Arg_24)), ([]string)(DecodeArrayOfstring(arg.Arg_25)), ([]string)(DecodeArrayOfstring(arg.Arg_26)), nil, (*wtype.LHChannelParameter)(DecodePtrToLHChannelParameter(arg.Arg_27)), ([]*wtype.LHChannelParameter)(DecodeArrayOfPtrToLHChannelParameter(arg.Arg_28)), (map[string]wtype.Coordinates)(DecodeMapstringCoordinatesMessage(arg.Arg_29)), (material.MaterialType)(arg.Arg_30)}
+	ret := liquidhandling.LHProperties{(string)(arg.Arg_1), (int)(arg.Arg_2), (map[string]*wtype.LHPosition)(DecodeMapstringPtrToLHPositionMessage(arg.Arg_3)), (map[string]interface{})(DecodeMapstringinterfaceMessage(arg.Arg_4)), (map[string]string)(DecodeMapstringstringMessage(arg.Arg_5)), (map[string]string)(DecodeMapstringstringMessage(arg.Arg_6)), (map[string]*wtype.LHPlate)(DecodeMapstringPtrToLHPlateMessage(arg.Arg_7)), (map[string]*wtype.LHTipbox)(DecodeMapstringPtrToLHTipboxMessage(arg.Arg_8)), (map[string]*wtype.LHTipwaste)(DecodeMapstringPtrToLHTipwasteMessage(arg.Arg_9)), (map[string]*wtype.LHPlate)(DecodeMapstringPtrToLHPlateMessage(arg.Arg_10)), (map[string]*wtype.LHPlate)(DecodeMapstringPtrToLHPlateMessage(arg.Arg_11)), (map[string]string)(DecodeMapstringstringMessage(arg.Arg_12)), (string)(arg.Arg_13), (string)(arg.Arg_14), (string)(arg.Arg_15), (string)(arg.Arg_16), ([]*wtype.LHHead)(DecodeArrayOfPtrToLHHead(arg.Arg_17)), ([]*wtype.LHHead)(DecodeArrayOfPtrToLHHead(arg.Arg_18)), ([]*wtype.LHAdaptor)(DecodeArrayOfPtrToLHAdaptor(arg.Arg_19)), ([]*wtype.LHTip)(DecodeArrayOfPtrToLHTip(arg.Arg_20)), ([]string)(DecodeArrayOfstring(arg.Arg_21)), ([]string)(DecodeArrayOfstring(arg.Arg_22)), ([]string)(DecodeArrayOfstring(arg.Arg_23)), ([]string)(DecodeArrayOfstring(arg.Arg_24)), ([]string)(DecodeArrayOfstring(arg.Arg_25)), ([]string)(DecodeArrayOfstring(arg.Arg_26)), nil, (*wtype.LHChannelParameter)(DecodePtrToLHChannelParameter(arg.Arg_27)), ([]*wtype.LHChannelParameter)(DecodeArrayOfPtrToLHChannelParameter(arg.Arg_28)), (map[string]wtype.Coordinates)(DecodeMapstringCoordinatesMessage(arg.Arg_29)), (material.MaterialType)(arg.Arg_30), nil}
 	return ret
 }
 func EncodePtrToLHProperties(arg *liquidhandling.LHProperties) *pb.PtrToLHPropertiesMessage {
@@ -205,7 +208,7 @@ func DecodeLHTip(arg *pb.LHTipMessage) wtype.LHTip {
 	if arg == nil {
 		return wtype.LHTip{}
 	}
-	ret := wtype.LHTip{(string)(arg.Arg_1), (string)(arg.Arg_2), (string)(arg.Arg_3), (bool)(arg.Arg_4), (wunit.Volume)(DecodeVolume(arg.Arg_5)), (wunit.Volume)(DecodeVolume(arg.Arg_6))}
+	ret := wtype.LHTip{(string)(arg.Arg_1), (string)(arg.Arg_2), (string)(arg.Arg_3), (bool)(arg.Arg_4), (wunit.Volume)(DecodeVolume(arg.Arg_5)), (wunit.Volume)(DecodeVolume(arg.Arg_6)), nil}
 	return ret
 }
 func EncodeLHPlate(arg wtype.LHPlate) *pb.LHPlateMessage {
@@ -213,7 +216,7 @@ func EncodeLHPlate(arg wtype.LHPlate) *pb.LHPlateMessage {
 	return &ret
 }
 func DecodeLHPlate(arg *pb.LHPlateMessage) wtype.LHPlate {
-	ret := wtype.LHPlate{(string)(arg.Arg_1), (string)(arg.Arg_2), (string)(arg.Arg_3), (string)(arg.Arg_4), (string)(arg.Arg_5), (string)(arg.Arg_6), (int)(arg.Arg_7), (int)(arg.Arg_8), (int)(arg.Arg_9), (map[string]*wtype.LHWell)(DecodeMapstringPtrToLHWellMessage(arg.Arg_10)), (float64)(arg.Arg_11), (string)(arg.Arg_12), ([][]*wtype.LHWell)(DecodeArrayOfArrayOfPtrToLHWell(arg.Arg_13)), ([][]*wtype.LHWell)(DecodeArrayOfArrayOfPtrToLHWell(arg.Arg_14)), (*wtype.LHWell)(DecodePtrToLHWell(arg.Arg_15)), (map[string]*wtype.LHWell)(DecodeMapstringPtrToLHWellMessage(arg.Arg_16)), (float64)(arg.Arg_17), (float64)(arg.Arg_18), (float64)(arg.Arg_19), (float64)(arg.Arg_20), (float64)(arg.Arg_21)}
+	ret := wtype.LHPlate{(string)(arg.Arg_1), (string)(arg.Arg_2), (string)(arg.Arg_3), (string)(arg.Arg_4), (string)(arg.Arg_5), (string)(arg.Arg_6), (int)(arg.Arg_7), (int)(arg.Arg_8), (int)(arg.Arg_9), (map[string]*wtype.LHWell)(DecodeMapstringPtrToLHWellMessage(arg.Arg_10)), (float64)(arg.Arg_11), (string)(arg.Arg_12), ([][]*wtype.LHWell)(DecodeArrayOfArrayOfPtrToLHWell(arg.Arg_13)), ([][]*wtype.LHWell)(DecodeArrayOfArrayOfPtrToLHWell(arg.Arg_14)), (*wtype.LHWell)(DecodePtrToLHWell(arg.Arg_15)), (map[string]*wtype.LHWell)(DecodeMapstringPtrToLHWellMessage(arg.Arg_16)), (float64)(arg.Arg_17), (float64)(arg.Arg_18), (float64)(arg.Arg_19), (float64)(arg.Arg_20), (float64)(arg.Arg_21), "", ""}
 	return ret
 }
 func EncodeLHHead(arg wtype.LHHead) *pb.LHHeadMessage {
@@ -360,7 +363,7 @@ func EncodeLHTipbox(arg wtype.LHTipbox) *pb.LHTipboxMessage {
 	return &ret
 }
 func DecodeLHTipbox(arg *pb.LHTipboxMessage) wtype.LHTipbox {
-	ret := wtype.LHTipbox{(string)(arg.Arg_1), (string)(arg.Arg_2), (string)(arg.Arg_3), (string)(arg.Arg_4), (int)(arg.Arg_5), (int)(arg.Arg_6), (float64)(arg.Arg_7), (*wtype.LHTip)(DecodePtrToLHTip(arg.Arg_8)), (*wtype.LHWell)(DecodePtrToLHWell(arg.Arg_9)), (int)(arg.Arg_10), ([][]*wtype.LHTip)(DecodeArrayOfArrayOfPtrToLHTip(arg.Arg_11)), (float64)(arg.Arg_12), (float64)(arg.Arg_13), (float64)(arg.Arg_14), (float64)(arg.Arg_15), (float64)(arg.Arg_16)}
+	ret := wtype.LHTipbox{(string)(arg.Arg_1), (string)(arg.Arg_2), (string)(arg.Arg_3), (string)(arg.Arg_4), (int)(arg.Arg_5), (int)(arg.Arg_6), (float64)(arg.Arg_7), (*wtype.LHTip)(DecodePtrToLHTip(arg.Arg_8)), (*wtype.LHWell)(DecodePtrToLHWell(arg.Arg_9)), (int)(arg.Arg_10), ([][]*wtype.LHTip)(DecodeArrayOfArrayOfPtrToLHTip(arg.Arg_11)), (float64)(arg.Arg_12), (float64)(arg.Arg_13), (float64)(arg.Arg_14), (float64)(arg.Arg_15), (float64)(arg.Arg_16), ""}
 	return ret
 }
 func EncodePtrToLHAdaptor(arg *wtype.LHAdaptor) *pb.PtrToLHAdaptorMessage {
@@ -473,6 +476,40 @@ func DecodeMapstringCoordinatesMessageFieldEntry(arg *pb.MapstringCoordinatesMes
 	v := DecodeCoordinates(arg.Value)
 	return k, v
 }
+func EncodeMapstringSoluteConcentrationMessage(arg map[string]wtype.SoluteConcentration) *pb.MapstringSoluteConcentrationMessageMessage {
+	a := make([]*pb.MapstringSoluteConcentrationMessageMessageFieldEntry, 0, len(arg))
+	for k, v := range arg {
+		fe := EncodeMapstringSoluteConcentrationMessageFieldEntry(k, v)
+		a = append(a, &fe)
+	}
+	ret := pb.MapstringSoluteConcentrationMessageMessage{
+		a,
+	}
+	return &ret
+}
+func EncodeMapstringSoluteConcentrationMessageFieldEntry(k string, v wtype.SoluteConcentration) pb.MapstringSoluteConcentrationMessageMessageFieldEntry {
+	ret := pb.MapstringSoluteConcentrationMessageMessageFieldEntry{
+		(string)(k),
+		EncodeSoluteConcentration(v),
+	}
+	return ret
+}
+func DecodeMapstringSoluteConcentrationMessage(arg *pb.MapstringSoluteConcentrationMessageMessage) map[string]wtype.SoluteConcentration {
+	if arg == nil || len(arg.MapField) == 0 {
+		return nil
+	}
+	a := make(map[(string)](wtype.SoluteConcentration), len(arg.MapField))
+	for _, fe := range arg.MapField {
+		k, v := DecodeMapstringSoluteConcentrationMessageFieldEntry(fe)
+		a[k] = v
+	}
+	return a
+}
+func DecodeMapstringSoluteConcentrationMessageFieldEntry(arg *pb.MapstringSoluteConcentrationMessageMessageFieldEntry) (string, wtype.SoluteConcentration) {
+	k := (string)(arg.Key)
+	v := DecodeSoluteConcentration(arg.Value)
+	return k, v
+}
 func EncodeMapstringPtrToLHPlateMessage(arg map[string]*wtype.LHPlate) *pb.MapstringPtrToLHPlateMessageMessage {
 	a := make([]*pb.MapstringPtrToLHPlateMessageMessageFieldEntry, 0, len(arg))
 	for k, v := range arg {
@@ -603,7 +640,7 @@ func DecodeLHChannelParameter(arg *pb.LHChannelParameterMessage) wtype.LHChannel
 	if arg == nil {
 		return wtype.LHChannelParameter{}
 	}
-	ret := wtype.LHChannelParameter{(string)(arg.Arg_1), (string)(arg.Arg_2), (string)(arg.Arg_3), (wunit.Volume)(DecodeVolume(arg.Arg_4)), (wunit.Volume)(DecodeVolume(arg.Arg_5)), (wunit.FlowRate)(DecodeFlowRate(arg.Arg_6)), (wunit.FlowRate)(DecodeFlowRate(arg.Arg_7)), (int)(arg.Arg_8), (bool)(arg.Arg_9), (int)(arg.Arg_10), (int)(arg.Arg_11)}
+	ret := wtype.LHChannelParameter{(string)(arg.Arg_1), (string)(arg.Arg_2), (string)(arg.Arg_3), (wunit.Volume)(DecodeVolume(arg.Arg_4)), (wunit.Volume)(DecodeVolume(arg.Arg_5)), (wunit.FlowRate)(DecodeFlowRate(arg.Arg_6)), (wunit.FlowRate)(DecodeFlowRate(arg.Arg_7)), (int)(arg.Arg_8), (bool)(arg.Arg_9), (int)(arg.Arg_10), (int)(arg.Arg_11), nil}
 	return ret
 }
 func EncodeCoordinates(arg wtype.Coordinates) *pb.CoordinatesMessage {
@@ -614,6 +651,17 @@ func DecodeCoordinates(arg *pb.CoordinatesMessage) wtype.Coordinates {
 	ret := wtype.Coordinates{(float64)(arg.Arg_1), (float64)(arg.Arg_2), (float64)(arg.Arg_3)}
 	return ret
 }
+func EncodeSoluteConcentration(arg wtype.SoluteConcentration) *pb.SoluteConcentrationMessage {
+	ret := pb.SoluteConcentrationMessage{(float64)(arg.Conc), (float64)(arg.Err), (string)(arg.Unit)}
+	return &ret
+}
+func DecodeSoluteConcentration(arg *pb.SoluteConcentrationMessage) wtype.SoluteConcentration {
+	if arg == nil {
+		return wtype.SoluteConcentration{}
+	}
+	ret := wtype.SoluteConcentration{(float64)(arg.Arg_1), (float64)(arg.Arg_2), (string)(arg.Arg_3)}
+	return ret
+}
 func EncodeLHPosition(arg wtype.LHPosition) *pb.LHPositionMessage {
 	ret := pb.LHPositionMessage{(string)(arg.ID), (string)(arg.Name), int64(arg.Num), EncodeArrayOfLHDevice(arg.Extra), (float64)(arg.Maxh)}
 	return &ret
@@ -898,11 +946,11 @@ func DecodePtrToConcreteMeasurement(arg *pb.PtrToConcreteMeasurementMessage) *wu
 	return &ret
 }
 func EncodeLHComponent(arg wtype.LHComponent) *pb.LHComponentMessage {
-	ret := pb.LHComponentMessage{(string)(arg.ID), EncodeBlockID(arg.BlockID), (string)(arg.DaughterID), (string)(arg.ParentID), (string)(arg.Inst), int64(arg.Order), (string)(arg.CName), int64(arg.Type), (float64)(arg.Vol), (float64)(arg.Conc), (string)(arg.Vunit), (string)(arg.Cunit), (float64)(arg.Tvol), (float64)(arg.Smax), (float64)(arg.Visc), (float64)(arg.StockConcentration), EncodeMapstringinterfaceMessage(arg.Extra)}
+	ret := pb.LHComponentMessage{(string)(arg.ID), EncodeBlockID(arg.BlockID), (string)(arg.DaughterID), (string)(arg.ParentID), (string)(arg.Inst), int64(arg.Order), (string)(arg.CName), int64(arg.Type), (float64)(arg.Vol), (float64)(arg.Conc), (string)(arg.Vunit), (string)(arg.Cunit), (float64)(arg.Tvol), (float64)(arg.Smax), (float64)(arg.Visc), (float64)(arg.StockConcentration), EncodeMapstringinterfaceMessage(arg.Extra), (float64)(arg.VolErr), (float64)(arg.ConcErr), EncodeMapstringSoluteConcentrationMessage(arg.Solutes)}
 	return &ret
 }
 func DecodeLHComponent(arg *pb.LHComponentMessage) wtype.LHComponent {
-	ret := wtype.LHComponent{(string)(arg.Arg_1), (wtype.BlockID)(DecodeBlockID(arg.Arg_2)), (string)(arg.Arg_3), (string)(arg.Arg_4), (string)(arg.Arg_5), (int)(arg.Arg_6), (string)(arg.Arg_7), (wtype.LiquidType)(arg.Arg_8), (float64)(arg.Arg_9), (float64)(arg.Arg_10), (string)(arg.Arg_11), (string)(arg.Arg_12), (float64)(arg.Arg_13), (float64)(arg.Arg_14), (float64)(arg.Arg_15), (float64)(arg.Arg_16), (map[string]interface{})(DecodeMapstringinterfaceMessage(arg.Arg_17)), "", ""}
+	ret := wtype.LHComponent{(string)(arg.Arg_1), (wtype.BlockID)(DecodeBlockID(arg.Arg_2)), (string)(arg.Arg_3), (string)(arg.Arg_4), (string)(arg.Arg_5), (int)(arg.Arg_6), (string)(arg.Arg_7), (wtype.LiquidType)(arg.Arg_8), (float64)(arg.Arg_9), (float64)(arg.Arg_10), (string)(arg.Arg_11), (string)(arg.Arg_12), (float64)(arg.Arg_13), (float64)(arg.Arg_14), (float64)(arg.Arg_15), (float64)(arg.Arg_16), (map[string]interface{})(DecodeMapstringinterfaceMessage(arg.Arg_17)), "", "", (float64)(arg.Arg_18), (float64)(arg.Arg_19), (map[string]wtype.SoluteConcentration)(DecodeMapstringSoluteConcentrationMessage(arg.Arg_20))}
 	return ret
 }
 func EncodeConcreteMeasurement(arg wunit.ConcreteMeasurement) *pb.ConcreteMeasurementMessage {
@@ -1195,6 +1243,18 @@ func (d *Driver) ResetPistons(arg_1 int, arg_2 int) driver.CommandStatus {
 	ret, _ := d.C.ResetPistons(context.Background(), &req)
 	return (driver.CommandStatus)(DecodeCommandStatus(ret.Ret_1))
 }
+
+// ScanBarcodes asks the driver for the barcodes it can read on the deck, keyed
+// by deck position. Drivers built before the call existed report NIM.
+func (d *Driver) ScanBarcodes() (map[string]string, driver.CommandStatus) {
+	ret, err := d.C.ScanBarcodes(context.Background(), &pb.ScanBarcodesRequest{})
+	if grpc.Code(err) == codes.Unimplemented {
+		return nil, driver.CommandStatus{OK: true, Errorcode: driver.NIM, Msg: "ScanBarcodes not implemented"}
+	} else if err != nil {
+		return nil, driver.CommandStatus{OK: false, Errorcode: driver.ERR, Msg: err.Error()}
+	}
+	return DecodeMapstringstringMessage(ret.Ret_1), (driver.CommandStatus)(DecodeCommandStatus(ret.Ret_2))
+}
 func (d *Driver) SetDriveSpeed(arg_1 string, arg_2 float64) driver.CommandStatus {
 	req := pb.SetDriveSpeedRequest{
 		(string)(arg_1),
@@ -0,0 +1,258 @@
+// driver/offline/offline.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+// Package offline provides what liquid handling drivers which write
+// instructions up, rather than carrying them out, have in common: following
+// where each channel is and where what it holds came from, and accepting
+// the instructions which have nothing to write
+package offline
+
+import (
+	"fmt"
+
+	"github.com/antha-lang/antha/microArch/driver"
+	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+// A Channel is where a channel of the head is and where what it holds came
+// from
+type Channel struct {
+	Position   string
+	Well       string
+	Source     string // deck position aspirated from, empty if holding nothing
+	SourceWell string
+	Policy     string // liquid handling policy aspirated with
+}
+
+// A Base is embedded in drivers which write instructions up, which
+// override the methods of the instructions they write
+type Base struct {
+	Props    *liquidhandling.LHProperties // the robot instructions are planned for
+	Output   string                       // what the driver writes, used in messages
+	Channels []Channel
+}
+
+// OK is the status of an instruction which succeeded
+func OK() driver.CommandStatus {
+	return driver.CommandStatus{OK: true, Errorcode: driver.OK, Msg: "OK"}
+}
+
+// Fail is the status of an instruction which failed
+func Fail(msg string) driver.CommandStatus {
+	return driver.CommandStatus{OK: false, Errorcode: driver.ERR, Msg: msg}
+}
+
+// NotImplemented is the status of an instruction which is accepted but not
+// written
+func (b *Base) NotImplemented(what string) driver.CommandStatus {
+	return driver.CommandStatus{OK: true, Errorcode: driver.NIM, Msg: fmt.Sprintf("%s not written to %s", what, b.Output)}
+}
+
+// Channel returns channel i
+func (b *Base) Channel(i int) *Channel {
+	for len(b.Channels) <= i {
+		b.Channels = append(b.Channels, Channel{})
+	}
+	return &b.Channels[i]
+}
+
+// Holding returns channel i if it holds something to dispense
+func (b *Base) Holding(i int) (*Channel, driver.CommandStatus) {
+	if i >= len(b.Channels) || b.Channels[i].Source == "" {
+		return nil, Fail(fmt.Sprintf("dispense from channel %d which hasn't aspirated anything", i))
+	}
+	return &b.Channels[i], OK()
+}
+
+// ResetChannels forgets where every channel is
+func (b *Base) ResetChannels() {
+	b.Channels = nil
+}
+
+// Move implements a LiquidhandlingDriver
+func (b *Base) Move(deckposition []string, wellcoords []string, reference []int, offsetX, offsetY, offsetZ []float64, plate_type []string, head int) driver.CommandStatus {
+	for i, pos := range deckposition {
+		if pos == "" || i >= len(wellcoords) {
+			continue
+		}
+		ch := b.Channel(i)
+		ch.Position = pos
+		ch.Well = wellcoords[i]
+	}
+	return OK()
+}
+
+// MoveRaw implements a LiquidhandlingDriver
+func (b *Base) MoveRaw(head int, x, y, z float64) driver.CommandStatus {
+	return b.NotImplemented("MoveRaw")
+}
+
+// Aspirate implements a LiquidhandlingDriver: each channel used remembers
+// where it aspirated from for the dispenses which follow
+func (b *Base) Aspirate(volume []float64, overstroke []bool, head int, multi int, platetype []string, what []string, llf []bool) driver.CommandStatus {
+	for i, v := range volume {
+		if v <= 0.0 || i >= len(b.Channels) || b.Channels[i].Position == "" {
+			continue
+		}
+		ch := b.Channel(i)
+		ch.Source = ch.Position
+		ch.SourceWell = ch.Well
+		if i < len(what) {
+			ch.Policy = what[i]
+		}
+	}
+	return OK()
+}
+
+// LoadTips implements a LiquidhandlingDriver
+func (b *Base) LoadTips(channels []int, head, multi int, platetype, position, well []string) driver.CommandStatus {
+	return OK()
+}
+
+// UnloadTips implements a LiquidhandlingDriver
+func (b *Base) UnloadTips(channels []int, head, multi int, platetype, position, well []string) driver.CommandStatus {
+	for _, i := range channels {
+		if i >= 0 && i < len(b.Channels) {
+			b.Channels[i].Source = ""
+			b.Channels[i].SourceWell = ""
+		}
+	}
+	return OK()
+}
+
+// SetPipetteSpeed implements a LiquidhandlingDriver
+func (b *Base) SetPipetteSpeed(head, channel int, rate float64) driver.CommandStatus {
+	return b.NotImplemented("SetPipetteSpeed")
+}
+
+// SetDriveSpeed implements a LiquidhandlingDriver
+func (b *Base) SetDriveSpeed(drive string, rate float64) driver.CommandStatus {
+	return b.NotImplemented("SetDriveSpeed")
+}
+
+// Stop implements a LiquidhandlingDriver
+func (b *Base) Stop() driver.CommandStatus {
+	return OK()
+}
+
+// Go implements a LiquidhandlingDriver
+func (b *Base) Go() driver.CommandStatus {
+	return OK()
+}
+
+// Initialize implements a LiquidhandlingDriver
+func (b *Base) Initialize() driver.CommandStatus {
+	return OK()
+}
+
+// Finalize implements a LiquidhandlingDriver
+func (b *Base) Finalize() driver.CommandStatus {
+	return OK()
+}
+
+// Wait implements a LiquidhandlingDriver
+func (b *Base) Wait(time float64) driver.CommandStatus {
+	return b.NotImplemented("Wait")
+}
+
+// ResetPistons implements a LiquidhandlingDriver
+func (b *Base) ResetPistons(head, channel int) driver.CommandStatus {
+	return OK()
+}
+
+// SetPositionState implements an ExtendedLiquidhandlingDriver
+func (b *Base) SetPositionState(position string, state driver.PositionState) driver.CommandStatus {
+	return OK()
+}
+
+// GetCapabilities implements an ExtendedLiquidhandlingDriver
+func (b *Base) GetCapabilities() (liquidhandling.LHProperties, driver.CommandStatus) {
+	if b.Props == nil {
+		return liquidhandling.LHProperties{}, Fail(fmt.Sprintf("no robot properties given for %s", b.Output))
+	}
+	return *b.Props, OK()
+}
+
+// GetCurrentPosition implements an ExtendedLiquidhandlingDriver
+func (b *Base) GetCurrentPosition(head int) (string, driver.CommandStatus) {
+	return "", b.NotImplemented("GetCurrentPosition")
+}
+
+// GetPositionState implements an ExtendedLiquidhandlingDriver
+func (b *Base) GetPositionState(position string) (string, driver.CommandStatus) {
+	return "", b.NotImplemented("GetPositionState")
+}
+
+// GetHeadState implements an ExtendedLiquidhandlingDriver
+func (b *Base) GetHeadState(head int) (string, driver.CommandStatus) {
+	return "", b.NotImplemented("GetHeadState")
+}
+
+// GetStatus implements an ExtendedLiquidhandlingDriver
+func (b *Base) GetStatus() (driver.Status, driver.CommandStatus) {
+	return driver.Status{}, OK()
+}
+
+// UpdateMetaData implements an ExtendedLiquidhandlingDriver
+func (b *Base) UpdateMetaData(props *liquidhandling.LHProperties) driver.CommandStatus {
+	return OK()
+}
+
+// UnloadHead implements an ExtendedLiquidhandlingDriver
+func (b *Base) UnloadHead(param int) driver.CommandStatus {
+	return OK()
+}
+
+// LoadHead implements an ExtendedLiquidhandlingDriver
+func (b *Base) LoadHead(param int) driver.CommandStatus {
+	return OK()
+}
+
+// LightsOn implements an ExtendedLiquidhandlingDriver
+func (b *Base) LightsOn() driver.CommandStatus {
+	return OK()
+}
+
+// LightsOff implements an ExtendedLiquidhandlingDriver
+func (b *Base) LightsOff() driver.CommandStatus {
+	return OK()
+}
+
+// LoadAdaptor implements an ExtendedLiquidhandlingDriver
+func (b *Base) LoadAdaptor(param int) driver.CommandStatus {
+	return OK()
+}
+
+// UnloadAdaptor implements an ExtendedLiquidhandlingDriver
+func (b *Base) UnloadAdaptor(param int) driver.CommandStatus {
+	return OK()
+}
+
+// Open implements an ExtendedLiquidhandlingDriver
+func (b *Base) Open() driver.CommandStatus {
+	return OK()
+}
+
+// Close implements an ExtendedLiquidhandlingDriver
+func (b *Base) Close() driver.CommandStatus {
+	return OK()
+}
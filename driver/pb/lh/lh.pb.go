@@ -144,6 +144,11 @@ It has these top-level messages:
 	PtrToGenericPrefixedUnitMessage
 	SIPrefixMessage
 	GenericUnitMessage
+	SoluteConcentrationMessage
+	MapstringSoluteConcentrationMessageMessageFieldEntry
+	MapstringSoluteConcentrationMessageMessage
+	ScanBarcodesRequest
+	ScanBarcodesReply
 */
 package lh
 
@@ -3621,24 +3626,28 @@ func (m *FlowRateMessage) GetArg_1() *PtrToConcreteMeasurementMessage {
 	return nil
 }
 
+
 type LHComponentMessage struct {
-	Arg_1  string                      `protobuf:"bytes,1,opt,name=Arg_1,json=arg1" json:"Arg_1,omitempty"`
-	Arg_2  *BlockIDMessage             `protobuf:"bytes,2,opt,name=Arg_2,json=arg2" json:"Arg_2,omitempty"`
-	Arg_3  string                      `protobuf:"bytes,3,opt,name=Arg_3,json=arg3" json:"Arg_3,omitempty"`
-	Arg_4  string                      `protobuf:"bytes,4,opt,name=Arg_4,json=arg4" json:"Arg_4,omitempty"`
-	Arg_5  string                      `protobuf:"bytes,5,opt,name=Arg_5,json=arg5" json:"Arg_5,omitempty"`
-	Arg_6  int64                       `protobuf:"varint,6,opt,name=Arg_6,json=arg6" json:"Arg_6,omitempty"`
-	Arg_7  string                      `protobuf:"bytes,7,opt,name=Arg_7,json=arg7" json:"Arg_7,omitempty"`
-	Arg_8  int64                       `protobuf:"varint,8,opt,name=Arg_8,json=arg8" json:"Arg_8,omitempty"`
-	Arg_9  float64                     `protobuf:"fixed64,9,opt,name=Arg_9,json=arg9" json:"Arg_9,omitempty"`
-	Arg_10 float64                     `protobuf:"fixed64,10,opt,name=Arg_10,json=arg10" json:"Arg_10,omitempty"`
-	Arg_11 string                      `protobuf:"bytes,11,opt,name=Arg_11,json=arg11" json:"Arg_11,omitempty"`
-	Arg_12 string                      `protobuf:"bytes,12,opt,name=Arg_12,json=arg12" json:"Arg_12,omitempty"`
-	Arg_13 float64                     `protobuf:"fixed64,13,opt,name=Arg_13,json=arg13" json:"Arg_13,omitempty"`
-	Arg_14 float64                     `protobuf:"fixed64,14,opt,name=Arg_14,json=arg14" json:"Arg_14,omitempty"`
-	Arg_15 float64                     `protobuf:"fixed64,15,opt,name=Arg_15,json=arg15" json:"Arg_15,omitempty"`
-	Arg_16 float64                     `protobuf:"fixed64,16,opt,name=Arg_16,json=arg16" json:"Arg_16,omitempty"`
-	Arg_17 *MapstringAnyMessageMessage `protobuf:"bytes,17,opt,name=Arg_17,json=arg17" json:"Arg_17,omitempty"`
+	Arg_1  string                                      `protobuf:"bytes,1,opt,name=Arg_1,json=arg1" json:"Arg_1,omitempty"`
+	Arg_2  *BlockIDMessage                             `protobuf:"bytes,2,opt,name=Arg_2,json=arg2" json:"Arg_2,omitempty"`
+	Arg_3  string                                      `protobuf:"bytes,3,opt,name=Arg_3,json=arg3" json:"Arg_3,omitempty"`
+	Arg_4  string                                      `protobuf:"bytes,4,opt,name=Arg_4,json=arg4" json:"Arg_4,omitempty"`
+	Arg_5  string                                      `protobuf:"bytes,5,opt,name=Arg_5,json=arg5" json:"Arg_5,omitempty"`
+	Arg_6  int64                                       `protobuf:"varint,6,opt,name=Arg_6,json=arg6" json:"Arg_6,omitempty"`
+	Arg_7  string                                      `protobuf:"bytes,7,opt,name=Arg_7,json=arg7" json:"Arg_7,omitempty"`
+	Arg_8  int64                                       `protobuf:"varint,8,opt,name=Arg_8,json=arg8" json:"Arg_8,omitempty"`
+	Arg_9  float64                                     `protobuf:"fixed64,9,opt,name=Arg_9,json=arg9" json:"Arg_9,omitempty"`
+	Arg_10 float64                                     `protobuf:"fixed64,10,opt,name=Arg_10,json=arg10" json:"Arg_10,omitempty"`
+	Arg_11 string                                      `protobuf:"bytes,11,opt,name=Arg_11,json=arg11" json:"Arg_11,omitempty"`
+	Arg_12 string                                      `protobuf:"bytes,12,opt,name=Arg_12,json=arg12" json:"Arg_12,omitempty"`
+	Arg_13 float64                                     `protobuf:"fixed64,13,opt,name=Arg_13,json=arg13" json:"Arg_13,omitempty"`
+	Arg_14 float64                                     `protobuf:"fixed64,14,opt,name=Arg_14,json=arg14" json:"Arg_14,omitempty"`
+	Arg_15 float64                                     `protobuf:"fixed64,15,opt,name=Arg_15,json=arg15" json:"Arg_15,omitempty"`
+	Arg_16 float64                                     `protobuf:"fixed64,16,opt,name=Arg_16,json=arg16" json:"Arg_16,omitempty"`
+	Arg_17 *MapstringAnyMessageMessage                 `protobuf:"bytes,17,opt,name=Arg_17,json=arg17" json:"Arg_17,omitempty"`
+	Arg_18 float64                                     `protobuf:"fixed64,18,opt,name=Arg_18,json=arg18" json:"Arg_18,omitempty"`
+	Arg_19 float64                                     `protobuf:"fixed64,19,opt,name=Arg_19,json=arg19" json:"Arg_19,omitempty"`
+	Arg_20 *MapstringSoluteConcentrationMessageMessage `protobuf:"bytes,20,opt,name=Arg_20,json=arg20" json:"Arg_20,omitempty"`
 }
 
 func (m *LHComponentMessage) Reset()                    { *m = LHComponentMessage{} }
@@ -3765,6 +3774,27 @@ func (m *LHComponentMessage) GetArg_17() *MapstringAnyMessageMessage {
 	return nil
 }
 
+func (m *LHComponentMessage) GetArg_18() float64 {
+	if m != nil {
+		return m.Arg_18
+	}
+	return 0
+}
+
+func (m *LHComponentMessage) GetArg_19() float64 {
+	if m != nil {
+		return m.Arg_19
+	}
+	return 0
+}
+
+func (m *LHComponentMessage) GetArg_20() *MapstringSoluteConcentrationMessageMessage {
+	if m != nil {
+		return m.Arg_20
+	}
+	return nil
+}
+
 type ShapeMessage struct {
 	Arg_1 string  `protobuf:"bytes,1,opt,name=Arg_1,json=arg1" json:"Arg_1,omitempty"`
 	Arg_2 string  `protobuf:"bytes,2,opt,name=Arg_2,json=arg2" json:"Arg_2,omitempty"`
@@ -4009,6 +4039,122 @@ func (m *GenericUnitMessage) GetArg_4() string {
 	return ""
 }
 
+type SoluteConcentrationMessage struct {
+	Arg_1 float64 `protobuf:"fixed64,1,opt,name=Arg_1,json=arg1" json:"Arg_1,omitempty"`
+	Arg_2 float64 `protobuf:"fixed64,2,opt,name=Arg_2,json=arg2" json:"Arg_2,omitempty"`
+	Arg_3 string  `protobuf:"bytes,3,opt,name=Arg_3,json=arg3" json:"Arg_3,omitempty"`
+}
+
+func (m *SoluteConcentrationMessage) Reset()                    { *m = SoluteConcentrationMessage{} }
+func (m *SoluteConcentrationMessage) String() string            { return proto.CompactTextString(m) }
+func (*SoluteConcentrationMessage) ProtoMessage()               {}
+func (*SoluteConcentrationMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{136} }
+
+func (m *SoluteConcentrationMessage) GetArg_1() float64 {
+	if m != nil {
+		return m.Arg_1
+	}
+	return 0
+}
+
+func (m *SoluteConcentrationMessage) GetArg_2() float64 {
+	if m != nil {
+		return m.Arg_2
+	}
+	return 0
+}
+
+func (m *SoluteConcentrationMessage) GetArg_3() string {
+	if m != nil {
+		return m.Arg_3
+	}
+	return ""
+}
+
+type MapstringSoluteConcentrationMessageMessageFieldEntry struct {
+	Key   string                      `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value *SoluteConcentrationMessage `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *MapstringSoluteConcentrationMessageMessageFieldEntry) Reset() {
+	*m = MapstringSoluteConcentrationMessageMessageFieldEntry{}
+}
+func (m *MapstringSoluteConcentrationMessageMessageFieldEntry) String() string {
+	return proto.CompactTextString(m)
+}
+func (*MapstringSoluteConcentrationMessageMessageFieldEntry) ProtoMessage() {}
+func (*MapstringSoluteConcentrationMessageMessageFieldEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{137}
+}
+
+func (m *MapstringSoluteConcentrationMessageMessageFieldEntry) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *MapstringSoluteConcentrationMessageMessageFieldEntry) GetValue() *SoluteConcentrationMessage {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type MapstringSoluteConcentrationMessageMessage struct {
+	MapField []*MapstringSoluteConcentrationMessageMessageFieldEntry `protobuf:"bytes,1,rep,name=map_field,json=mapField" json:"map_field,omitempty"`
+}
+
+func (m *MapstringSoluteConcentrationMessageMessage) Reset() {
+	*m = MapstringSoluteConcentrationMessageMessage{}
+}
+func (m *MapstringSoluteConcentrationMessageMessage) String() string {
+	return proto.CompactTextString(m)
+}
+func (*MapstringSoluteConcentrationMessageMessage) ProtoMessage() {}
+func (*MapstringSoluteConcentrationMessageMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{138}
+}
+
+func (m *MapstringSoluteConcentrationMessageMessage) GetMapField() []*MapstringSoluteConcentrationMessageMessageFieldEntry {
+	if m != nil {
+		return m.MapField
+	}
+	return nil
+}
+
+type ScanBarcodesRequest struct {
+}
+
+func (m *ScanBarcodesRequest) Reset()                    { *m = ScanBarcodesRequest{} }
+func (m *ScanBarcodesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ScanBarcodesRequest) ProtoMessage()               {}
+func (*ScanBarcodesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{139} }
+
+type ScanBarcodesReply struct {
+	Ret_1 *MapstringstringMessage `protobuf:"bytes,1,opt,name=Ret_1,json=Ret1" json:"Ret_1,omitempty"`
+	Ret_2 *CommandStatusMessage   `protobuf:"bytes,2,opt,name=Ret_2,json=Ret2" json:"Ret_2,omitempty"`
+}
+
+func (m *ScanBarcodesReply) Reset()                    { *m = ScanBarcodesReply{} }
+func (m *ScanBarcodesReply) String() string            { return proto.CompactTextString(m) }
+func (*ScanBarcodesReply) ProtoMessage()               {}
+func (*ScanBarcodesReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{140} }
+
+func (m *ScanBarcodesReply) GetRet_1() *MapstringstringMessage {
+	if m != nil {
+		return m.Ret_1
+	}
+	return nil
+}
+
+func (m *ScanBarcodesReply) GetRet_2() *CommandStatusMessage {
+	if m != nil {
+		return m.Ret_2
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*MapMessage)(nil), "lh.MapMessage")
 	proto.RegisterType((*AnyMessage)(nil), "lh.AnyMessage")
@@ -4146,6 +4292,11 @@ func init() {
 	proto.RegisterType((*PtrToGenericPrefixedUnitMessage)(nil), "lh.PtrToGenericPrefixedUnitMessage")
 	proto.RegisterType((*SIPrefixMessage)(nil), "lh.SIPrefixMessage")
 	proto.RegisterType((*GenericUnitMessage)(nil), "lh.GenericUnitMessage")
+	proto.RegisterType((*SoluteConcentrationMessage)(nil), "lh.SoluteConcentrationMessage")
+	proto.RegisterType((*MapstringSoluteConcentrationMessageMessageFieldEntry)(nil), "lh.MapstringSoluteConcentrationMessageMessageFieldEntry")
+	proto.RegisterType((*MapstringSoluteConcentrationMessageMessage)(nil), "lh.MapstringSoluteConcentrationMessageMessage")
+	proto.RegisterType((*ScanBarcodesRequest)(nil), "lh.ScanBarcodesRequest")
+	proto.RegisterType((*ScanBarcodesReply)(nil), "lh.ScanBarcodesReply")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4185,6 +4336,7 @@ type ExtendedLiquidhandlingDriverClient interface {
 	RemoveAllPlates(ctx context.Context, in *RemoveAllPlatesRequest, opts ...grpc.CallOption) (*RemoveAllPlatesReply, error)
 	RemovePlateAt(ctx context.Context, in *RemovePlateAtRequest, opts ...grpc.CallOption) (*RemovePlateAtReply, error)
 	ResetPistons(ctx context.Context, in *ResetPistonsRequest, opts ...grpc.CallOption) (*ResetPistonsReply, error)
+	ScanBarcodes(ctx context.Context, in *ScanBarcodesRequest, opts ...grpc.CallOption) (*ScanBarcodesReply, error)
 	SetDriveSpeed(ctx context.Context, in *SetDriveSpeedRequest, opts ...grpc.CallOption) (*SetDriveSpeedReply, error)
 	SetPipetteSpeed(ctx context.Context, in *SetPipetteSpeedRequest, opts ...grpc.CallOption) (*SetPipetteSpeedReply, error)
 	SetPositionState(ctx context.Context, in *SetPositionStateRequest, opts ...grpc.CallOption) (*SetPositionStateReply, error)
@@ -4438,6 +4590,15 @@ func (c *extendedLiquidhandlingDriverClient) ResetPistons(ctx context.Context, i
 	return out, nil
 }
 
+func (c *extendedLiquidhandlingDriverClient) ScanBarcodes(ctx context.Context, in *ScanBarcodesRequest, opts ...grpc.CallOption) (*ScanBarcodesReply, error) {
+	out := new(ScanBarcodesReply)
+	err := grpc.Invoke(ctx, "/lh.ExtendedLiquidhandlingDriver/ScanBarcodes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *extendedLiquidhandlingDriverClient) SetDriveSpeed(ctx context.Context, in *SetDriveSpeedRequest, opts ...grpc.CallOption) (*SetDriveSpeedReply, error) {
 	out := new(SetDriveSpeedReply)
 	err := grpc.Invoke(ctx, "/lh.ExtendedLiquidhandlingDriver/SetDriveSpeed", in, out, c.cc, opts...)
@@ -4548,6 +4709,7 @@ type ExtendedLiquidhandlingDriverServer interface {
 	RemoveAllPlates(context.Context, *RemoveAllPlatesRequest) (*RemoveAllPlatesReply, error)
 	RemovePlateAt(context.Context, *RemovePlateAtRequest) (*RemovePlateAtReply, error)
 	ResetPistons(context.Context, *ResetPistonsRequest) (*ResetPistonsReply, error)
+	ScanBarcodes(context.Context, *ScanBarcodesRequest) (*ScanBarcodesReply, error)
 	SetDriveSpeed(context.Context, *SetDriveSpeedRequest) (*SetDriveSpeedReply, error)
 	SetPipetteSpeed(context.Context, *SetPipetteSpeedRequest) (*SetPipetteSpeedReply, error)
 	SetPositionState(context.Context, *SetPositionStateRequest) (*SetPositionStateReply, error)
@@ -5031,6 +5193,24 @@ func _ExtendedLiquidhandlingDriver_ResetPistons_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExtendedLiquidhandlingDriver_ScanBarcodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScanBarcodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtendedLiquidhandlingDriverServer).ScanBarcodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lh.ExtendedLiquidhandlingDriver/ScanBarcodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtendedLiquidhandlingDriverServer).ScanBarcodes(ctx, req.(*ScanBarcodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ExtendedLiquidhandlingDriver_SetDriveSpeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetDriveSpeedRequest)
 	if err := dec(in); err != nil {
@@ -5301,6 +5481,10 @@ var _ExtendedLiquidhandlingDriver_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ResetPistons",
 			Handler:    _ExtendedLiquidhandlingDriver_ResetPistons_Handler,
 		},
+		{
+			MethodName: "ScanBarcodes",
+			Handler:    _ExtendedLiquidhandlingDriver_ScanBarcodes_Handler,
+		},
 		{
 			MethodName: "SetDriveSpeed",
 			Handler:    _ExtendedLiquidhandlingDriver_SetDriveSpeed_Handler,
@@ -5345,219 +5529,224 @@ var _ExtendedLiquidhandlingDriver_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("lh/lh.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 3411 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5c, 0x5f, 0x73, 0xdb, 0xc6,
-	0x11, 0x37, 0x4c, 0x3b, 0x11, 0x97, 0xfa, 0x7b, 0x14, 0x25, 0x98, 0xb1, 0x13, 0x17, 0xb6, 0x15,
-	0xc5, 0xb1, 0x15, 0x81, 0xa4, 0xa8, 0x3f, 0x8e, 0x1d, 0x33, 0x92, 0x25, 0x3b, 0x95, 0x62, 0x55,
-	0x94, 0xe3, 0xce, 0x64, 0xda, 0x14, 0x36, 0xcf, 0x16, 0x26, 0x10, 0x80, 0x90, 0x90, 0x6c, 0xb5,
-	0xaf, 0x7d, 0xed, 0x63, 0x67, 0xfa, 0x39, 0xfa, 0xd2, 0x69, 0x67, 0xfa, 0xd4, 0xc7, 0xbe, 0xf7,
-	0xa1, 0xd3, 0xef, 0xd0, 0x0f, 0xd0, 0x87, 0x74, 0x80, 0xbb, 0x03, 0xee, 0x80, 0x03, 0x08, 0x41,
-	0x4a, 0xa7, 0xd3, 0xbe, 0x64, 0xac, 0xc3, 0xee, 0xdd, 0xde, 0xde, 0xee, 0xfe, 0xf6, 0x96, 0xb7,
-	0x81, 0x8a, 0x75, 0xf0, 0x89, 0x75, 0xb0, 0xe0, 0xf6, 0x1d, 0xcf, 0x41, 0x17, 0xad, 0x03, 0xed,
-	0xd7, 0x0a, 0xc0, 0x8e, 0xe1, 0xee, 0xe0, 0xc1, 0xc0, 0x78, 0x8d, 0xd1, 0x2a, 0x94, 0x0f, 0x0d,
-	0xf7, 0x9b, 0x57, 0x26, 0xb6, 0x7a, 0xaa, 0x72, 0xbd, 0x34, 0x5f, 0x69, 0x5c, 0x5d, 0xb0, 0x0e,
-	0x16, 0x22, 0x12, 0xff, 0x9f, 0x9b, 0xfe, 0xe7, 0x47, 0xb6, 0xd7, 0x3f, 0xd9, 0x1b, 0x39, 0xa4,
-	0x7f, 0xd6, 0xef, 0xc1, 0x98, 0xf0, 0x09, 0x4d, 0x42, 0xe9, 0x5b, 0x7c, 0xa2, 0x2a, 0xd7, 0x95,
-	0xf9, 0xf2, 0x9e, 0xff, 0x4f, 0x34, 0x0d, 0x97, 0x8f, 0x0d, 0xeb, 0x08, 0xab, 0x17, 0x83, 0x31,
-	0xf2, 0xc7, 0xda, 0xc5, 0x15, 0x45, 0xfb, 0x11, 0x40, 0xc7, 0x3e, 0x61, 0x52, 0x54, 0xe1, 0x72,
-	0xa7, 0xff, 0xfa, 0x1b, 0x9d, 0xf2, 0x5e, 0x32, 0xfa, 0xaf, 0x75, 0xad, 0x0a, 0x53, 0x4f, 0x6c,
-	0xd3, 0x33, 0x0d, 0xcb, 0xfc, 0x25, 0xde, 0xc3, 0xdf, 0x1d, 0xe1, 0x81, 0xa7, 0x3d, 0x84, 0x09,
-	0x7e, 0xd0, 0xb5, 0x4e, 0xd0, 0x5d, 0xb8, 0xbc, 0x87, 0x3d, 0xca, 0x5c, 0x69, 0xa8, 0xbe, 0xf8,
-	0xeb, 0xce, 0xe1, 0xa1, 0x61, 0xf7, 0xba, 0x9e, 0xe1, 0x1d, 0x0d, 0xe8, 0x2a, 0x7b, 0x97, 0xfa,
-	0xd8, 0xd3, 0xb5, 0x31, 0xa8, 0x3c, 0x75, 0xb1, 0xcd, 0x26, 0x5c, 0x83, 0x32, 0xf9, 0xb3, 0xc0,
-	0x54, 0x9f, 0x41, 0x75, 0x0f, 0x0f, 0xb0, 0xb7, 0x6b, 0x0e, 0x3c, 0xc7, 0x1e, 0xd0, 0x29, 0xfd,
-	0xdd, 0x18, 0xe1, 0x6e, 0x4a, 0x64, 0x37, 0x6c, 0xb0, 0x11, 0xa8, 0x82, 0x0c, 0x36, 0xb4, 0xcf,
-	0x61, 0x4a, 0x9c, 0xa0, 0x80, 0x10, 0xe3, 0x30, 0xba, 0x6e, 0x39, 0x83, 0x50, 0x43, 0xf7, 0x00,
-	0xe8, 0xdf, 0x05, 0x26, 0xfb, 0xe3, 0x45, 0xa8, 0xec, 0x38, 0xc7, 0x6c, 0x32, 0x34, 0xc7, 0x6f,
-	0xa5, 0xd2, 0x98, 0xf2, 0xd9, 0x3b, 0xfd, 0xbe, 0x71, 0xf2, 0xf4, 0xd5, 0xc0, 0xeb, 0x9b, 0xf6,
-	0x6b, 0xba, 0xbb, 0x39, 0x7e, 0x77, 0xa9, 0x74, 0x0d, 0x74, 0x8b, 0xd0, 0x35, 0xd5, 0x52, 0x40,
-	0x37, 0xc9, 0xd1, 0x99, 0xb6, 0xd7, 0x6e, 0x05, 0x64, 0x4d, 0x36, 0x5d, 0x4b, 0xbd, 0x94, 0x98,
-	0xae, 0xe7, 0x1c, 0xbd, 0xb0, 0x70, 0x40, 0xd7, 0x62, 0x74, 0x4b, 0xea, 0xe5, 0x2c, 0xba, 0x25,
-	0x46, 0xd7, 0x56, 0xdf, 0xc9, 0xa2, 0x6b, 0x33, 0xba, 0x65, 0xf5, 0xdd, 0xac, 0x6d, 0x2c, 0xb3,
-	0xc3, 0x5c, 0x51, 0x47, 0xc2, 0xc3, 0x5c, 0xf1, 0x2d, 0x89, 0xa8, 0xae, 0x80, 0xde, 0xe7, 0x61,
-	0xea, 0x99, 0x6d, 0x39, 0x46, 0xef, 0x31, 0x36, 0x7a, 0x59, 0x76, 0xe4, 0x3b, 0x00, 0x4f, 0x59,
-	0x60, 0xad, 0xef, 0x15, 0x98, 0xd8, 0x30, 0x07, 0x2e, 0xb6, 0x07, 0x79, 0xce, 0x99, 0x53, 0x90,
-	0x8e, 0x6e, 0x8a, 0xe7, 0x3c, 0xc1, 0xd1, 0xbd, 0x70, 0x1c, 0x8b, 0x9e, 0x72, 0x95, 0x3f, 0xe5,
-	0x12, 0x3d, 0xd3, 0x2a, 0x7f, 0xa6, 0xa5, 0xe1, 0x07, 0xc8, 0x29, 0x3c, 0xf3, 0x00, 0x39, 0xba,
-	0x36, 0x93, 0x8f, 0x1d, 0xa0, 0x54, 0xbe, 0x65, 0xed, 0x01, 0x8c, 0x45, 0x0a, 0x28, 0xa0, 0xc1,
-	0x29, 0x98, 0xd8, 0x34, 0x6d, 0x21, 0x2e, 0x3d, 0x80, 0xb1, 0x68, 0xa8, 0xc0, 0x94, 0x8b, 0x70,
-	0x65, 0x0b, 0x7b, 0xeb, 0x47, 0xfd, 0x3e, 0xb6, 0xbd, 0x5d, 0x67, 0x60, 0x7a, 0xa6, 0x63, 0x67,
-	0x1a, 0xc2, 0xcf, 0x60, 0x56, 0xc6, 0xe1, 0xaf, 0x5d, 0xe5, 0xd7, 0x2e, 0x93, 0x15, 0x98, 0x40,
-	0xec, 0xe8, 0xb2, 0x05, 0x6a, 0x68, 0x08, 0x26, 0xb7, 0xb0, 0x47, 0xbe, 0xb0, 0x4d, 0x7a, 0x30,
-	0xce, 0x8d, 0xf9, 0x2b, 0x35, 0xc5, 0x5d, 0xbe, 0x4f, 0xa1, 0x83, 0x9c, 0x49, 0x14, 0xe0, 0x85,
-	0xbd, 0x9e, 0x56, 0x92, 0xe7, 0x30, 0xd3, 0xf5, 0x43, 0xa4, 0x8b, 0x3d, 0x0f, 0x77, 0x5d, 0x8c,
-	0x7b, 0xa7, 0x0e, 0xb4, 0xa2, 0x45, 0x2a, 0xc4, 0x22, 0xb5, 0x47, 0x30, 0x9d, 0x98, 0xb8, 0x18,
-	0xa0, 0x74, 0x3d, 0xc7, 0xe5, 0x00, 0x85, 0xfc, 0x59, 0x60, 0x2a, 0x0d, 0x2a, 0xcf, 0x0d, 0xd3,
-	0x93, 0xee, 0x4f, 0xa1, 0xe7, 0xbe, 0x06, 0x65, 0x42, 0x53, 0xd0, 0xf5, 0x3b, 0x03, 0xd7, 0xec,
-	0x1b, 0xde, 0xff, 0xaf, 0xeb, 0x47, 0x0a, 0x28, 0xa0, 0xc1, 0x85, 0xc0, 0xeb, 0x98, 0xbb, 0xf9,
-	0x14, 0x58, 0x7a, 0x5a, 0x2c, 0x89, 0xf9, 0x1a, 0x6a, 0x49, 0xfa, 0xf3, 0xf2, 0xd1, 0x19, 0x98,
-	0xde, 0xc2, 0xde, 0xd3, 0x23, 0xcf, 0x3d, 0xf2, 0x36, 0x4d, 0x2b, 0x0c, 0x46, 0x3f, 0x05, 0x14,
-	0x1b, 0x3f, 0xaf, 0x15, 0x2b, 0x50, 0xde, 0x72, 0xd8, 0x32, 0x2b, 0xf0, 0xae, 0xff, 0x47, 0x01,
-	0x2d, 0xfe, 0xcb, 0x4f, 0x42, 0xcd, 0xb7, 0x99, 0x7e, 0x9c, 0x91, 0x52, 0x70, 0x76, 0xd9, 0x60,
-	0x74, 0x2c, 0xa5, 0x48, 0xb1, 0x8f, 0x26, 0x4b, 0x3d, 0x58, 0x4e, 0x21, 0x4f, 0x3d, 0x5a, 0x4c,
-	0x16, 0x62, 0x96, 0xa5, 0x1f, 0xc4, 0x06, 0x57, 0x61, 0x24, 0xd8, 0x7c, 0x31, 0xe4, 0xd9, 0x36,
-	0x5f, 0x1f, 0x78, 0x83, 0xa7, 0x36, 0x87, 0x3c, 0xd1, 0x50, 0x81, 0x29, 0x7b, 0x30, 0x1e, 0xa4,
-	0x2d, 0xc6, 0x9b, 0xfc, 0x61, 0x55, 0xc9, 0x08, 0xab, 0xa2, 0xb7, 0x93, 0xc1, 0x96, 0x76, 0x1f,
-	0x46, 0xc3, 0x55, 0x0a, 0x08, 0xa9, 0xc2, 0xcc, 0x1e, 0x3e, 0x74, 0x8e, 0x71, 0xc7, 0xb2, 0x76,
-	0x2d, 0xc3, 0xc3, 0x21, 0x26, 0x3d, 0x82, 0xe9, 0xc4, 0x97, 0x02, 0x0b, 0x7c, 0xcc, 0xa6, 0x09,
-	0xe6, 0xe8, 0x78, 0x99, 0x4e, 0xbd, 0x0e, 0x28, 0x46, 0x5c, 0x6c, 0x45, 0x92, 0xc8, 0x75, 0x7a,
-	0x86, 0xeb, 0x39, 0xfd, 0x4c, 0xb0, 0x5f, 0x07, 0x14, 0x23, 0x2e, 0xb0, 0xe2, 0x0b, 0x98, 0xea,
-	0xf4, 0x7a, 0x81, 0xcc, 0xfb, 0x4e, 0xd6, 0x06, 0xd1, 0x0d, 0xd1, 0xf7, 0xc6, 0x03, 0x3b, 0x0e,
-	0xd1, 0x5c, 0x76, 0xf8, 0x65, 0x8a, 0xa9, 0x0f, 0x61, 0x82, 0x5f, 0xa3, 0x80, 0x94, 0xb7, 0xa1,
-	0xba, 0x85, 0x3d, 0x3f, 0xbb, 0x4d, 0x8f, 0xae, 0x4c, 0x2d, 0xcf, 0x61, 0x4a, 0xa4, 0x3d, 0xaf,
-	0x38, 0xf7, 0x11, 0xa0, 0xed, 0x9c, 0x47, 0xd3, 0x81, 0xc9, 0xed, 0x33, 0x1e, 0xcc, 0x1c, 0x4c,
-	0x6c, 0xe7, 0xc9, 0xfd, 0x7d, 0x57, 0x3f, 0x4b, 0xe6, 0xff, 0x05, 0xd4, 0x9e, 0xb9, 0x3d, 0xc3,
-	0xc3, 0x3b, 0xd8, 0x33, 0x36, 0x0c, 0xcf, 0x60, 0xab, 0xe9, 0x62, 0x0e, 0x10, 0x54, 0x00, 0x76,
-	0xbd, 0xfe, 0xbe, 0xb3, 0xfd, 0x78, 0xb7, 0xef, 0xb8, 0xb8, 0xef, 0x99, 0x78, 0xc0, 0x9f, 0xbe,
-	0xae, 0x6d, 0x40, 0x35, 0x3e, 0x57, 0x31, 0xbf, 0xf6, 0x93, 0x58, 0xc3, 0x35, 0x5e, 0x98, 0x96,
-	0xe9, 0xaf, 0xc2, 0xfc, 0x7a, 0x10, 0x60, 0x9b, 0xf8, 0xc5, 0x5f, 0xe0, 0x8e, 0xb8, 0xc0, 0xac,
-	0xbf, 0x80, 0x54, 0xca, 0x82, 0x49, 0x2f, 0x8d, 0xa5, 0xaf, 0x5e, 0x31, 0x41, 0x3e, 0x83, 0x71,
-	0x6e, 0xac, 0xc0, 0x1e, 0x5f, 0xc2, 0x6c, 0xf7, 0x14, 0x29, 0x83, 0x9f, 0x53, 0xf3, 0xce, 0x37,
-	0x34, 0xa7, 0x0e, 0x2a, 0x09, 0x9b, 0x50, 0xeb, 0x4a, 0xf3, 0x8c, 0x53, 0x0a, 0xfb, 0x0f, 0x85,
-	0xdd, 0x44, 0xf7, 0x4d, 0x37, 0xac, 0x68, 0xdc, 0x12, 0xed, 0x43, 0x8e, 0x9d, 0x79, 0x52, 0xef,
-	0xd2, 0xf0, 0x0b, 0x3e, 0x07, 0xa8, 0xe7, 0x9e, 0x24, 0x46, 0xb7, 0x67, 0xb2, 0xbb, 0x02, 0x0a,
-	0xfa, 0xbb, 0x42, 0x9c, 0xf5, 0x7f, 0x52, 0x3d, 0x34, 0xc0, 0x14, 0x56, 0x8e, 0x9f, 0x4b, 0xd0,
-	0x81, 0xdc, 0xb9, 0x44, 0x39, 0x03, 0x4e, 0xc4, 0x5c, 0x62, 0x84, 0xcb, 0x25, 0xd8, 0x2a, 0x05,
-	0x84, 0x7c, 0x18, 0x5c, 0xfb, 0x36, 0xfa, 0xe6, 0x71, 0xc6, 0x6d, 0xb2, 0x9c, 0x91, 0xf6, 0xf8,
-	0x68, 0x1c, 0x9b, 0xa1, 0x80, 0x18, 0xbf, 0xa9, 0x40, 0x55, 0x12, 0xb8, 0xa4, 0xb5, 0x50, 0x36,
-	0x28, 0x9a, 0xce, 0x67, 0x64, 0x90, 0x65, 0xbe, 0xb7, 0x85, 0x40, 0xc1, 0xc2, 0x37, 0x8d, 0x08,
-	0x92, 0xa0, 0xd1, 0xf4, 0x23, 0x4d, 0x87, 0x33, 0xb3, 0x3c, 0x91, 0xa6, 0x85, 0x3e, 0x21, 0x4c,
-	0xcc, 0xe6, 0xea, 0x02, 0x13, 0xf9, 0x2f, 0xcf, 0xb0, 0xc4, 0x18, 0x98, 0xf1, 0x0d, 0x63, 0x68,
-	0xa3, 0x4f, 0x09, 0x03, 0xcb, 0xa2, 0x3f, 0x94, 0xee, 0xcb, 0x0a, 0xa0, 0x27, 0x21, 0xdf, 0x32,
-	0xba, 0x4f, 0xb8, 0x49, 0x6d, 0xae, 0xd2, 0x98, 0x97, 0x71, 0xef, 0x9b, 0xee, 0x0b, 0xe7, 0xad,
-	0x84, 0x7d, 0x85, 0x29, 0x75, 0x55, 0x2d, 0xa7, 0x2b, 0x75, 0xdf, 0x74, 0xdf, 0x18, 0x03, 0xe9,
-	0xfa, 0xab, 0xe8, 0x01, 0xbc, 0x13, 0x9c, 0xdf, 0xa2, 0x0a, 0xa7, 0x13, 0xdf, 0x37, 0x34, 0x7d,
-	0x31, 0xe4, 0xd7, 0xd5, 0x4a, 0x01, 0x7e, 0x1d, 0xe9, 0x94, 0xbf, 0xa1, 0x8e, 0x0e, 0xd5, 0x77,
-	0xc0, 0xd2, 0x40, 0x35, 0xca, 0xd2, 0x54, 0xc7, 0x48, 0x9d, 0xde, 0x1f, 0x6e, 0x86, 0xc3, 0x2d,
-	0x75, 0x3c, 0x1a, 0x6e, 0x85, 0xc3, 0x4b, 0xea, 0x44, 0x34, 0xbc, 0x14, 0x0e, 0xb7, 0xd5, 0xc9,
-	0x68, 0xb8, 0x8d, 0x5a, 0x74, 0x78, 0x59, 0x9d, 0x0a, 0xc4, 0xb9, 0xc6, 0xc5, 0x1e, 0xba, 0x19,
-	0x3f, 0x9f, 0x11, 0x24, 0x5a, 0x0e, 0xb9, 0x56, 0x54, 0x94, 0x9b, 0x6b, 0x05, 0x2d, 0x53, 0xae,
-	0x55, 0xb5, 0x1a, 0x70, 0x5d, 0x4f, 0x72, 0xd1, 0x34, 0x4d, 0x60, 0x5c, 0x45, 0x4d, 0xc2, 0xd8,
-	0x58, 0x54, 0xa7, 0xa3, 0x04, 0x48, 0x64, 0xdc, 0x37, 0x5d, 0x9e, 0xa9, 0xb1, 0x88, 0xe6, 0x29,
-	0x93, 0xae, 0xd6, 0xd2, 0xa2, 0x6a, 0x40, 0xa9, 0x87, 0x94, 0x0d, 0x75, 0x26, 0x93, 0xb2, 0x11,
-	0x52, 0x36, 0xd5, 0xd9, 0x4c, 0xca, 0x66, 0x48, 0xd9, 0x52, 0xd5, 0x4c, 0xca, 0x56, 0x48, 0xb9,
-	0xa4, 0x5e, 0xc9, 0xa4, 0x5c, 0x0a, 0x29, 0xdb, 0x6a, 0x3d, 0x93, 0xb2, 0x8d, 0x56, 0x29, 0xe5,
-	0xb2, 0xfa, 0x5e, 0x40, 0xa9, 0x71, 0x19, 0xe3, 0xfa, 0x81, 0x61, 0xdb, 0xd8, 0xda, 0x35, 0xfa,
-	0xc6, 0x21, 0xf6, 0xb0, 0xa0, 0xeb, 0xc6, 0x32, 0x7a, 0x48, 0x59, 0x57, 0xd4, 0xab, 0x01, 0xeb,
-	0x47, 0x49, 0x5d, 0x67, 0xce, 0xb0, 0x82, 0xee, 0xd3, 0x19, 0x56, 0xd5, 0x6b, 0xc1, 0x0c, 0x73,
-	0x82, 0x85, 0xaf, 0x3b, 0x4e, 0xbf, 0x67, 0xda, 0xfe, 0x4d, 0x50, 0xe2, 0x20, 0x8d, 0x55, 0x66,
-	0xa8, 0xcd, 0x45, 0xf5, 0xfd, 0x20, 0x98, 0xfa, 0xc3, 0xcd, 0x45, 0xed, 0x31, 0xa8, 0x69, 0x29,
-	0xaf, 0x9f, 0x74, 0xf2, 0x00, 0x9f, 0x9e, 0x74, 0x06, 0xa9, 0xf1, 0x4d, 0x18, 0x13, 0x94, 0xc6,
-	0x23, 0x4b, 0x29, 0xbc, 0x44, 0xde, 0x80, 0x51, 0x3e, 0x47, 0x10, 0x89, 0x4a, 0x89, 0xa9, 0x48,
-	0xcd, 0x43, 0xa4, 0x62, 0x25, 0x41, 0x0d, 0x2a, 0x5c, 0x95, 0x41, 0xa4, 0x19, 0xa1, 0x34, 0x3f,
-	0x87, 0x9b, 0xe9, 0xa1, 0x3d, 0xf3, 0x47, 0xbc, 0x9b, 0xfc, 0x8f, 0x78, 0xc9, 0xcb, 0x20, 0xf9,
-	0xa8, 0xbd, 0x84, 0x7a, 0xfa, 0xfc, 0xe8, 0x51, 0xf2, 0x67, 0xc6, 0xf9, 0x6c, 0xb4, 0x91, 0xfd,
-	0xe4, 0xa8, 0x3d, 0x83, 0x69, 0x19, 0xa2, 0x8a, 0x98, 0x39, 0x92, 0x85, 0x99, 0x55, 0x1e, 0x33,
-	0xd9, 0xa5, 0xf5, 0x18, 0x16, 0xf3, 0xe1, 0x66, 0xa6, 0x9e, 0x16, 0x45, 0x3d, 0xd5, 0xf9, 0x4b,
-	0x94, 0x38, 0x1b, 0xd3, 0xd9, 0xaf, 0x60, 0x2e, 0xdf, 0xba, 0xe8, 0x27, 0x49, 0xfd, 0xb5, 0xf2,
-	0xc3, 0xbd, 0x54, 0x97, 0x7f, 0x29, 0xc1, 0x44, 0x0c, 0x09, 0x73, 0xe4, 0x1e, 0xe5, 0x0c, 0x3d,
-	0xb2, 0x41, 0x92, 0x4f, 0x94, 0xa3, 0x82, 0x5a, 0x27, 0x51, 0x50, 0xab, 0xf2, 0x39, 0x41, 0x89,
-	0xe2, 0x7e, 0x95, 0xc7, 0x7d, 0x85, 0xc2, 0xf9, 0x6d, 0x11, 0xce, 0x6b, 0x9c, 0x56, 0xb9, 0x90,
-	0x4c, 0xb0, 0xfb, 0x63, 0x11, 0xbb, 0x67, 0x38, 0xda, 0xe7, 0xd8, 0xb2, 0x44, 0x9c, 0xae, 0x09,
-	0x38, 0x5d, 0x62, 0xf0, 0xbb, 0x16, 0x83, 0xdf, 0x1b, 0x5c, 0x78, 0xca, 0x44, 0x04, 0x5d, 0x0f,
-	0xa7, 0x24, 0xd0, 0xab, 0xc8, 0xe1, 0x55, 0x91, 0xc3, 0xab, 0x22, 0x87, 0x57, 0x45, 0x0e, 0xaf,
-	0x74, 0xb8, 0xad, 0xfd, 0x55, 0x81, 0xc9, 0x38, 0xaa, 0x9d, 0xf5, 0x18, 0x97, 0xc5, 0xb4, 0x30,
-	0x4f, 0x6c, 0xcf, 0x38, 0xea, 0xdb, 0x62, 0xfa, 0x97, 0x71, 0x80, 0x6d, 0x6d, 0x0d, 0xa6, 0x12,
-	0x9f, 0xa4, 0xb7, 0xab, 0x04, 0xaf, 0xae, 0xd9, 0x70, 0x67, 0x38, 0x06, 0x64, 0x3a, 0xf0, 0x1d,
-	0xd1, 0x81, 0x67, 0x48, 0x02, 0x1f, 0x9f, 0x89, 0x39, 0xef, 0x00, 0xb4, 0xe1, 0xeb, 0xa1, 0x9d,
-	0xa4, 0xe3, 0x2e, 0xe6, 0x83, 0x2b, 0xa9, 0xd3, 0x6e, 0xc0, 0x8c, 0xdc, 0xd3, 0x7d, 0x35, 0xf3,
-	0x5a, 0xaa, 0x51, 0x88, 0x8a, 0x05, 0x1e, 0xa2, 0xaa, 0x4d, 0x50, 0xd3, 0x4c, 0x99, 0x9f, 0xa7,
-	0x94, 0x7d, 0x5c, 0xba, 0xf6, 0x00, 0xaa, 0x92, 0x7c, 0x14, 0x7d, 0x28, 0x8a, 0x82, 0xa8, 0x28,
-	0x1c, 0x09, 0xe5, 0x77, 0xe1, 0x6e, 0x8e, 0xc4, 0x36, 0xf3, 0xcc, 0xee, 0x8a, 0x67, 0x36, 0xcb,
-	0x07, 0x5d, 0x7e, 0x41, 0x7a, 0x68, 0x47, 0x70, 0x23, 0xc7, 0x8a, 0xe8, 0xcb, 0xe4, 0xa9, 0xe9,
-	0x39, 0xd3, 0x70, 0xe9, 0xb1, 0x7d, 0x0e, 0x35, 0x69, 0xfe, 0x89, 0x3e, 0x12, 0x55, 0x35, 0x4d,
-	0x54, 0x15, 0x4b, 0x52, 0x89, 0xb2, 0x7e, 0xa7, 0xc0, 0x54, 0xf2, 0xd8, 0x0b, 0xba, 0x3a, 0x2b,
-	0x34, 0x7c, 0x22, 0xba, 0x7a, 0x9d, 0x0b, 0x76, 0xdb, 0x8f, 0x37, 0xf0, 0xb1, 0xf9, 0x12, 0x67,
-	0xb8, 0x38, 0x89, 0xd1, 0x4b, 0xda, 0x3e, 0xbc, 0x9f, 0x1d, 0x1f, 0x50, 0x43, 0xdc, 0xe7, 0x35,
-	0xb2, 0xcf, 0xac, 0x68, 0xa2, 0x6b, 0xbf, 0x80, 0x5b, 0xb9, 0xd2, 0x42, 0x3f, 0x5e, 0xf1, 0x26,
-	0x9b, 0x37, 0x5e, 0xe9, 0xda, 0x97, 0x70, 0x35, 0xeb, 0x76, 0x80, 0x16, 0xc4, 0x89, 0xaf, 0x70,
-	0x13, 0x4b, 0x8f, 0xe8, 0x21, 0x4c, 0xcb, 0x2e, 0x98, 0x68, 0x5e, 0xdc, 0x7d, 0x35, 0x8c, 0x60,
-	0x11, 0x0d, 0x9d, 0xe1, 0xfb, 0xe0, 0x90, 0x63, 0xf7, 0xcb, 0x73, 0x85, 0xe5, 0x52, 0x6e, 0x58,
-	0x56, 0xb2, 0x60, 0xb9, 0xca, 0xc3, 0xb2, 0x42, 0xf1, 0xb7, 0xca, 0xe3, 0xaf, 0x42, 0x71, 0xf6,
-	0x6e, 0xec, 0x3e, 0x9c, 0x86, 0xca, 0x04, 0x7f, 0xb5, 0x3f, 0x28, 0x30, 0x26, 0x5c, 0xee, 0xce,
-	0xba, 0xfb, 0x05, 0xd1, 0xc4, 0x87, 0x1c, 0x62, 0x8b, 0xa1, 0x1f, 0xab, 0x6f, 0xe4, 0xb5, 0xa6,
-	0x25, 0xad, 0x0b, 0x28, 0x19, 0xcc, 0x45, 0xe9, 0x15, 0x99, 0xf4, 0x8a, 0x4c, 0x7a, 0xf6, 0x46,
-	0xa1, 0x0f, 0xb9, 0x8a, 0x17, 0x99, 0x31, 0x72, 0x41, 0x8c, 0x91, 0xaa, 0x18, 0xd2, 0x39, 0x1b,
-	0xa4, 0x41, 0xf2, 0x0d, 0x77, 0x55, 0xc8, 0x58, 0x13, 0x3d, 0x4d, 0x46, 0xc9, 0x46, 0xde, 0x6a,
-	0xcb, 0x10, 0x74, 0x8b, 0x7b, 0x80, 0x1c, 0xdd, 0x62, 0x54, 0xd4, 0x87, 0x3e, 0x05, 0x94, 0x2c,
-	0x11, 0x48, 0x9f, 0x39, 0x88, 0x45, 0x04, 0xc2, 0xfd, 0x7b, 0x05, 0x46, 0x05, 0x40, 0x3c, 0x4f,
-	0xe7, 0x1b, 0x89, 0xea, 0xb6, 0x9d, 0x78, 0xdd, 0xf6, 0x2b, 0xc7, 0x3a, 0x3a, 0xc4, 0x62, 0xe9,
-	0x6c, 0x4e, 0xcc, 0x9d, 0x52, 0xe8, 0xda, 0xda, 0x6f, 0x2f, 0xc3, 0x78, 0x0c, 0x84, 0x7f, 0xf0,
-	0x54, 0xbe, 0x2c, 0x8b, 0x19, 0x65, 0x59, 0xcc, 0x28, 0xc9, 0x62, 0x46, 0x49, 0x16, 0x33, 0x4a,
-	0x34, 0x66, 0xdc, 0x8f, 0xc5, 0x8c, 0x39, 0x99, 0x59, 0x71, 0xc1, 0x23, 0x56, 0x42, 0xab, 0x09,
-	0x39, 0xbc, 0x22, 0x4f, 0xcf, 0xcb, 0x2c, 0x3d, 0xbf, 0x27, 0xa4, 0xe7, 0x95, 0xc6, 0xcd, 0xd4,
-	0x8c, 0x3f, 0x11, 0xae, 0x9a, 0x21, 0x33, 0x49, 0xe2, 0x4f, 0xc5, 0xdc, 0x0a, 0x43, 0x23, 0x49,
-	0xf5, 0x87, 0x84, 0xc6, 0xa5, 0x50, 0x2b, 0xe4, 0x0a, 0x70, 0x4a, 0xad, 0xb4, 0xc3, 0xed, 0x93,
-	0x4a, 0x9c, 0xc2, 0x4a, 0x6d, 0x35, 0xa1, 0xd4, 0xa6, 0xb0, 0x5a, 0x5a, 0x4d, 0xa8, 0xa5, 0x29,
-	0xac, 0x52, 0x56, 0x13, 0x2a, 0x65, 0x0a, 0xab, 0x85, 0xd5, 0x84, 0x5a, 0x18, 0x1d, 0xd6, 0xb5,
-	0xc7, 0x70, 0x25, 0xb5, 0x68, 0xe7, 0xdf, 0xd6, 0x78, 0x74, 0xe5, 0x37, 0x9f, 0x74, 0xcb, 0xbf,
-	0x5d, 0x84, 0x2b, 0xe9, 0x19, 0xc0, 0x19, 0x8d, 0x7d, 0x4e, 0x84, 0x88, 0x14, 0x37, 0xcb, 0xef,
-	0xb6, 0xf3, 0xa2, 0xdb, 0x06, 0x78, 0xbf, 0x69, 0x39, 0x6f, 0xf6, 0x62, 0x19, 0x70, 0x9b, 0x51,
-	0xb2, 0x52, 0x77, 0x2a, 0x65, 0x1e, 0xe7, 0x19, 0xc9, 0xbe, 0xd8, 0x8a, 0x4e, 0x41, 0x87, 0x75,
-	0x59, 0xed, 0x43, 0x5e, 0xde, 0x2e, 0x5a, 0xfb, 0x88, 0x07, 0xe9, 0xf4, 0xda, 0x87, 0x7c, 0xdd,
-	0xdc, 0xb5, 0x8f, 0x61, 0x62, 0x73, 0x40, 0xf3, 0x05, 0x5c, 0x97, 0x57, 0xc4, 0x4f, 0xff, 0x9a,
-	0x5d, 0xfb, 0x1a, 0x66, 0xe4, 0x73, 0xa1, 0x4e, 0x52, 0xf0, 0x9b, 0xe9, 0xc5, 0xf8, 0x94, 0x8b,
-	0xc3, 0x8c, 0x3c, 0x1d, 0xe7, 0x73, 0xca, 0x52, 0x94, 0x53, 0x26, 0x53, 0x76, 0x1e, 0x0f, 0xb9,
-	0xd8, 0x90, 0x82, 0x87, 0xf1, 0x32, 0x89, 0xae, 0xed, 0xc3, 0x07, 0x43, 0xaa, 0x1f, 0xfc, 0xeb,
-	0x81, 0xd2, 0xd0, 0xe2, 0x39, 0x99, 0x35, 0x11, 0x18, 0x78, 0xd1, 0x32, 0x02, 0x43, 0x52, 0xbe,
-	0x7f, 0x96, 0xfc, 0x7c, 0x91, 0x67, 0xff, 0x2f, 0x42, 0x3e, 0x69, 0xb6, 0x5c, 0xa6, 0xce, 0xbb,
-	0x28, 0x56, 0xab, 0xde, 0xe3, 0x13, 0x4d, 0xe7, 0xd0, 0x75, 0x6c, 0x6c, 0x7b, 0x59, 0x25, 0x2b,
-	0x85, 0x79, 0xf6, 0x9d, 0x58, 0xc9, 0x2a, 0xba, 0xb3, 0x77, 0x0f, 0x0c, 0x17, 0x67, 0x16, 0xa9,
-	0x4a, 0xff, 0x81, 0x22, 0x55, 0x0c, 0x79, 0xca, 0x0c, 0x79, 0x96, 0x62, 0x3f, 0xf2, 0x0c, 0xfb,
-	0xfd, 0x91, 0x20, 0x93, 0x50, 0xe8, 0x49, 0x05, 0xbd, 0x53, 0x17, 0x7a, 0x64, 0xb0, 0x9b, 0x2c,
-	0xf4, 0xa4, 0xae, 0x37, 0xbc, 0xd0, 0x93, 0x43, 0x54, 0xce, 0xf1, 0x77, 0x61, 0x22, 0xee, 0xf1,
-	0x67, 0xb3, 0x6b, 0xed, 0x2b, 0xb8, 0x3e, 0x2c, 0x2f, 0xe1, 0xaf, 0xe9, 0x25, 0xf9, 0xaf, 0x6e,
-	0x49, 0x07, 0x7c, 0x02, 0x63, 0x02, 0x06, 0xa2, 0x15, 0x5e, 0x4e, 0x5a, 0x40, 0x0d, 0xb8, 0xd7,
-	0x1d, 0xfb, 0x65, 0x1f, 0xfb, 0x01, 0xda, 0x18, 0x1c, 0xf5, 0xf1, 0x61, 0xcc, 0xbe, 0x75, 0xed,
-	0xc7, 0x30, 0x11, 0x03, 0xbf, 0x33, 0x4c, 0xf6, 0xe7, 0x12, 0xa0, 0xa4, 0x27, 0xc9, 0xb5, 0xf8,
-	0x21, 0xaf, 0x45, 0x5a, 0xb1, 0xfa, 0xdc, 0x72, 0x5e, 0x7e, 0xfb, 0x64, 0x23, 0xf1, 0xe6, 0xed,
-	0xdc, 0x22, 0x86, 0xb4, 0xec, 0x5d, 0xce, 0x0d, 0xf7, 0x4a, 0x76, 0x50, 0x10, 0xe1, 0xbe, 0x3c,
-	0x24, 0x07, 0xfe, 0x21, 0xbd, 0x7f, 0x29, 0xf6, 0x0b, 0x70, 0x2e, 0x37, 0x5f, 0xd6, 0xde, 0xc0,
-	0x28, 0x1f, 0xbe, 0x8a, 0x9a, 0xbf, 0x22, 0x3b, 0x24, 0x25, 0xab, 0x9a, 0xc5, 0x8a, 0xd0, 0xc2,
-	0xea, 0xb2, 0x22, 0xb4, 0x10, 0x5d, 0x89, 0xd1, 0x7d, 0x0b, 0xf5, 0x74, 0xc3, 0x94, 0xd7, 0x02,
-	0x56, 0x44, 0xdb, 0x8b, 0x2c, 0x7c, 0x0b, 0xdb, 0xb8, 0x6f, 0xbe, 0xdc, 0xed, 0xe3, 0x57, 0xe6,
-	0x5b, 0xdc, 0x7b, 0x66, 0x9b, 0x5e, 0xfc, 0xcd, 0xd7, 0x6c, 0x0a, 0x5e, 0xf0, 0x10, 0x1a, 0x46,
-	0xb8, 0x14, 0x58, 0xd1, 0xb5, 0xaf, 0xe0, 0x83, 0x21, 0x2e, 0xc5, 0xde, 0xa4, 0x09, 0x7d, 0x1e,
-	0x43, 0x3d, 0xf0, 0x16, 0x8c, 0x8b, 0x4e, 0x24, 0xef, 0xf3, 0x1b, 0x40, 0x3d, 0x7d, 0xab, 0xec,
-	0x37, 0x1d, 0x61, 0x27, 0x94, 0x3c, 0xae, 0x11, 0x9d, 0xa5, 0xd3, 0x4c, 0x97, 0x41, 0x52, 0xd4,
-	0x7d, 0x42, 0xa6, 0x15, 0x75, 0xc7, 0xf6, 0x9c, 0xb1, 0xb2, 0x6c, 0xcf, 0x43, 0xce, 0x44, 0xd7,
-	0xee, 0xc1, 0x44, 0x6c, 0xc1, 0x1c, 0x86, 0xcb, 0xde, 0x15, 0x1d, 0x00, 0x4a, 0x6e, 0xed, 0x5c,
-	0x0d, 0x9f, 0x46, 0xa7, 0xc6, 0x9f, 0x26, 0xe1, 0xea, 0xa3, 0xb7, 0x1e, 0xb6, 0x7b, 0xb8, 0xb7,
-	0x6d, 0x7e, 0x77, 0x64, 0xf6, 0x0e, 0x0c, 0xbb, 0x67, 0x99, 0xf6, 0xeb, 0xe0, 0x55, 0x53, 0x1f,
-	0xad, 0x01, 0x44, 0xef, 0x78, 0x51, 0x90, 0x51, 0x24, 0xde, 0x0e, 0xd7, 0xab, 0xf1, 0x61, 0xd7,
-	0x3a, 0xd1, 0x2e, 0xa0, 0x16, 0x8c, 0xb0, 0x1e, 0x0b, 0x44, 0x48, 0xc4, 0x96, 0x93, 0xfa, 0x94,
-	0x38, 0x48, 0xb8, 0x3e, 0x86, 0xcb, 0x41, 0xdf, 0x22, 0x0a, 0x7c, 0x8b, 0x6f, 0x69, 0xac, 0x8f,
-	0x73, 0x23, 0xe1, 0x12, 0xac, 0x83, 0x8b, 0x2c, 0x11, 0x6b, 0x68, 0x23, 0x4b, 0x08, 0x4d, 0x5e,
-	0x84, 0x8b, 0x35, 0x69, 0x11, 0xae, 0x58, 0x17, 0x17, 0xe1, 0x12, 0xfa, 0xb8, 0xb4, 0x0b, 0xe8,
-	0x09, 0x4c, 0xc4, 0x5e, 0xa2, 0xa2, 0x3a, 0xb1, 0x05, 0xd9, 0xc3, 0xd5, 0xba, 0x2a, 0xfd, 0x46,
-	0xa6, 0xda, 0x0b, 0x1a, 0x33, 0x62, 0x3d, 0x5b, 0xe8, 0x1a, 0xe3, 0x90, 0x76, 0x7f, 0xd5, 0xdf,
-	0x4b, 0xfb, 0x4c, 0xe6, 0x7c, 0x08, 0xa3, 0xfc, 0x1b, 0x68, 0x34, 0x4b, 0xc9, 0xe3, 0x2f, 0xa8,
-	0xeb, 0xb5, 0xe4, 0x07, 0x32, 0xc3, 0x76, 0xd0, 0xea, 0x25, 0xbc, 0x1d, 0x45, 0x6c, 0x51, 0xd9,
-	0xb3, 0xd5, 0xfa, 0x15, 0xf9, 0x47, 0x32, 0xdb, 0x3a, 0x8c, 0x09, 0xcd, 0x27, 0x88, 0x29, 0x24,
-	0xd1, 0xa7, 0x52, 0x9f, 0x91, 0x7c, 0x21, 0x93, 0x2c, 0x43, 0x39, 0xec, 0x34, 0x43, 0xd3, 0x94,
-	0x4c, 0x68, 0x46, 0xab, 0xa3, 0xd8, 0x28, 0x61, 0xd4, 0xe0, 0xe2, 0x96, 0x83, 0xc6, 0x82, 0x6f,
-	0xa1, 0x9d, 0x56, 0xd8, 0x9f, 0x84, 0x66, 0x0d, 0x20, 0xea, 0x21, 0x26, 0xb6, 0x9d, 0x68, 0x34,
-	0x26, 0xb6, 0x1d, 0x6b, 0x35, 0x26, 0x82, 0x85, 0xaf, 0x81, 0x89, 0x60, 0xf1, 0x07, 0xc3, 0x44,
-	0x30, 0xf1, 0xc9, 0x30, 0xb1, 0x3d, 0xd6, 0xa6, 0x41, 0x6c, 0x2f, 0xd6, 0xc7, 0x41, 0x6c, 0x4f,
-	0xe8, 0xe4, 0xd0, 0x2e, 0xa0, 0xfb, 0x50, 0xe1, 0x1e, 0x97, 0x23, 0x12, 0xc7, 0x13, 0x0f, 0xd3,
-	0xeb, 0xd3, 0x89, 0xf1, 0x68, 0x51, 0xfa, 0x60, 0x9c, 0x2e, 0x2a, 0x3e, 0x33, 0xa7, 0x8b, 0xf2,
-	0x6f, 0xca, 0x23, 0xae, 0x7d, 0xd3, 0x1d, 0x44, 0x5c, 0xdc, 0x7b, 0xd7, 0x88, 0x2b, 0x7c, 0x28,
-	0xaa, 0x5d, 0x40, 0x3a, 0xbc, 0xcb, 0x22, 0x56, 0xa0, 0x01, 0xf1, 0x21, 0x68, 0x7d, 0x52, 0x18,
-	0x23, 0x2c, 0xb7, 0xa0, 0xb4, 0x63, 0xbe, 0x45, 0x81, 0x7b, 0x47, 0xed, 0x40, 0xf5, 0xd1, 0xf0,
-	0x6f, 0x42, 0x36, 0x0f, 0x97, 0x76, 0x9c, 0x63, 0x8c, 0x82, 0x76, 0x1a, 0xae, 0x3b, 0xb9, 0x3e,
-	0x16, 0x0d, 0x44, 0x32, 0x90, 0x2e, 0x13, 0x2a, 0x83, 0xd0, 0xd8, 0x42, 0x65, 0xe0, 0xda, 0x50,
-	0xc8, 0xe4, 0x4f, 0x5d, 0x6c, 0x93, 0xc9, 0xb9, 0xc6, 0x70, 0x32, 0x79, 0xd8, 0x1a, 0x4e, 0xe2,
-	0x40, 0xac, 0xd3, 0x84, 0xc4, 0x01, 0x79, 0x63, 0x0a, 0x89, 0x03, 0xb2, 0xd6, 0x14, 0xe2, 0x23,
-	0x42, 0x03, 0x09, 0xe2, 0x88, 0xc5, 0x06, 0x14, 0xe2, 0x23, 0xc9, 0x6e, 0x13, 0xe2, 0xf8, 0x7c,
-	0xf3, 0x38, 0x71, 0x7c, 0x49, 0x3f, 0x3a, 0x71, 0xfc, 0x44, 0x9f, 0x39, 0x11, 0x43, 0x78, 0xc7,
-	0x4a, 0xc4, 0x90, 0x3d, 0x8e, 0x25, 0x62, 0x24, 0x1f, 0xbd, 0x12, 0xb5, 0xc4, 0xba, 0x28, 0x89,
-	0x5a, 0xe4, 0x3d, 0x9b, 0x75, 0x55, 0xfa, 0x2d, 0x0c, 0x44, 0x5d, 0x69, 0x20, 0xea, 0x66, 0x05,
-	0xa2, 0x6e, 0x4a, 0x20, 0x9a, 0x87, 0x4b, 0x5d, 0xcf, 0x71, 0xc9, 0xc9, 0x72, 0x1d, 0x9a, 0xe4,
-	0x64, 0xc3, 0x1e, 0x4d, 0xa2, 0x07, 0xa1, 0xbb, 0x86, 0xe8, 0x41, 0xd6, 0x9d, 0x43, 0xf4, 0x90,
-	0x6c, 0xc5, 0x21, 0x51, 0x25, 0x6a, 0xcc, 0x26, 0x51, 0x25, 0xd1, 0xd2, 0x4d, 0xa2, 0x4a, 0xac,
-	0x7f, 0x9b, 0xe7, 0x0d, 0x7c, 0x8e, 0xe3, 0xe5, 0xbd, 0xae, 0x1a, 0x1f, 0x26, 0xbc, 0x9b, 0x30,
-	0x2e, 0x36, 0x62, 0xa0, 0x40, 0x2b, 0xd2, 0x46, 0x8f, 0xfa, 0xac, 0xec, 0x53, 0xa8, 0xae, 0xe7,
-	0x86, 0xe9, 0x11, 0x75, 0x71, 0x5d, 0xa8, 0x44, 0x5d, 0x61, 0xcb, 0xa9, 0x76, 0xe1, 0xc5, 0x3b,
-	0xc1, 0xff, 0x4d, 0xa2, 0xf9, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x72, 0x2f, 0x42, 0x7f, 0x5c,
-	0x42, 0x00, 0x00,
+	// 3490 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x3c, 0xdd, 0x73, 0xdb, 0xc6,
+	0xf1, 0x3f, 0x98, 0x76, 0x22, 0x2e, 0x45, 0x49, 0x3c, 0x8a, 0x12, 0x8c, 0xd8, 0x8e, 0x7f, 0xb0,
+	0xad, 0x28, 0xfe, 0x90, 0xc5, 0x0f, 0x51, 0x92, 0x1d, 0x27, 0x96, 0x25, 0x4b, 0x76, 0x2a, 0xc7,
+	0xaa, 0x28, 0xc7, 0x9d, 0xc9, 0xb4, 0x29, 0x2c, 0xc2, 0x16, 0x26, 0x14, 0xc0, 0x90, 0x90, 0x3f,
+	0xda, 0x99, 0xbe, 0xb4, 0xaf, 0x7d, 0xec, 0x4c, 0xff, 0x8e, 0xbc, 0x74, 0xfa, 0xdc, 0xc7, 0xbe,
+	0xf7, 0xa1, 0xd3, 0xff, 0xa1, 0x7f, 0x40, 0x3b, 0x93, 0x0e, 0x70, 0x77, 0xc0, 0x2d, 0x70, 0x00,
+	0x21, 0x48, 0xe9, 0x74, 0xda, 0x17, 0x8d, 0xb8, 0xb7, 0xbb, 0x77, 0xb7, 0xb7, 0x5f, 0xb7, 0xbc,
+	0x25, 0x94, 0x7a, 0x07, 0xb7, 0x7b, 0x07, 0x0b, 0xfd, 0x81, 0xe3, 0x3a, 0xe4, 0x4c, 0xef, 0x40,
+	0xff, 0x8d, 0x02, 0xf0, 0xc4, 0xe8, 0x3f, 0x31, 0x87, 0x43, 0xe3, 0x95, 0x49, 0x56, 0xa1, 0x78,
+	0x68, 0xf4, 0xbf, 0x7e, 0x69, 0x99, 0xbd, 0xae, 0xaa, 0x5c, 0x2e, 0xcc, 0x97, 0x1a, 0x17, 0x16,
+	0x7a, 0x07, 0x0b, 0x21, 0x8a, 0xf7, 0xef, 0xa6, 0x37, 0xfc, 0xd0, 0x76, 0x07, 0xef, 0x76, 0xc7,
+	0x0e, 0xd9, 0x47, 0xed, 0x2e, 0x94, 0xd1, 0x10, 0x99, 0x82, 0xc2, 0x37, 0xe6, 0x3b, 0x55, 0xb9,
+	0xac, 0xcc, 0x17, 0x77, 0xbd, 0x7f, 0xc9, 0x34, 0x9c, 0x7b, 0x6d, 0xf4, 0x8e, 0x4c, 0xf5, 0x8c,
+	0x0f, 0xa3, 0x1f, 0xee, 0x9c, 0x59, 0x51, 0xf4, 0xff, 0x07, 0x58, 0xb3, 0xdf, 0xf1, 0x55, 0x54,
+	0xe1, 0xdc, 0xda, 0xe0, 0xd5, 0xd7, 0x75, 0x46, 0x7b, 0xd6, 0x18, 0xbc, 0xaa, 0xeb, 0x55, 0xa8,
+	0x3c, 0xb6, 0x2d, 0xd7, 0x32, 0x7a, 0xd6, 0x2f, 0xcc, 0x5d, 0xf3, 0xdb, 0x23, 0x73, 0xe8, 0xea,
+	0xf7, 0x61, 0x52, 0x04, 0xf6, 0x7b, 0xef, 0xc8, 0x2d, 0x38, 0xb7, 0x6b, 0xba, 0x8c, 0xb8, 0xd4,
+	0x50, 0xbd, 0xe5, 0xaf, 0x3b, 0x87, 0x87, 0x86, 0xdd, 0xed, 0xb8, 0x86, 0x7b, 0x34, 0x64, 0xb3,
+	0xec, 0x9e, 0x1d, 0x98, 0x6e, 0x5d, 0x2f, 0x43, 0xe9, 0x69, 0xdf, 0xb4, 0x39, 0xc3, 0x3b, 0x50,
+	0xa4, 0x1f, 0x73, 0xb0, 0xfa, 0x0c, 0xaa, 0xbb, 0xe6, 0xd0, 0x74, 0x77, 0xac, 0xa1, 0xeb, 0xd8,
+	0x43, 0xc6, 0xd2, 0xdb, 0x8d, 0x11, 0xec, 0xa6, 0x40, 0x77, 0xc3, 0x81, 0x0d, 0xf5, 0x4c, 0x00,
+	0x6c, 0xe8, 0x0f, 0xa0, 0x82, 0x19, 0xe4, 0x58, 0xc4, 0x04, 0x8c, 0xaf, 0xf7, 0x9c, 0x61, 0x20,
+	0xa1, 0xbb, 0x00, 0xec, 0x73, 0x0e, 0x66, 0x7f, 0x3c, 0x03, 0xa5, 0x27, 0xce, 0x6b, 0xce, 0x8c,
+	0xcc, 0x89, 0x5b, 0x29, 0x35, 0x2a, 0x1e, 0xf9, 0xda, 0x60, 0x60, 0xbc, 0x7b, 0xfa, 0x72, 0xe8,
+	0x0e, 0x2c, 0xfb, 0x15, 0xdb, 0xdd, 0x9c, 0xb8, 0xbb, 0x44, 0xbc, 0x06, 0xb9, 0x46, 0xf1, 0x9a,
+	0x6a, 0xc1, 0xc7, 0x9b, 0x12, 0xf0, 0x2c, 0xdb, 0x6d, 0xb7, 0x7c, 0xb4, 0x26, 0x67, 0xd7, 0x52,
+	0xcf, 0xc6, 0xd8, 0x75, 0x9d, 0xa3, 0x17, 0x3d, 0xd3, 0xc7, 0x6b, 0x71, 0xbc, 0x25, 0xf5, 0x5c,
+	0x1a, 0xde, 0x12, 0xc7, 0x6b, 0xab, 0xef, 0xa5, 0xe1, 0xb5, 0x39, 0xde, 0xb2, 0xfa, 0x7e, 0xda,
+	0x36, 0x96, 0xf9, 0x61, 0xae, 0xa8, 0x63, 0xc1, 0x61, 0xae, 0x78, 0x9a, 0x44, 0x45, 0x97, 0x43,
+	0xee, 0xf3, 0x50, 0x79, 0x66, 0xf7, 0x1c, 0xa3, 0xfb, 0xc8, 0x34, 0xba, 0x69, 0x7a, 0xe4, 0x19,
+	0x80, 0x88, 0x99, 0x63, 0xae, 0xef, 0x15, 0x98, 0xdc, 0xb0, 0x86, 0x7d, 0xd3, 0x1e, 0x66, 0x39,
+	0x67, 0x41, 0x40, 0x75, 0x72, 0x15, 0x9f, 0xf3, 0xa4, 0x80, 0xf7, 0xc2, 0x71, 0x7a, 0xec, 0x94,
+	0xab, 0xe2, 0x29, 0x17, 0xd8, 0x99, 0x56, 0xc5, 0x33, 0x2d, 0x8c, 0x3e, 0x40, 0x41, 0xe0, 0xa9,
+	0x07, 0x28, 0xe0, 0xb5, 0xc9, 0x55, 0x7c, 0x80, 0xd2, 0xf5, 0x2d, 0xeb, 0x9f, 0x42, 0x39, 0x14,
+	0x40, 0x0e, 0x09, 0x56, 0x60, 0x72, 0xd3, 0xb2, 0x91, 0x5f, 0xfa, 0x14, 0xca, 0x21, 0x28, 0x07,
+	0xcb, 0x45, 0x38, 0xbf, 0x65, 0xba, 0xeb, 0x47, 0x83, 0x81, 0x69, 0xbb, 0x3b, 0xce, 0xd0, 0x72,
+	0x2d, 0xc7, 0x4e, 0x55, 0x84, 0x9f, 0xc2, 0xac, 0x8c, 0xc2, 0x9b, 0xbb, 0x2a, 0xce, 0x5d, 0xa4,
+	0x33, 0xf0, 0x05, 0xf1, 0xa3, 0x4b, 0x5f, 0x50, 0x43, 0x27, 0x30, 0xb5, 0x65, 0xba, 0x74, 0x84,
+	0x6f, 0xd2, 0x85, 0x09, 0x01, 0xe6, 0xcd, 0xd4, 0xc4, 0xbb, 0xbc, 0xc4, 0x42, 0x07, 0x3d, 0x93,
+	0xd0, 0xc1, 0xa3, 0xbd, 0x1e, 0x77, 0x25, 0xcf, 0x61, 0xa6, 0xe3, 0xb9, 0xc8, 0xbe, 0xe9, 0xba,
+	0x66, 0xa7, 0x6f, 0x9a, 0xdd, 0x63, 0x3b, 0x5a, 0xac, 0x91, 0x0a, 0xd5, 0x48, 0xfd, 0x21, 0x4c,
+	0xc7, 0x18, 0xe7, 0x0b, 0x28, 0x1d, 0xd7, 0xe9, 0x0b, 0x01, 0x85, 0x7e, 0xcc, 0xc1, 0x4a, 0x87,
+	0xd2, 0x73, 0xc3, 0x72, 0xa5, 0xfb, 0x53, 0xd8, 0xb9, 0xdf, 0x81, 0x22, 0xc5, 0xc9, 0x69, 0xfa,
+	0x6b, 0xc3, 0xbe, 0x35, 0x30, 0xdc, 0xff, 0x5d, 0xd3, 0x0f, 0x05, 0x90, 0x43, 0x82, 0x0b, 0xbe,
+	0xd5, 0x71, 0x73, 0xeb, 0xb8, 0x82, 0x20, 0xd1, 0x69, 0xf1, 0x24, 0xe6, 0x2b, 0xa8, 0xc5, 0xf1,
+	0x4f, 0xcb, 0x46, 0x67, 0x60, 0x7a, 0xcb, 0x74, 0x9f, 0x1e, 0xb9, 0xfd, 0x23, 0x77, 0xd3, 0xea,
+	0x05, 0xce, 0xe8, 0x27, 0x40, 0x22, 0xf0, 0xd3, 0x9a, 0xb1, 0x04, 0xc5, 0x2d, 0x87, 0x4f, 0xb3,
+	0x02, 0xef, 0x6f, 0x39, 0x94, 0xf7, 0x31, 0xa5, 0xf8, 0x0f, 0x2f, 0x09, 0xb5, 0xde, 0xa6, 0xda,
+	0x71, 0x4a, 0x4a, 0x21, 0xe8, 0x65, 0x83, 0xcc, 0x89, 0x1a, 0x97, 0xa8, 0x1f, 0x4d, 0x72, 0x4d,
+	0x54, 0xc2, 0xa4, 0xd4, 0xa3, 0x45, 0xaa, 0xa2, 0x5a, 0x16, 0x7e, 0x10, 0x1d, 0x5c, 0x85, 0x31,
+	0x7f, 0xf3, 0xf9, 0x22, 0xcf, 0xb6, 0xf5, 0xea, 0xc0, 0x1d, 0x3e, 0xb5, 0x85, 0xc8, 0x13, 0x82,
+	0x72, 0xb0, 0xec, 0xc2, 0x84, 0x9f, 0xb6, 0x18, 0x6f, 0xb2, 0xbb, 0x55, 0x25, 0xc5, 0xad, 0x62,
+	0x6b, 0xa7, 0xc0, 0x96, 0x7e, 0x0f, 0xc6, 0x83, 0x59, 0x72, 0x2c, 0x52, 0x85, 0x99, 0x5d, 0xf3,
+	0xd0, 0x79, 0x6d, 0xae, 0xf5, 0x7a, 0x3b, 0x3d, 0xc3, 0x35, 0x83, 0x98, 0xf4, 0x10, 0xa6, 0x63,
+	0x23, 0x39, 0x26, 0xb8, 0xc1, 0xd9, 0xf8, 0x3c, 0xd6, 0xdc, 0x54, 0xa3, 0x5e, 0x07, 0x12, 0x41,
+	0xce, 0x37, 0x23, 0x4d, 0xe4, 0xd6, 0xba, 0x46, 0xdf, 0x75, 0x06, 0xa9, 0xc1, 0x7e, 0x1d, 0x48,
+	0x04, 0x39, 0xc7, 0x8c, 0x2f, 0xa0, 0xb2, 0xd6, 0xed, 0xfa, 0x6b, 0xde, 0x73, 0xd2, 0x36, 0x48,
+	0xae, 0x60, 0xdb, 0x9b, 0xf0, 0xf5, 0x38, 0x88, 0xe6, 0xb2, 0xc3, 0x2f, 0xb2, 0x98, 0x7a, 0x1f,
+	0x26, 0xc5, 0x39, 0x72, 0xac, 0xf2, 0x3a, 0x54, 0xb7, 0x4c, 0xd7, 0xcb, 0x6e, 0x93, 0xbd, 0x2b,
+	0x17, 0xcb, 0x73, 0xa8, 0x60, 0xdc, 0xd3, 0xf2, 0x73, 0x1f, 0x03, 0xd9, 0xce, 0x78, 0x34, 0x6b,
+	0x30, 0xb5, 0x7d, 0xc2, 0x83, 0x99, 0x83, 0xc9, 0xed, 0x2c, 0xb9, 0xbf, 0x67, 0xea, 0x27, 0xc9,
+	0xfc, 0x3f, 0x87, 0xda, 0xb3, 0x7e, 0xd7, 0x70, 0xcd, 0x27, 0xa6, 0x6b, 0x6c, 0x18, 0xae, 0xc1,
+	0x67, 0xab, 0xe3, 0x1c, 0xc0, 0xaf, 0x00, 0xec, 0xb8, 0x83, 0x3d, 0x67, 0xfb, 0xd1, 0xce, 0xc0,
+	0xe9, 0x9b, 0x03, 0xd7, 0x32, 0x87, 0xe2, 0xe9, 0xd7, 0xf5, 0x0d, 0xa8, 0x46, 0x79, 0xe5, 0xb3,
+	0x6b, 0x2f, 0x89, 0x35, 0xfa, 0xc6, 0x0b, 0xab, 0x67, 0x79, 0xb3, 0x70, 0xbb, 0x1e, 0xc2, 0x74,
+	0x6c, 0xc4, 0x9b, 0xe0, 0x26, 0x9e, 0x60, 0xd6, 0x9b, 0x40, 0xba, 0xca, 0x9c, 0x49, 0x2f, 0xf3,
+	0xa5, 0x2f, 0x5f, 0xf2, 0x85, 0x7c, 0x06, 0x13, 0x02, 0x2c, 0xc7, 0x1e, 0xf7, 0x61, 0xb6, 0x73,
+	0x8c, 0x94, 0x81, 0x34, 0x29, 0x90, 0xaf, 0x79, 0x64, 0x4e, 0xed, 0x57, 0x12, 0x36, 0xa1, 0xd6,
+	0x91, 0xe6, 0x19, 0xc7, 0x5c, 0xec, 0xdf, 0x14, 0x7e, 0x13, 0xdd, 0xb3, 0xfa, 0x41, 0x45, 0xe3,
+	0x1a, 0xd6, 0x0f, 0x79, 0xec, 0xcc, 0x92, 0x7a, 0x17, 0x46, 0x5f, 0xf0, 0x85, 0x80, 0x7a, 0xea,
+	0x49, 0x62, 0x78, 0x7b, 0xa6, 0xbb, 0xcb, 0x21, 0xa0, 0xbf, 0x2a, 0xd4, 0x58, 0xff, 0x2b, 0xc5,
+	0xc3, 0x1c, 0x4c, 0x6e, 0xe1, 0x78, 0xb9, 0x04, 0x03, 0x64, 0xce, 0x25, 0x8a, 0x29, 0xe1, 0x04,
+	0xe7, 0x12, 0x63, 0x42, 0x2e, 0xc1, 0x67, 0xc9, 0xb1, 0xc8, 0xfb, 0xfe, 0xb5, 0x6f, 0x63, 0x60,
+	0xbd, 0x4e, 0xb9, 0x4d, 0x16, 0x53, 0xd2, 0x1e, 0x2f, 0x1a, 0x47, 0x38, 0xe4, 0x58, 0xc6, 0x6f,
+	0x4b, 0x50, 0x95, 0x38, 0x2e, 0x69, 0x2d, 0x94, 0x03, 0xb1, 0xea, 0x7c, 0x46, 0x81, 0x3c, 0xf3,
+	0xbd, 0x8e, 0x1c, 0x05, 0x77, 0xdf, 0xcc, 0x23, 0x48, 0x9c, 0x46, 0x93, 0x34, 0x29, 0x03, 0xae,
+	0x66, 0x59, 0x3c, 0x4d, 0x8b, 0xdc, 0xa6, 0x44, 0x5c, 0xe7, 0x34, 0x44, 0x44, 0xff, 0x8a, 0x04,
+	0x4b, 0x9c, 0x80, 0x2b, 0xdf, 0x28, 0x82, 0x36, 0xf9, 0x84, 0x12, 0xf0, 0x2c, 0xfa, 0x23, 0xe9,
+	0xbe, 0x7a, 0x7e, 0xe8, 0x89, 0xad, 0x6f, 0x99, 0xdc, 0xa3, 0xd4, 0xb4, 0x36, 0x57, 0x6a, 0xcc,
+	0xcb, 0xa8, 0xf7, 0xac, 0xfe, 0x0b, 0xe7, 0xad, 0x84, 0x7c, 0x85, 0x0b, 0x75, 0x55, 0x2d, 0x26,
+	0x0b, 0x75, 0xcf, 0xea, 0xbf, 0x31, 0x86, 0xd2, 0xf9, 0x57, 0xc9, 0xa7, 0xf0, 0x9e, 0x7f, 0x7e,
+	0x8b, 0x2a, 0x1c, 0x6f, 0xf9, 0x9e, 0xa2, 0xd5, 0x17, 0x03, 0xfa, 0xba, 0x5a, 0xca, 0x41, 0x5f,
+	0x27, 0x75, 0x46, 0xdf, 0x50, 0xc7, 0x47, 0xca, 0xdb, 0x27, 0x69, 0x90, 0x1a, 0x23, 0x69, 0xaa,
+	0x65, 0x5a, 0xa7, 0xf7, 0xc0, 0xcd, 0x00, 0xdc, 0x52, 0x27, 0x42, 0x70, 0x2b, 0x00, 0x2f, 0xa9,
+	0x93, 0x21, 0x78, 0x29, 0x00, 0xb7, 0xd5, 0xa9, 0x10, 0xdc, 0x26, 0x2d, 0x06, 0x5e, 0x56, 0x2b,
+	0xfe, 0x72, 0x2e, 0x0a, 0xbe, 0x87, 0x6d, 0xc6, 0xcb, 0x67, 0xd0, 0x8a, 0x96, 0x03, 0xaa, 0x15,
+	0x95, 0x64, 0xa6, 0x5a, 0x21, 0xcb, 0x8c, 0x6a, 0x55, 0xad, 0xfa, 0x54, 0x97, 0xe3, 0x54, 0x2c,
+	0x4d, 0x43, 0x84, 0xab, 0xa4, 0x49, 0x09, 0x1b, 0x8b, 0xea, 0x74, 0x98, 0x00, 0x61, 0xc2, 0x3d,
+	0xab, 0x2f, 0x12, 0x35, 0x16, 0xc9, 0x3c, 0x23, 0xaa, 0xab, 0xb5, 0x24, 0xaf, 0xea, 0x63, 0xd6,
+	0x03, 0xcc, 0x86, 0x3a, 0x93, 0x8a, 0xd9, 0x08, 0x30, 0x9b, 0xea, 0x6c, 0x2a, 0x66, 0x33, 0xc0,
+	0x6c, 0xa9, 0x6a, 0x2a, 0x66, 0x2b, 0xc0, 0x5c, 0x52, 0xcf, 0xa7, 0x62, 0x2e, 0x05, 0x98, 0x6d,
+	0x55, 0x4b, 0xc5, 0x6c, 0x93, 0x55, 0x86, 0xb9, 0xac, 0x7e, 0xe0, 0x63, 0xea, 0x42, 0xc6, 0xb8,
+	0x7e, 0x60, 0xd8, 0xb6, 0xd9, 0xdb, 0x31, 0x06, 0xc6, 0xa1, 0xe9, 0x9a, 0x48, 0xd6, 0x8d, 0x65,
+	0x72, 0x9f, 0x91, 0xae, 0xa8, 0x17, 0x7c, 0xd2, 0x8f, 0xe3, 0xb2, 0x4e, 0xe5, 0xb0, 0x42, 0xee,
+	0x31, 0x0e, 0xab, 0xea, 0x45, 0x9f, 0xc3, 0x1c, 0xd2, 0xf0, 0x75, 0xc7, 0x19, 0x74, 0x2d, 0xdb,
+	0x70, 0x03, 0x97, 0x8a, 0xc8, 0x57, 0xb9, 0xa2, 0x36, 0x17, 0xd5, 0x4b, 0xbe, 0x33, 0xf5, 0xc0,
+	0xcd, 0x45, 0xfd, 0x11, 0xa8, 0x49, 0x29, 0x2f, 0xb9, 0x49, 0xa3, 0xc0, 0xe8, 0xa4, 0xd3, 0x4f,
+	0x8d, 0xaf, 0x42, 0x19, 0x09, 0x4d, 0x8c, 0x2c, 0x85, 0xe0, 0x12, 0x79, 0x05, 0xc6, 0xc5, 0x1c,
+	0x01, 0x23, 0x15, 0x62, 0xac, 0x68, 0xcd, 0x03, 0x63, 0xf1, 0x92, 0xa0, 0x0e, 0x25, 0xa1, 0xca,
+	0x80, 0x71, 0xc6, 0x18, 0xce, 0xcf, 0xe0, 0x6a, 0xb2, 0x6b, 0x4f, 0xfd, 0x12, 0xef, 0xaa, 0xf8,
+	0x25, 0x5e, 0xfc, 0x32, 0x48, 0x07, 0xf5, 0x7d, 0xd0, 0x92, 0xf9, 0x93, 0x87, 0xf1, 0xaf, 0x19,
+	0xe7, 0xd3, 0xa3, 0x8d, 0xec, 0x2b, 0x47, 0xfd, 0x19, 0x4c, 0xcb, 0x22, 0x2a, 0x8e, 0x99, 0x63,
+	0x69, 0x31, 0xb3, 0x2a, 0xc6, 0x4c, 0x7e, 0x69, 0x7d, 0x0d, 0x8b, 0xd9, 0xe2, 0x66, 0xaa, 0x9c,
+	0x16, 0xb1, 0x9c, 0x34, 0xf1, 0x12, 0x85, 0xb9, 0x71, 0x99, 0xfd, 0x12, 0xe6, 0xb2, 0xcd, 0x4b,
+	0x7e, 0x1c, 0x97, 0x5f, 0x2b, 0x7b, 0xb8, 0x97, 0xca, 0xf2, 0x4f, 0x05, 0x98, 0x8c, 0x44, 0xc2,
+	0x0c, 0xb9, 0x47, 0x31, 0x45, 0x8e, 0xa4, 0x2a, 0xe6, 0x13, 0xc5, 0xb0, 0xa0, 0xb6, 0x16, 0x2b,
+	0xa8, 0x55, 0xc5, 0x9c, 0xa0, 0xc0, 0xe2, 0x7e, 0x55, 0x8c, 0xfb, 0x0a, 0x0b, 0xe7, 0xd7, 0x71,
+	0x38, 0xaf, 0x09, 0x52, 0x15, 0x5c, 0x32, 0x8d, 0xdd, 0x37, 0x70, 0xec, 0x9e, 0x11, 0x70, 0x9f,
+	0x9b, 0xbd, 0x1e, 0x8e, 0xd3, 0x35, 0x14, 0xa7, 0x0b, 0x3c, 0xfc, 0xde, 0x89, 0x84, 0xdf, 0x2b,
+	0x82, 0x7b, 0x4a, 0x8d, 0x08, 0xf5, 0x3a, 0xa9, 0xa1, 0xd0, 0xab, 0xc8, 0xc3, 0xab, 0x22, 0x0f,
+	0xaf, 0x8a, 0x3c, 0xbc, 0x2a, 0xf2, 0xf0, 0xca, 0xc0, 0x6d, 0xfd, 0xcf, 0x0a, 0x4c, 0x45, 0xa3,
+	0xda, 0x49, 0x8f, 0x71, 0x19, 0xa7, 0x85, 0x59, 0x7c, 0x7b, 0xca, 0x51, 0x5f, 0xc7, 0xe9, 0x5f,
+	0xca, 0x01, 0xb6, 0xf5, 0x3b, 0x50, 0x89, 0x0d, 0x49, 0x6f, 0x57, 0x31, 0xda, 0xba, 0x6e, 0xc3,
+	0xcd, 0xd1, 0x31, 0x20, 0xd5, 0x80, 0x6f, 0x62, 0x03, 0x9e, 0xa1, 0x09, 0x7c, 0x94, 0x13, 0x37,
+	0xde, 0x21, 0xe8, 0xa3, 0xe7, 0x23, 0x4f, 0xe2, 0x86, 0xbb, 0x98, 0x2d, 0x5c, 0x49, 0x8d, 0x76,
+	0x03, 0x66, 0xe4, 0x96, 0x4e, 0xae, 0x63, 0x29, 0xd5, 0x58, 0x88, 0xc2, 0x58, 0x4c, 0x54, 0x9b,
+	0xa0, 0x26, 0xa9, 0xb2, 0xc8, 0xa7, 0x90, 0x7e, 0x5c, 0x5e, 0x3d, 0xaa, 0x2a, 0xc9, 0x47, 0xc9,
+	0x47, 0x78, 0x29, 0x84, 0x2d, 0x45, 0x40, 0x61, 0xf4, 0x7d, 0xb8, 0x95, 0x21, 0xb1, 0x4d, 0x3d,
+	0xb3, 0x5b, 0xf8, 0xcc, 0x66, 0x45, 0xa7, 0x2b, 0x4e, 0xc8, 0x0e, 0xed, 0x08, 0xae, 0x64, 0x98,
+	0x91, 0x7c, 0x11, 0x3f, 0xb5, 0x7a, 0xc6, 0x34, 0x5c, 0x7a, 0x6c, 0x0f, 0xa0, 0x26, 0xcd, 0x3f,
+	0xc9, 0xc7, 0x58, 0x54, 0xd3, 0x54, 0x54, 0x18, 0x89, 0x09, 0xeb, 0xf7, 0x0a, 0x54, 0xe2, 0xc7,
+	0x9e, 0xd3, 0xd4, 0x79, 0xa1, 0xe1, 0x36, 0x36, 0x75, 0x4d, 0x70, 0x76, 0xdb, 0x8f, 0x36, 0xcc,
+	0xd7, 0xd6, 0xbe, 0x99, 0x62, 0xe2, 0xd4, 0x47, 0x2f, 0xe9, 0x7b, 0x70, 0x29, 0xdd, 0x3f, 0x90,
+	0x06, 0xde, 0xe7, 0x45, 0xba, 0xcf, 0x34, 0x6f, 0x52, 0xd7, 0x7f, 0x0e, 0xd7, 0x32, 0xa5, 0x85,
+	0x64, 0x39, 0x64, 0x5e, 0x38, 0x86, 0xbf, 0xaa, 0xeb, 0x5f, 0xc0, 0x85, 0xb4, 0xdb, 0x01, 0x59,
+	0xc0, 0x8c, 0xcf, 0x0b, 0x8c, 0xa5, 0x47, 0x74, 0x1f, 0xa6, 0x65, 0x17, 0x4c, 0x32, 0x8f, 0x77,
+	0x5f, 0x0d, 0x3c, 0x58, 0x88, 0xc3, 0x38, 0x7c, 0xef, 0x1f, 0x72, 0xe4, 0x7e, 0x79, 0xaa, 0x61,
+	0xb9, 0x90, 0x39, 0x2c, 0x2b, 0x69, 0x61, 0xb9, 0x2a, 0x86, 0x65, 0x85, 0xc5, 0xdf, 0xaa, 0x18,
+	0x7f, 0x15, 0x16, 0x67, 0x6f, 0x45, 0xee, 0xc3, 0x49, 0x51, 0x99, 0xc6, 0x5f, 0xfd, 0x0f, 0x0a,
+	0x94, 0xd1, 0xe5, 0xee, 0xa4, 0xbb, 0x5f, 0xc0, 0x2a, 0x3e, 0xe2, 0x10, 0x5b, 0x64, 0x59, 0x14,
+	0x4c, 0x76, 0x6d, 0x5a, 0xd2, 0x3b, 0x40, 0xe2, 0xce, 0x1c, 0xaf, 0x5e, 0x91, 0xad, 0x5e, 0x91,
+	0xad, 0x9e, 0xbf, 0x51, 0x18, 0x40, 0xa6, 0xe2, 0x45, 0xaa, 0x8f, 0x5c, 0xc0, 0x3e, 0x52, 0xc5,
+	0x2e, 0x5d, 0xd0, 0x41, 0xe6, 0x24, 0xdf, 0x08, 0x57, 0x85, 0x94, 0x39, 0xc9, 0xd3, 0xb8, 0x97,
+	0x6c, 0x64, 0xad, 0xb6, 0x8c, 0x88, 0x6e, 0x51, 0x0b, 0x90, 0x47, 0xb7, 0x08, 0x16, 0xb3, 0xa1,
+	0x4f, 0x80, 0xc4, 0x4b, 0x04, 0xd2, 0x67, 0x0e, 0x08, 0x83, 0x51, 0x7f, 0xa7, 0xc0, 0x38, 0x0a,
+	0x88, 0xa7, 0x69, 0x7c, 0x63, 0x61, 0xdd, 0x76, 0x2d, 0x5a, 0xb7, 0xfd, 0xd2, 0xe9, 0x1d, 0x1d,
+	0x9a, 0xb8, 0x74, 0x36, 0x87, 0x73, 0xa7, 0x04, 0xbc, 0xb6, 0xfe, 0xbb, 0x73, 0x30, 0x11, 0x09,
+	0xc2, 0x3f, 0x78, 0x2a, 0x5f, 0x94, 0xf9, 0x8c, 0xa2, 0xcc, 0x67, 0x14, 0x64, 0x3e, 0xa3, 0x20,
+	0xf3, 0x19, 0x05, 0xe6, 0x33, 0xee, 0x45, 0x7c, 0xc6, 0x9c, 0x4c, 0xad, 0x04, 0xe7, 0x11, 0x29,
+	0xa1, 0xd5, 0x50, 0x0e, 0xaf, 0xc8, 0xd3, 0xf3, 0x22, 0x4f, 0xcf, 0xef, 0xa2, 0xf4, 0xbc, 0xd4,
+	0xb8, 0x9a, 0x98, 0xf1, 0xc7, 0xdc, 0x55, 0x93, 0xdc, 0x45, 0x49, 0xfc, 0xb1, 0x88, 0x5b, 0xe4,
+	0x16, 0x4a, 0xf5, 0x47, 0xb8, 0xc6, 0x25, 0x72, 0x0f, 0x5d, 0x01, 0x8e, 0x29, 0x95, 0x36, 0xa9,
+	0xa1, 0x4a, 0x9c, 0xc2, 0x4b, 0x6d, 0x35, 0x54, 0x6a, 0x53, 0x78, 0x2d, 0xad, 0x86, 0x6a, 0x69,
+	0x0a, 0xaf, 0x94, 0xd5, 0x50, 0xa5, 0x4c, 0xe1, 0xb5, 0xb0, 0x1a, 0xaa, 0x85, 0x31, 0x70, 0x5d,
+	0x7f, 0x04, 0xe7, 0x13, 0x8b, 0x76, 0xe4, 0x46, 0x68, 0x91, 0x85, 0xc8, 0xe6, 0xe3, 0x66, 0xf9,
+	0x97, 0x33, 0x70, 0x3e, 0x39, 0x03, 0x38, 0xa1, 0xb2, 0xcf, 0xe1, 0x10, 0x91, 0x60, 0x66, 0xd9,
+	0xcd, 0x76, 0x1e, 0x9b, 0xad, 0x1f, 0xef, 0x37, 0x7b, 0xce, 0x9b, 0xdd, 0x48, 0x06, 0xdc, 0x26,
+	0xf3, 0xa2, 0x9d, 0xa4, 0x60, 0x66, 0x31, 0x9e, 0xb1, 0xf4, 0x8b, 0x2d, 0x36, 0x0a, 0x06, 0xae,
+	0xcb, 0x6a, 0x1f, 0xf2, 0xf2, 0x76, 0xde, 0xda, 0x47, 0xd4, 0x49, 0x27, 0xd7, 0x3e, 0xe4, 0xf3,
+	0x66, 0xae, 0x7d, 0x8c, 0x5a, 0xb6, 0x10, 0x68, 0x3e, 0x87, 0xcb, 0xf2, 0x8a, 0xf8, 0xf1, 0x5f,
+	0xb3, 0xeb, 0x5f, 0xc1, 0x8c, 0x9c, 0x17, 0x59, 0x8b, 0x2f, 0xfc, 0x6a, 0x72, 0x31, 0x3e, 0xe1,
+	0xe2, 0x30, 0x23, 0x4f, 0xc7, 0xc5, 0x9c, 0xb2, 0x10, 0xe6, 0x94, 0xf1, 0x94, 0x5d, 0x8c, 0x87,
+	0x82, 0x6f, 0x48, 0x88, 0x87, 0xd1, 0x32, 0x49, 0x5d, 0xdf, 0x83, 0x0f, 0x47, 0x54, 0x3f, 0x48,
+	0x1d, 0x2f, 0x25, 0xbd, 0x78, 0x4e, 0xb9, 0xc6, 0x1c, 0x83, 0xb8, 0xb4, 0x14, 0xc7, 0x10, 0x5f,
+	0xdf, 0xdf, 0x0b, 0x50, 0x46, 0xf0, 0xff, 0xa4, 0xc8, 0x27, 0xcd, 0x96, 0x8b, 0xcc, 0x78, 0x17,
+	0x71, 0xb5, 0xea, 0x03, 0x31, 0xd1, 0x74, 0x0e, 0xfb, 0x8e, 0x6d, 0xda, 0x6e, 0x5a, 0xc9, 0x4a,
+	0xe1, 0x96, 0x7d, 0x33, 0x52, 0xb2, 0x0a, 0xef, 0xec, 0x9d, 0x03, 0xa3, 0x6f, 0xa6, 0x16, 0xa9,
+	0x0a, 0xff, 0x86, 0x22, 0x55, 0x24, 0xf2, 0x14, 0x79, 0xe4, 0x59, 0x8a, 0x7c, 0xc9, 0x33, 0xea,
+	0xfb, 0x47, 0x1a, 0x99, 0x50, 0xa1, 0x27, 0x31, 0xe8, 0x1d, 0xbb, 0xd0, 0x23, 0x0b, 0xbb, 0xf1,
+	0x42, 0x4f, 0xe2, 0x7c, 0xa3, 0x0b, 0x3d, 0x19, 0x96, 0x2a, 0x18, 0xfe, 0x0e, 0x4c, 0x46, 0xac,
+	0xf9, 0x84, 0x7a, 0xad, 0x7f, 0x09, 0x97, 0x47, 0xe5, 0x25, 0xe2, 0x35, 0xbd, 0x20, 0xff, 0xd6,
+	0x2d, 0x6e, 0x80, 0x8f, 0xa1, 0x8c, 0x62, 0x20, 0x59, 0x11, 0xd7, 0xc9, 0x0a, 0xa8, 0x3e, 0xf5,
+	0xba, 0x63, 0xef, 0x0f, 0x4c, 0xcf, 0x41, 0x1b, 0xc3, 0xa3, 0x81, 0x79, 0x18, 0xd1, 0xef, 0xba,
+	0xfe, 0x23, 0x98, 0x8c, 0x04, 0xbf, 0x13, 0x30, 0xfb, 0xee, 0x2c, 0x90, 0xb8, 0x25, 0xc9, 0xa5,
+	0xf8, 0x91, 0x28, 0x45, 0x56, 0xb1, 0x7a, 0xd0, 0x73, 0xf6, 0xbf, 0x79, 0xbc, 0x11, 0x7b, 0xf3,
+	0x76, 0x6a, 0x1e, 0x43, 0x5a, 0xf6, 0x2e, 0x66, 0x0e, 0xf7, 0x4a, 0xba, 0x53, 0xc0, 0xe1, 0xbe,
+	0x38, 0x22, 0x07, 0xfe, 0x21, 0xad, 0x7f, 0x29, 0xf2, 0x0d, 0x70, 0x26, 0x33, 0x3f, 0x6e, 0x5e,
+	0xfa, 0x30, 0xf2, 0x0d, 0xee, 0x02, 0x9a, 0xa4, 0xe3, 0xf4, 0x8e, 0x5c, 0xd3, 0x53, 0x19, 0xd3,
+	0x76, 0x07, 0x86, 0xe4, 0x41, 0x04, 0xcd, 0x63, 0xf5, 0x37, 0x30, 0x2e, 0xfa, 0xcc, 0xbc, 0x36,
+	0xa7, 0xc8, 0x34, 0x43, 0x49, 0x2b, 0xa1, 0xf1, 0xca, 0x37, 0x9a, 0x5d, 0x56, 0xf9, 0x16, 0x11,
+	0x98, 0xa6, 0x7f, 0x03, 0x5a, 0xb2, 0x35, 0xc8, 0x0b, 0x10, 0x2b, 0x58, 0xe1, 0x43, 0xb3, 0xda,
+	0x32, 0x6d, 0x73, 0x60, 0xed, 0xef, 0x0c, 0xcc, 0x97, 0xd6, 0x5b, 0xb3, 0xfb, 0xcc, 0xb6, 0xdc,
+	0xe8, 0x43, 0xb3, 0xd9, 0x84, 0x20, 0x25, 0xc6, 0xed, 0xc0, 0xad, 0x26, 0xc4, 0xb2, 0xba, 0xfe,
+	0x25, 0x7c, 0x38, 0xc2, 0x8e, 0x49, 0x13, 0xf3, 0xbb, 0x44, 0xeb, 0xf1, 0x23, 0xcc, 0xfe, 0x1a,
+	0x4c, 0x60, 0xcb, 0x95, 0x37, 0x17, 0x0e, 0x41, 0x4b, 0xde, 0x2a, 0xb9, 0x21, 0x92, 0xb0, 0x9d,
+	0x30, 0xf4, 0xa8, 0x44, 0xea, 0x64, 0x1e, 0xcb, 0xd2, 0xcf, 0xc4, 0x3a, 0x8f, 0x29, 0x5b, 0x2c,
+	0x3b, 0xbe, 0xe7, 0x94, 0x99, 0x65, 0x7b, 0x1e, 0x71, 0x26, 0x75, 0xfd, 0x2e, 0x4c, 0x46, 0x26,
+	0xcc, 0xa0, 0xb8, 0xfc, 0x31, 0xd3, 0x01, 0x10, 0x36, 0x81, 0xb8, 0x8e, 0xd3, 0x54, 0x7c, 0xe6,
+	0x12, 0xf5, 0xaf, 0x40, 0x4b, 0x36, 0xc4, 0xbc, 0x85, 0x32, 0x1e, 0xde, 0x7e, 0x05, 0xad, 0xec,
+	0xe6, 0x9e, 0x9a, 0x1d, 0xb4, 0x70, 0x76, 0xe0, 0x1f, 0x41, 0x32, 0x47, 0x9e, 0x25, 0xfc, 0x5a,
+	0x81, 0xeb, 0xd9, 0x17, 0x40, 0x9e, 0xc5, 0xd3, 0x85, 0x95, 0xe3, 0xb9, 0x2c, 0x69, 0xda, 0x50,
+	0x83, 0x6a, 0x67, 0xdf, 0xb0, 0x1f, 0x18, 0x83, 0x7d, 0xa7, 0x2b, 0x3e, 0xa6, 0xad, 0x60, 0xb0,
+	0xf7, 0x5e, 0xed, 0x36, 0x7e, 0xaf, 0x96, 0xfa, 0x2e, 0x2b, 0xc7, 0x63, 0xda, 0xc6, 0x3f, 0xa7,
+	0xe0, 0xc2, 0xc3, 0xb7, 0xae, 0x69, 0x77, 0xcd, 0xee, 0xb6, 0xf5, 0xed, 0x91, 0xd5, 0x3d, 0x30,
+	0xec, 0x6e, 0xcf, 0xb2, 0x5f, 0xf9, 0x2f, 0xe7, 0x06, 0xe4, 0x0e, 0x40, 0xf8, 0x56, 0x9c, 0xf8,
+	0x59, 0x6b, 0xec, 0x7d, 0xba, 0x56, 0x8d, 0x82, 0xfb, 0xbd, 0x77, 0xfa, 0xff, 0x91, 0x16, 0x8c,
+	0xf1, 0x3e, 0x1e, 0x42, 0x51, 0x70, 0x5b, 0x93, 0x56, 0xc1, 0x40, 0x4a, 0x75, 0x03, 0xce, 0xf9,
+	0xbd, 0xb1, 0xc4, 0x77, 0xa5, 0x62, 0xdb, 0xac, 0x36, 0x21, 0x40, 0x82, 0x29, 0x78, 0x97, 0x20,
+	0x9d, 0x22, 0xd2, 0x34, 0xa9, 0x55, 0x30, 0x30, 0xa0, 0xe2, 0x8d, 0x80, 0x94, 0x2a, 0xd2, 0x29,
+	0xa8, 0x55, 0x30, 0x90, 0x52, 0x3d, 0x86, 0xc9, 0xc8, 0x6b, 0x67, 0xa2, 0x51, 0xd3, 0x97, 0x3d,
+	0x8e, 0xd6, 0x54, 0xe9, 0x18, 0x65, 0xb5, 0x0b, 0x24, 0xde, 0x17, 0x48, 0x2e, 0x72, 0x0a, 0x69,
+	0x87, 0xa1, 0xf6, 0x41, 0xd2, 0x30, 0xe5, 0x79, 0x1f, 0xc6, 0xc5, 0x77, 0xf6, 0x64, 0x96, 0xa1,
+	0x47, 0x5f, 0xe9, 0x6b, 0xb5, 0xf8, 0x00, 0xe5, 0xb0, 0xed, 0xb7, 0x13, 0xa2, 0xf7, 0xc9, 0x84,
+	0x4f, 0x2a, 0x7b, 0x1a, 0xad, 0x9d, 0x97, 0x0f, 0x52, 0x6e, 0xeb, 0x50, 0x46, 0x0d, 0x4e, 0x84,
+	0x0b, 0x24, 0xd6, 0x0b, 0xa5, 0xcd, 0x48, 0x46, 0x28, 0x93, 0x65, 0x28, 0x06, 0xdd, 0x8c, 0x64,
+	0x9a, 0xa1, 0xa1, 0x86, 0x47, 0x8d, 0x44, 0xa0, 0x94, 0x50, 0x87, 0x33, 0x5b, 0x0e, 0x29, 0xfb,
+	0x63, 0x81, 0x9e, 0x96, 0xf8, 0x47, 0x8a, 0x73, 0x07, 0x20, 0xec, 0x53, 0xa7, 0xba, 0x1d, 0x6b,
+	0x66, 0xd7, 0xaa, 0x51, 0x70, 0xb0, 0xb0, 0xe0, 0xc5, 0x39, 0x5d, 0x58, 0xf4, 0x51, 0xba, 0x46,
+	0x22, 0xd0, 0x40, 0xf7, 0x18, 0xcc, 0xa6, 0xba, 0x17, 0xe9, 0x15, 0xd2, 0x2a, 0x18, 0x48, 0xa9,
+	0xee, 0x41, 0x49, 0x68, 0x60, 0x20, 0x34, 0x6c, 0xc7, 0x9a, 0x1f, 0xb4, 0xe9, 0x18, 0x3c, 0x9c,
+	0x94, 0x35, 0x25, 0xb0, 0x49, 0x71, 0x2b, 0x83, 0x56, 0xc1, 0x40, 0x44, 0xe5, 0xbd, 0x34, 0x0e,
+	0xa9, 0x84, 0x37, 0xd5, 0x5a, 0x05, 0x03, 0x29, 0x55, 0x1d, 0xde, 0xe7, 0x0e, 0xd4, 0x97, 0x00,
+	0x7e, 0x6c, 0xac, 0x4d, 0x21, 0x18, 0x25, 0xb9, 0x06, 0x85, 0x27, 0xd6, 0x5b, 0xe2, 0x9b, 0x77,
+	0xd8, 0x72, 0xa6, 0x8d, 0x07, 0x9f, 0x29, 0xda, 0x3c, 0x9c, 0xf5, 0xfa, 0x93, 0x88, 0xdf, 0xb2,
+	0x25, 0x74, 0xc0, 0x6b, 0xe5, 0x10, 0x10, 0xae, 0x81, 0x76, 0x32, 0xb1, 0x35, 0xa0, 0xe6, 0x29,
+	0x6d, 0x0a, 0xc1, 0x02, 0xe6, 0xde, 0x6f, 0x0c, 0x50, 0xe6, 0xc2, 0x8f, 0x0f, 0x68, 0xe5, 0x10,
+	0x10, 0xf8, 0x81, 0x48, 0x37, 0x13, 0xf5, 0x03, 0xf2, 0xe6, 0x27, 0x4d, 0x95, 0x8e, 0x05, 0x36,
+	0x82, 0x9a, 0x94, 0x88, 0x80, 0x8c, 0x9b, 0x9c, 0xb4, 0x19, 0xc9, 0x48, 0x60, 0xf8, 0xe2, 0x0f,
+	0x14, 0x50, 0xc3, 0x97, 0xfc, 0xe6, 0x81, 0x56, 0x8b, 0x0f, 0x04, 0x1c, 0xc4, 0xd0, 0x43, 0x39,
+	0x48, 0x62, 0x94, 0x56, 0x8b, 0x0f, 0x04, 0x1b, 0x41, 0xaf, 0xad, 0xe9, 0x46, 0x64, 0x4f, 0xb8,
+	0xb5, 0x19, 0xc9, 0x48, 0x20, 0xd8, 0x48, 0xaf, 0x2f, 0x15, 0xac, 0xbc, 0xb3, 0x58, 0x53, 0xa5,
+	0x63, 0x81, 0x2b, 0xeb, 0x48, 0x5d, 0x59, 0x27, 0xcd, 0x95, 0x75, 0x12, 0x5c, 0xd9, 0x3c, 0x9c,
+	0xf5, 0xda, 0x85, 0xa9, 0x6e, 0x08, 0x7d, 0xc4, 0x5a, 0x39, 0x04, 0x04, 0x72, 0x40, 0x3d, 0x60,
+	0x54, 0x0e, 0xb2, 0x1e, 0x32, 0x6d, 0x46, 0x32, 0x12, 0xf8, 0xa5, 0xf0, 0xe7, 0x03, 0xa8, 0x5f,
+	0x8a, 0xfd, 0xf0, 0x80, 0x56, 0x8d, 0x82, 0x23, 0xb4, 0xbe, 0xd5, 0x0a, 0xb4, 0xa2, 0xdd, 0x56,
+	0xa3, 0x60, 0x4a, 0xbb, 0x09, 0x13, 0xb8, 0x5d, 0x88, 0xf8, 0x52, 0x91, 0xb6, 0x23, 0x69, 0xb3,
+	0xb2, 0xa1, 0x40, 0x5c, 0x5e, 0xf7, 0x33, 0x15, 0x97, 0xd0, 0x2b, 0xad, 0x95, 0x43, 0x80, 0x8f,
+	0xf9, 0xe2, 0x3d, 0xff, 0x37, 0x4f, 0x9a, 0xff, 0x1a, 0x00, 0xae, 0xf3, 0x21, 0x12, 0x02, 0x45,
+	0x00, 0x00,
 }
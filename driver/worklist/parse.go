@@ -0,0 +1,194 @@
+// driver/worklist/parse.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package worklist
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+// columnsOf works out which field is in which column of a worklist. With a
+// header row, columns are matched to opt.Columns by their header, so they
+// can come in any order and unknown columns are ignored
+func columnsOf(header []string, opt Opt) map[Field]int {
+	cols := make(map[Field]int)
+
+	if header == nil {
+		for i, c := range opt.Columns {
+			cols[c.Field] = i
+		}
+		return cols
+	}
+
+	for i, h := range header {
+		h = strings.TrimSpace(h)
+		for _, c := range opt.Columns {
+			if strings.EqualFold(h, c.Header) {
+				cols[c.Field] = i
+			}
+		}
+	}
+
+	return cols
+}
+
+// Parse reads a worklist written with the same options back into robot
+// instructions: a single channel move, aspirate, move and dispense for each
+// row, or a message for each row with no volume but a message. Plates are
+// placed by deck position where the worklist gives it and by label where it
+// doesn't, and liquid classes are mapped back to policy names through
+// opt.LiquidClasses
+func Parse(r io.Reader, opt Opt) ([]liquidhandling.RobotInstruction, error) {
+	opt = opt.withDefaults()
+
+	cr := csv.NewReader(r)
+	cr.Comma = opt.Separator
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var header []string
+	if !opt.NoHeader {
+		h, err := cr.Read()
+		if err == io.EOF {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		header = h
+	}
+
+	cols := columnsOf(header, opt)
+
+	pick := func(fields ...Field) (Field, bool) {
+		for _, f := range fields {
+			if _, ok := cols[f]; ok {
+				return f, true
+			}
+		}
+		return "", false
+	}
+
+	srcPos, okS := pick(SourcePosition, SourceLabel)
+	dstPos, okD := pick(DestPosition, DestLabel)
+	_, okSW := cols[SourceWell]
+	_, okDW := cols[DestWell]
+	_, okV := cols[Volume]
+
+	if !(okS && okD && okSW && okDW && okV) {
+		return nil, fmt.Errorf("worklist must give source and destination plates and wells, and volumes")
+	}
+
+	// messages are in the first column unless there is one for them
+
+	msgCol := 0
+	if i, ok := cols[Message]; ok {
+		msgCol = i
+	}
+
+	policies := make(map[string]string, len(opt.LiquidClasses))
+	for p, lc := range opt.LiquidClasses {
+		policies[lc] = p
+	}
+
+	var ris []liquidhandling.RobotInstruction
+
+	for line := 1; ; line++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		get := func(f Field) string {
+			if i, ok := cols[f]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		if get(Volume) == "" {
+			if i := msgCol; i < len(row) && strings.TrimSpace(row[i]) != "" {
+				msg := liquidhandling.NewMessageInstruction(nil)
+				msg.Message = strings.TrimSpace(row[i])
+				ris = append(ris, msg)
+				continue
+			}
+		}
+
+		v, err := strconv.ParseFloat(get(Volume), 64)
+		if err != nil {
+			return nil, fmt.Errorf("worklist row %d: bad volume %q", line, get(Volume))
+		}
+		vol := wunit.NewVolume(v, opt.VolumeUnit)
+
+		what := get(Policy)
+		if what == "" {
+			what = get(LiquidClass)
+			if p, ok := policies[what]; ok {
+				what = p
+			}
+		}
+
+		mov := func(pos, plt, well string) *liquidhandling.MoveInstruction {
+			ins := liquidhandling.NewMoveInstruction()
+			ins.Pos = append(ins.Pos, pos)
+			ins.Plt = append(ins.Plt, plt)
+			ins.Well = append(ins.Well, well)
+			ins.Reference = append(ins.Reference, 0)
+			ins.OffsetX = append(ins.OffsetX, 0.0)
+			ins.OffsetY = append(ins.OffsetY, 0.0)
+			ins.OffsetZ = append(ins.OffsetZ, 0.0)
+			return ins
+		}
+
+		asp := liquidhandling.NewAspirateInstruction()
+		asp.Volume = append(asp.Volume, vol)
+		asp.Plt = append(asp.Plt, get(SourcePlateType))
+		asp.What = append(asp.What, what)
+		asp.LLF = append(asp.LLF, false)
+		asp.Multi = 1
+
+		dsp := liquidhandling.NewDispenseInstruction()
+		dsp.Volume = append(dsp.Volume, wunit.CopyVolume(vol))
+		dsp.Plt = append(dsp.Plt, get(DestPlateType))
+		dsp.What = append(dsp.What, what)
+		dsp.LLF = append(dsp.LLF, false)
+		dsp.Multi = 1
+
+		ris = append(ris,
+			mov(get(srcPos), get(SourcePlateType), get(SourceWell)),
+			asp,
+			mov(get(dstPos), get(DestPlateType), get(DestWell)),
+			dsp,
+		)
+	}
+
+	return ris, nil
+}
@@ -0,0 +1,88 @@
+package worklist
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+func TestParse(t *testing.T) {
+	opt := Opt{
+		PlateLabels:   map[string]string{"Input_plate_1": "Source1"},
+		LiquidClasses: map[string]string{"water": "Water Free Single"},
+	}
+
+	in := `Source Plate,Source Well,Destination Plate,Destination Well,Volume,Liquid Class
+Source1,A1,Output_plate_1,A1,10,Water Free Single
+Run paused: please empty the tip waste at position position_1,,,,,
+Source1,B1,Output_plate_1,B1,2.5,dna
+`
+
+	ris, err := Parse(strings.NewReader(in), opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := liquidhandling.Transfers(ris)
+
+	if len(ts) != 2 {
+		t.Fatalf("expected 2 transfers, got %v", ts)
+	}
+
+	if tr := ts[0]; tr.FromPos != "Source1" || tr.FromWell != "A1" || tr.ToPos != "Output_plate_1" || tr.ToWell != "A1" || tr.What != "water" || tr.Volume.ConvertToString("ul") != 10.0 {
+		t.Errorf("expected 10 ul of water from Source1 A1 to Output_plate_1 A1, got %s", tr)
+	}
+
+	if tr := ts[1]; tr.What != "dna" || tr.Volume.ConvertToString("ul") != 2.5 {
+		t.Errorf("expected 2.5 ul of dna, got %s", tr)
+	}
+
+	if msg, ok := ris[4].(*liquidhandling.MessageInstruction); !ok || msg.Message != "Run paused: please empty the tip waste at position position_1" {
+		t.Errorf("expected message after the first transfer, got %v", ris[4])
+	}
+
+	// written out again, the worklist is the same
+	d := NewDriver(nil, opt)
+	for _, ins := range ris {
+		if err := ins.(liquidhandling.TerminalRobotInstruction).OutputTo(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if out, _ := d.GetOutputFile(); out != in {
+		t.Errorf("expected worklist\n%s\ngot\n%s", in, out)
+	}
+}
+
+func TestParseColumns(t *testing.T) {
+	opt := Opt{
+		Columns: []Column{
+			{Header: "SrcPos", Field: SourcePosition},
+			{Header: "SrcWell", Field: SourceWell},
+			{Header: "DstPos", Field: DestPosition},
+			{Header: "DstWell", Field: DestWell},
+			{Header: "nl", Field: Volume},
+		},
+		VolumeUnit: "nl",
+		Separator:  ';',
+	}
+
+	// columns may come in any order and unknown ones are ignored
+	in := "DstWell;nl;Comment;SrcPos;SrcWell;DstPos\nH12;1500;hello;position_4;B2;position_7\n"
+
+	ris, err := Parse(strings.NewReader(in), opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := liquidhandling.Transfers(ris)
+
+	if len(ts) != 1 || ts[0].FromPos != "position_4" || ts[0].FromWell != "B2" || ts[0].ToPos != "position_7" || ts[0].ToWell != "H12" || ts[0].Volume.ConvertToString("ul") != 1.5 {
+		t.Errorf("expected 1.5 ul from position_4 B2 to position_7 H12, got %v", ts)
+	}
+
+	if _, err := Parse(strings.NewReader("SrcPos;SrcWell\nposition_4;A1\n"), opt); err == nil {
+		t.Error("expected error parsing worklist without destinations")
+	}
+}
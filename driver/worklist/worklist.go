@@ -0,0 +1,363 @@
+// driver/worklist/worklist.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+// Package worklist provides an in-process liquid handling driver which,
+// rather than controlling a robot, writes the transfers it is asked to make
+// into a worklist: a CSV file of sources, destinations, volumes and liquid
+// classes of the kind accepted by many liquid handlers' own software
+package worklist
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/driver/offline"
+	"github.com/antha-lang/antha/microArch/driver"
+	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+var (
+	_ liquidhandling.ExtendedLiquidhandlingDriver = &Driver{}
+)
+
+// A Field is something which can be written in a worklist column
+type Field string
+
+// Fields which can be written in a worklist
+const (
+	SourceLabel     Field = "SourceLabel"     // label of the source plate
+	SourcePosition  Field = "SourcePosition"  // deck position of the source plate
+	SourcePlateType Field = "SourcePlateType" // type of the source plate
+	SourceWell      Field = "SourceWell"      // source well, e.g. A1
+	SourceWellIndex Field = "SourceWellIndex" // source well numbered down columns from 1
+	DestLabel       Field = "DestLabel"
+	DestPosition    Field = "DestPosition"
+	DestPlateType   Field = "DestPlateType"
+	DestWell        Field = "DestWell"
+	DestWellIndex   Field = "DestWellIndex"
+	Volume          Field = "Volume"       // volume in Opt.VolumeUnit
+	LiquidClass     Field = "LiquidClass"  // liquid class mapped from the policy name
+	Policy          Field = "Policy"       // liquid handling policy name
+	PreMixCycles    Field = "PreMixCycles" // times the source is mixed before aspirating
+	PreMixVolume    Field = "PreMixVolume" // volume the source is mixed with, in Opt.VolumeUnit
+	MixCycles       Field = "MixCycles"    // times the destination is mixed after dispensing
+	MixVolume       Field = "MixVolume"    // volume the destination is mixed with, in Opt.VolumeUnit
+	Message         Field = "Message"      // message to the operator, on a row of its own
+)
+
+// A Column is a column of the worklist: the header written at its top and
+// what goes in it
+type Column struct {
+	Header string
+	Field  Field
+}
+
+// DefaultColumns are the worklist columns used unless others are given
+var DefaultColumns = []Column{
+	{Header: "Source Plate", Field: SourceLabel},
+	{Header: "Source Well", Field: SourceWell},
+	{Header: "Destination Plate", Field: DestLabel},
+	{Header: "Destination Well", Field: DestWell},
+	{Header: "Volume", Field: Volume},
+	{Header: "Liquid Class", Field: LiquidClass},
+}
+
+// Opt are options for a worklist Driver
+type Opt struct {
+	Columns       []Column          // Columns to write; defaults to DefaultColumns
+	PlateLabels   map[string]string // Labels to use for plates by plate name; defaults to the name
+	LiquidClasses map[string]string // Liquid classes by policy name; unmapped names are written as they are
+	VolumeUnit    string            // Unit volumes are written in; defaults to ul
+	Separator     rune              // Field separator; defaults to comma
+	NoHeader      bool              // Don't write a header row
+}
+
+type plate struct {
+	label string
+	ptype string
+	rows  int
+}
+
+// a mix of a well, in ul
+type mix struct {
+	cycles int
+	volume float64
+}
+
+// A Driver turns the instructions sent to it into a worklist, returned by
+// GetOutputFile. The worklist is started again whenever the plates are
+// removed from the deck, as they are at the start of every instruction
+// stream
+type Driver struct {
+	offline.Base
+	opt    Opt
+	plates map[string]plate
+	rows   []map[Field]string
+	// the last row written by each channel and any mix of its source
+	// waiting for the next
+	last   map[int]int
+	premix map[int]mix
+}
+
+func (opt Opt) withDefaults() Opt {
+	if len(opt.Columns) == 0 {
+		opt.Columns = DefaultColumns
+	}
+	if opt.VolumeUnit == "" {
+		opt.VolumeUnit = "ul"
+	}
+	if opt.Separator == 0 {
+		opt.Separator = ','
+	}
+	return opt
+}
+
+// NewDriver creates a worklist driver standing in for a robot with the
+// given properties, which are what instructions are planned for
+func NewDriver(props *liquidhandling.LHProperties, opt Opt) *Driver {
+	d := &Driver{
+		Base: offline.Base{Props: props, Output: "worklists"},
+		opt:  opt.withDefaults(),
+	}
+	d.reset()
+
+	return d
+}
+
+func (d *Driver) reset() {
+	d.plates = make(map[string]plate)
+	d.ResetChannels()
+	d.rows = nil
+	d.last = make(map[int]int)
+	d.premix = make(map[int]mix)
+}
+
+func (d *Driver) label(pos string) string {
+	if p, ok := d.plates[pos]; ok {
+		return p.label
+	}
+	return pos
+}
+
+func (d *Driver) wellIndex(pos, well string) string {
+	rows := 8
+	if p, ok := d.plates[pos]; ok && p.rows > 0 {
+		rows = p.rows
+	}
+
+	wc := wtype.MakeWellCoords(well)
+	if wc.X < 0 || wc.Y < 0 {
+		return ""
+	}
+
+	return strconv.Itoa(wc.X*rows + wc.Y + 1)
+}
+
+func (d *Driver) liquidClass(policy string) string {
+	if lc, ok := d.opt.LiquidClasses[policy]; ok {
+		return lc
+	}
+	return policy
+}
+
+// formatVolume writes v to no more than six decimal places, which is
+// plenty for any robot and hides rounding from unit conversion
+func formatVolume(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 6, 64)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+func (d *Driver) volume(v float64) string {
+	return formatVolume(wunit.NewVolume(v, "ul").ConvertToString(d.opt.VolumeUnit))
+}
+
+func (d *Driver) addRow(i int, ch *offline.Channel, volume float64) {
+	row := map[Field]string{
+		SourceLabel:     d.label(ch.Source),
+		SourcePosition:  ch.Source,
+		SourcePlateType: d.plates[ch.Source].ptype,
+		SourceWell:      ch.SourceWell,
+		SourceWellIndex: d.wellIndex(ch.Source, ch.SourceWell),
+		DestLabel:       d.label(ch.Position),
+		DestPosition:    ch.Position,
+		DestPlateType:   d.plates[ch.Position].ptype,
+		DestWell:        ch.Well,
+		DestWellIndex:   d.wellIndex(ch.Position, ch.Well),
+		Volume:          d.volume(volume),
+		LiquidClass:     d.liquidClass(ch.Policy),
+		Policy:          ch.Policy,
+	}
+
+	if m, ok := d.premix[i]; ok {
+		row[PreMixCycles] = strconv.Itoa(m.cycles)
+		row[PreMixVolume] = d.volume(m.volume)
+		delete(d.premix, i)
+	}
+
+	d.last[i] = len(d.rows)
+	d.rows = append(d.rows, row)
+}
+
+// Dispense implements a LiquidhandlingDriver: every dispense is a row of the
+// worklist
+func (d *Driver) Dispense(volume []float64, blowout []bool, head int, multi int, platetype []string, what []string, llf []bool) driver.CommandStatus {
+	for i, v := range volume {
+		if v <= 0.0 {
+			continue
+		}
+		ch, st := d.Holding(i)
+		if !st.OK {
+			return st
+		}
+		if i < len(what) && what[i] != "" {
+			ch.Policy = what[i]
+		}
+		d.addRow(i, ch, v)
+	}
+	return offline.OK()
+}
+
+// Mix implements a LiquidhandlingDriver. Mixing a well a channel has just
+// dispensed into is written in the mix columns of that row; mixing
+// anywhere else is taken to be of a source and written in the pre-mix
+// columns of the channel's next row
+func (d *Driver) Mix(head int, volume []float64, platetype []string, cycles []int, multi int, what []string, blowout []bool) driver.CommandStatus {
+	for i, v := range volume {
+		if v <= 0.0 || i >= len(cycles) || i >= len(d.Channels) {
+			continue
+		}
+		ch := d.Channel(i)
+
+		if r, ok := d.last[i]; ok && d.rows[r][DestPosition] == ch.Position && d.rows[r][DestWell] == ch.Well {
+			d.rows[r][MixCycles] = strconv.Itoa(cycles[i])
+			d.rows[r][MixVolume] = d.volume(v)
+			continue
+		}
+
+		d.premix[i] = mix{cycles: cycles[i], volume: v}
+	}
+	return offline.OK()
+}
+
+// AddPlateTo implements a LiquidhandlingDriver
+func (d *Driver) AddPlateTo(position string, p interface{}, name string) driver.CommandStatus {
+	label := name
+	if l, ok := d.opt.PlateLabels[name]; ok {
+		label = l
+	}
+
+	pl := plate{label: label}
+
+	if lp, ok := p.(*wtype.LHPlate); ok {
+		pl.ptype = lp.Type
+		pl.rows = lp.WellsY()
+	}
+
+	d.plates[position] = pl
+
+	return offline.OK()
+}
+
+// RemoveAllPlates implements a LiquidhandlingDriver; this starts a new
+// worklist
+func (d *Driver) RemoveAllPlates() driver.CommandStatus {
+	d.reset()
+	return offline.OK()
+}
+
+// RemovePlateAt implements a LiquidhandlingDriver
+func (d *Driver) RemovePlateAt(position string) driver.CommandStatus {
+	delete(d.plates, position)
+	return offline.OK()
+}
+
+// Message implements a LiquidhandlingDriver. Messages, such as asking for
+// tips to be replaced, are written on a row of their own where the run
+// should pause: in the Message column if there is one and otherwise in the
+// first column
+func (d *Driver) Message(level int, title, text string, showcancel bool) driver.CommandStatus {
+	msg := text
+	if title != "" {
+		msg = title + ": " + text
+	}
+	d.rows = append(d.rows, map[Field]string{Message: msg})
+	return offline.OK()
+}
+
+// messageColumn is the column messages are written in, see Message
+func (opt Opt) messageColumn() int {
+	for i, c := range opt.Columns {
+		if c.Field == Message {
+			return i
+		}
+	}
+	return 0
+}
+
+// GetOutputFile implements an ExtendedLiquidhandlingDriver and returns the
+// worklist as CSV
+func (d *Driver) GetOutputFile() (string, driver.CommandStatus) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = d.opt.Separator
+
+	if !d.opt.NoHeader {
+		header := make([]string, len(d.opt.Columns))
+		for i, c := range d.opt.Columns {
+			header[i] = c.Header
+		}
+		if err := w.Write(header); err != nil {
+			return "", offline.Fail(err.Error())
+		}
+	}
+
+	for _, values := range d.rows {
+		row := make([]string, len(d.opt.Columns))
+		for i, c := range d.opt.Columns {
+			row[i] = values[c.Field]
+		}
+		if msg, ok := values[Message]; ok {
+			row[d.opt.messageColumn()] = msg
+		}
+		if err := w.Write(row); err != nil {
+			return "", offline.Fail(err.Error())
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", offline.Fail(err.Error())
+	}
+
+	return buf.String(), offline.OK()
+}
+
+// OutputFileName is the name given to the worklist in the files produced
+// for a mix
+func (d *Driver) OutputFileName() string {
+	return "worklist.csv"
+}
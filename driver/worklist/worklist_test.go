@@ -0,0 +1,196 @@
+package worklist
+
+import (
+	"context"
+	"testing"
+
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/testinventory"
+	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+func setUpDeck(t *testing.T, d *Driver) {
+	ctx := testinventory.NewContext(context.Background())
+
+	for pos, name := range map[string]string{"position_4": "Input_plate_1", "position_7": "Output_plate_1"} {
+		p, err := inventory.NewPlate(ctx, "pcrplate_skirted")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if st := d.AddPlateTo(pos, p, name); !st.OK {
+			t.Fatal(st.Msg)
+		}
+	}
+}
+
+func TestWorklist(t *testing.T) {
+	d := NewDriver(nil, Opt{
+		PlateLabels:   map[string]string{"Input_plate_1": "Source1"},
+		LiquidClasses: map[string]string{"water": "Water Free Single"},
+	})
+
+	d.RemoveAllPlates()
+	setUpDeck(t, d)
+
+	// two channels aspirate from A1 and B1 then each dispenses twice
+	d.Move([]string{"position_4", "position_4"}, []string{"A1", "B1"}, nil, nil, nil, nil, nil, 0)
+	d.Aspirate([]float64{20.0, 20.0}, nil, 0, 2, nil, []string{"water", "water"}, nil)
+	d.Move([]string{"position_7", "position_7"}, []string{"A1", "B1"}, nil, nil, nil, nil, nil, 0)
+	d.Dispense([]float64{10.0, 10.0}, nil, 0, 2, nil, []string{"water", "water"}, nil)
+	d.Move([]string{"position_7", ""}, []string{"A2", ""}, nil, nil, nil, nil, nil, 0)
+	d.Dispense([]float64{10.0, 0.0}, nil, 0, 2, nil, []string{"water", ""}, nil)
+
+	out, st := d.GetOutputFile()
+	if !st.OK {
+		t.Fatal(st.Msg)
+	}
+
+	expected := `Source Plate,Source Well,Destination Plate,Destination Well,Volume,Liquid Class
+Source1,A1,Output_plate_1,A1,10,Water Free Single
+Source1,B1,Output_plate_1,B1,10,Water Free Single
+Source1,A1,Output_plate_1,A2,10,Water Free Single
+`
+	if out != expected {
+		t.Errorf("expected worklist\n%s\ngot\n%s", expected, out)
+	}
+
+	// new instructions start a new worklist
+	d.RemoveAllPlates()
+
+	if out, _ := d.GetOutputFile(); out != "Source Plate,Source Well,Destination Plate,Destination Well,Volume,Liquid Class\n" {
+		t.Errorf("expected empty worklist, got\n%s", out)
+	}
+}
+
+func TestWorklistColumns(t *testing.T) {
+	d := NewDriver(nil, Opt{
+		Columns: []Column{
+			{Header: "SrcPos", Field: SourcePosition},
+			{Header: "SrcIdx", Field: SourceWellIndex},
+			{Header: "DstIdx", Field: DestWellIndex},
+			{Header: "nl", Field: Volume},
+			{Header: "Policy", Field: Policy},
+		},
+		VolumeUnit: "nl",
+		Separator:  ';',
+		NoHeader:   true,
+	})
+
+	setUpDeck(t, d)
+
+	d.Move([]string{"position_4"}, []string{"B2"}, nil, nil, nil, nil, nil, 0)
+	d.Aspirate([]float64{1.5}, nil, 0, 1, nil, []string{"dna"}, nil)
+	d.Move([]string{"position_7"}, []string{"H12"}, nil, nil, nil, nil, nil, 0)
+	d.Dispense([]float64{1.5}, nil, 0, 1, nil, []string{"dna"}, nil)
+
+	out, st := d.GetOutputFile()
+	if !st.OK {
+		t.Fatal(st.Msg)
+	}
+
+	if expected := "position_4;10;96;1500;dna\n"; out != expected {
+		t.Errorf("expected %q, got %q", expected, out)
+	}
+}
+
+func TestWorklistDispenseWithoutAspirate(t *testing.T) {
+	d := NewDriver(nil, Opt{})
+	setUpDeck(t, d)
+
+	d.Move([]string{"position_7"}, []string{"A1"}, nil, nil, nil, nil, nil, 0)
+
+	if st := d.Dispense([]float64{10.0}, nil, 0, 1, nil, []string{"water"}, nil); st.OK {
+		t.Errorf("expected dispense with nothing aspirated to fail")
+	}
+}
+
+func TestWorklistMix(t *testing.T) {
+	d := NewDriver(nil, Opt{
+		Columns: []Column{
+			{Header: "Source", Field: SourceWell},
+			{Header: "Dest", Field: DestWell},
+			{Header: "Volume", Field: Volume},
+			{Header: "PreMix", Field: PreMixCycles},
+			{Header: "PreMixVolume", Field: PreMixVolume},
+			{Header: "Mix", Field: MixCycles},
+			{Header: "MixVolume", Field: MixVolume},
+		},
+	})
+	setUpDeck(t, d)
+
+	// mix the source, transfer and mix the destination
+	d.Move([]string{"position_4"}, []string{"A1"}, nil, nil, nil, nil, nil, 0)
+	d.Mix(0, []float64{50.0}, nil, []int{3}, 1, []string{"water"}, nil)
+	d.Aspirate([]float64{20.0}, nil, 0, 1, nil, []string{"water"}, nil)
+	d.Move([]string{"position_7"}, []string{"C3"}, nil, nil, nil, nil, nil, 0)
+	d.Dispense([]float64{20.0}, nil, 0, 1, nil, []string{"water"}, nil)
+	d.Mix(0, []float64{15.0}, nil, []int{5}, 1, []string{"water"}, nil)
+
+	out, st := d.GetOutputFile()
+	if !st.OK {
+		t.Fatal(st.Msg)
+	}
+
+	expected := `Source,Dest,Volume,PreMix,PreMixVolume,Mix,MixVolume
+A1,C3,20,3,50,5,15
+`
+	if out != expected {
+		t.Errorf("expected worklist\n%s\ngot\n%s", expected, out)
+	}
+}
+
+func TestWorklistMessage(t *testing.T) {
+	transfer := func(d *Driver, from, to string) {
+		d.Move([]string{"position_4"}, []string{from}, nil, nil, nil, nil, nil, 0)
+		d.Aspirate([]float64{10.0}, nil, 0, 1, nil, []string{"water"}, nil)
+		d.Move([]string{"position_7"}, []string{to}, nil, nil, nil, nil, nil, 0)
+		d.Dispense([]float64{10.0}, nil, 0, 1, nil, []string{"water"}, nil)
+	}
+
+	d := NewDriver(nil, Opt{})
+	setUpDeck(t, d)
+
+	// the operator is told to change tips between the transfers
+
+	transfer(d, "A1", "A1")
+	if err := liquidhandling.NewReplaceTipboxMessage("position_2", "Gilson20").OutputTo(d); err != nil {
+		t.Fatal(err)
+	}
+	transfer(d, "B1", "B1")
+
+	out, st := d.GetOutputFile()
+	if !st.OK {
+		t.Fatal(st.Msg)
+	}
+
+	expected := `Source Plate,Source Well,Destination Plate,Destination Well,Volume,Liquid Class
+Input_plate_1,A1,Output_plate_1,A1,10,water
+Run paused: please replace the tip box at position position_2 with a full box of Gilson20,,,,,
+Input_plate_1,B1,Output_plate_1,B1,10,water
+`
+	if out != expected {
+		t.Errorf("expected worklist\n%s\ngot\n%s", expected, out)
+	}
+
+	// or in a column of their own
+
+	d = NewDriver(nil, Opt{
+		Columns: []Column{
+			{Header: "Source", Field: SourceWell},
+			{Header: "Dest", Field: DestWell},
+			{Header: "Volume", Field: Volume},
+			{Header: "Note", Field: Message},
+		},
+	})
+	setUpDeck(t, d)
+
+	transfer(d, "A1", "A1")
+	if st := d.Message(0, "Pause", "empty the tip waste", false); !st.OK {
+		t.Fatal(st.Msg)
+	}
+
+	out, _ = d.GetOutputFile()
+	if expected := "Source,Dest,Volume,Note\nA1,A1,10,\n,,,Pause: empty the tip waste\n"; out != expected {
+		t.Errorf("expected %q, got %q", expected, out)
+	}
+}
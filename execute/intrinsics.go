@@ -23,7 +23,10 @@ type commandInst struct {
 	// Arguments to this command. Used to determine command dependencies.
 	Args []*wtype.LHComponent
 	// Component created by this command. Returned back to user code
-	result  *wtype.LHComponent
+	result *wtype.LHComponent
+	// Further components created by commands with more than one product,
+	// e.g., Aliquot
+	extra   []*wtype.LHComponent
 	Command *ast.Command
 }
 
@@ -319,6 +322,67 @@ func MixTo(ctx context.Context, outplatetype, address string, platenum int, comp
 	}))
 }
 
+// Aliquot distributes n samples of volume vol of a component into new wells
+// of plate, returning the resulting components in order
+func Aliquot(ctx context.Context, in *wtype.LHComponent, vol wunit.Volume, n int, plate *wtype.LHPlate) []*wtype.LHComponent {
+	if n <= 0 {
+		Errorf(ctx, "cannot aliquot %s into %d wells", in.CName, n)
+	}
+
+	inst := aliquot(ctx, in, vol, n, plate)
+	trace.Issue(ctx, inst)
+
+	return append([]*wtype.LHComponent{inst.result}, inst.extra...)
+}
+
+func aliquot(ctx context.Context, in *wtype.LHComponent, vol wunit.Volume, n int, plate *wtype.LHPlate) *commandInst {
+	inst := wtype.NewLHSplitInstruction()
+	inst.BlockID = wtype.NewBlockID(getID(ctx))
+	inst.SetGeneration(in.Generation())
+
+	if plate != nil {
+		inst.ContainerType = plate.Type
+		inst.Platetype = plate.Type
+		inst.SetPlateID(plate.ID)
+		inst.OutPlate = plate
+	}
+
+	var reqs []ast.Request
+	var results []*wtype.LHComponent
+
+	for i := 0; i < n; i++ {
+		smp := mixer.Sample(in, vol)
+		smp.Order = i
+
+		res := wtype.NewLHComponent()
+		res.Mix(smp)
+		res.BlockID = inst.BlockID
+		res.SetGeneration(in.Generation() + 1)
+		res.DeclareInstance()
+
+		inst.AddSplitProduct(smp, res)
+		results = append(results, res)
+
+		reqs = append(reqs, ast.Request{
+			Selector: []ast.NameValue{
+				target.DriverSelectorV1Mixer,
+			},
+		})
+
+		getMaker(ctx).UpdateAfterInst(smp.ID, res.ID)
+	}
+
+	return &commandInst{
+		Args: inst.Components,
+		Command: &ast.Command{
+			Requests: reqs,
+			Inst:     inst,
+		},
+		result: results[0],
+		extra:  results[1:],
+	}
+}
+
 // AwaitData breaks execution pending return of requested data
 func AwaitData(
 	ctx context.Context,
@@ -0,0 +1,54 @@
+package execute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/target"
+	"github.com/antha-lang/antha/target/human"
+)
+
+func TestAliquot(t *testing.T) {
+	tgt := target.New()
+	tgt.AddDevice(human.New(human.Opt{CanMix: true}))
+
+	ctx := context.Background()
+	ctx = target.WithTarget(ctx, tgt)
+	ctx = withID(ctx, "")
+
+	cmp := wtype.NewLHComponent()
+	cmp.CName = "water"
+	cmp.Vol = 100
+	cmp.Vunit = "ul"
+
+	inst := aliquot(ctx, cmp, wunit.NewVolume(10, "ul"), 3, nil)
+
+	results := append([]*wtype.LHComponent{inst.result}, inst.extra...)
+	if len(results) != 3 {
+		t.Fatalf("expecting 3 aliquots but found %d", len(results))
+	}
+	seen := make(map[string]bool)
+	for _, r := range results {
+		if seen[r.ID] {
+			t.Errorf("aliquot %s returned twice", r.ID)
+		}
+		seen[r.ID] = true
+		if r.Vol != 10 || r.Vunit != "ul" {
+			t.Errorf("expecting 10ul aliquot but found %g%s", r.Vol, r.Vunit)
+		}
+	}
+
+	split, ok := inst.Command.Inst.(*wtype.LHInstruction)
+	if !ok || split.Type != wtype.LHISPL {
+		t.Fatalf("expecting split instruction but found %v", inst.Command.Inst)
+	}
+
+	r := &resolver{}
+	if _, err := r.resolve(ctx, []interface{}{inst}); err != nil {
+		t.Fatal(err)
+	} else if len(r.insts) == 0 {
+		t.Fatal("no instructions")
+	}
+}
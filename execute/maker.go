@@ -40,14 +40,18 @@ func (a *maker) makeComp(c *wtype.LHComponent) *ast.UseComp {
 	return u
 }
 
-func (a *maker) makeCommand(in *commandInst) ast.Node {
+func (a *maker) makeCommand(in *commandInst) []ast.Node {
 	for _, arg := range in.Args {
 		in.Command.From = append(in.Command.From, a.makeComp(arg))
 	}
 
-	out := a.makeComp(in.result)
-	out.From = append(out.From, in.Command)
-	return out
+	var outs []ast.Node
+	for _, res := range append([]*wtype.LHComponent{in.result}, in.extra...) {
+		out := a.makeComp(res)
+		out.From = append(out.From, in.Command)
+		outs = append(outs, out)
+	}
+	return outs
 }
 
 // resolveReuses tracks samples of the same component sharing the same id.
@@ -118,7 +122,7 @@ func (a *maker) UpdateAfterInst(oldID, newID string) {
 func (a *maker) MakeNodes(insts []*commandInst) ([]ast.Node, error) {
 	var nodes []ast.Node
 	for _, inst := range insts {
-		nodes = append(nodes, a.makeCommand(inst))
+		nodes = append(nodes, a.makeCommand(inst)...)
 	}
 
 	for comp := range a.byComp {
@@ -0,0 +1,371 @@
+// inventory/fileinventory/definitions.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package fileinventory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/meta"
+	"github.com/ghodss/yaml"
+)
+
+// A File holds definitions of any of the kinds of item in an inventory.
+// Lengths are in mm
+type File struct {
+	Plates     []Plate     `json:"plates,omitempty"`
+	Tipboxes   []Tipbox    `json:"tipboxes,omitempty"`
+	Tipwastes  []Tipwaste  `json:"tipwastes,omitempty"`
+	Components []Component `json:"components,omitempty"`
+}
+
+// Save writes the definitions to filename, in JSON if it ends in .json and
+// in YAML otherwise
+func (f *File) Save(filename string) error {
+	var bs []byte
+	var err error
+	if strings.ToLower(filepath.Ext(filename)) == ".json" {
+		bs, err = json.MarshalIndent(f, "", "  ")
+	} else {
+		bs, err = yaml.Marshal(f)
+	}
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filename, bs, 0644)
+}
+
+// unmarshalStrict unmarshals js into v, complaining about any fields v
+// doesn't have rather than leaving misspelt ones out
+func unmarshalStrict(js []byte, v interface{}) error {
+	return meta.UnmarshalJSONStrict(js, v)
+}
+
+// A Well is the shape and capacity of the wells of a plate, or of the
+// holders of a tipbox or tipwaste
+type Well struct {
+	Shape          string                 `json:"shape"` // box, cylinder and the like
+	X              float64                `json:"xdim"`
+	Y              float64                `json:"ydim"`
+	Z              float64                `json:"zdim"`
+	Bottom         string                 `json:"bottom"` // flat, u, v or conical
+	BottomHeight   float64                `json:"bottomHeight"`
+	VolumeUnit     string                 `json:"volumeUnit"` // defaults to ul
+	MaxVolume      float64                `json:"maxVolume"`
+	ResidualVolume float64                `json:"residualVolume"`
+	Extra          map[string]interface{} `json:"extra,omitempty"`
+}
+
+var bottomTypes = map[string]int{
+	"":        wtype.LHWBFLAT,
+	"flat":    wtype.LHWBFLAT,
+	"u":       wtype.LHWBU,
+	"v":       wtype.LHWBV,
+	"conical": wtype.LHWBCONICAL,
+}
+
+func positive(what string, v float64) error {
+	if v <= 0.0 {
+		return fmt.Errorf("%s must be positive, not %g", what, v)
+	}
+	return nil
+}
+
+func notNegative(what string, v float64) error {
+	if v < 0.0 {
+		return fmt.Errorf("%s must not be negative, not %g", what, v)
+	}
+	return nil
+}
+
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Make checks the well and makes it
+func (w Well) Make(platetype, crds string) (*wtype.LHWell, error) {
+	bottom, ok := bottomTypes[strings.ToLower(w.Bottom)]
+	if !ok {
+		return nil, fmt.Errorf("unknown well bottom %q", w.Bottom)
+	}
+
+	vunit := w.VolumeUnit
+	if vunit == "" {
+		vunit = "ul"
+	}
+	if err := wunit.ValidMeasurementUnit("Volume", vunit); err != nil {
+		return nil, err
+	}
+
+	if err := firstError(
+		positive("well xdim", w.X),
+		positive("well ydim", w.Y),
+		positive("well zdim", w.Z),
+		notNegative("well bottomHeight", w.BottomHeight),
+		positive("well maxVolume", w.MaxVolume),
+		notNegative("well residualVolume", w.ResidualVolume),
+	); err != nil {
+		return nil, err
+	}
+
+	if w.ResidualVolume > w.MaxVolume {
+		return nil, fmt.Errorf("well residualVolume %g is more than its maxVolume %g", w.ResidualVolume, w.MaxVolume)
+	}
+
+	shp := wtype.NewShape(w.Shape, "mm", w.X, w.Y, w.Z)
+	if _, err := shp.MaxCrossSectionalArea(); err != nil {
+		return nil, fmt.Errorf("unknown well shape %q", w.Shape)
+	}
+
+	well := wtype.NewLHWell(platetype, "", crds, vunit, w.MaxVolume, w.ResidualVolume, shp, bottom, w.X, w.Y, w.Z, w.BottomHeight, "mm")
+	for k, v := range w.Extra {
+		well.Extra[k] = v
+	}
+
+	return well, nil
+}
+
+// A Plate defines a type of plate
+type Plate struct {
+	Type         string  `json:"type"`
+	Manufacturer string  `json:"manufacturer"`
+	Rows         int     `json:"rows"`
+	Columns      int     `json:"columns"`
+	Height       float64 `json:"height"`
+	WellXOffset  float64 `json:"wellXOffset"` // distance between well centres
+	WellYOffset  float64 `json:"wellYOffset"`
+	WellXStart   float64 `json:"wellXStart"` // offset of the first well
+	WellYStart   float64 `json:"wellYStart"`
+	WellZStart   float64 `json:"wellZStart"`
+	Skirt        string  `json:"skirt,omitempty"` // none, semi or full
+	Well         Well    `json:"well"`
+}
+
+// Make checks the plate definition and makes the plate
+func (p Plate) Make() (*wtype.LHPlate, error) {
+	if p.Type == "" {
+		return nil, errors.New("plate has no type")
+	}
+	if p.Rows <= 0 || p.Columns <= 0 {
+		return nil, fmt.Errorf("plate must have some rows and columns, not %d by %d", p.Rows, p.Columns)
+	}
+	if err := firstError(
+		positive("height", p.Height),
+		notNegative("wellXOffset", p.WellXOffset),
+		notNegative("wellYOffset", p.WellYOffset),
+	); err != nil {
+		return nil, err
+	}
+	switch p.Skirt {
+	case "", wtype.SkirtNone, wtype.SkirtSemi, wtype.SkirtFull:
+	default:
+		return nil, fmt.Errorf("unknown skirt %q", p.Skirt)
+	}
+
+	w, err := p.Well.Make(p.Type, "")
+	if err != nil {
+		return nil, err
+	}
+
+	mfr := p.Manufacturer
+	if mfr == "" {
+		mfr = "Unknown"
+	}
+
+	plate := wtype.NewLHPlate(p.Type, mfr, p.Rows, p.Columns, p.Height, "mm", w, p.WellXOffset, p.WellYOffset, p.WellXStart, p.WellYStart, p.WellZStart)
+	plate.Skirt = p.Skirt
+
+	return plate, nil
+}
+
+// A Tip defines the tips in a tipbox
+type Tip struct {
+	Type         string  `json:"type"`
+	Manufacturer string  `json:"manufacturer"`
+	MinVolume    float64 `json:"minVolume"`
+	MaxVolume    float64 `json:"maxVolume"`
+	VolumeUnit   string  `json:"volumeUnit"` // defaults to ul
+}
+
+// Make checks the tip definition and makes the tip
+func (t Tip) Make() (*wtype.LHTip, error) {
+	if t.Type == "" {
+		return nil, errors.New("tip has no type")
+	}
+
+	vunit := t.VolumeUnit
+	if vunit == "" {
+		vunit = "ul"
+	}
+	if err := wunit.ValidMeasurementUnit("Volume", vunit); err != nil {
+		return nil, err
+	}
+
+	if err := firstError(
+		positive("tip minVolume", t.MinVolume),
+		positive("tip maxVolume", t.MaxVolume),
+	); err != nil {
+		return nil, err
+	}
+	if t.MinVolume > t.MaxVolume {
+		return nil, fmt.Errorf("tip minVolume %g is more than its maxVolume %g", t.MinVolume, t.MaxVolume)
+	}
+
+	return wtype.NewLHTip(t.Manufacturer, t.Type, t.MinVolume, t.MaxVolume, vunit), nil
+}
+
+// A Tipbox defines a type of tipbox
+type Tipbox struct {
+	Type         string  `json:"type"`
+	Manufacturer string  `json:"manufacturer"`
+	Rows         int     `json:"rows"`
+	Columns      int     `json:"columns"`
+	Height       float64 `json:"height"`
+	Tip          Tip     `json:"tip"`
+	Well         Well    `json:"well"` // the holder of each tip
+	TipXOffset   float64 `json:"tipXOffset"`
+	TipYOffset   float64 `json:"tipYOffset"`
+	TipXStart    float64 `json:"tipXStart"`
+	TipYStart    float64 `json:"tipYStart"`
+	TipZStart    float64 `json:"tipZStart"`
+}
+
+// Make checks the tipbox definition and makes the tipbox
+func (tb Tipbox) Make() (*wtype.LHTipbox, error) {
+	if tb.Type == "" {
+		return nil, errors.New("tipbox has no type")
+	}
+	if tb.Rows <= 0 || tb.Columns <= 0 {
+		return nil, fmt.Errorf("tipbox must have some rows and columns, not %d by %d", tb.Rows, tb.Columns)
+	}
+	if err := positive("height", tb.Height); err != nil {
+		return nil, err
+	}
+
+	tip, err := tb.Tip.Make()
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := tb.Well.Make(tb.Type, "A1")
+	if err != nil {
+		return nil, err
+	}
+
+	return wtype.NewLHTipbox(tb.Rows, tb.Columns, tb.Height, tb.Manufacturer, tb.Type, tip, w, tb.TipXOffset, tb.TipYOffset, tb.TipXStart, tb.TipYStart, tb.TipZStart), nil
+}
+
+// A Tipwaste defines a type of tipwaste
+type Tipwaste struct {
+	Type         string  `json:"type"`
+	Manufacturer string  `json:"manufacturer"`
+	Capacity     int     `json:"capacity"` // number of tips it holds
+	Height       float64 `json:"height"`
+	Well         Well    `json:"well"`
+	WellXStart   float64 `json:"wellXStart"`
+	WellYStart   float64 `json:"wellYStart"`
+	WellZStart   float64 `json:"wellZStart"`
+}
+
+// Make checks the tipwaste definition and makes the tipwaste
+func (tw Tipwaste) Make() (*wtype.LHTipwaste, error) {
+	if tw.Type == "" {
+		return nil, errors.New("tipwaste has no type")
+	}
+	if tw.Capacity <= 0 {
+		return nil, fmt.Errorf("capacity must be positive, not %d", tw.Capacity)
+	}
+	if err := positive("height", tw.Height); err != nil {
+		return nil, err
+	}
+
+	w, err := tw.Well.Make(tw.Type, "A1")
+	if err != nil {
+		return nil, err
+	}
+
+	return wtype.NewLHTipwaste(tw.Capacity, tw.Type, tw.Manufacturer, tw.Height, w, tw.WellXStart, tw.WellYStart, tw.WellZStart), nil
+}
+
+// A Component defines a component
+type Component struct {
+	Name              string  `json:"name"`
+	Type              string  `json:"type"` // liquid type; defaults to water
+	Smax              float64 `json:"smax"` // maximum solubility; defaults to 9999
+	Concentration     float64 `json:"concentration,omitempty"`
+	ConcentrationUnit string  `json:"concentrationUnit,omitempty"`
+}
+
+// Make checks the component definition and makes the component
+func (c Component) Make() (*wtype.LHComponent, error) {
+	if c.Name == "" {
+		return nil, errors.New("component has no name")
+	}
+
+	typ := wtype.LTWater
+	if c.Type != "" {
+		var err error
+		if typ, err = wtype.LiquidTypeFromString(wtype.PolicyName(c.Type)); err != nil {
+			return nil, err
+		}
+	}
+
+	smax := c.Smax
+	if smax == 0.0 {
+		smax = 9999
+	}
+	if err := positive("smax", smax); err != nil {
+		return nil, err
+	}
+
+	lhc := wtype.NewLHComponent()
+	lhc.CName = c.Name
+	lhc.Type = typ
+	lhc.Smax = smax
+
+	if c.ConcentrationUnit != "" {
+		if err := wunit.ValidConcentrationUnit(c.ConcentrationUnit); err != nil {
+			return nil, err
+		}
+		if err := positive("concentration", c.Concentration); err != nil {
+			return nil, err
+		}
+		lhc.SetConcentration(wunit.NewConcentration(c.Concentration, c.ConcentrationUnit))
+	} else if c.Concentration != 0.0 {
+		return nil, errors.New("concentration has no unit")
+	}
+
+	return lhc, nil
+}
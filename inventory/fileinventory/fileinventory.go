@@ -0,0 +1,318 @@
+// inventory/fileinventory/fileinventory.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+// Package fileinventory provides an inventory of plates, tipboxes, tipwastes
+// and components defined in JSON or YAML files rather than in code.
+//
+// An inventory is made from any number of directories, each searched
+// recursively for .json, .yaml and .yml files, laid over a base inventory
+// such as the built-in one. Definitions in later directories replace those
+// of the same type in earlier ones and in the base, so that a site or a
+// user can add to or adjust what comes with antha without recompiling.
+package fileinventory
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/inventory"
+	"github.com/ghodss/yaml"
+)
+
+var (
+	_ inventory.Inventory = &Inventory{}
+)
+
+// An Error lists the problems found with the definitions in an inventory's
+// directories
+type Error []string
+
+func (e Error) Error() string {
+	return strings.Join(e, "\n")
+}
+
+// An Inventory returns items defined in files, or failing that from its base
+type Inventory struct {
+	base            inventory.Inventory
+	componentByName map[string]*wtype.LHComponent
+	plateByType     map[string]*wtype.LHPlate
+	tipboxByType    map[string]*wtype.LHTipbox
+	tipwasteByType  map[string]*wtype.LHTipwaste
+}
+
+// layer is what one directory defines, and where
+type layer struct {
+	defined  map[string]string
+	problems Error
+	inv      *Inventory
+}
+
+// define records that the item of kind and name is defined in file, which is
+// a problem if something else in the same directory already defines it
+func (l *layer) define(kind, name, file string) bool {
+	key := kind + " " + name
+	if prev, seen := l.defined[key]; seen {
+		l.problems = append(l.problems, fmt.Sprintf("%s: %s %q already defined in %s", file, kind, name, prev))
+		return false
+	}
+	l.defined[key] = file
+	return true
+}
+
+func (l *layer) problem(file, kind, name string, err error) {
+	l.problems = append(l.problems, fmt.Sprintf("%s: %s %q: %s", file, kind, name, err))
+}
+
+func (l *layer) load(file string) {
+	contents, err := ioutil.ReadFile(file)
+	if err != nil {
+		l.problems = append(l.problems, err.Error())
+		return
+	}
+
+	var f File
+
+	// YAMLToJSON leaves JSON as it is
+	js, err := yaml.YAMLToJSON(contents)
+	if err == nil {
+		err = unmarshalStrict(js, &f)
+	}
+	if err != nil {
+		l.problems = append(l.problems, fmt.Sprintf("%s: %s", file, err))
+		return
+	}
+
+	for _, d := range f.Components {
+		if !l.define("component", d.Name, file) {
+			continue
+		}
+		if c, err := d.Make(); err != nil {
+			l.problem(file, "component", d.Name, err)
+		} else {
+			l.inv.componentByName[c.CName] = c
+		}
+	}
+
+	for _, d := range f.Plates {
+		if !l.define("plate", d.Type, file) {
+			continue
+		}
+		if p, err := d.Make(); err != nil {
+			l.problem(file, "plate", d.Type, err)
+		} else {
+			l.inv.plateByType[p.Type] = p
+		}
+	}
+
+	for _, d := range f.Tipboxes {
+		if !l.define("tipbox", d.Type, file) {
+			continue
+		}
+		if tb, err := d.Make(); err != nil {
+			l.problem(file, "tipbox", d.Type, err)
+		} else {
+			l.inv.tipboxByType[tb.Type] = tb
+			l.inv.tipboxByType[tb.Tiptype.Type] = tb
+		}
+	}
+
+	for _, d := range f.Tipwastes {
+		if !l.define("tipwaste", d.Type, file) {
+			continue
+		}
+		if tw, err := d.Make(); err != nil {
+			l.problem(file, "tipwaste", d.Type, err)
+		} else {
+			l.inv.tipwasteByType[tw.Type] = tw
+		}
+	}
+}
+
+func isDefinitionFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// New makes an inventory from the definitions in dirs, in order, on top of
+// base, which may be nil. Every definition is checked and any problems are
+// returned together as an Error
+func New(base inventory.Inventory, dirs ...string) (*Inventory, error) {
+	inv := &Inventory{
+		base:            base,
+		componentByName: make(map[string]*wtype.LHComponent),
+		plateByType:     make(map[string]*wtype.LHPlate),
+		tipboxByType:    make(map[string]*wtype.LHTipbox),
+		tipwasteByType:  make(map[string]*wtype.LHTipwaste),
+	}
+
+	var problems Error
+
+	for _, dir := range dirs {
+		l := &layer{
+			defined: make(map[string]string),
+			inv:     inv,
+		}
+
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && isDefinitionFile(path) {
+				l.load(path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		problems = append(problems, l.problems...)
+	}
+
+	if len(problems) != 0 {
+		return nil, problems
+	}
+
+	return inv, nil
+}
+
+// NewContext returns a context with an inventory made from the definitions
+// in dirs on top of base
+func NewContext(ctx context.Context, base inventory.Inventory, dirs ...string) (context.Context, error) {
+	inv, err := New(base, dirs...)
+	if err != nil {
+		return nil, err
+	}
+	return inventory.NewContext(ctx, inv), nil
+}
+
+// NewComponent implements an inventory.Inventory
+func (i *Inventory) NewComponent(ctx context.Context, name string) (*wtype.LHComponent, error) {
+	if c, ok := i.componentByName[name]; ok {
+		return c.Dup(), nil
+	} else if i.base != nil {
+		return i.base.NewComponent(ctx, name)
+	}
+	return nil, inventory.ErrUnknownType
+}
+
+// NewPlate implements an inventory.Inventory
+func (i *Inventory) NewPlate(ctx context.Context, typ string) (*wtype.LHPlate, error) {
+	if p, ok := i.plateByType[typ]; ok {
+		return p.Dup(), nil
+	} else if i.base != nil {
+		return i.base.NewPlate(ctx, typ)
+	}
+	return nil, inventory.ErrUnknownType
+}
+
+// NewTipbox implements an inventory.Inventory
+func (i *Inventory) NewTipbox(ctx context.Context, typ string) (*wtype.LHTipbox, error) {
+	if tb, ok := i.tipboxByType[typ]; ok {
+		return tb.Dup(), nil
+	} else if i.base != nil {
+		return i.base.NewTipbox(ctx, typ)
+	}
+	return nil, inventory.ErrUnknownType
+}
+
+// NewTipwaste implements an inventory.Inventory
+func (i *Inventory) NewTipwaste(ctx context.Context, typ string) (*wtype.LHTipwaste, error) {
+	if tw, ok := i.tipwasteByType[typ]; ok {
+		return tw.Dup(), nil
+	} else if i.base != nil {
+		return i.base.NewTipwaste(ctx, typ)
+	}
+	return nil, inventory.ErrUnknownType
+}
+
+// Plates implements an inventory.Inventory. Plates of the base whose types
+// are defined here are replaced by those defined here.
+func (i *Inventory) Plates(ctx context.Context, q inventory.PlateQuery) ([]*wtype.LHPlate, error) {
+	var ps []*wtype.LHPlate
+
+	if i.base != nil {
+		// the base may need to find itself in the context
+		bps, err := i.base.Plates(inventory.NewContext(ctx, i.base), q)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range bps {
+			if _, replaced := i.plateByType[p.Type]; !replaced {
+				ps = append(ps, p)
+			}
+		}
+	}
+
+	for _, p := range i.plateByType {
+		if q.Matches(p) {
+			ps = append(ps, p.Dup())
+		}
+	}
+
+	sort.Slice(ps, func(a, b int) bool {
+		return ps[a].Type < ps[b].Type
+	})
+
+	return ps, nil
+}
+
+// Tipboxes implements an inventory.Inventory. Tipboxes of the base whose
+// types are defined here are replaced by those defined here.
+func (i *Inventory) Tipboxes(ctx context.Context, q inventory.TipboxQuery) ([]*wtype.LHTipbox, error) {
+	var tbs []*wtype.LHTipbox
+
+	if i.base != nil {
+		btbs, err := i.base.Tipboxes(inventory.NewContext(ctx, i.base), q)
+		if err != nil {
+			return nil, err
+		}
+		for _, tb := range btbs {
+			if _, replaced := i.tipboxByType[tb.Type]; !replaced {
+				tbs = append(tbs, tb)
+			}
+		}
+	}
+
+	for typ, tb := range i.tipboxByType {
+		// tipboxes are also found by the type of their tips
+		if typ != tb.Type || !q.Matches(tb) {
+			continue
+		}
+		tbs = append(tbs, tb.Dup())
+	}
+
+	sort.Slice(tbs, func(a, b int) bool {
+		return tbs[a].Type < tbs[b].Type
+	})
+
+	return tbs, nil
+}
@@ -0,0 +1,242 @@
+package fileinventory
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/testinventory"
+)
+
+const sitePlates = `
+plates:
+  - type: mywellplate
+    manufacturer: ACME
+    rows: 8
+    columns: 12
+    height: 15
+    wellXOffset: 9
+    wellYOffset: 9
+    well:
+      shape: cylinder
+      xdim: 8.2
+      ydim: 8.2
+      zdim: 11
+      bottom: u
+      maxVolume: 340
+      residualVolume: 25
+  - type: pcrplate_skirted
+    rows: 8
+    columns: 12
+    height: 99
+    wellXOffset: 9
+    wellYOffset: 9
+    skirt: full
+    well: {shape: cylinder, xdim: 5.5, ydim: 5.5, zdim: 20, maxVolume: 200, residualVolume: 5}
+`
+
+const siteOther = `{
+  "tipboxes": [{
+    "type": "MyTipbox",
+    "manufacturer": "ACME",
+    "rows": 8,
+    "columns": 12,
+    "height": 60,
+    "tip": {"type": "MyTip", "manufacturer": "ACME", "minVolume": 1, "maxVolume": 200},
+    "well": {"shape": "cylinder", "xdim": 7.3, "ydim": 7.3, "zdim": 51.2, "maxVolume": 200, "residualVolume": 1},
+    "tipXOffset": 9,
+    "tipYOffset": 9
+  }],
+  "tipwastes": [{
+    "type": "MyTipwaste",
+    "capacity": 500,
+    "height": 90,
+    "well": {"shape": "box", "xdim": 90, "ydim": 170, "zdim": 90, "maxVolume": 800000, "residualVolume": 0}
+  }],
+  "components": [{"name": "mybuffer", "type": "glycerol", "concentration": 10, "concentrationUnit": "X"}]
+}`
+
+const userPlates = `
+plates:
+  - type: mywellplate
+    rows: 4
+    columns: 6
+    height: 20
+    wellXOffset: 18
+    wellYOffset: 18
+    well: {shape: cylinder, xdim: 16, ydim: 16, zdim: 18, maxVolume: 3000, residualVolume: 100}
+`
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "fileinventory")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, contents := range files {
+		fn := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(fn), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(fn, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestLayers(t *testing.T) {
+	site := writeFiles(t, map[string]string{"plates/plates.yaml": sitePlates, "other.json": siteOther, "README": "not a definition"})
+	defer os.RemoveAll(site) // nolint: errcheck
+	user := writeFiles(t, map[string]string{"plates.yml": userPlates})
+	defer os.RemoveAll(user) // nolint: errcheck
+
+	ctx := testinventory.NewContext(context.Background())
+	ctx, err := NewContext(ctx, inventory.GetInventory(ctx), site, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the user's definition replaces the site's
+	p, err := inventory.NewPlate(ctx, "mywellplate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.WellsX() != 6 || p.WellsY() != 4 {
+		t.Errorf("expected user's 4x6 plate, got %dx%d", p.WellsY(), p.WellsX())
+	}
+
+	// the site's definition replaces the built-in one
+	if p, err := inventory.NewPlate(ctx, "pcrplate_skirted"); err != nil {
+		t.Fatal(err)
+	} else if p.Height != 99.0 {
+		t.Errorf("expected site's pcrplate_skirted, got one %g mm high", p.Height)
+	}
+
+	// built-in definitions are still there
+	if _, err := inventory.NewPlate(ctx, "DSW96"); err != nil {
+		t.Error(err)
+	}
+	if _, err := inventory.NewComponent(ctx, "water"); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := inventory.NewPlate(ctx, "noplate"); err != inventory.ErrUnknownType {
+		t.Errorf("expected %v, got %v", inventory.ErrUnknownType, err)
+	}
+
+	for _, typ := range []string{"MyTipbox", "MyTip"} {
+		if tb, err := inventory.NewTipbox(ctx, typ); err != nil {
+			t.Error(err)
+		} else if tb.Tiptype.MaxVol.ConvertToString("ul") != 200.0 {
+			t.Errorf("expected 200ul tips, got %s", tb.Tiptype.MaxVol)
+		}
+	}
+
+	if _, err := inventory.NewTipwaste(ctx, "MyTipwaste"); err != nil {
+		t.Error(err)
+	}
+
+	if c, err := inventory.NewComponent(ctx, "mybuffer"); err != nil {
+		t.Error(err)
+	} else if c.TypeName() != "glycerol" || c.Concentration().ToString() != "10 X" {
+		t.Errorf("expected 10 X glycerol, got %s %s", c.Concentration().ToString(), c.TypeName())
+	}
+
+	plates, err := inventory.Plates(ctx, inventory.PlateQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := make(map[string]int)
+	for _, p := range plates {
+		found[p.Type]++
+	}
+
+	if found["mywellplate"] != 1 || found["pcrplate_skirted"] != 1 || found["DSW96"] != 1 {
+		t.Errorf("expected every plate once, got %v", found)
+	}
+
+	// only the replacement is that tall
+	plates, err = inventory.Plates(ctx, inventory.PlateQuery{Skirt: wtype.SkirtFull, MinHeight: 90})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(plates) != 1 || plates[0].Type != "pcrplate_skirted" {
+		t.Errorf("expected site's pcrplate_skirted, got %v", plateTypes(plates))
+	}
+
+	// the user's mywellplate has replaced ACME's
+	plates, err = inventory.Plates(ctx, inventory.PlateQuery{Manufacturer: "acme"})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(plates) != 0 {
+		t.Errorf("expected no ACME plates, got %v", plateTypes(plates))
+	}
+
+	tbs, err := inventory.Tipboxes(ctx, inventory.TipboxQuery{Manufacturer: "ACME"})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(tbs) != 1 || tbs[0].Type != "MyTipbox" {
+		t.Errorf("expected only MyTipbox, got %d tipboxes", len(tbs))
+	}
+}
+
+func plateTypes(plates []*wtype.LHPlate) (types []string) {
+	for _, p := range plates {
+		types = append(types, p.Type)
+	}
+	return
+}
+
+func TestProblems(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.yaml": `
+plates:
+  - type: badplate
+    rows: 8
+    columns: 12
+    height: 15
+    well: {shape: cylinder, xdim: 8, ydim: 8, zdim: 11, maxVolume: 100, residualVolume: 200}
+  - type: dupplate
+    rows: 1
+    columns: 1
+    height: 15
+    well: {shape: box, xdim: 8, ydim: 8, zdim: 11, maxVolume: 100}
+components:
+  - name: badcomponent
+    type: nosuchtype
+`,
+		"b.yaml": `
+plates:
+  - type: dupplate
+    rows: 1
+    columns: 1
+    height: 15
+    well: {shape: box, xdim: 8, ydim: 8, zdim: 11, maxVolume: 100}
+`,
+		"c.json": `{"plates": [{"type": "typo", "colums": 12}]}`,
+	})
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	_, err := New(nil, dir)
+	if err == nil {
+		t.Fatal("expected problems")
+	}
+
+	for _, s := range []string{
+		`plate "badplate": well residualVolume 200 is more than its maxVolume 100`,
+		`component "badcomponent"`,
+		`plate "dupplate" already defined in`,
+		`unknown field "colums"`,
+	} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("expected problem %q, got\n%s", s, err)
+		}
+	}
+}
@@ -1,3 +1,5 @@
+//go:generate protoc -I${GOPATH}/src ${GOPATH}/src/github.com/antha-lang/antha/inventory/antha_inventory_v1/inventory.proto --go_out=plugins=grpc:${GOPATH}/src
+
 package inventory
 
 import (
@@ -10,8 +12,6 @@ import (
 var (
 	// ErrUnknownType is returned if type is not in inventory
 	ErrUnknownType = errors.New("unknown type")
-
-	errCannotListPlates = errors.New("cannot list plates")
 )
 
 const (
@@ -35,10 +35,12 @@ type Inventory interface {
 	NewPlate(ctx context.Context, typ string) (*wtype.LHPlate, error)
 	NewTipwaste(ctx context.Context, typ string) (*wtype.LHTipwaste, error)
 	NewTipbox(ctx context.Context, typ string) (*wtype.LHTipbox, error)
-}
-
-type hasXXXGetPlates interface {
-	XXXGetPlates(ctx context.Context) ([]*wtype.LHPlate, error)
+	// Plates returns a new plate of each type matching the query, in order
+	// of type
+	Plates(ctx context.Context, q PlateQuery) ([]*wtype.LHPlate, error)
+	// Tipboxes returns a new tipbox of each type matching the query, in
+	// order of type
+	Tipboxes(ctx context.Context, q TipboxQuery) ([]*wtype.LHTipbox, error)
 }
 
 // NewContext returns a context with the given inventory
@@ -71,14 +73,12 @@ func NewTipbox(ctx context.Context, typ string) (*wtype.LHTipbox, error) {
 	return fromContext(ctx).NewTipbox(ctx, typ)
 }
 
-// XXXNewPlates is a transitional call that will be removed once the planner
-// requires input plate types to be explicitly set.
-func XXXNewPlates(ctx context.Context) ([]*wtype.LHPlate, error) {
-	inv := GetInventory(ctx)
-	xInv, ok := inv.(hasXXXGetPlates)
-	if !ok {
-		return nil, errCannotListPlates
-	}
+// Plates returns a new plate of each type matching the query
+func Plates(ctx context.Context, q PlateQuery) ([]*wtype.LHPlate, error) {
+	return fromContext(ctx).Plates(ctx, q)
+}
 
-	return xInv.XXXGetPlates(ctx)
+// Tipboxes returns a new tipbox of each type matching the query
+func Tipboxes(ctx context.Context, q TipboxQuery) ([]*wtype.LHTipbox, error) {
+	return fromContext(ctx).Tipboxes(ctx, q)
 }
@@ -0,0 +1,321 @@
+// inventory/labware/labware.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+// Package labware imports plate types from sheets of their SBS dimensions
+// and well geometry, as vendors publish them, into definitions for the
+// file-backed inventory.
+package labware
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/inventory/fileinventory"
+)
+
+const (
+	// SBSLength and SBSWidth are the footprint (mm) of an SBS plate
+	SBSLength = 127.76
+	SBSWidth  = 85.48
+
+	// sbsA1ColumnOffset and sbsA1RowOffset are where A1 is on an SBS 96 well
+	// plate. The well starts of a plate type are relative to these.
+	sbsA1ColumnOffset = 14.38
+	sbsA1RowOffset    = 11.24
+
+	// tolerance (mm) of the geometry checks, for the rounding of vendor
+	// drawings
+	tolerance = 0.1
+	// volumeTolerance is the fraction by which wells may hold more than
+	// their shape suggests
+	volumeTolerance = 0.05
+)
+
+// A Plate describes a type of plate as vendor drawings do. Lengths are in mm.
+type Plate struct {
+	Type         string `json:"type"`
+	Manufacturer string `json:"manufacturer"`
+	Rows         int    `json:"rows"`
+	Columns      int    `json:"columns"`
+
+	// Footprint, which defaults to SBSLength by SBSWidth
+	Length float64 `json:"length"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	Skirt  string  `json:"skirt"` // none, semi or full
+
+	// Distances from the left and top edges to the centre of A1
+	A1ColumnOffset float64 `json:"a1ColumnOffset"`
+	A1RowOffset    float64 `json:"a1RowOffset"`
+	// Distances between the centres of neighbouring columns and rows
+	ColumnSpacing float64 `json:"columnSpacing"`
+	RowSpacing    float64 `json:"rowSpacing"`
+	// Distance from the base of the plate to the bottom of its wells
+	WellBottomElevation float64 `json:"wellBottomElevation"`
+
+	WellShape       string  `json:"wellShape"`    // cylinder or box
+	WellDiameter    float64 `json:"wellDiameter"` // of cylinders
+	WellLength      float64 `json:"wellLength"`   // of boxes, along the rows
+	WellWidth       float64 `json:"wellWidth"`    // of boxes, down the columns
+	WellDepth       float64 `json:"wellDepth"`
+	WellBottom      string  `json:"wellBottom"`      // flat, u, v or conical
+	WellBottomDepth float64 `json:"wellBottomDepth"` // of the rounded or pointed part
+	MaxVolume       float64 `json:"maxVolume"`
+	ResidualVolume  float64 `json:"residualVolume"`
+	VolumeUnit      string  `json:"volumeUnit"` // defaults to ul
+
+	Risers []Riser `json:"risers,omitempty"`
+}
+
+// A Riser is something a plate can be put on, which makes another type of
+// plate named by the type of the plate and the riser. Plates can sit down
+// into risers, by Offset, which differs from plate to plate. Plates on risers
+// which only fit some positions of a robot are constrained to those.
+type Riser struct {
+	Name      string   `json:"name"`
+	Height    float64  `json:"height"`
+	Offset    float64  `json:"offset,omitempty"`
+	Robot     string   `json:"robot,omitempty"`
+	Positions []string `json:"positions,omitempty"`
+}
+
+func (p Plate) footprint() (float64, float64) {
+	l, w := p.Length, p.Width
+	if l == 0 {
+		l = SBSLength
+	}
+	if w == 0 {
+		w = SBSWidth
+	}
+	return l, w
+}
+
+// wellSize returns the extent of a well along the rows and down the columns
+func (p Plate) wellSize() (float64, float64) {
+	if strings.ToLower(p.WellShape) == "cylinder" {
+		return p.WellDiameter, p.WellDiameter
+	}
+	return p.WellLength, p.WellWidth
+}
+
+// capacity returns the most a well could hold, in ul, ignoring its bottom
+func (p Plate) capacity() float64 {
+	l, w := p.wellSize()
+	if strings.ToLower(p.WellShape) == "cylinder" {
+		return math.Pi * l * w / 4 * p.WellDepth
+	}
+	return l * w * p.WellDepth
+}
+
+func (p Plate) volumeUnit() string {
+	if p.VolumeUnit == "" {
+		return "ul"
+	}
+	return p.VolumeUnit
+}
+
+// fits returns an error unless the wells, n of them size wide with centres
+// spacing apart starting at offset, fit in extent
+func fits(what string, n int, offset, spacing, size, extent float64) error {
+	if n > 1 && spacing+tolerance < size {
+		return fmt.Errorf("%s %g mm wide overlap at %g mm spacing", what, size, spacing)
+	}
+	if offset-size/2 < -tolerance {
+		return fmt.Errorf("first of the %s overhangs the plate by %g mm", what, size/2-offset)
+	}
+	if end := offset + float64(n-1)*spacing + size/2; end > extent+tolerance {
+		return fmt.Errorf("last of the %s overhangs the plate by %g mm", what, end-extent)
+	}
+	return nil
+}
+
+// Check returns the first problem with the geometry of the plate: wells
+// which overlap, stick out of the footprint, are deeper than the plate or
+// hold more than they could
+func (p Plate) Check() error {
+	if p.Type == "" {
+		return fmt.Errorf("plate has no type")
+	}
+	if p.Rows <= 0 || p.Columns <= 0 {
+		return fmt.Errorf("plate must have some rows and columns, not %d by %d", p.Rows, p.Columns)
+	}
+
+	switch strings.ToLower(p.WellShape) {
+	case "cylinder", "box":
+	default:
+		return fmt.Errorf("wellShape must be cylinder or box, not %q", p.WellShape)
+	}
+
+	switch p.Skirt {
+	case "", wtype.SkirtNone, wtype.SkirtSemi, wtype.SkirtFull:
+	default:
+		return fmt.Errorf("skirt must be %s, %s or %s, not %q", wtype.SkirtNone, wtype.SkirtSemi, wtype.SkirtFull, p.Skirt)
+	}
+
+	l, w := p.footprint()
+	wl, ww := p.wellSize()
+	for _, v := range []struct {
+		what  string
+		value float64
+	}{
+		{"height", p.Height},
+		{"well length or diameter", wl},
+		{"well width or diameter", ww},
+		{"wellDepth", p.WellDepth},
+		{"maxVolume", p.MaxVolume},
+	} {
+		if v.value <= 0 {
+			return fmt.Errorf("%s must be positive, not %g", v.what, v.value)
+		}
+	}
+
+	if p.Columns > 1 && p.ColumnSpacing <= 0 {
+		return fmt.Errorf("columnSpacing must be positive with %d columns", p.Columns)
+	}
+	if p.Rows > 1 && p.RowSpacing <= 0 {
+		return fmt.Errorf("rowSpacing must be positive with %d rows", p.Rows)
+	}
+
+	if err := fits("columns of wells", p.Columns, p.A1ColumnOffset, p.ColumnSpacing, wl, l); err != nil {
+		return err
+	}
+	if err := fits("rows of wells", p.Rows, p.A1RowOffset, p.RowSpacing, ww, w); err != nil {
+		return err
+	}
+
+	if p.WellBottomElevation < 0 {
+		return fmt.Errorf("wellBottomElevation must not be negative, not %g", p.WellBottomElevation)
+	}
+	if top := p.WellBottomElevation + p.WellDepth; top > p.Height+tolerance {
+		return fmt.Errorf("wells reach %g mm, above the plate's height of %g mm", top, p.Height)
+	}
+	if p.WellBottomDepth > p.WellDepth {
+		return fmt.Errorf("wellBottomDepth %g is more than the wellDepth %g", p.WellBottomDepth, p.WellDepth)
+	}
+
+	if err := wunit.ValidMeasurementUnit("Volume", p.volumeUnit()); err != nil {
+		return err
+	}
+	max := wunit.NewVolume(p.MaxVolume, p.volumeUnit()).ConvertToString("ul")
+	if c := p.capacity(); max > c*(1+volumeTolerance) {
+		return fmt.Errorf("wells of maxVolume %g %s would not fit in their %.4g ul", p.MaxVolume, p.volumeUnit(), c)
+	}
+
+	for _, r := range p.Risers {
+		if r.Name == "" {
+			return fmt.Errorf("riser has no name")
+		}
+		if r.Height-r.Offset < 0 {
+			return fmt.Errorf("riser %s lowers the plate", r.Name)
+		}
+		if r.Robot != "" && len(r.Positions) == 0 {
+			return fmt.Errorf("riser %s is for %s but no positions on it", r.Name, r.Robot)
+		}
+	}
+
+	return nil
+}
+
+// Definitions checks the plate and returns the file inventory definitions
+// of it and of it on each of its risers
+func (p Plate) Definitions() ([]fileinventory.Plate, error) {
+	if err := p.Check(); err != nil {
+		return nil, err
+	}
+
+	wl, ww := p.wellSize()
+	base := fileinventory.Plate{
+		Type:         p.Type,
+		Manufacturer: p.Manufacturer,
+		Rows:         p.Rows,
+		Columns:      p.Columns,
+		Height:       p.Height,
+		WellXOffset:  p.ColumnSpacing,
+		WellYOffset:  p.RowSpacing,
+		WellXStart:   p.A1ColumnOffset - sbsA1ColumnOffset,
+		WellYStart:   p.A1RowOffset - sbsA1RowOffset,
+		WellZStart:   p.WellBottomElevation,
+		Skirt:        p.Skirt,
+		Well: fileinventory.Well{
+			Shape:          strings.ToLower(p.WellShape),
+			X:              wl,
+			Y:              ww,
+			Z:              p.WellDepth,
+			Bottom:         strings.ToLower(p.WellBottom),
+			BottomHeight:   p.WellBottomDepth,
+			VolumeUnit:     p.VolumeUnit,
+			MaxVolume:      p.MaxVolume,
+			ResidualVolume: p.ResidualVolume,
+		},
+	}
+
+	defs := []fileinventory.Plate{base}
+	for _, r := range p.Risers {
+		d := base
+		d.Type = p.Type + "_" + r.Name
+		d.WellZStart += r.Height - r.Offset
+		if r.Robot != "" {
+			d.Well.Extra = map[string]interface{}{r.Robot: r.Positions}
+		}
+		defs = append(defs, d)
+	}
+
+	// what the file inventory would make of them should be fine too
+	for _, d := range defs {
+		if _, err := d.Make(); err != nil {
+			return nil, fmt.Errorf("%s: %s", d.Type, err)
+		}
+	}
+
+	return defs, nil
+}
+
+// Import returns a file inventory file defining the plates, or the problems
+// with them
+func Import(plates []Plate) (*fileinventory.File, error) {
+	var problems fileinventory.Error
+	seen := make(map[string]bool)
+	f := &fileinventory.File{}
+	for i, p := range plates {
+		defs, err := p.Definitions()
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("plate %d (%s): %s", i+1, p.Type, err))
+			continue
+		}
+		for _, d := range defs {
+			if seen[d.Type] {
+				problems = append(problems, fmt.Sprintf("plate %d: type %s already defined", i+1, d.Type))
+				continue
+			}
+			seen[d.Type] = true
+			f.Plates = append(f.Plates, d)
+		}
+	}
+
+	if len(problems) != 0 {
+		return nil, problems
+	}
+	return f, nil
+}
@@ -0,0 +1,167 @@
+package labware
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/fileinventory"
+)
+
+const sheetCSV = `type,manufacturer,rows,columns,height,skirt,a1ColumnOffset,a1RowOffset,columnSpacing,rowSpacing,wellBottomElevation,wellShape,wellDiameter,wellLength,wellWidth,wellDepth,wellBottom,wellBottomDepth,maxVolume,residualVolume,risers
+acme96,ACME,8,12,14.4,full,14.38,11.24,9,9,3.1,cylinder,6.96,,,10.9,u,1.5,340,10,riser20=20-1.5;shaker=46@Pipetmax:position_1 position_2
+acme384,ACME,16,24,14.4,,12.13,8.99,4.5,4.5,2.5,box,,3.7,3.7,11.5,flat,,112,5,
+`
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestImportCSV(t *testing.T) {
+	plates, err := ReadCSV(strings.NewReader(sheetCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plates) != 2 {
+		t.Fatalf("expecting 2 plates but found %d", len(plates))
+	}
+
+	f, err := Import(plates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defs := make(map[string]fileinventory.Plate)
+	for _, d := range f.Plates {
+		defs[d.Type] = d
+	}
+	if len(defs) != 4 {
+		t.Fatalf("expecting 4 plate types but found %d", len(defs))
+	}
+
+	base := defs["acme96"]
+	if !near(base.WellXStart, 0) || !near(base.WellYStart, 0) || !near(base.WellZStart, 3.1) || base.Skirt != "full" {
+		t.Errorf("unexpected definition %+v", base)
+	}
+
+	if r := defs["acme96_riser20"]; !near(r.WellZStart, 3.1+20-1.5) || r.Well.Extra != nil {
+		t.Errorf("unexpected riser definition %+v", r)
+	}
+
+	s := defs["acme96_shaker"]
+	if !near(s.WellZStart, 3.1+46) {
+		t.Errorf("expecting shaker plate %g mm up but found %g", 3.1+46, s.WellZStart)
+	}
+	p, err := s.Make()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pos, ok := p.IsConstrainedOn("Pipetmax"); !ok || len(pos) != 2 {
+		t.Errorf("expecting shaker plate constrained to 2 positions, found %v", pos)
+	}
+
+	if d := defs["acme384"]; !near(d.WellXStart, 12.13-14.38) || d.Well.Shape != "box" || d.Well.X != 3.7 {
+		t.Errorf("unexpected definition %+v", d)
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	plates, err := ReadJSON(strings.NewReader(`{
+  "type": "tubes24", "rows": 4, "columns": 6, "height": 40,
+  "a1ColumnOffset": 18, "a1RowOffset": 15, "columnSpacing": 18, "rowSpacing": 18,
+  "wellShape": "cylinder", "wellDiameter": 10, "wellDepth": 38, "wellBottom": "v",
+  "maxVolume": 1.5, "volumeUnit": "ml",
+  "risers": [{"name": "riser40", "height": 40}]
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plates) != 1 || len(plates[0].Risers) != 1 {
+		t.Fatalf("unexpected plates %+v", plates)
+	}
+
+	if _, err := ReadJSON(strings.NewReader(`[{"type": "x", "colour": "red"}]`)); err == nil {
+		t.Error("expecting error for unknown key")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	good := Plate{
+		Type:           "good",
+		Rows:           8,
+		Columns:        12,
+		Height:         14.4,
+		A1ColumnOffset: 14.38,
+		A1RowOffset:    11.24,
+		ColumnSpacing:  9,
+		RowSpacing:     9,
+		WellShape:      "cylinder",
+		WellDiameter:   6.96,
+		WellDepth:      10.9,
+		MaxVolume:      340,
+	}
+	if err := good.Check(); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, change := range map[string]func(p *Plate){
+		"overlapping": func(p *Plate) { p.WellDiameter = 9.5 },
+		"overhanging": func(p *Plate) { p.A1ColumnOffset = 30 },
+		"off the top": func(p *Plate) { p.A1RowOffset = 2 },
+		"too deep":    func(p *Plate) { p.WellBottomElevation = 5 },
+		"too full":    func(p *Plate) { p.MaxVolume = 500 },
+		"no spacing":  func(p *Plate) { p.RowSpacing = 0 },
+		"bad skirt":   func(p *Plate) { p.Skirt = "pleated" },
+		"bad shape":   func(p *Plate) { p.WellShape = "hexagon" },
+		"bad riser":   func(p *Plate) { p.Risers = []Riser{{Name: "r", Height: 20, Robot: "Pipetmax"}} },
+	} {
+		p := good
+		change(&p)
+		if err := p.Check(); err == nil {
+			t.Errorf("%s: expecting error", name)
+		}
+	}
+
+	if _, err := Import([]Plate{good, good}); err == nil {
+		t.Error("expecting error for plates of the same type")
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	plates, err := ReadCSV(strings.NewReader(sheetCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := Import(plates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "labware")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	if err := f.Save(filepath.Join(dir, "acme.yaml")); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, err := fileinventory.NewContext(context.Background(), nil, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := inventory.NewPlate(ctx, "acme96_riser20")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Nwells != 96 || !inventory.IsSBS(p) || p.Skirt != "full" {
+		t.Errorf("unexpected plate %s with %d wells", p.Type, p.Nwells)
+	}
+}
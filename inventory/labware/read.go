@@ -0,0 +1,214 @@
+// inventory/labware/read.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package labware
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/antha-lang/antha/meta"
+)
+
+const risersColumn = "risers"
+
+// ReadFile reads the plates described in a CSV or JSON file
+func ReadFile(filename string) ([]Plate, error) {
+	bs, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(filename)); ext {
+	case ".csv":
+		return ReadCSV(bytes.NewReader(bs))
+	case ".json":
+		return ReadJSON(bytes.NewReader(bs))
+	default:
+		return nil, fmt.Errorf("%s: cannot read plates from %q files", filename, ext)
+	}
+}
+
+// ReadJSON reads a plate, or a list of them, in JSON
+func ReadJSON(r io.Reader) ([]Plate, error) {
+	bs, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	bs = bytes.TrimSpace(bs)
+	if len(bs) != 0 && bs[0] == '{' {
+		var p Plate
+		if err := meta.UnmarshalJSONStrict(bs, &p); err != nil {
+			return nil, err
+		}
+		return []Plate{p}, nil
+	}
+
+	var ps []Plate
+	if err := meta.UnmarshalJSONStrict(bs, &ps); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+// ReadCSV reads plates in CSV, one a row after a header row naming the
+// columns as the JSON keys of a Plate. Risers are given as
+// name=height[-offset][@robot:position ...] separated by semicolons, e.g.,
+// "riser20=20-1.5;bioshake=46@Pipetmax:position_1".
+func ReadCSV(r io.Reader) ([]Plate, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	fields := csvFields()
+	for _, h := range header {
+		if _, ok := fields[h]; !ok && h != risersColumn {
+			return nil, fmt.Errorf("unknown column %q", h)
+		}
+	}
+
+	var ps []Plate
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		var p Plate
+		v := reflect.ValueOf(&p).Elem()
+		for i, cell := range rec {
+			cell = strings.TrimSpace(cell)
+			if cell == "" {
+				continue
+			}
+
+			if header[i] == risersColumn {
+				if p.Risers, err = parseRisers(cell); err != nil {
+					return nil, fmt.Errorf("line %d: %s", line, err)
+				}
+				continue
+			}
+
+			if err := setField(v.Field(fields[header[i]]), cell); err != nil {
+				return nil, fmt.Errorf("line %d: %s: %s", line, header[i], err)
+			}
+		}
+		ps = append(ps, p)
+	}
+
+	return ps, nil
+}
+
+// csvFields returns the indices of the scalar fields of a Plate by their
+// JSON keys
+func csvFields() map[string]int {
+	fields := make(map[string]int)
+	t := reflect.TypeOf(Plate{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		switch f.Type.Kind() {
+		case reflect.String, reflect.Int, reflect.Float64:
+			fields[strings.Split(f.Tag.Get("json"), ",")[0]] = i
+		}
+	}
+	return fields
+}
+
+func setField(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int:
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(i))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	}
+	return nil
+}
+
+func parseRisers(s string) ([]Riser, error) {
+	var rs []Riser
+	for _, term := range strings.Split(s, ";") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		var r Riser
+		if at := strings.Index(term, "@"); at >= 0 {
+			place := strings.SplitN(term[at+1:], ":", 2)
+			r.Robot = strings.TrimSpace(place[0])
+			if len(place) == 2 {
+				r.Positions = strings.Fields(place[1])
+			}
+			term = term[:at]
+		}
+
+		kv := strings.SplitN(term, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("riser %q has no height", term)
+		}
+		r.Name = strings.TrimSpace(kv[0])
+
+		height := strings.TrimSpace(kv[1])
+		if dash := strings.LastIndex(height, "-"); dash > 0 {
+			off, err := strconv.ParseFloat(height[dash+1:], 64)
+			if err != nil {
+				return nil, fmt.Errorf("riser %s: %s", r.Name, err)
+			}
+			r.Offset = off
+			height = height[:dash]
+		}
+		h, err := strconv.ParseFloat(height, 64)
+		if err != nil {
+			return nil, fmt.Errorf("riser %s: %s", r.Name, err)
+		}
+		r.Height = h
+
+		rs = append(rs, r)
+	}
+	return rs, nil
+}
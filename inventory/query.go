@@ -0,0 +1,281 @@
+package inventory
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+// sbsLayouts are the rows and columns of SBS plates by number of wells
+var sbsLayouts = map[int][2]int{
+	6:    {2, 3},
+	12:   {3, 4},
+	24:   {4, 6},
+	48:   {6, 8},
+	96:   {8, 12},
+	384:  {16, 24},
+	1536: {32, 48},
+}
+
+// sbsPitch is the distance (mm) between well centres that the SBS standard
+// sets for plates with these numbers of wells
+var sbsPitch = map[int]float64{
+	96:   9,
+	384:  4.5,
+	1536: 2.25,
+}
+
+// A PlateQuery selects plate types by their properties. Zero fields match
+// every plate.
+type PlateQuery struct {
+	Wells         int          // number of wells
+	MinWellVolume wunit.Volume // least well capacity
+	MaxWellVolume wunit.Volume // greatest well capacity
+	Manufacturer  string       // compared ignoring case
+	SBS           *bool        // whether the plate has an SBS well layout
+	Skirt         string       // one of wtype.SkirtNone, SkirtSemi or SkirtFull; plates whose skirt is not known never match
+	MinHeight     float64      // least plate height (mm)
+	MaxHeight     float64      // greatest plate height (mm)
+}
+
+// Matches returns whether the plate meets every constraint of the query
+func (q PlateQuery) Matches(p *wtype.LHPlate) bool {
+	if p == nil {
+		return false
+	}
+
+	if q.Wells != 0 && p.Nwells != q.Wells {
+		return false
+	}
+
+	vol := p.Welltype.MaxVolume()
+	if !q.MinWellVolume.IsNil() && vol.LessThan(q.MinWellVolume) {
+		return false
+	}
+	if !q.MaxWellVolume.IsNil() && vol.GreaterThan(q.MaxWellVolume) {
+		return false
+	}
+
+	if q.Manufacturer != "" && !strings.EqualFold(p.Mnfr, q.Manufacturer) {
+		return false
+	}
+
+	if q.SBS != nil && IsSBS(p) != *q.SBS {
+		return false
+	}
+
+	if q.Skirt != "" && p.Skirt != q.Skirt {
+		return false
+	}
+
+	h := heightInMM(p)
+	if q.MinHeight != 0 && h < q.MinHeight {
+		return false
+	}
+	if q.MaxHeight != 0 && h > q.MaxHeight {
+		return false
+	}
+
+	return true
+}
+
+// String returns the query in the form ParsePlateQuery reads
+func (q PlateQuery) String() string {
+	var terms []string
+	if q.Wells != 0 {
+		terms = append(terms, fmt.Sprintf("wells=%d", q.Wells))
+	}
+	if !q.MinWellVolume.IsNil() {
+		terms = append(terms, "minWellVolume="+volumeTerm(q.MinWellVolume))
+	}
+	if !q.MaxWellVolume.IsNil() {
+		terms = append(terms, "maxWellVolume="+volumeTerm(q.MaxWellVolume))
+	}
+	if q.Manufacturer != "" {
+		terms = append(terms, "manufacturer="+q.Manufacturer)
+	}
+	if q.SBS != nil {
+		terms = append(terms, fmt.Sprintf("sbs=%t", *q.SBS))
+	}
+	if q.Skirt != "" {
+		terms = append(terms, "skirt="+q.Skirt)
+	}
+	if q.MinHeight != 0 {
+		terms = append(terms, fmt.Sprintf("minHeight=%gmm", q.MinHeight))
+	}
+	if q.MaxHeight != 0 {
+		terms = append(terms, fmt.Sprintf("maxHeight=%gmm", q.MaxHeight))
+	}
+	return strings.Join(terms, ",")
+}
+
+func volumeTerm(v wunit.Volume) string {
+	return fmt.Sprintf("%g%s", v.RawValue(), v.Unit().PrefixedSymbol())
+}
+
+// IsSBS returns whether a plate has the rows and columns of an SBS plate
+// and, for 96, 384 and 1536 well plates, the standard well spacing
+func IsSBS(p *wtype.LHPlate) bool {
+	layout, ok := sbsLayouts[p.Nwells]
+	if !ok || p.WlsY != layout[0] || p.WlsX != layout[1] {
+		return false
+	}
+
+	if pitch, ok := sbsPitch[p.Nwells]; ok {
+		const tolerance = 0.1 // mm
+		if math.Abs(p.WellXOffset-pitch) > tolerance || math.Abs(p.WellYOffset-pitch) > tolerance {
+			return false
+		}
+	}
+
+	return true
+}
+
+func heightInMM(p *wtype.LHPlate) float64 {
+	if p.Hunit == "" || p.Hunit == "mm" {
+		return p.Height
+	}
+	return wunit.NewLength(p.Height, p.Hunit).ConvertToString("mm")
+}
+
+// A TipboxQuery selects tipbox types by their tips. Zero fields match every
+// tipbox.
+type TipboxQuery struct {
+	MinTipVolume wunit.Volume // least tip capacity
+	MaxTipVolume wunit.Volume // greatest tip capacity
+	Manufacturer string       // compared ignoring case
+}
+
+// Matches returns whether the tipbox meets every constraint of the query
+func (q TipboxQuery) Matches(tb *wtype.LHTipbox) bool {
+	if tb == nil {
+		return false
+	}
+
+	if q.Manufacturer != "" && !strings.EqualFold(tb.Mnfr, q.Manufacturer) {
+		return false
+	}
+
+	if q.MinTipVolume.IsNil() && q.MaxTipVolume.IsNil() {
+		return true
+	}
+
+	if tb.Tiptype == nil {
+		return false
+	}
+
+	vol := tb.Tiptype.MaxVol
+	if !q.MinTipVolume.IsNil() && vol.LessThan(q.MinTipVolume) {
+		return false
+	}
+	if !q.MaxTipVolume.IsNil() && vol.GreaterThan(q.MaxTipVolume) {
+		return false
+	}
+
+	return true
+}
+
+// String returns the query in the form ParseTipboxQuery reads
+func (q TipboxQuery) String() string {
+	var terms []string
+	if !q.MinTipVolume.IsNil() {
+		terms = append(terms, "minTipVolume="+volumeTerm(q.MinTipVolume))
+	}
+	if !q.MaxTipVolume.IsNil() {
+		terms = append(terms, "maxTipVolume="+volumeTerm(q.MaxTipVolume))
+	}
+	if q.Manufacturer != "" {
+		terms = append(terms, "manufacturer="+q.Manufacturer)
+	}
+	return strings.Join(terms, ",")
+}
+
+// splitQuery splits a query of comma separated key=value terms
+func splitQuery(s string, term func(key, value string) error) error {
+	for _, t := range strings.Split(s, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+
+		kv := strings.SplitN(t, "=", 2)
+		key, value := strings.TrimSpace(kv[0]), ""
+		if len(kv) == 2 {
+			value = strings.TrimSpace(kv[1])
+		}
+
+		if err := term(key, value); err != nil {
+			return fmt.Errorf("invalid query term %q: %s", t, err)
+		}
+	}
+	return nil
+}
+
+func parseLength(s string) (float64, error) {
+	return strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s, "mm")), 64)
+}
+
+// ParsePlateQuery parses a plate query written as comma separated terms,
+// e.g., "wells=96,minWellVolume=100ul,skirt=full,sbs". The keys are wells,
+// minWellVolume, maxWellVolume, manufacturer, sbs (true if given without a
+// value), skirt, minHeight and maxHeight (in mm). Plate types which don't
+// say what skirt they have never match a skirt term.
+func ParsePlateQuery(s string) (q PlateQuery, err error) {
+	err = splitQuery(s, func(key, value string) (err error) {
+		switch key {
+		case "wells":
+			q.Wells, err = strconv.Atoi(value)
+		case "minWellVolume":
+			q.MinWellVolume, err = wunit.ParseVolume(value)
+		case "maxWellVolume":
+			q.MaxWellVolume, err = wunit.ParseVolume(value)
+		case "manufacturer":
+			q.Manufacturer = value
+		case "sbs":
+			sbs := true
+			if value != "" {
+				sbs, err = strconv.ParseBool(value)
+			}
+			q.SBS = &sbs
+		case "skirt":
+			switch value {
+			case wtype.SkirtNone, wtype.SkirtSemi, wtype.SkirtFull:
+				q.Skirt = value
+			default:
+				err = fmt.Errorf("skirt must be one of %s, %s or %s", wtype.SkirtNone, wtype.SkirtSemi, wtype.SkirtFull)
+			}
+		case "minHeight":
+			q.MinHeight, err = parseLength(value)
+		case "maxHeight":
+			q.MaxHeight, err = parseLength(value)
+		default:
+			err = fmt.Errorf("unknown key %q", key)
+		}
+		return
+	})
+	return
+}
+
+// ParseTipboxQuery parses a tipbox query written as comma separated terms,
+// e.g., "minTipVolume=200ul". The keys are minTipVolume, maxTipVolume and
+// manufacturer.
+func ParseTipboxQuery(s string) (q TipboxQuery, err error) {
+	err = splitQuery(s, func(key, value string) (err error) {
+		switch key {
+		case "minTipVolume":
+			q.MinTipVolume, err = wunit.ParseVolume(value)
+		case "maxTipVolume":
+			q.MaxTipVolume, err = wunit.ParseVolume(value)
+		case "manufacturer":
+			q.Manufacturer = value
+		default:
+			err = fmt.Errorf("unknown key %q", key)
+		}
+		return
+	})
+	return
+}
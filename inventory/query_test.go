@@ -0,0 +1,110 @@
+package inventory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/testinventory"
+)
+
+func TestParsePlateQuery(t *testing.T) {
+	q, err := inventory.ParsePlateQuery("wells=96, minWellVolume=100ul,maxWellVolume=1ml,manufacturer=ACME,sbs,skirt=full,minHeight=10,maxHeight=20mm")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e, f := "wells=96,minWellVolume=100ul,maxWellVolume=1ml,manufacturer=ACME,sbs=true,skirt=full,minHeight=10mm,maxHeight=20mm", q.String(); e != f {
+		t.Errorf("expecting %q but found %q", e, f)
+	}
+
+	// queries read what they write
+	q2, err := inventory.ParsePlateQuery(q.String())
+	if err != nil {
+		t.Fatal(err)
+	} else if q2.String() != q.String() {
+		t.Errorf("expecting %q but found %q", q, q2)
+	}
+
+	for _, s := range []string{"wells=many", "skirt=pleated", "colour=red", "sbs=perhaps", "minWellVolume=100"} {
+		if _, err := inventory.ParsePlateQuery(s); err == nil {
+			t.Errorf("expecting error parsing %q", s)
+		}
+	}
+}
+
+func TestPlates(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	all, err := inventory.Plates(ctx, inventory.PlateQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := len(testinventory.GetPlates(ctx)), len(all); e != f {
+		t.Errorf("expecting %d plates but found %d", e, f)
+	}
+
+	q, err := inventory.ParsePlateQuery("wells=96,minWellVolume=1ml,sbs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plates, err := inventory.Plates(ctx, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := make(map[string]bool)
+	for _, p := range plates {
+		found[p.Type] = true
+		if p.Nwells != 96 || p.Welltype.MaxVolume().ConvertToString("ul") < 1000 || !inventory.IsSBS(p) {
+			t.Errorf("plate %s does not match %q", p.Type, q)
+		}
+	}
+	if !found["DSW96"] || found["pcrplate_skirted"] {
+		t.Errorf("expecting DSW96 and not pcrplate_skirted, found %v", found)
+	}
+
+	semi, err := inventory.Plates(ctx, inventory.PlateQuery{Skirt: wtype.SkirtSemi})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range semi {
+		if p.Skirt != wtype.SkirtSemi {
+			t.Errorf("plate %s has %q skirt", p.Type, p.Skirt)
+		}
+	}
+	if len(semi) == 0 {
+		t.Error("expecting some semi-skirted plates")
+	}
+
+	if p, err := inventory.NewPlate(ctx, "EGEL96_1"); err != nil {
+		t.Fatal(err)
+	} else if inventory.IsSBS(p) {
+		t.Errorf("expecting %s not to be SBS", p.Type)
+	}
+}
+
+func TestTipboxes(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	q, err := inventory.ParseTipboxQuery("minTipVolume=1ml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tbs, err := inventory.Tipboxes(ctx, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[string]bool)
+	for _, tb := range tbs {
+		if seen[tb.Type] {
+			t.Errorf("tipbox %s listed twice", tb.Type)
+		}
+		seen[tb.Type] = true
+		if tb.Tiptype.MaxVol.ConvertToString("ul") < 1000 {
+			t.Errorf("tipbox %s has %s tips", tb.Type, tb.Tiptype.MaxVol)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// inventory/remoteinventory/client.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package remoteinventory
+
+import (
+	"context"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/inventory"
+	pb "github.com/antha-lang/antha/inventory/antha_inventory_v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	_ inventory.Inventory = &Client{}
+)
+
+// A Client is an inventory whose items come from a Server
+type Client struct {
+	conn *grpc.ClientConn
+	c    pb.InventoryClient
+}
+
+// NewClient returns an inventory of the items served on conn
+func NewClient(conn *grpc.ClientConn) *Client {
+	return &Client{conn: conn, c: pb.NewInventoryClient(conn)}
+}
+
+// Dial returns an inventory of the items served at address
+func Dial(address string) (*Client, error) {
+	conn, err := grpc.Dial(address, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(conn), nil
+}
+
+// Close closes the client's connection to the server
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// fromStatus turns gRPC errors back into inventory ones
+func fromStatus(err error) error {
+	if grpc.Code(err) == codes.NotFound {
+		return inventory.ErrUnknownType
+	}
+	return err
+}
+
+// NewComponent returns a new component of the given type
+func (c *Client) NewComponent(ctx context.Context, typ string) (*wtype.LHComponent, error) {
+	item, err := c.c.NewComponent(ctx, &pb.NewItemRequest{Type: typ})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return LHComponentFromComponent(item)
+}
+
+// NewPlate returns a new plate of the given type
+func (c *Client) NewPlate(ctx context.Context, typ string) (*wtype.LHPlate, error) {
+	item, err := c.c.NewPlate(ctx, &pb.NewItemRequest{Type: typ})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return LHPlateFromPlate(item)
+}
+
+// NewTipbox returns a new tipbox of the given type
+func (c *Client) NewTipbox(ctx context.Context, typ string) (*wtype.LHTipbox, error) {
+	item, err := c.c.NewTipbox(ctx, &pb.NewItemRequest{Type: typ})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return LHTipboxFromTipbox(item)
+}
+
+// NewTipwaste returns a new tipwaste of the given type
+func (c *Client) NewTipwaste(ctx context.Context, typ string) (*wtype.LHTipwaste, error) {
+	item, err := c.c.NewTipwaste(ctx, &pb.NewItemRequest{Type: typ})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return LHTipwasteFromTipwaste(item)
+}
+
+// Plates implements an inventory.Inventory. The server lists the plate types
+// it has matching the query.
+func (c *Client) Plates(ctx context.Context, q inventory.PlateQuery) ([]*wtype.LHPlate, error) {
+	reply, err := c.c.ListPlates(ctx, PlatesRequestFromQuery(q))
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+
+	var plates []*wtype.LHPlate
+	for _, item := range reply.GetItems() {
+		p, err := LHPlateFromPlate(item)
+		if err != nil {
+			return nil, err
+		}
+		plates = append(plates, p)
+	}
+	return plates, nil
+}
+
+// Tipboxes implements an inventory.Inventory. The server lists the tipbox
+// types it has matching the query.
+func (c *Client) Tipboxes(ctx context.Context, q inventory.TipboxQuery) ([]*wtype.LHTipbox, error) {
+	reply, err := c.c.ListTipboxes(ctx, TipboxesRequestFromQuery(q))
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+
+	var tbs []*wtype.LHTipbox
+	for _, item := range reply.GetItems() {
+		tb, err := LHTipboxFromTipbox(item)
+		if err != nil {
+			return nil, err
+		}
+		tbs = append(tbs, tb)
+	}
+	return tbs, nil
+}
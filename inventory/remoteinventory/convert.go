@@ -0,0 +1,389 @@
+// inventory/remoteinventory/convert.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package remoteinventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	api "github.com/antha-lang/antha/api/v1"
+	"github.com/golang/protobuf/ptypes/any"
+)
+
+const (
+	// wtypeKey is the metadata key of the JSON of the wtype an item was
+	// converted from, for what the api messages have no fields for
+	wtypeKey = "antha.wtype"
+	// extraKey is the metadata key of the JSON of the extra properties of
+	// the wells of a plate type
+	extraKey = "antha.extra"
+	// skirtKey is the metadata key of the JSON of the skirt of a plate type
+	skirtKey = "antha.skirt"
+	// jsonTypeURLPrefix prefixes the type urls of JSON metadata
+	jsonTypeURLPrefix = "antha-lang.org/json/"
+)
+
+// bottomNames are the api names of the well bottoms, indexed by
+// wtype.LHWBFLAT and friends
+var bottomNames = []string{"flat", "u", "v", "conical"}
+
+func bottomName(bottom int) (string, error) {
+	if bottom < 0 || bottom >= len(bottomNames) {
+		return "", fmt.Errorf("unknown well bottom %d", bottom)
+	}
+	return bottomNames[bottom], nil
+}
+
+func bottomFromName(name string) (int, error) {
+	for i, n := range bottomNames {
+		if n == strings.ToLower(name) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown well bottom %q", name)
+}
+
+func measurement(value float64, unit string) *api.Measurement {
+	return &api.Measurement{Value: value, Unit: unit}
+}
+
+// jsonAny wraps the JSON of v, whose type is named by name, as an Any
+func jsonAny(name string, v interface{}) (*any.Any, error) {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &any.Any{TypeUrl: jsonTypeURLPrefix + name, Value: bs}, nil
+}
+
+// fromJSONAny unmarshals the JSON in the metadata of item under key into v,
+// returning whether there was any
+func fromJSONAny(item *api.InventoryItem, key, name string, v interface{}) (bool, error) {
+	a, ok := item.GetMetadata()[key]
+	if !ok {
+		return false, nil
+	}
+	if a.GetTypeUrl() != jsonTypeURLPrefix+name {
+		return false, fmt.Errorf("expecting %s metadata of type %s but found %s", key, jsonTypeURLPrefix+name, a.GetTypeUrl())
+	}
+	if err := json.Unmarshal(a.GetValue(), v); err != nil {
+		return false, fmt.Errorf("cannot unmarshal %s metadata: %s", key, err)
+	}
+	return true, nil
+}
+
+// withWtype returns an item carrying the JSON of v, whose wtype is named by
+// name, in its metadata
+func withWtype(id string, name string, v interface{}) (*api.InventoryItem, error) {
+	a, err := jsonAny(name, v)
+	if err != nil {
+		return nil, err
+	}
+	return &api.InventoryItem{
+		Id:       id,
+		Metadata: map[string]*any.Any{wtypeKey: a},
+	}, nil
+}
+
+// PlateTypeFromLHPlate returns the plate type of a plate. Lengths are in the
+// units of the plate and its wells; the plate's extent in X and Y is not
+// known so is left unset.
+func PlateTypeFromLHPlate(p *wtype.LHPlate) (*api.PlateType, error) {
+	w := p.Welltype
+	if w == nil {
+		return nil, fmt.Errorf("plate type %s has no well type", p.Type)
+	}
+
+	bottom, err := bottomName(w.Bottom)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.PlateType{
+		Dim: &api.PhysicalCoord{
+			Z: measurement(p.Height, p.Hunit),
+		},
+		WellDim: &api.PhysicalCoord{
+			X: measurement(w.Xdim, w.Dunit),
+			Y: measurement(w.Ydim, w.Dunit),
+			Z: measurement(w.Zdim, w.Dunit),
+		},
+		NumWells: &api.OrdinalCoord{
+			X: int32(p.WlsX),
+			Y: int32(p.WlsY),
+			Z: 1,
+		},
+		MaxVolume:      measurement(w.MaxVol, w.Vunit),
+		ResidualVolume: measurement(w.Rvol, w.Vunit),
+		WellShape:      w.Shape().ShapeName,
+		WellOffset: &api.PhysicalCoord{
+			X: measurement(p.WellXOffset, "mm"),
+			Y: measurement(p.WellYOffset, "mm"),
+		},
+		WellOrigin: &api.PhysicalCoord{
+			X: measurement(p.WellXStart, "mm"),
+			Y: measurement(p.WellYStart, "mm"),
+			Z: measurement(p.WellZStart, "mm"),
+		},
+		Mnfr:             p.Mnfr,
+		WellBottomHeight: measurement(w.Bottomh, w.Dunit),
+		WellBottomShape:  bottom,
+	}, nil
+}
+
+// PlateFromLHPlate returns an item for a plate: the api.Plate, with its
+// non-empty wells, made from the api.PlateType item of its type
+func PlateFromLHPlate(p *wtype.LHPlate) (*api.InventoryItem, error) {
+	pt, err := PlateTypeFromLHPlate(p)
+	if err != nil {
+		return nil, err
+	}
+
+	typeItem := &api.InventoryItem{
+		Id:       p.Type,
+		Item:     &api.InventoryItem_PlateType{PlateType: pt},
+		Metadata: make(map[string]*any.Any),
+	}
+	if len(p.Welltype.Extra) != 0 {
+		a, err := jsonAny("map", p.Welltype.Extra)
+		if err != nil {
+			return nil, err
+		}
+		typeItem.Metadata[extraKey] = a
+	}
+	if p.Skirt != "" {
+		a, err := jsonAny("string", p.Skirt)
+		if err != nil {
+			return nil, err
+		}
+		typeItem.Metadata[skirtKey] = a
+	}
+
+	plate := &api.Plate{Type: p.Type}
+	for _, row := range p.Rows {
+		for _, w := range row {
+			if w.Empty() {
+				continue
+			}
+			wc := wtype.MakeWellCoords(w.Crds)
+			c, err := ComponentFromLHComponent(w.WContents)
+			if err != nil {
+				return nil, err
+			}
+			plate.Wells = append(plate.Wells, &api.Well{
+				Position:  &api.OrdinalCoord{X: int32(wc.X), Y: int32(wc.Y)},
+				Component: c,
+			})
+		}
+	}
+
+	return &api.InventoryItem{
+		Id:        p.ID,
+		Barcode:   p.Barcode,
+		FromItems: []*api.InventoryItem{typeItem},
+		Item:      &api.InventoryItem_Plate{Plate: plate},
+	}, nil
+}
+
+// LHPlateFromPlate returns the plate of an item made by PlateFromLHPlate
+func LHPlateFromPlate(item *api.InventoryItem) (*wtype.LHPlate, error) {
+	p := item.GetPlate()
+	if p == nil {
+		return nil, fmt.Errorf("item %q is not a plate", item.GetId())
+	}
+	for _, from := range item.GetFromItems() {
+		pt := from.GetPlateType()
+		if pt == nil {
+			continue
+		}
+		extra := make(map[string]interface{})
+		if _, err := fromJSONAny(from, extraKey, "map", &extra); err != nil {
+			return nil, err
+		}
+		var skirt string
+		if _, err := fromJSONAny(from, skirtKey, "string", &skirt); err != nil {
+			return nil, err
+		}
+		lhp, err := LHPlateFromAPI(pt, p)
+		if err != nil {
+			return nil, err
+		}
+		lhp.Skirt = skirt
+		for _, w := range lhp.Wellcoords {
+			for k, v := range extra {
+				w.Extra[k] = v
+			}
+		}
+		for k, v := range extra {
+			lhp.Welltype.Extra[k] = v
+		}
+		if item.GetId() != "" {
+			lhp.ID = item.GetId()
+		}
+		lhp.Barcode = item.GetBarcode()
+		return lhp, nil
+	}
+	return nil, fmt.Errorf("plate %q has no plate type", item.GetId())
+}
+
+// LHPlateFromAPI makes a plate of type pt, named by p.Type, holding the
+// contents of the wells of p
+func LHPlateFromAPI(pt *api.PlateType, p *api.Plate) (*wtype.LHPlate, error) {
+	if p.GetType() == "" {
+		return nil, fmt.Errorf("plate has no type")
+	}
+	nw := pt.GetNumWells()
+	if nw.GetX() <= 0 || nw.GetY() <= 0 {
+		return nil, fmt.Errorf("plate type %s has no wells", p.GetType())
+	}
+	bottom, err := bottomFromName(pt.GetWellBottomShape())
+	if err != nil {
+		return nil, err
+	}
+
+	wd := pt.GetWellDim()
+	dunit := wd.GetX().GetUnit()
+	if dunit == "" {
+		dunit = "mm"
+	}
+	vunit := pt.GetMaxVolume().GetUnit()
+	if vunit == "" {
+		vunit = "ul"
+	}
+	hunit := pt.GetDim().GetZ().GetUnit()
+	if hunit == "" {
+		hunit = "mm"
+	}
+
+	shp := wtype.NewShape(pt.GetWellShape(), dunit, wd.GetX().GetValue(), wd.GetY().GetValue(), wd.GetZ().GetValue())
+	welltype := wtype.NewLHWell(p.GetType(), "", "", vunit, pt.GetMaxVolume().GetValue(), pt.GetResidualVolume().GetValue(), shp, bottom, wd.GetX().GetValue(), wd.GetY().GetValue(), wd.GetZ().GetValue(), pt.GetWellBottomHeight().GetValue(), dunit)
+
+	off := pt.GetWellOffset()
+	org := pt.GetWellOrigin()
+	plate := wtype.NewLHPlate(p.GetType(), pt.GetMnfr(), int(nw.GetY()), int(nw.GetX()), pt.GetDim().GetZ().GetValue(), hunit, welltype, off.GetX().GetValue(), off.GetY().GetValue(), org.GetX().GetValue(), org.GetY().GetValue(), org.GetZ().GetValue())
+
+	for _, w := range p.GetWells() {
+		pos := w.GetPosition()
+		wc := wtype.WellCoords{X: int(pos.GetX()), Y: int(pos.GetY())}
+		well := plate.WellAt(wc)
+		if well == nil {
+			return nil, fmt.Errorf("plate type %s has no well %s", p.GetType(), wc.FormatA1())
+		}
+		c, err := LHComponentFromComponent(w.GetComponent())
+		if err != nil {
+			return nil, fmt.Errorf("well %s: %s", wc.FormatA1(), err)
+		}
+		well.Add(c)
+	}
+
+	return plate, nil
+}
+
+// ComponentFromLHComponent returns an item for a component
+func ComponentFromLHComponent(c *wtype.LHComponent) (*api.InventoryItem, error) {
+	item, err := withWtype(c.ID, "wtype.LHComponent", c)
+	if err != nil {
+		return nil, err
+	}
+	item.Item = &api.InventoryItem_Component{Component: &api.Component{
+		Type:   wtype.LiquidTypeName(c.Type).String(),
+		Name:   c.CName,
+		Volume: measurement(c.Vol, c.Vunit),
+	}}
+	return item, nil
+}
+
+// LHComponentFromComponent returns the component of an item. Components
+// made by ComponentFromLHComponent come back whole; others have only their
+// type, name and volume.
+func LHComponentFromComponent(item *api.InventoryItem) (*wtype.LHComponent, error) {
+	var c wtype.LHComponent
+	if ok, err := fromJSONAny(item, wtypeKey, "wtype.LHComponent", &c); err != nil {
+		return nil, err
+	} else if ok {
+		return &c, nil
+	}
+
+	ac := item.GetComponent()
+	if ac == nil {
+		return nil, fmt.Errorf("item %q is not a component", item.GetId())
+	}
+	typ, err := wtype.LiquidTypeFromString(wtype.PolicyName(ac.GetType()))
+	if err != nil {
+		return nil, err
+	}
+
+	lhc := wtype.NewLHComponent()
+	lhc.CName = ac.GetName()
+	lhc.Type = typ
+	lhc.Vol = ac.GetVolume().GetValue()
+	lhc.Vunit = ac.GetVolume().GetUnit()
+	return lhc, nil
+}
+
+// TipboxFromLHTipbox returns an item for a tipbox
+func TipboxFromLHTipbox(tb *wtype.LHTipbox) (*api.InventoryItem, error) {
+	item, err := withWtype(tb.ID, "wtype.LHTipbox", tb)
+	if err != nil {
+		return nil, err
+	}
+	item.Barcode = tb.Barcode
+	item.Item = &api.InventoryItem_Tipbox{Tipbox: &api.Tipbox{Type: tb.Type}}
+	return item, nil
+}
+
+// LHTipboxFromTipbox returns the tipbox of an item made by TipboxFromLHTipbox
+func LHTipboxFromTipbox(item *api.InventoryItem) (*wtype.LHTipbox, error) {
+	var tb wtype.LHTipbox
+	if ok, err := fromJSONAny(item, wtypeKey, "wtype.LHTipbox", &tb); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, fmt.Errorf("tipbox %q has no %s metadata", item.GetTipbox().GetType(), wtypeKey)
+	}
+	tb.Barcode = item.GetBarcode()
+	return &tb, nil
+}
+
+// TipwasteFromLHTipwaste returns an item for a tipwaste
+func TipwasteFromLHTipwaste(tw *wtype.LHTipwaste) (*api.InventoryItem, error) {
+	item, err := withWtype(tw.ID, "wtype.LHTipwaste", tw)
+	if err != nil {
+		return nil, err
+	}
+	item.Item = &api.InventoryItem_Tipwaste{Tipwaste: &api.Tipwaste{Type: tw.Type}}
+	return item, nil
+}
+
+// LHTipwasteFromTipwaste returns the tipwaste of an item made by
+// TipwasteFromLHTipwaste
+func LHTipwasteFromTipwaste(item *api.InventoryItem) (*wtype.LHTipwaste, error) {
+	var tw wtype.LHTipwaste
+	if ok, err := fromJSONAny(item, wtypeKey, "wtype.LHTipwaste", &tw); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, fmt.Errorf("tipwaste %q has no %s metadata", item.GetTipwaste().GetType(), wtypeKey)
+	}
+	return &tw, nil
+}
@@ -0,0 +1,108 @@
+// inventory/remoteinventory/query.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package remoteinventory
+
+import (
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/inventory"
+	pb "github.com/antha-lang/antha/inventory/antha_inventory_v1"
+)
+
+// ul returns v in ul, or 0 if v is nil
+func ul(v wunit.Volume) float64 {
+	if v.IsNil() {
+		return 0
+	}
+	return v.ConvertToString("ul")
+}
+
+// volumeFromUl returns a volume of v ul, or the nil volume for 0
+func volumeFromUl(v float64) wunit.Volume {
+	if v == 0 {
+		return wunit.Volume{}
+	}
+	return wunit.NewVolume(v, "ul")
+}
+
+// PlatesRequestFromQuery returns the request for the plates matching q
+func PlatesRequestFromQuery(q inventory.PlateQuery) *pb.ListPlatesRequest {
+	req := &pb.ListPlatesRequest{
+		Wells:           int32(q.Wells),
+		MinWellVolumeUl: ul(q.MinWellVolume),
+		MaxWellVolumeUl: ul(q.MaxWellVolume),
+		Manufacturer:    q.Manufacturer,
+		Skirt:           q.Skirt,
+		MinHeightMm:     q.MinHeight,
+		MaxHeightMm:     q.MaxHeight,
+	}
+	if q.SBS != nil {
+		if *q.SBS {
+			req.Layout = pb.Layout_SBS
+		} else {
+			req.Layout = pb.Layout_NOT_SBS
+		}
+	}
+	return req
+}
+
+// PlateQueryFromRequest returns the query a request for plates was made
+// from
+func PlateQueryFromRequest(req *pb.ListPlatesRequest) inventory.PlateQuery {
+	q := inventory.PlateQuery{
+		Wells:         int(req.GetWells()),
+		MinWellVolume: volumeFromUl(req.GetMinWellVolumeUl()),
+		MaxWellVolume: volumeFromUl(req.GetMaxWellVolumeUl()),
+		Manufacturer:  req.GetManufacturer(),
+		Skirt:         req.GetSkirt(),
+		MinHeight:     req.GetMinHeightMm(),
+		MaxHeight:     req.GetMaxHeightMm(),
+	}
+	switch req.GetLayout() {
+	case pb.Layout_SBS:
+		sbs := true
+		q.SBS = &sbs
+	case pb.Layout_NOT_SBS:
+		sbs := false
+		q.SBS = &sbs
+	}
+	return q
+}
+
+// TipboxesRequestFromQuery returns the request for the tipboxes matching q
+func TipboxesRequestFromQuery(q inventory.TipboxQuery) *pb.ListTipboxesRequest {
+	return &pb.ListTipboxesRequest{
+		MinTipVolumeUl: ul(q.MinTipVolume),
+		MaxTipVolumeUl: ul(q.MaxTipVolume),
+		Manufacturer:   q.Manufacturer,
+	}
+}
+
+// TipboxQueryFromRequest returns the query a request for tipboxes was made
+// from
+func TipboxQueryFromRequest(req *pb.ListTipboxesRequest) inventory.TipboxQuery {
+	return inventory.TipboxQuery{
+		MinTipVolume: volumeFromUl(req.GetMinTipVolumeUl()),
+		MaxTipVolume: volumeFromUl(req.GetMaxTipVolumeUl()),
+		Manufacturer: req.GetManufacturer(),
+	}
+}
@@ -0,0 +1,214 @@
+package remoteinventory
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/inventory"
+	pb "github.com/antha-lang/antha/inventory/antha_inventory_v1"
+	"github.com/antha-lang/antha/inventory/testinventory"
+	"google.golang.org/grpc"
+)
+
+// serve serves the built-in inventory on a local port, returning a client of
+// it and a function to stop the server
+func serve(t *testing.T) (*Client, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := grpc.NewServer()
+	srv := NewServer(inventory.GetInventory(testinventory.NewContext(context.Background())))
+	pb.RegisterInventoryServer(s, srv)
+	go s.Serve(lis) // nolint: errcheck
+
+	c, err := Dial(lis.Addr().String())
+	if err != nil {
+		s.Stop()
+		t.Fatal(err)
+	}
+	return c, func() {
+		c.Close() // nolint: errcheck
+		s.Stop()
+	}
+}
+
+func TestClient(t *testing.T) {
+	c, stop := serve(t)
+	defer stop()
+
+	ctx := context.Background()
+	local := testinventory.NewContext(ctx)
+
+	p, err := c.NewPlate(ctx, "pcrplate_skirted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lp, err := inventory.NewPlate(local, "pcrplate_skirted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Type != lp.Type || p.WlsX != lp.WlsX || p.WlsY != lp.WlsY || p.Height != lp.Height || p.WellZStart != lp.WellZStart {
+		t.Errorf("expecting plate like %v but found %v", lp, p)
+	}
+	if p.Welltype.MaxVol != lp.Welltype.MaxVol || p.Welltype.Bottom != lp.Welltype.Bottom || p.Welltype.Zdim != lp.Welltype.Zdim {
+		t.Errorf("expecting well like %v but found %v", lp.Welltype, p.Welltype)
+	}
+
+	tb, err := c.NewTipbox(ctx, "CyBio250Tipbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ltb, err := inventory.NewTipbox(local, "CyBio250Tipbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tb.Type != ltb.Type || !tb.Tiptype.MaxVol.EqualTo(ltb.Tiptype.MaxVol) || tb.TipZStart != ltb.TipZStart {
+		t.Errorf("expecting tipbox like %v but found %v", ltb, tb)
+	}
+
+	tw, err := c.NewTipwaste(ctx, "Gilsontipwaste")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tw.Type != "Gilsontipwaste" || tw.Capacity != 600 {
+		t.Errorf("unexpected tipwaste %v", tw)
+	}
+
+	cmp, err := c.NewComponent(ctx, "water")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lcmp, err := inventory.NewComponent(local, "water")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmp.CName != lcmp.CName || cmp.Type != lcmp.Type || cmp.Smax != lcmp.Smax {
+		t.Errorf("expecting component like %v but found %v", lcmp, cmp)
+	}
+
+	if _, err := c.NewPlate(ctx, "nosuchplate"); err != inventory.ErrUnknownType {
+		t.Errorf("expecting %q but found %v", inventory.ErrUnknownType, err)
+	}
+
+	plates, err := c.Plates(ctx, inventory.PlateQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := len(testinventory.GetPlates(local)), len(plates); e != f {
+		t.Errorf("expecting %d plates but found %d", e, f)
+	}
+
+	// the server filters on the query sent
+	q := inventory.PlateQuery{Wells: 96, Skirt: wtype.SkirtFull}
+	lplates, err := inventory.Plates(local, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plates, err = c.Plates(ctx, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lplates) == 0 || len(plates) != len(lplates) {
+		t.Errorf("expecting %d skirted plates but found %d", len(lplates), len(plates))
+	}
+
+	tq := inventory.TipboxQuery{MinTipVolume: wunit.NewVolume(200, "ul")}
+	ltbs, err := inventory.Tipboxes(local, tq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tbs, err := c.Tipboxes(ctx, tq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ltbs) == 0 || len(tbs) != len(ltbs) {
+		t.Errorf("expecting %d tipboxes but found %d", len(ltbs), len(tbs))
+	}
+}
+
+func TestPlateConversion(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+	p, err := inventory.NewPlate(ctx, "pcrplate_skirted_bioshake")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmp, err := inventory.NewComponent(ctx, "water")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmp.Vol = 50
+	cmp.Vunit = "ul"
+	p.WellAt(wtype.MakeWellCoords("B3")).Add(cmp)
+	p.Barcode = "P000123"
+
+	item, err := PlateFromLHPlate(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(item.GetPlate().GetWells()); n != 1 {
+		t.Fatalf("expecting 1 well with contents but found %d", n)
+	}
+
+	q, err := LHPlateFromPlate(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if q.ID != p.ID || q.Type != p.Type || q.Mnfr != p.Mnfr {
+		t.Errorf("expecting plate %s of type %s but found %s of type %s", p.ID, p.Type, q.ID, q.Type)
+	}
+	if q.Barcode != p.Barcode {
+		t.Errorf("expecting barcode %q but found %q", p.Barcode, q.Barcode)
+	}
+	if e, f := p.Welltype.Extra, q.Welltype.Extra; len(e) != len(f) {
+		t.Errorf("expecting well extras %v but found %v", e, f)
+	}
+	if f, ok := q.IsConstrainedOn("Pipetmax"); !ok || !reflect.DeepEqual(f, []string{"position_1"}) {
+		t.Errorf("expecting constraint to position_1 but found %v", f)
+	}
+
+	w := q.WellAt(wtype.MakeWellCoords("B3"))
+	if w.WContents.CName != "water" || w.WContents.Vol != 50 {
+		t.Errorf("expecting 50ul of water in B3 but found %v", w.WContents)
+	}
+	for _, crds := range []string{"A1", "B2", "C3"} {
+		if !q.WellAt(wtype.MakeWellCoords(crds)).Empty() {
+			t.Errorf("expecting %s to be empty", crds)
+		}
+	}
+}
+
+func TestQueryConversion(t *testing.T) {
+	notSBS := false
+	pq := inventory.PlateQuery{
+		Wells:         96,
+		MinWellVolume: wunit.NewVolume(100, "ul"),
+		MaxWellVolume: wunit.NewVolume(500, "ul"),
+		Manufacturer:  "ACME",
+		SBS:           &notSBS,
+		Skirt:         wtype.SkirtSemi,
+		MinHeight:     10,
+		MaxHeight:     20,
+	}
+	if e, f := pq.String(), PlateQueryFromRequest(PlatesRequestFromQuery(pq)).String(); e != f {
+		t.Errorf("expecting plate query %s but found %s", e, f)
+	}
+	if q := PlateQueryFromRequest(PlatesRequestFromQuery(inventory.PlateQuery{})); q.SBS != nil || !q.MinWellVolume.IsNil() {
+		t.Errorf("expecting empty plate query but found %s", q)
+	}
+
+	tq := inventory.TipboxQuery{
+		MinTipVolume: wunit.NewVolume(20, "ul"),
+		Manufacturer: "ACME",
+	}
+	if e, f := tq.String(), TipboxQueryFromRequest(TipboxesRequestFromQuery(tq)).String(); e != f {
+		t.Errorf("expecting tipbox query %s but found %s", e, f)
+	}
+}
@@ -0,0 +1,150 @@
+// inventory/remoteinventory/server.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+// Package remoteinventory shares one inventory among several machines over
+// gRPC: a Server serves any inventory, such as one made by fileinventory,
+// and a Client is an inventory.Inventory that asks a server for its items.
+//
+// Plates travel as api.Plate items made from an api.PlateType item. Other
+// items, and the components in wells, carry the JSON of their wtype in their
+// metadata as well, so they arrive exactly as they left.
+package remoteinventory
+
+import (
+	"net"
+
+	api "github.com/antha-lang/antha/api/v1"
+	"github.com/antha-lang/antha/inventory"
+	pb "github.com/antha-lang/antha/inventory/antha_inventory_v1"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	_ pb.InventoryServer = &Server{}
+)
+
+// A Server serves the items of an inventory
+type Server struct {
+	inv inventory.Inventory
+}
+
+// NewServer returns a server of the items of inv
+func NewServer(inv inventory.Inventory) *Server {
+	return &Server{inv: inv}
+}
+
+// Serve serves inv on lis until it fails
+func Serve(lis net.Listener, inv inventory.Inventory) error {
+	s := grpc.NewServer()
+	pb.RegisterInventoryServer(s, NewServer(inv))
+	return s.Serve(lis)
+}
+
+// withInventory returns ctx with the server's inventory in it, for
+// inventories that find themselves there
+func (s *Server) withInventory(ctx context.Context) context.Context {
+	return inventory.NewContext(ctx, s.inv)
+}
+
+// toStatus turns inventory errors into gRPC ones
+func toStatus(err error) error {
+	if err == inventory.ErrUnknownType {
+		return grpc.Errorf(codes.NotFound, "%s", err)
+	}
+	return err
+}
+
+// NewComponent returns a new component of the requested type
+func (s *Server) NewComponent(ctx context.Context, req *pb.NewItemRequest) (*api.InventoryItem, error) {
+	c, err := s.inv.NewComponent(s.withInventory(ctx), req.GetType())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return ComponentFromLHComponent(c)
+}
+
+// NewPlate returns a new plate of the requested type
+func (s *Server) NewPlate(ctx context.Context, req *pb.NewItemRequest) (*api.InventoryItem, error) {
+	p, err := s.inv.NewPlate(s.withInventory(ctx), req.GetType())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return PlateFromLHPlate(p)
+}
+
+// NewTipbox returns a new tipbox of the requested type
+func (s *Server) NewTipbox(ctx context.Context, req *pb.NewItemRequest) (*api.InventoryItem, error) {
+	tb, err := s.inv.NewTipbox(s.withInventory(ctx), req.GetType())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return TipboxFromLHTipbox(tb)
+}
+
+// NewTipwaste returns a new tipwaste of the requested type
+func (s *Server) NewTipwaste(ctx context.Context, req *pb.NewItemRequest) (*api.InventoryItem, error) {
+	tw, err := s.inv.NewTipwaste(s.withInventory(ctx), req.GetType())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return TipwasteFromLHTipwaste(tw)
+}
+
+// ListPlates returns a plate of every type in the inventory meeting the
+// request's constraints
+func (s *Server) ListPlates(ctx context.Context, req *pb.ListPlatesRequest) (*pb.ListPlatesReply, error) {
+	plates, err := s.inv.Plates(s.withInventory(ctx), PlateQueryFromRequest(req))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	reply := &pb.ListPlatesReply{}
+	for _, p := range plates {
+		item, err := PlateFromLHPlate(p)
+		if err != nil {
+			return nil, err
+		}
+		reply.Items = append(reply.Items, item)
+	}
+	return reply, nil
+}
+
+// ListTipboxes returns a tipbox of every type in the inventory meeting the
+// request's constraints
+func (s *Server) ListTipboxes(ctx context.Context, req *pb.ListTipboxesRequest) (*pb.ListTipboxesReply, error) {
+	tbs, err := s.inv.Tipboxes(s.withInventory(ctx), TipboxQueryFromRequest(req))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	reply := &pb.ListTipboxesReply{}
+	for _, tb := range tbs {
+		item, err := TipboxFromLHTipbox(tb)
+		if err != nil {
+			return nil, err
+		}
+		reply.Items = append(reply.Items, item)
+	}
+	return reply, nil
+}
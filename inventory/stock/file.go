@@ -0,0 +1,239 @@
+// inventory/stock/file.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package stock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/ghodss/yaml"
+)
+
+// A File is how a ledger is kept on disk, as JSON or YAML. Amounts are
+// written as a number and a unit, like "500ml" or "2.5 g", and times in RFC
+// 3339 format, like "2018-06-01T00:00:00Z"
+type File struct {
+	Lots         []FileLot         `json:"lots"`
+	Reservations []FileReservation `json:"reservations,omitempty"`
+}
+
+// A FileLot is a Lot in a File
+type FileLot struct {
+	ID        string     `json:"id"`
+	Component string     `json:"component"`
+	Volume    string     `json:"volume,omitempty"`
+	Mass      string     `json:"mass,omitempty"`
+	Expiry    *time.Time `json:"expiry,omitempty"`
+	Location  string     `json:"location,omitempty"`
+}
+
+// A FileReservation is a Reservation in a File
+type FileReservation struct {
+	ID        string    `json:"id"`
+	Holder    string    `json:"holder"`
+	Lot       string    `json:"lot"`
+	Component string    `json:"component"`
+	Volume    string    `json:"volume"`
+	Created   time.Time `json:"created"`
+}
+
+// formatAmount writes an amount in full, where ToString would round it
+func formatAmount(m wunit.Measurement) string {
+	return strconv.FormatFloat(m.RawValue(), 'g', -1, 64) + m.Unit().PrefixedSymbol()
+}
+
+// parseAmount splits an amount into its value and a unit of the given
+// dimension
+func parseAmount(dim, s string) (float64, string, error) {
+	value, unit := wunit.SplitValueAndUnit(strings.TrimSpace(s))
+	if _, ok := wunit.UnitMap[dim][unit]; !ok {
+		return 0, "", fmt.Errorf("cannot parse %q as a %s", s, strings.ToLower(dim))
+	}
+	if value < 0 {
+		return 0, "", fmt.Errorf("%s %q is negative", strings.ToLower(dim), s)
+	}
+	return value, unit, nil
+}
+
+func parseVolume(s string) (wunit.Volume, error) {
+	value, unit, err := parseAmount("Volume", s)
+	if err != nil {
+		return wunit.Volume{}, err
+	}
+	return wunit.NewVolume(value, unit), nil
+}
+
+func parseMass(s string) (wunit.Mass, error) {
+	value, unit, err := parseAmount("Mass", s)
+	if err != nil {
+		return wunit.Mass{}, err
+	}
+	return wunit.NewMass(value, unit), nil
+}
+
+// Ledger returns the ledger a file describes
+func (f *File) Ledger() (*Ledger, error) {
+	l, err := NewLedger()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fl := range f.Lots {
+		lot := &Lot{
+			ID:        fl.ID,
+			Component: fl.Component,
+			Expiry:    fl.Expiry,
+			Location:  fl.Location,
+		}
+		if len(fl.Volume) != 0 {
+			v, err := parseVolume(fl.Volume)
+			if err != nil {
+				return nil, fmt.Errorf("lot %s: %s", fl.ID, err)
+			}
+			lot.Volume = &v
+		}
+		if len(fl.Mass) != 0 {
+			m, err := parseMass(fl.Mass)
+			if err != nil {
+				return nil, fmt.Errorf("lot %s: %s", fl.ID, err)
+			}
+			lot.Mass = &m
+		}
+		if err := l.AddLot(lot); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, fr := range f.Reservations {
+		lot, ok := l.lots[fr.Lot]
+		if !ok {
+			return nil, fmt.Errorf("reservation %s is of unknown lot %s", fr.ID, fr.Lot)
+		} else if lot.Volume == nil {
+			return nil, fmt.Errorf("reservation %s is of lot %s, which has no volume", fr.ID, fr.Lot)
+		}
+		v, err := parseVolume(fr.Volume)
+		if err != nil {
+			return nil, fmt.Errorf("reservation %s: %s", fr.ID, err)
+		}
+		l.reservations = append(l.reservations, &Reservation{
+			ID:        fr.ID,
+			Holder:    fr.Holder,
+			Lot:       fr.Lot,
+			Component: fr.Component,
+			Volume:    v,
+			Created:   fr.Created,
+		})
+	}
+
+	return l, nil
+}
+
+// File returns how a ledger is kept on disk
+func (l *Ledger) File() *File {
+	f := &File{}
+	for _, lot := range l.Lots("") {
+		fl := FileLot{
+			ID:        lot.ID,
+			Component: lot.Component,
+			Expiry:    lot.Expiry,
+			Location:  lot.Location,
+		}
+		if lot.Volume != nil {
+			fl.Volume = formatAmount(lot.Volume)
+		}
+		if lot.Mass != nil {
+			fl.Mass = formatAmount(lot.Mass)
+		}
+		f.Lots = append(f.Lots, fl)
+	}
+
+	for _, r := range l.Reservations("") {
+		f.Reservations = append(f.Reservations, FileReservation{
+			ID:        r.ID,
+			Holder:    r.Holder,
+			Lot:       r.Lot,
+			Component: r.Component,
+			Volume:    formatAmount(r.Volume),
+			Created:   r.Created,
+		})
+	}
+	return f
+}
+
+func isYAML(filename string) bool {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// Load reads a ledger from a JSON or YAML file, which is taken to be YAML if
+// its name ends in .yaml or .yml
+func Load(filename string) (*Ledger, error) {
+	bs, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if isYAML(filename) {
+		if bs, err = yaml.YAMLToJSON(bs); err != nil {
+			return nil, fmt.Errorf("%s: %s", filename, err)
+		}
+	}
+
+	var f File
+	if err := json.Unmarshal(bs, &f); err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+
+	l, err := f.Ledger()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	return l, nil
+}
+
+// Save writes a ledger to a file in the format Load reads
+func (l *Ledger) Save(filename string) error {
+	f := l.File()
+
+	var bs []byte
+	var err error
+	if isYAML(filename) {
+		bs, err = yaml.Marshal(f)
+	} else {
+		bs, err = json.MarshalIndent(f, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filename, bs, 0644)
+}
@@ -0,0 +1,365 @@
+// inventory/stock/stock.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+// Package stock keeps a ledger of the physical stock of components: lots of
+// each, with the volume or mass on hand, their expiry and where they are
+// kept, and the reservations made against them by the plans of workflows.
+//
+// Where an inventory says what a component is, the ledger says how much of
+// it there really is. Plans reserve what their input wells will hold when
+// they are made, so that a run can stop before it starts, or order more, if
+// there is not enough of something.
+package stock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+const (
+	theCtxKey ctxKey = "stock"
+)
+
+type ctxKey string
+
+// NewContext returns a context with the given ledger
+func NewContext(ctx context.Context, l *Ledger) context.Context {
+	return context.WithValue(ctx, theCtxKey, l)
+}
+
+// GetLedger returns the ledger in a context, if there is one
+func GetLedger(ctx context.Context) (*Ledger, bool) {
+	l, ok := ctx.Value(theCtxKey).(*Ledger)
+	return l, ok && l != nil
+}
+
+// A Lot is an amount of one component bought or made at one time. Liquids
+// are counted by volume and solids by mass; only volume can be reserved by
+// plans
+type Lot struct {
+	ID        string        `json:"id"`
+	Component string        `json:"component"`
+	Volume    *wunit.Volume `json:"volume,omitempty"` // on hand
+	Mass      *wunit.Mass   `json:"mass,omitempty"`   // on hand
+	Expiry    *time.Time    `json:"expiry,omitempty"`
+	Location  string        `json:"location,omitempty"`
+}
+
+// Expired returns whether the lot is past its expiry at a time
+func (l *Lot) Expired(at time.Time) bool {
+	return l.Expiry != nil && !at.Before(*l.Expiry)
+}
+
+func (l *Lot) String() string {
+	var amount string
+	switch {
+	case l.Volume != nil:
+		amount = l.Volume.ToString()
+	case l.Mass != nil:
+		amount = l.Mass.ToString()
+	}
+	s := fmt.Sprintf("lot %s of %s %s", l.ID, amount, l.Component)
+	if len(l.Location) != 0 {
+		s += " in " + l.Location
+	}
+	return s
+}
+
+// A Reservation is a volume of a lot held for a plan
+type Reservation struct {
+	ID        string       `json:"id"`
+	Holder    string       `json:"holder"`
+	Lot       string       `json:"lot"`
+	Component string       `json:"component"`
+	Volume    wunit.Volume `json:"volume"`
+	Created   time.Time    `json:"created"`
+}
+
+func (r *Reservation) String() string {
+	return fmt.Sprintf("%s of %s from lot %s", r.Volume.ToString(), r.Component, r.Lot)
+}
+
+// A Shortfall is how much more of a component a plan needs than is
+// available
+type Shortfall struct {
+	Component string       `json:"component"`
+	Needed    wunit.Volume `json:"needed"`
+	Available wunit.Volume `json:"available"`
+}
+
+// Short returns how much more of the component is needed
+func (s Shortfall) Short() wunit.Volume {
+	v := wunit.CopyVolume(s.Needed)
+	v.Subtract(s.Available)
+	return v
+}
+
+func (s Shortfall) String() string {
+	return fmt.Sprintf("%s of %s (%s needed, %s available)", s.Short().ToString(), s.Component, s.Needed.ToString(), s.Available.ToString())
+}
+
+// Shortfalls are the shortfalls of a plan, which is an error if the plan is
+// to go ahead regardless
+type Shortfalls []Shortfall
+
+func (s Shortfalls) Error() string {
+	var lines []string
+	for _, sf := range s {
+		lines = append(lines, sf.String())
+	}
+	return "not enough stock: " + strings.Join(lines, "; ")
+}
+
+// A Ledger records the lots of stock and the reservations against them. It
+// is safe for concurrent use
+type Ledger struct {
+	lock         sync.Mutex
+	lots         map[string]*Lot
+	reservations []*Reservation
+}
+
+// NewLedger returns a ledger of the given lots
+func NewLedger(lots ...*Lot) (*Ledger, error) {
+	l := &Ledger{lots: make(map[string]*Lot, len(lots))}
+	for _, lot := range lots {
+		if err := l.AddLot(lot); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// AddLot records a new lot
+func (l *Ledger) AddLot(lot *Lot) error {
+	switch {
+	case len(lot.ID) == 0:
+		return fmt.Errorf("lot of %q has no id", lot.Component)
+	case len(lot.Component) == 0:
+		return fmt.Errorf("lot %s has no component", lot.ID)
+	case (lot.Volume == nil) == (lot.Mass == nil):
+		return fmt.Errorf("lot %s needs either a volume or a mass", lot.ID)
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if _, seen := l.lots[lot.ID]; seen {
+		return fmt.Errorf("lot %s already exists", lot.ID)
+	}
+	l.lots[lot.ID] = lot
+	return nil
+}
+
+// Lots returns the lots of a component, or of all components if component
+// is empty, in the order they are used: soonest to expire first
+func (l *Ledger) Lots(component string) []*Lot {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.lotsOf(component)
+}
+
+func (l *Ledger) lotsOf(component string) []*Lot {
+	var lots []*Lot
+	for _, lot := range l.lots {
+		if len(component) == 0 || lot.Component == component {
+			lots = append(lots, lot)
+		}
+	}
+	sort.Slice(lots, func(i, j int) bool {
+		a, b := lots[i], lots[j]
+		if a.Component != b.Component {
+			return a.Component < b.Component
+		}
+		// lots that expire go before those that don't
+		if (a.Expiry == nil) != (b.Expiry == nil) {
+			return b.Expiry == nil
+		}
+		if a.Expiry != nil && !a.Expiry.Equal(*b.Expiry) {
+			return a.Expiry.Before(*b.Expiry)
+		}
+		return a.ID < b.ID
+	})
+	return lots
+}
+
+// Reservations returns the reservations held by holder, or by anyone if
+// holder is empty
+func (l *Ledger) Reservations(holder string) []*Reservation {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var rs []*Reservation
+	for _, r := range l.reservations {
+		if len(holder) == 0 || r.Holder == holder {
+			rs = append(rs, r)
+		}
+	}
+	return rs
+}
+
+// tolerance is the smallest volume in ul the ledger minds about, so that
+// rounding in unit conversions doesn't leave crumbs of lots behind
+const tolerance = 1e-6
+
+func positive(v wunit.Volume) bool {
+	return v.ConvertToString("ul") > tolerance
+}
+
+// subtract takes b from a, returning a
+func subtract(a, b wunit.Volume) wunit.Volume {
+	a.Subtract(b)
+	return a
+}
+
+// free returns the volume of a lot not yet reserved
+func (l *Ledger) free(lot *Lot) wunit.Volume {
+	v := wunit.CopyVolume(*lot.Volume)
+	for _, r := range l.reservations {
+		if r.Lot == lot.ID {
+			v.Subtract(r.Volume)
+		}
+	}
+	return v
+}
+
+// Available returns the volume of a component on hand, unreserved and
+// unexpired at a time
+func (l *Ledger) Available(component string, at time.Time) wunit.Volume {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.available(component, at)
+}
+
+func (l *Ledger) available(component string, at time.Time) wunit.Volume {
+	v := wunit.NewVolume(0.0, "ul")
+	for _, lot := range l.lotsOf(component) {
+		if lot.Volume == nil || lot.Expired(at) {
+			continue
+		}
+		if f := l.free(lot); positive(f) {
+			v.Add(f)
+		}
+	}
+	return v
+}
+
+// Reserve reserves the volumes of components a holder needs, taking from the
+// lots soonest to expire first. As much as there is of each component is
+// reserved; the shortfalls say what is missing. Components of which there
+// are no lots at all are taken not to be tracked and are left out.
+func (l *Ledger) Reserve(holder string, needs map[string]wunit.Volume) ([]*Reservation, Shortfalls) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+
+	var components []string
+	for c := range needs {
+		components = append(components, c)
+	}
+	sort.Strings(components)
+
+	var rs []*Reservation
+	var short Shortfalls
+	for _, c := range components {
+		lots := l.lotsOf(c)
+		if len(lots) == 0 {
+			continue
+		}
+
+		need := wunit.CopyVolume(needs[c])
+		avail := l.available(c, now)
+		if positive(subtract(wunit.CopyVolume(need), avail)) {
+			short = append(short, Shortfall{Component: c, Needed: wunit.CopyVolume(need), Available: avail})
+		}
+
+		for _, lot := range lots {
+			if !positive(need) {
+				break
+			}
+			if lot.Volume == nil || lot.Expired(now) {
+				continue
+			}
+			free := l.free(lot)
+			if !positive(free) {
+				continue
+			}
+			take := wunit.CopyVolume(need)
+			if free.LessThan(take) {
+				take = free
+			}
+			r := &Reservation{
+				ID:        wtype.GetUUID(),
+				Holder:    holder,
+				Lot:       lot.ID,
+				Component: c,
+				Volume:    take,
+				Created:   now,
+			}
+			l.reservations = append(l.reservations, r)
+			rs = append(rs, r)
+			need.Subtract(take)
+		}
+	}
+
+	return rs, short
+}
+
+// Release cancels the reservations of a holder
+func (l *Ledger) Release(holder string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var keep []*Reservation
+	for _, r := range l.reservations {
+		if r.Holder != holder {
+			keep = append(keep, r)
+		}
+	}
+	l.reservations = keep
+}
+
+// Consume takes what a holder has reserved out of the lots it was reserved
+// from, once it has really been used
+func (l *Ledger) Consume(holder string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var keep []*Reservation
+	for _, r := range l.reservations {
+		if r.Holder != holder {
+			keep = append(keep, r)
+			continue
+		}
+		if lot, ok := l.lots[r.Lot]; ok && lot.Volume != nil {
+			lot.Volume.Subtract(r.Volume)
+		}
+	}
+	l.reservations = keep
+}
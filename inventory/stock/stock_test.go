@@ -0,0 +1,178 @@
+package stock
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+func volume(v float64, unit string) *wunit.Volume {
+	vol := wunit.NewVolume(v, unit)
+	return &vol
+}
+
+func makeLedger(t *testing.T) *Ledger {
+	now := time.Now()
+	yesterday := now.Add(-24 * time.Hour)
+	nextWeek := now.Add(7 * 24 * time.Hour)
+	nextMonth := now.Add(30 * 24 * time.Hour)
+
+	l, err := NewLedger(
+		&Lot{ID: "w1", Component: "water", Volume: volume(1, "ml")},
+		&Lot{ID: "w2", Component: "water", Volume: volume(500, "ul"), Expiry: &nextMonth},
+		&Lot{ID: "w3", Component: "water", Volume: volume(200, "ul"), Expiry: &nextWeek},
+		&Lot{ID: "b1", Component: "buffer", Volume: volume(50, "ul"), Expiry: &yesterday},
+		&Lot{ID: "b2", Component: "buffer", Volume: volume(100, "ul")},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func reserved(rs []*Reservation) map[string]float64 {
+	m := make(map[string]float64)
+	for _, r := range rs {
+		m[r.Lot] += r.Volume.ConvertToString("ul")
+	}
+	return m
+}
+
+func TestReserve(t *testing.T) {
+	l := makeLedger(t)
+
+	rs, short := l.Reserve("plan1", map[string]wunit.Volume{
+		"water":   wunit.NewVolume(900, "ul"),
+		"glucose": wunit.NewVolume(10, "ul"),
+	})
+	if len(short) != 0 {
+		t.Fatalf("expecting no shortfalls but found %s", short)
+	}
+	// soonest to expire first, and lots that never expire last
+	got := reserved(rs)
+	expected := map[string]float64{"w3": 200, "w2": 500, "w1": 200}
+	for lot, v := range expected {
+		if !near(got[lot], v) {
+			t.Errorf("expecting %gul of lot %s reserved but found %gul", v, lot, got[lot])
+		}
+	}
+	if len(got) != len(expected) {
+		t.Errorf("expecting reservations of %v but found %v", expected, got)
+	}
+
+	if v := l.Available("water", time.Now()).ConvertToString("ul"); !near(v, 800) {
+		t.Errorf("expecting 800ul of water left but found %gul", v)
+	}
+
+	// the expired buffer doesn't count
+	rs, short = l.Reserve("plan2", map[string]wunit.Volume{
+		"water":  wunit.NewVolume(1, "ml"),
+		"buffer": wunit.NewVolume(120, "ul"),
+	})
+	if len(short) != 2 {
+		t.Fatalf("expecting 2 shortfalls but found %s", short)
+	}
+	if s := short[0]; s.Component != "buffer" || !near(s.Short().ConvertToString("ul"), 20) {
+		t.Errorf("expecting to be 20ul short of buffer but found %s", s)
+	}
+	if s := short[1]; s.Component != "water" || !near(s.Short().ConvertToString("ul"), 200) {
+		t.Errorf("expecting to be 200ul short of water but found %s", s)
+	}
+	if got := reserved(rs); got["b1"] != 0 || !near(got["b2"], 100) || !near(got["w1"], 800) {
+		t.Errorf("expecting what there is to be reserved but found %v", got)
+	}
+
+	l.Release("plan2")
+	if n := len(l.Reservations("plan2")); n != 0 {
+		t.Errorf("expecting no reservations after release but found %d", n)
+	}
+	if n := len(l.Reservations("")); n != 3 {
+		t.Errorf("expecting 3 reservations left but found %d", n)
+	}
+
+	l.Consume("plan1")
+	if n := len(l.Reservations("")); n != 0 {
+		t.Errorf("expecting no reservations after consumption but found %d", n)
+	}
+	for _, lot := range l.Lots("water") {
+		if lot.ID == "w1" && !near(lot.Volume.ConvertToString("ul"), 800) {
+			t.Errorf("expecting 800ul left in lot w1 but found %s", lot.Volume.ToString())
+		}
+	}
+}
+
+func TestBadLots(t *testing.T) {
+	if _, err := NewLedger(&Lot{ID: "x", Component: "water"}); err == nil {
+		t.Error("expecting error for lot without an amount")
+	}
+	if _, err := NewLedger(&Lot{ID: "x", Component: "water", Volume: volume(1, "ul")}, &Lot{ID: "x", Component: "water", Volume: volume(1, "ul")}); err == nil {
+		t.Error("expecting error for duplicate lot")
+	}
+}
+
+func TestFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	yml := filepath.Join(dir, "stock.yaml")
+	if err := ioutil.WriteFile(yml, []byte(`
+lots:
+  - id: g1
+    component: glycerol
+    volume: 12.345 ml
+    expiry: 2100-01-01T00:00:00Z
+    location: cold room
+  - id: s1
+    component: sucrose
+    mass: 250g
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := Load(yml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, short := l.Reserve("plan", map[string]wunit.Volume{"glycerol": wunit.NewVolume(1.5, "ul")}); len(short) != 0 {
+		t.Fatalf("expecting no shortfalls but found %s", short)
+	}
+
+	js := filepath.Join(dir, "stock.json")
+	if err := l.Save(js); err != nil {
+		t.Fatal(err)
+	}
+	l2, err := Load(js)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := l2.Available("glycerol", time.Now()).ConvertToString("ul"); !near(v, 12343.5) {
+		t.Errorf("expecting 12343.5ul of glycerol available but found %gul", v)
+	}
+	if lots := l2.Lots("sucrose"); len(lots) != 1 || lots[0].Mass == nil || lots[0].Mass.ConvertToString("g") != 250 {
+		t.Errorf("expecting 250g of sucrose but found %v", lots)
+	}
+	if rs := l2.Reservations("plan"); len(rs) != 1 || rs[0].Lot != "g1" {
+		t.Errorf("expecting reservation of lot g1 but found %v", rs)
+	}
+
+	bad := filepath.Join(dir, "bad.yaml")
+	if err := ioutil.WriteFile(bad, []byte("lots: [{id: x, component: water, volume: 5 kg}]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(bad); err == nil {
+		t.Error("expecting error for volume in kg")
+	}
+}
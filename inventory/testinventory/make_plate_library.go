@@ -196,39 +196,49 @@ func makeBasicPlates() (plates []*wtype.LHPlate) {
 	afs := string(afb)
 
 	// pcr plate with cooler
+	//
+	// Only the pcr plates say what skirt they have; the other plates
+	// leave it empty, so searches for a skirt never find them
 	cone := wtype.NewShape("cylinder", "mm", 5.5, 5.5, 15)
 
 	pcrplatewell := wtype.NewLHWell("pcrplate", "", "", "ul", 200, 5, cone, wtype.LHWBU, 5.5, 5.5, 15, 1.4, "mm")
 	pcrplatewell.SetAfVFunc(afs)
 
 	plate = wtype.NewLHPlate("pcrplate_with_cooler", "Unknown", 8, 12, 15.5, "mm", pcrplatewell, 9, 9, 0.0, 0.0, coolerheight+0.5)
+	plate.Skirt = wtype.SkirtNone
 	plates = append(plates, plate)
 
 	// pcr plate with isofreeze_cooler
 	plate = wtype.NewLHPlate("pcrplate_with_isofreeze_cooler", "Unknown", 8, 12, 15.5, "mm", pcrplatewell, 9, 9, 0.0, 0.0, isofreezecoolerheight)
+	plate.Skirt = wtype.SkirtNone
 	plates = append(plates, plate)
 
 	// pcr plate skirted with isofreeze_cooler (to be used only with transformations (into 10-20ul) as plate not fully secured in the cooler)
 	plate = wtype.NewLHPlate("pcrplate_skirted_with_isofreeze_cooler", "Unknown", 8, 12, 15.5, "mm", pcrplatewell, 9, 9, 0.0, 0.0, isofreezecoolerheight+2.0)
+	plate.Skirt = wtype.SkirtFull
 	plates = append(plates, plate)
 
 	// pcr plate with 496rack
 
 	plate = wtype.NewLHPlate("pcrplate_with_496rack", "Unknown", 8, 12, 15.5, "mm", pcrplatewell, 9, 9, 0.0, 0.0, pcrtuberack496)
+	plate.Skirt = wtype.SkirtNone
 	plates = append(plates, plate)
 
 	// pcr plate semi-skirted with 496rack
 
 	plate = wtype.NewLHPlate("pcrplate_semi_skirted_with_496rack", "Unknown", 8, 12, 15.5, "mm", pcrplatewell, 9, 9, 0.0, 0.0, pcrtuberack496+1.0)
+	plate.Skirt = wtype.SkirtSemi
 	plates = append(plates, plate)
 
 	// 0.2ml strip tubes with 496rack
 
 	plate = wtype.NewLHPlate("strip_tubes_0.2ml_with_496rack", "Unknown", 8, 12, 15.5, "mm", pcrplatewell, 9, 9, 0.0, 0.0, pcrtuberack496-2.5)
+	plate.Skirt = wtype.SkirtNone
 	plates = append(plates, plate)
 
 	// pcr plate skirted
 	plate = wtype.NewLHPlate("pcrplate_skirted", "Unknown", 8, 12, 15.5, "mm", pcrplatewell, 9, 9, 0.0, 0.0, 0.636)
+	plate.Skirt = wtype.SkirtFull
 	plates = append(plates, plate)
 
 	pcrplatewellinc := wtype.NewLHWell("pcrplate", "", "", "ul", 200, 5, cone, wtype.LHWBU, 5.5, 5.5, 1.55, 1.4, "mm")
@@ -57,6 +57,7 @@ func makeTipboxes() (tipboxes []*wtype.LHTipbox) {
 	w.Extra["InnerW"] = 5.6
 	w.Extra["Tipeffectiveheight"] = 44.7
 	tip = wtype.NewLHTip("gilson", "Gilson200", 10.0, 200.0, "ul")
+	tip.Accuracy = gilson200Accuracy()
 	tb = wtype.NewLHTipbox(8, 12, 60.13, "Gilson", "DF200 Tip Rack (PIPETMAX 8x200)", tip, w, 9.0, 9.0, 0.0, 0.0, 24.78)
 	tipboxes = append(tipboxes, tb)
 
@@ -65,6 +66,7 @@ func makeTipboxes() (tipboxes []*wtype.LHTipbox) {
 	w.Extra["InnerW"] = 5.5
 	w.Extra["Tipeffectiveheight"] = 34.6
 	tip = wtype.NewLHTip("gilson", "Gilson20", 0.5, 20.0, "ul")
+	tip.Accuracy = gilson20Accuracy()
 	tb = wtype.NewLHTipbox(8, 12, 60.13, "Gilson", "DL10 Tip Rack (PIPETMAX 8x20)", tip, w, 9.0, 9.0, 0.0, 0.0, 28.93)
 	tipboxes = append(tipboxes, tb)
 
@@ -73,6 +75,26 @@ func makeTipboxes() (tipboxes []*wtype.LHTipbox) {
 	return tipboxes
 }
 
+// accuracy and CV (%) from the specifications of the equivalent PIPETMAN
+// models, P20 and P200
+func gilson20Accuracy() wtype.AccuracyCurve {
+	return wtype.AccuracyCurve{
+		{Volume: 2.0, Accuracy: 5.0, CV: 1.5},
+		{Volume: 5.0, Accuracy: 2.0, CV: 0.8},
+		{Volume: 10.0, Accuracy: 1.0, CV: 0.5},
+		{Volume: 20.0, Accuracy: 1.0, CV: 0.3},
+	}
+}
+
+func gilson200Accuracy() wtype.AccuracyCurve {
+	return wtype.AccuracyCurve{
+		{Volume: 20.0, Accuracy: 2.5, CV: 1.0},
+		{Volume: 50.0, Accuracy: 1.0, CV: 0.4},
+		{Volume: 100.0, Accuracy: 0.8, CV: 0.25},
+		{Volume: 200.0, Accuracy: 0.8, CV: 0.15},
+	}
+}
+
 func makeTecanTipBoxes() []*wtype.LHTipbox {
 	shp := wtype.NewShape("cylinder", "mm", 7.3, 7.3, 51.2)
 
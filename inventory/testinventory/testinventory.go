@@ -48,9 +48,36 @@ func (i *testInventory) NewTipwaste(ctx context.Context, typ string) (*wtype.LHT
 	return tw.Dup(), nil
 }
 
-func (i *testInventory) XXXGetPlates(ctx context.Context) ([]*wtype.LHPlate, error) {
-	plates := GetPlates(ctx)
-	return plates, nil
+func (i *testInventory) Plates(ctx context.Context, q inventory.PlateQuery) ([]*wtype.LHPlate, error) {
+	var ps []*wtype.LHPlate
+	for _, p := range i.plateByType {
+		if q.Matches(p) {
+			ps = append(ps, p.Dup())
+		}
+	}
+
+	sort.Slice(ps, func(a, b int) bool {
+		return ps[a].Type < ps[b].Type
+	})
+
+	return ps, nil
+}
+
+func (i *testInventory) Tipboxes(ctx context.Context, q inventory.TipboxQuery) ([]*wtype.LHTipbox, error) {
+	var tbs []*wtype.LHTipbox
+	for typ, tb := range i.tipboxByType {
+		// tipboxes are also found by the type of their tips
+		if typ != tb.Type || !q.Matches(tb) {
+			continue
+		}
+		tbs = append(tbs, tb.Dup())
+	}
+
+	sort.Slice(tbs, func(a, b int) bool {
+		return tbs[a].Type < tbs[b].Type
+	})
+
+	return tbs, nil
 }
 
 // NewContext creates a new test inventory context
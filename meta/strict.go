@@ -0,0 +1,156 @@
+package meta
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// UnmarshalJSONStrict unmarshals data into obj as json.Unmarshal does but
+// returns an error for any object key which obj has no field for, rather
+// than silently dropping it. Values obj unmarshals itself are not checked.
+func UnmarshalJSONStrict(data []byte, obj interface{}) error {
+	if err := json.Unmarshal(data, obj); err != nil {
+		return err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return err
+	}
+
+	return checkFields(generic, reflect.TypeOf(obj), "")
+}
+
+// customUnmarshal is whether values of typ decide for themselves what JSON
+// they accept
+func customUnmarshal(typ reflect.Type) bool {
+	for _, t := range []reflect.Type{typ, reflect.PtrTo(typ)} {
+		if t.Implements(jsonUnmarshalerType) || t.Implements(textUnmarshalerType) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonFields returns the types of the fields of a struct by their JSON
+// names, including those of embedded structs
+func jsonFields(typ reflect.Type, fields map[string]reflect.Type) {
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		ft := f.Type
+		if f.Anonymous && name == "" {
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				jsonFields(ft, fields)
+				continue
+			}
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		if _, seen := fields[name]; !seen {
+			fields[name] = f.Type
+		}
+	}
+}
+
+// fieldType finds the field key unmarshals to which, as in encoding/json,
+// is the one with exactly that name or else any whose name differs only in
+// case
+func fieldType(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if t, ok := fields[key]; ok {
+		return t, true
+	}
+	for name, t := range fields {
+		if strings.EqualFold(name, key) {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+func checkFields(v interface{}, typ reflect.Type, path string) error {
+	if customUnmarshal(typ) {
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		return checkFields(v, typ.Elem(), path)
+
+	case reflect.Struct:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		fields := make(map[string]reflect.Type)
+		jsonFields(typ, fields)
+
+		for key, value := range obj {
+			ft, ok := fieldType(fields, key)
+			if !ok {
+				if path == "" {
+					return fmt.Errorf("json: unknown field %q", key)
+				}
+				return fmt.Errorf("json: unknown field %q in %s", key, path)
+			}
+			if err := checkFields(value, ft, join(path, key)); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for key, value := range obj {
+			if err := checkFields(value, typ.Elem(), join(path, key)); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		arr, ok := v.([]interface{})
+		if !ok {
+			return nil
+		}
+		for i, value := range arr {
+			if err := checkFields(value, typ.Elem(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
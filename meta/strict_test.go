@@ -0,0 +1,64 @@
+package meta
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type strictInner struct {
+	Name  string `json:"name"`
+	Count int    `json:"count,omitempty"`
+	Skip  string `json:"-"`
+}
+
+type strictEmbedded struct {
+	Embedded string `json:"embedded"`
+}
+
+type strictOuter struct {
+	strictEmbedded
+	Inner   strictInner            `json:"inner"`
+	Inners  []strictInner          `json:"inners"`
+	ByName  map[string]strictInner `json:"byName"`
+	Pointer *strictInner           `json:"pointer"`
+	Any     map[string]interface{} `json:"any"`
+	When    time.Time              `json:"when"`
+	Plain   string
+}
+
+func TestUnmarshalJSONStrict(t *testing.T) {
+	good := `{
+		"embedded": "e",
+		"inner": {"name": "a", "count": 0},
+		"inners": [{"name": "b"}],
+		"byName": {"c": {"NAME": "c"}},
+		"pointer": {"name": "d"},
+		"any": {"anything": {"goes": true}},
+		"when": "2017-01-01T00:00:00Z",
+		"plain": "p"
+	}`
+
+	var o strictOuter
+	if err := UnmarshalJSONStrict([]byte(good), &o); err != nil {
+		t.Fatal(err)
+	}
+
+	if o.Embedded != "e" || o.Inners[0].Name != "b" || o.ByName["c"].Name != "c" || o.Pointer.Name != "d" || o.Plain != "p" {
+		t.Errorf("not unmarshalled: %+v", o)
+	}
+
+	for bad, expected := range map[string]string{
+		`{"colour": 1}`:                         `json: unknown field "colour"`,
+		`{"inner": {"nmae": "a"}}`:              `json: unknown field "nmae" in inner`,
+		`{"inners": [{}, {"Skip": "x"}]}`:       `json: unknown field "Skip" in inners[1]`,
+		`{"byName": {"c": {"counts": 1}}}`:      `json: unknown field "counts" in byName.c`,
+		`{"pointer": {"name": "d", "x": null}}`: `json: unknown field "x" in pointer`,
+	} {
+		var o strictOuter
+		err := UnmarshalJSONStrict([]byte(bad), &o)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("%s: expected error %q, got %v", bad, expected, err)
+		}
+	}
+}
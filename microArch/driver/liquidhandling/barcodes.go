@@ -0,0 +1,112 @@
+// /anthalib/driver/liquidhandling/barcodes.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package liquidhandling
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/microArch/driver"
+)
+
+// A BarcodeScanner is a driver which can read the barcodes of the labware
+// on its deck
+type BarcodeScanner interface {
+	// ScanBarcodes returns the barcode read at each deck position; positions
+	// where nothing was read may be left out
+	ScanBarcodes() (map[string]string, driver.CommandStatus)
+}
+
+// Barcodes returns the barcode of each plate and tipbox on the deck which
+// has one, by position
+func (lhp *LHProperties) Barcodes() map[string]string {
+	ret := make(map[string]string)
+	for pos, id := range lhp.PosLookup {
+		var barcode string
+		switch lw := lhp.PlateLookup[id].(type) {
+		case *wtype.LHPlate:
+			barcode = lw.Barcode
+		case *wtype.LHTipbox:
+			barcode = lw.Barcode
+		}
+		if barcode != "" {
+			ret[pos] = barcode
+		}
+	}
+	return ret
+}
+
+// A BarcodeMismatch is a deck position where the barcode scanned is not the
+// one planned. Found is empty if nothing was scanned there.
+type BarcodeMismatch struct {
+	Position string
+	Expected string
+	Found    string
+}
+
+func (m BarcodeMismatch) String() string {
+	if m.Found == "" {
+		return fmt.Sprintf("position %s: expecting barcode %s but found none", m.Position, m.Expected)
+	}
+	return fmt.Sprintf("position %s: expecting barcode %s but found %s", m.Position, m.Expected, m.Found)
+}
+
+// BarcodeMismatches is the error returned when labware on the deck is not
+// what was planned
+type BarcodeMismatches []BarcodeMismatch
+
+func (ms BarcodeMismatches) Error() string {
+	var s []string
+	for _, m := range ms {
+		s = append(s, m.String())
+	}
+	return fmt.Sprintf("deck does not match plan: %s", strings.Join(s, "; "))
+}
+
+// VerifyBarcodes compares the barcodes scanned at each deck position with
+// those expected there, returning BarcodeMismatches if any expected
+// barcode was not scanned. Barcodes scanned at positions where none is
+// expected are ignored.
+func VerifyBarcodes(expected, scanned map[string]string) error {
+	var ms BarcodeMismatches
+	for pos, barcode := range expected {
+		if found := scanned[pos]; found != barcode {
+			ms = append(ms, BarcodeMismatch{
+				Position: pos,
+				Expected: barcode,
+				Found:    found,
+			})
+		}
+	}
+
+	if len(ms) == 0 {
+		return nil
+	}
+
+	sort.Slice(ms, func(i, j int) bool {
+		return ms[i].Position < ms[j].Position
+	})
+	return ms
+}
@@ -0,0 +1,61 @@
+package liquidhandling
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/testinventory"
+)
+
+func TestBarcodes(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	robot, err := makeTestGilson(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := inventory.NewPlate(ctx, "pcrplate_skirted_riser40")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Barcode = "P000123"
+	if err := robot.AddPlate("position_4", p); err != nil {
+		t.Fatal(err)
+	}
+
+	tb, err := inventory.NewTipbox(ctx, "DF200 Tip Rack (PIPETMAX 8x200)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tb.Barcode = "T000456"
+	if !robot.AddTipBoxTo("position_5", tb) {
+		t.Fatal("cannot add tipbox")
+	}
+
+	// barcodes are carried through copies of the deck
+	expected := map[string]string{"position_4": "P000123", "position_5": "T000456"}
+	if f := robot.Dup().Barcodes(); !reflect.DeepEqual(expected, f) {
+		t.Errorf("expecting barcodes %v but found %v", expected, f)
+	}
+
+	scanned := map[string]string{"position_4": "P000123", "position_5": "T000456", "position_9": "X"}
+	if err := VerifyBarcodes(expected, scanned); err != nil {
+		t.Error(err)
+	}
+
+	scanned = map[string]string{"position_4": "P000999"}
+	err = VerifyBarcodes(expected, scanned)
+	ms, ok := err.(BarcodeMismatches)
+	if !ok || len(ms) != 2 {
+		t.Fatalf("expecting 2 mismatches but found %v", err)
+	}
+	if e := (BarcodeMismatch{Position: "position_4", Expected: "P000123", Found: "P000999"}); ms[0] != e {
+		t.Errorf("expecting %v but found %v", e, ms[0])
+	}
+	if ms[1].Position != "position_5" || ms[1].Found != "" {
+		t.Errorf("expecting nothing found at position_5 but found %v", ms[1])
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	"github.com/antha-lang/antha/antha/anthalib/wunit"
 	"math"
+	"strings"
 )
 
 // it would probably make more sense for this to be a method of the robot
@@ -93,20 +94,168 @@ func (sc DefaultChannelScoreFunc) ScoreChannel(vol wunit.Volume, lhcp *wtype.LHC
 	return score
 }
 
+// AccuracyChannelScoreFunc chooses the head, adaptor and tip combination
+// with the smallest expected error in the volume moved, from the accuracy
+// curves of each. Combinations without any accuracy data are scored as
+// DefaultChannelScoreFunc does, which is always below the score of any
+// combination whose error is known
+type AccuracyChannelScoreFunc struct {
+}
+
+func (sc AccuracyChannelScoreFunc) ScoreCombinedChannel(vol wunit.Volume, head *wtype.LHHead, adaptor *wtype.LHAdaptor, tip *wtype.LHTip) ChannelScore {
+	lhcp := head.Params.MergeWithTip(tip)
+
+	if lhcp.Minvol.GreaterThanRounded(vol, 1) {
+		return 0
+	}
+
+	err, _, ok := ExpectedError(vol, head, adaptor, tip)
+
+	if !ok {
+		return DefaultChannelScoreFunc{}.ScoreCombinedChannel(vol, head, adaptor, tip)
+	}
+
+	// DefaultChannelScoreFunc scores are at most 1
+	return ChannelScore(1.0 + 1.0/(1.0+err))
+}
+
+// ErrorBudgetChannelScoreFunc chooses the quickest head, adaptor and tip
+// combination, that needing fewest movements, whose expected error is no more
+// than MaxError percent. If none is good enough it chooses the most accurate
+type ErrorBudgetChannelScoreFunc struct {
+	MaxError float64
+}
+
+func (sc ErrorBudgetChannelScoreFunc) ScoreCombinedChannel(vol wunit.Volume, head *wtype.LHHead, adaptor *wtype.LHAdaptor, tip *wtype.LHTip) ChannelScore {
+	acc := AccuracyChannelScoreFunc{}.ScoreCombinedChannel(vol, head, adaptor, tip)
+
+	if acc == 0 {
+		return 0
+	}
+
+	err, n, ok := ExpectedError(vol, head, adaptor, tip)
+
+	if !ok || err > sc.MaxError {
+		// always worse than anything within budget
+		return acc
+	}
+
+	// accuracy scores are at most 2 so within budget scores are above
+	// that, fewer movements first then accuracy to break ties
+	return ChannelScore(2.0+1.0/float64(n)) + acc/1000.0
+}
+
+// ChannelScoreFuncFor returns the ChannelScoreFunc called name: "default",
+// "accuracy" or "errorbudget", the last with maxError as its budget. An
+// empty name gives nil, meaning the default
+func ChannelScoreFuncFor(name string, maxError float64) (ChannelScoreFunc, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case "default":
+		return DefaultChannelScoreFunc{}, nil
+	case "accuracy":
+		return AccuracyChannelScoreFunc{}, nil
+	case "errorbudget":
+		if maxError <= 0.0 {
+			return nil, fmt.Errorf("channel scorer errorbudget needs a maximum error greater than zero")
+		}
+		return ErrorBudgetChannelScoreFunc{MaxError: maxError}, nil
+	default:
+		return nil, fmt.Errorf("unknown channel scorer %q: expecting default, accuracy or errorbudget", name)
+	}
+}
+
+// ExpectedError estimates the error in percent of moving vol with the given
+// head, adaptor and tip combination, along with the number of movements
+// needed. Systematic and random errors from each part are combined in
+// quadrature; random errors shrink as the square root of the number of
+// movements. ok is false if none of the parts has an accuracy curve
+func ExpectedError(vol wunit.Volume, head *wtype.LHHead, adaptor *wtype.LHAdaptor, tip *wtype.LHTip) (err float64, movements int, ok bool) {
+	lhcp := head.Params.MergeWithTip(tip)
+
+	movements = 1
+	if mx := lhcp.Maxvol.ConvertTo(vol.Unit()); mx > 0.0 {
+		movements = int(math.Ceil(vol.RawValue()/mx - 1e-9))
+	}
+	if movements < 1 {
+		movements = 1
+	}
+
+	per := wunit.NewVolume(vol.RawValue()/float64(movements), vol.Unit().PrefixedSymbol())
+
+	curves := []wtype.AccuracyCurve{head.Params.Accuracy, tip.Accuracy}
+	if adaptor != nil && adaptor.Params != nil {
+		curves = append(curves, adaptor.Params.Accuracy)
+	}
+
+	var acc2, cv2 float64
+
+	for _, c := range curves {
+		a, cv, has := c.At(per)
+		if !has {
+			continue
+		}
+		ok = true
+		acc2 += a * a
+		cv2 += cv * cv
+	}
+
+	return math.Sqrt(acc2 + cv2/float64(movements)), movements, ok
+}
+
+// PolicyTipTypes returns the tip types the policy permits, or nil if any of
+// those loaded may be used
+func PolicyTipTypes(pol wtype.LHPolicy) []string {
+	var ret []string
+	for _, tt := range strings.Split(SafeGetString(pol, "TIP_TYPES"), ",") {
+		tt = strings.TrimSpace(tt)
+		if tt != "" {
+			ret = append(ret, tt)
+		}
+	}
+	return ret
+}
+
 func ChooseChannel(vol wunit.Volume, prms *LHProperties) (*wtype.LHChannelParameter, *wtype.LHTip) {
+	return ChooseChannelWithPolicy(vol, prms, nil)
+}
+
+// ChooseChannelWithPolicy chooses the best channel and tip for moving vol
+// from those loaded, considering only the tip types the policy permits
+func ChooseChannelWithPolicy(vol wunit.Volume, prms *LHProperties, pol wtype.LHPolicy) (*wtype.LHChannelParameter, *wtype.LHTip) {
+	headchosen, tipchosen := chooseHeadAndTip(vol, prms, pol)
+
+	if headchosen == nil {
+		return nil, nil
+	}
+
+	// shouldn't we also return the adaptor?
+	// and probably the whole head rather than just its channel parameters
+
+	return headchosen.GetParams(), tipchosen
+}
+
+func chooseHeadAndTip(vol wunit.Volume, prms *LHProperties, pol wtype.LHPolicy) (*wtype.LHHead, *wtype.LHTip) {
 	var headchosen *wtype.LHHead = nil
 	var tipchosen *wtype.LHTip = nil
 	var bestscore ChannelScore = ChannelScore(0.0)
 
 	scorer := prms.GetChannelScoreFunc()
 
+	allowed := make(map[string]bool)
+	for _, tt := range PolicyTipTypes(pol) {
+		allowed[tt] = true
+	}
+
 	// just choose the best... need to iterate on this sometime though
 	// we don't consider head or adaptor changes now
 
-	//fmt.Println("There are ", len(prms.HeadsLoaded), " heads loaded and ", len(prms.Tips), " Tip types available ")
-
 	for _, head := range prms.HeadsLoaded {
 		for _, tip := range prms.Tips {
+			if tip == nil || len(allowed) != 0 && !allowed[tip.Type] {
+				continue
+			}
 			sc := scorer.ScoreCombinedChannel(vol, head, head.Adaptor, tip)
 			if sc > bestscore {
 				headchosen = head
@@ -117,17 +266,36 @@ func ChooseChannel(vol wunit.Volume, prms *LHProperties) (*wtype.LHChannelParame
 
 	}
 
-	if headchosen == nil {
-		return nil, nil
+	return headchosen, tipchosen
+}
+
+// TransferError estimates the standard uncertainty in moving vol with the
+// channel and tip ChooseChannelWithPolicy would use, from their accuracy
+// curves. ok is false if no channel can move vol or there is no accuracy
+// data
+func TransferError(vol wunit.Volume, prms *LHProperties, pol wtype.LHPolicy) (err wunit.Volume, ok bool) {
+	head, tip := chooseHeadAndTip(vol, prms, pol)
+
+	if head == nil {
+		return wunit.ZeroVolume(), false
 	}
 
-	// shouldn't we also return the adaptor?
-	// and probably the whole head rather than just its channel parameters
+	pct, _, ok := ExpectedError(vol, head, head.Adaptor, tip)
 
-	return headchosen.GetParams(), tipchosen
+	if !ok {
+		return wunit.ZeroVolume(), false
+	}
+
+	return wunit.NewVolume(vol.RawValue()*pct/100.0, vol.Unit().PrefixedSymbol()), true
 }
 
 func ChooseChannels(vols []wunit.Volume, prms *LHProperties) ([]*wtype.LHChannelParameter, []*wtype.LHTip, []string, error) {
+	return ChooseChannelsWithPolicy(vols, prms, nil)
+}
+
+// ChooseChannelsWithPolicy chooses a channel and tip for each volume,
+// considering only the tip types the policy permits
+func ChooseChannelsWithPolicy(vols []wunit.Volume, prms *LHProperties, pol wtype.LHPolicy) ([]*wtype.LHChannelParameter, []*wtype.LHTip, []string, error) {
 	prmA := make([]*wtype.LHChannelParameter, len(vols))
 	tipA := make([]*wtype.LHTip, len(vols))
 	tipTypeA := make([]string, len(vols))
@@ -138,7 +306,7 @@ func ChooseChannels(vols []wunit.Volume, prms *LHProperties) ([]*wtype.LHChannel
 		if vols[i].IsZero() {
 			continue
 		}
-		prm, tip := ChooseChannel(vols[i], prms)
+		prm, tip := ChooseChannelWithPolicy(vols[i], prms, pol)
 		if tip == nil {
 			return prmA, tipA, tipTypeA, fmt.Errorf(TipChosenError(vols[i], prms))
 		}
@@ -3,6 +3,7 @@ package liquidhandling
 import (
 	"context"
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
@@ -181,3 +182,201 @@ func SetUpTipsFor(lhp *LHProperties) *LHProperties {
 	}
 	return lhp
 }
+
+func chooseTipType(t *testing.T, lhp *LHProperties, vol float64) string {
+	_, tip := ChooseChannel(wunit.NewVolume(vol, "ul"), lhp)
+	if tip == nil {
+		t.Fatalf("no tip chosen for %f ul", vol)
+	}
+	return tip.Type
+}
+
+func TestAccuracyChooser(t *testing.T) {
+	lhp := makeTestLH()
+	lhp.ChannelScorer = AccuracyChannelScoreFunc{}
+
+	for vol, expected := range map[float64]string{15.0: "Gilson20", 30.0: "Gilson20", 100.0: "Gilson200"} {
+		if got := chooseTipType(t, lhp, vol); got != expected {
+			t.Errorf("%.1f ul: expected %s got %s", vol, expected, got)
+		}
+	}
+
+	// below the smallest tip's minimum nothing will do
+	if prm, tip := ChooseChannel(wunit.NewVolume(0.1, "ul"), lhp); prm != nil || tip != nil {
+		t.Errorf("expected no channel for 0.1 ul, got %s", tip.Type)
+	}
+}
+
+func TestChooseChannelWithPolicy(t *testing.T) {
+	lhp := makeTestLH()
+
+	// 50ul would normally use Gilson200
+	_, tip := ChooseChannelWithPolicy(wunit.NewVolume(50.0, "ul"), lhp, wtype.LHPolicy{"TIP_TYPES": "Gilson20"})
+	if tip == nil || tip.Type != "Gilson20" {
+		t.Errorf("expected policy to force Gilson20 for 50 ul, got %v", tip)
+	}
+
+	// 5ul is below what Gilson200 can do
+	if _, tip := ChooseChannelWithPolicy(wunit.NewVolume(5.0, "ul"), lhp, wtype.LHPolicy{"TIP_TYPES": "Gilson200"}); tip != nil {
+		t.Errorf("expected no tip for 5 ul restricted to Gilson200, got %s", tip.Type)
+	}
+
+	// listing everything is the same as no restriction
+	_, tip = ChooseChannelWithPolicy(wunit.NewVolume(5.0, "ul"), lhp, wtype.LHPolicy{"TIP_TYPES": " Gilson200, Gilson20 "})
+	if tip == nil || tip.Type != chooseTipType(t, lhp, 5.0) {
+		t.Errorf("expected unrestricted choice for 5 ul, got %v", tip)
+	}
+}
+
+func TestErrorBudgetChooser(t *testing.T) {
+	lhp := makeTestLH()
+
+	// both tips are within 5% for 30ul so take the one which needs one move
+	lhp.ChannelScorer = ErrorBudgetChannelScoreFunc{MaxError: 5.0}
+
+	if got := chooseTipType(t, lhp, 30.0); got != "Gilson200" {
+		t.Errorf("expected Gilson200 within 5%% budget, got %s", got)
+	}
+
+	// neither is within 0.5% so take the most accurate
+	lhp.ChannelScorer = ErrorBudgetChannelScoreFunc{MaxError: 0.5}
+
+	if got := chooseTipType(t, lhp, 30.0); got != "Gilson20" {
+		t.Errorf("expected Gilson20 with no tip in budget, got %s", got)
+	}
+}
+
+func TestAccuracyScoresAboveDefault(t *testing.T) {
+	lhp := makeTestLH()
+	head := lhp.HeadsLoaded[1]
+
+	var tip *wtype.LHTip
+	for _, tp := range lhp.Tips {
+		if tp.Type == "Gilson20" {
+			tip = tp
+		}
+	}
+
+	// the tip's curve is the only accuracy data for this combination
+
+	bare := tip.Dup()
+	bare.Accuracy = nil
+
+	vol := wunit.NewVolume(15.0, "ul")
+	sc := AccuracyChannelScoreFunc{}
+
+	known := sc.ScoreCombinedChannel(vol, head, head.Adaptor, tip)
+	unknown := sc.ScoreCombinedChannel(vol, head, head.Adaptor, bare)
+
+	if unknown <= 0 || unknown > 1 {
+		t.Errorf("expected a combination without accuracy data to score in (0, 1], got %f", unknown)
+	}
+
+	if known <= unknown {
+		t.Errorf("expected a combination with accuracy data to score above one without, got %f and %f", known, unknown)
+	}
+
+	eb := ErrorBudgetChannelScoreFunc{MaxError: 100.0}
+
+	if within := eb.ScoreCombinedChannel(vol, head, head.Adaptor, tip); within <= 2 {
+		t.Errorf("expected a combination within budget to score above 2, got %f", within)
+	}
+}
+
+func TestChannelScoreFuncFor(t *testing.T) {
+	for name, expected := range map[string]ChannelScoreFunc{
+		"":            nil,
+		"default":     DefaultChannelScoreFunc{},
+		"accuracy":    AccuracyChannelScoreFunc{},
+		"errorbudget": ErrorBudgetChannelScoreFunc{MaxError: 2.0},
+	} {
+		got, err := ChannelScoreFuncFor(name, 2.0)
+		if err != nil {
+			t.Errorf("%q: %s", name, err)
+		} else if got != expected {
+			t.Errorf("%q: expected %v, got %v", name, expected, got)
+		}
+	}
+
+	if _, err := ChannelScoreFuncFor("errorbudget", 0.0); err == nil {
+		t.Error("expected error for errorbudget without a budget")
+	}
+
+	if _, err := ChannelScoreFuncFor("fastest", 0.0); err == nil {
+		t.Error("expected error for unknown channel scorer")
+	}
+}
+
+func TestExpectedError(t *testing.T) {
+	lhp := makeTestLH()
+	head := lhp.HeadsLoaded[1]
+
+	var tip *wtype.LHTip
+	for _, tp := range lhp.Tips {
+		if tp.Type == "Gilson20" {
+			tip = tp
+		}
+	}
+
+	err, n, ok := ExpectedError(wunit.NewVolume(40.0, "ul"), head, head.Adaptor, tip)
+
+	if !ok {
+		t.Fatal("expected accuracy data for Gilson20 tips")
+	}
+
+	if n != 2 {
+		t.Errorf("expected 2 movements of 20ul, got %d", n)
+	}
+
+	// 1% systematic, 0.3% CV reduced by root 2
+	if expected := math.Sqrt(1.0 + 0.09/2.0); math.Abs(err-expected) > 1e-9 {
+		t.Errorf("expected error %f got %f", expected, err)
+	}
+
+	tip = tip.Dup()
+	tip.Accuracy = nil
+
+	if _, _, ok := ExpectedError(wunit.NewVolume(10.0, "ul"), head, head.Adaptor, tip); ok {
+		t.Errorf("expected no accuracy data without curves")
+	}
+}
+
+func TestTransferError(t *testing.T) {
+	lhp := makeTestLH()
+
+	vol := wunit.NewVolume(10.0, "ul")
+	e, ok := TransferError(vol, lhp, nil)
+
+	if !ok {
+		t.Fatal("expected accuracy data for 10 ul")
+	}
+
+	_, tip := ChooseChannel(vol, lhp)
+	pct, _, _ := ExpectedError(vol, lhp.HeadsLoaded[1], lhp.HeadsLoaded[1].Adaptor, tip)
+
+	if got, expected := e.ConvertToString("ul"), 10.0*pct/100.0; got != expected || got <= 0.0 {
+		t.Errorf("expected error of %g ul, got %g", expected, got)
+	}
+
+	// 50ul would normally use Gilson200 but the policy says otherwise
+
+	vol = wunit.NewVolume(50.0, "ul")
+	pol := wtype.LHPolicy{"TIP_TYPES": "Gilson20"}
+
+	e, ok = TransferError(vol, lhp, pol)
+
+	if !ok {
+		t.Fatal("expected accuracy data for 50 ul")
+	}
+
+	_, tip = ChooseChannelWithPolicy(vol, lhp, pol)
+	pct, _, _ = ExpectedError(vol, lhp.HeadsLoaded[1], lhp.HeadsLoaded[1].Adaptor, tip)
+
+	if got, expected := e.ConvertToString("ul"), 50.0*pct/100.0; got != expected {
+		t.Errorf("expected error of %g ul with Gilson20, got %g", expected, got)
+	}
+
+	if e2, _ := TransferError(vol, lhp, nil); e2.EqualTo(e) {
+		t.Errorf("expected the policy to change the error, got %s both ways", e.ToString())
+	}
+}
@@ -31,12 +31,22 @@ import (
 	anthadriver "github.com/antha-lang/antha/microArch/driver"
 	"github.com/antha-lang/antha/microArch/logger"
 	"reflect"
+	"strings"
 )
 
 func TipChosenError(v wunit.Volume, prms *LHProperties) string {
 	return fmt.Sprintf("No tip chosen: Volume %s is too low to be accurately moved by the liquid handler (current minimum %s). Low volume tips may not be available and / or the robot may need to be configured differently", v.ToString(), prms.MinPossibleVolume().ToString())
 }
 
+// TipChosenErrorFor explains why no tip could be chosen for v when the
+// policy pol restricts the tip types which may be used
+func TipChosenErrorFor(v wunit.Volume, prms *LHProperties, pol wtype.LHPolicy) string {
+	if tts := PolicyTipTypes(pol); len(tts) != 0 {
+		return fmt.Sprintf("No tip chosen: Volume %s cannot be moved with the tip types permitted by policy (%s)", v.ToString(), strings.Join(tts, ", "))
+	}
+	return TipChosenError(v, prms)
+}
+
 type TransferParams struct {
 	What       string
 	PltFrom    string
@@ -195,14 +205,15 @@ func (ins *SingleChannelBlockInstruction) Generate(ctx context.Context, policy *
 
 	ret := make([]RobotInstruction, 0)
 	// get tips
-	channel, tipp := ChooseChannel(ins.Volume[0], prms)
+	tpol := ins.transferPolicy(0, ins.Volume[0], policy)
+	channel, tipp := ChooseChannelWithPolicy(ins.Volume[0], prms, tpol)
 
 	tiptype := ""
 
 	if tipp != nil {
 		tiptype = tipp.Type
 	} else {
-		return ret, fmt.Errorf(TipChosenError(ins.Volume[0], prms))
+		return ret, fmt.Errorf(TipChosenErrorFor(ins.Volume[0], prms, tpol))
 	}
 
 	ins.Prms = channel
@@ -228,12 +239,23 @@ func (ins *SingleChannelBlockInstruction) Generate(ctx context.Context, policy *
 	var dirty bool
 
 	for t := 0; t < len(ins.Volume); t++ {
-		newchannel, newtipp := ChooseChannel(ins.Volume[t], prms)
+		tpol := ins.transferPolicy(t, ins.Volume[t], policy)
+		newchannel, newtipp := ChooseChannelWithPolicy(ins.Volume[t], prms, tpol)
+
+		// see if we can serve this and the following transfers
+		// from one aspirate
+
+		n_disp, excess, mdchannel, mdtipp := ins.multiDispenseSetFrom(t, policy, prms)
+
+		if n_disp > 1 {
+			newchannel, newtipp = mdchannel, mdtipp
+		}
+
 		newtiptype := ""
 		if newtipp != nil {
 			newtiptype = newtipp.Type
 		} else {
-			return ret, fmt.Errorf(TipChosenError(ins.Volume[t], prms))
+			return ret, fmt.Errorf(TipChosenErrorFor(ins.Volume[t], prms, tpol))
 		}
 		mergedchannel := newchannel.MergeWithTip(newtipp)
 		tipp = newtipp
@@ -301,6 +323,34 @@ func (ins *SingleChannelBlockInstruction) Generate(ctx context.Context, policy *
 				dirty = false
 			}
 
+			if n_disp > 1 {
+				mdi := NewMultiDispenseInstruction()
+				for j := t; j < t+n_disp; j++ {
+					mdi.AddDispense(ins.transferParams(j, channel.MergeWithTip(tipp), tiptype))
+				}
+				mdi.Excess = excess
+				ret = append(ret, mdi)
+				last_thing = this_thing
+
+				for j := t; j < t+n_disp; j++ {
+					ins.FVolume[t].Subtract(ins.Volume[j])
+					ins.TVolume[j].Add(ins.Volume[j])
+				}
+
+				// excess put in the waste is lost to every later
+				// transfer from the same source
+
+				if !mdi.Excess.IsZero() {
+					for j := t; j < len(ins.Volume); j++ {
+						if ins.PltFrom[j] == ins.PltFrom[t] && ins.WellFrom[j] == ins.WellFrom[t] {
+							ins.FVolume[j].Subtract(excess)
+						}
+					}
+				}
+				n_tip_uses += 1
+				break
+			}
+
 			stci := NewSingleChannelTransferInstruction()
 
 			stci.What = ins.What[t]
@@ -339,6 +389,10 @@ func (ins *SingleChannelBlockInstruction) Generate(ctx context.Context, policy *
 			n_tip_uses += 1
 		}
 
+		if n_disp > 1 {
+			// the following transfers are already done
+			t += n_disp - 1
+		}
 	}
 	tipdrp, err := DropTips(tt, prms, chanA)
 
@@ -479,9 +533,9 @@ func (ins *MultiChannelBlockInstruction) Generate(ctx context.Context, policy *w
 	// as we move to independent we need to get all volumes
 
 	//channels, _, tiptypes, err := ChooseChannels(ins.GetVolumes(), prms)
-	channels, _, tiptypes, err := ChooseChannels(ins.Volume[0], prms)
+	channels, _, tiptypes, err := ChooseChannelsWithPolicy(ins.Volume[0], prms, pol)
 	if err != nil {
-		return ret, fmt.Errorf(TipChosenError(ins.GetVolumes()[0], prms))
+		return ret, fmt.Errorf(TipChosenErrorFor(ins.GetVolumes()[0], prms, pol))
 	}
 
 	tipget, err := GetTips(ctx, tiptypes, prms, channels, usetiptracking)
@@ -501,7 +555,7 @@ func (ins *MultiChannelBlockInstruction) Generate(ctx context.Context, policy *w
 		}
 
 		// choose tips
-		newchannels, newtips, newtiptypes, err := ChooseChannels(ins.Volume[t], prms)
+		newchannels, newtips, newtiptypes, err := ChooseChannelsWithPolicy(ins.Volume[t], prms, pol)
 		if err != nil {
 			return ret, err
 		}
@@ -1865,6 +1919,17 @@ func (ins *SuckInstruction) Generate(ctx context.Context, policy *wtype.LHPolicy
 		mov.OffsetY = append(mov.OffsetY, ofy)
 		mov.OffsetZ = append(mov.OffsetZ, ofz)
 	}
+
+	// work out heights from the liquid level if asked to
+
+	llf := make([]bool, ins.Multi)
+
+	if SafeGetBool(pol, "USE_LLF") {
+		applyLiquidLevel(mov, llf, prms, ins.PltFrom, ins.WellFrom, ins.FVolume, ins.Volume, func(w *wtype.LHWell, before, vol wunit.Volume) (float64, bool, error) {
+			return aspirateHeight(pol, w, before, vol)
+		}, ofzadj)
+	}
+
 	ret = append(ret, mov)
 
 	// Set the pipette speed if needed
@@ -1899,10 +1964,7 @@ func (ins *SuckInstruction) Generate(ctx context.Context, policy *wtype.LHPolicy
 	aspins.Overstroke = ins.Overstroke
 	aspins.What = ins.What
 	aspins.Plt = ins.FPlateType
-
-	for i := 0; i < ins.Multi; i++ {
-		aspins.LLF = append(aspins.LLF, false)
-	}
+	aspins.LLF = llf
 
 	ret = append(ret, aspins)
 
@@ -2124,6 +2186,14 @@ func (ins *BlowInstruction) Generate(ctx context.Context, policy *wtype.LHPolicy
 		mov.OffsetZ = append(mov.OffsetZ, ofz)
 	}
 
+	llf := make([]bool, ins.Multi)
+
+	if SafeGetBool(pol, "USE_LLF") {
+		applyLiquidLevel(mov, llf, prms, ins.PltTo, ins.WellTo, ins.TVolume, ins.Volume, func(w *wtype.LHWell, before, vol wunit.Volume) (float64, bool, error) {
+			return dispenseHeight(pol, w, before, vol)
+		}, ofzadj)
+	}
+
 	ret = append(ret, mov)
 
 	// change pipette speed?
@@ -2190,10 +2260,7 @@ func (ins *BlowInstruction) Generate(ctx context.Context, policy *wtype.LHPolicy
 		dspins.Multi = ins.Multi
 		dspins.Plt = ins.TPlateType
 		dspins.What = ins.What
-
-		for i := 0; i < ins.Multi; i++ {
-			dspins.LLF = append(dspins.LLF, false)
-		}
+		dspins.LLF = llf
 
 		ret = append(ret, dspins)
 	}
@@ -3185,16 +3252,34 @@ func getFirstDefined(sa []string) int {
 }
 
 func GetTips(ctx context.Context, tiptypes []string, params *LHProperties, channel []*wtype.LHChannelParameter, usetiptracking bool) ([]RobotInstruction, error) {
+	inss := make([]RobotInstruction, 0, 1)
+
+	// make sure there will be somewhere to put these tips when we're done
+	// with them, pausing for the tip wastes to be emptied if not
+
+	if !canDisposeOf(params, getMulti(tiptypes)) {
+		for _, pos := range params.EmptyTipwastes() {
+			inss = append(inss, NewEmptyTipwasteMessage(pos))
+		}
+	}
+
 	// GetCleanTips returns enough sets of tip boxes to get all distinct tip types
 	tipwells, tipboxpositions, tipboxtypes, terr := params.GetCleanTips(ctx, tiptypes, channel, usetiptracking)
 
+	if terr != nil && wtype.LHErrorCodeFromErr(terr) == wtype.LH_ERR_NO_DECK_SPACE {
+		// no room on the deck for any more tips, pause for the
+		// operator to replace boxes we've used up and try again
+		if msgs := replenishTips(params, tiptypes); len(msgs) != 0 {
+			inss = append(inss, msgs...)
+			tipwells, tipboxpositions, tipboxtypes, terr = params.GetCleanTips(ctx, tiptypes, channel, usetiptracking)
+		}
+	}
+
 	if tipwells == nil || terr != nil {
 		err := wtype.LHError(wtype.LH_ERR_NO_TIPS, fmt.Sprint("PICKUP: types: ", tiptypes))
 		return []RobotInstruction{NewLoadTipsMoveInstruction()}, err
 	}
 
-	inss := make([]RobotInstruction, 0, 1)
-
 	for i := 0; i < len(tipwells); i++ {
 		// all instructions in a block must have a head in common
 		defPos := getFirstDefined(tipwells[i])
@@ -3216,6 +3301,48 @@ func GetTips(ctx context.Context, tiptypes []string, params *LHProperties, chann
 	return inss, nil
 }
 
+// canDisposeOf checks whether n tips can be dropped into one of the tip
+// wastes, as they must be for DropTips to succeed
+func canDisposeOf(params *LHProperties, n int) bool {
+	// nothing to check against
+	if len(params.Tipwastes) == 0 {
+		return true
+	}
+
+	for _, tw := range params.Tipwastes {
+		if tw.SpaceLeft() >= n {
+			return true
+		}
+	}
+
+	return false
+}
+
+// replenishTips refills the used boxes of each of the tip types needed and
+// returns messages asking the operator to replace them
+func replenishTips(params *LHProperties, tiptypes []string) []RobotInstruction {
+	need := make(map[string]int, len(tiptypes))
+	order := make([]string, 0, len(tiptypes))
+	for _, tt := range tiptypes {
+		if tt == "" {
+			continue
+		}
+		if need[tt] == 0 {
+			order = append(order, tt)
+		}
+		need[tt] += 1
+	}
+
+	var ret []RobotInstruction
+	for _, tt := range order {
+		for _, pos := range params.ReplaceTipboxes(tt, need[tt]) {
+			ret = append(ret, NewReplaceTipboxMessage(pos, params.Tipboxes[pos].Type))
+		}
+	}
+
+	return ret
+}
+
 func channelArrayToOldStyle(channels []*wtype.LHChannelParameter) (*wtype.LHChannelParameter, int) {
 	var ch *wtype.LHChannelParameter
 	multi := 0
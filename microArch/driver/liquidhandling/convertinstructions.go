@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"math"
 )
 
 func readableComponentArray(arr []*wtype.LHComponent) string {
@@ -65,7 +66,7 @@ func ConvertInstructions(ctx context.Context, inssIn LHIVector, robot *LHPropert
 	if multi != 1 {
 		rbt := robot.DupKeepIDs()
 
-		tfrs, err := convertInstructions(inssIn, rbt, carryvol, channelprms, multi, legacyVolume)
+		tfrs, err := convertInstructions(inssIn, rbt, carryvol, channelprms, multi, legacyVolume, policy)
 
 		if err != nil {
 			return []*TransferInstruction{}, robot, err
@@ -87,7 +88,7 @@ func ConvertInstructions(ctx context.Context, inssIn LHIVector, robot *LHPropert
 		}
 	}
 
-	tfrs, err := convertInstructions(inssIn, robot, carryvol, channelprms, multi, legacyVolume)
+	tfrs, err := convertInstructions(inssIn, robot, carryvol, channelprms, multi, legacyVolume, policy)
 
 	if err != nil {
 		return []*TransferInstruction{}, robot, err
@@ -133,7 +134,7 @@ func dupCmpAr(ins *wtype.LHInstruction) []*wtype.LHComponent {
 	return r
 }
 
-func convertInstructions(inssIn LHIVector, robot *LHProperties, carryvol wunit.Volume, channelprms *wtype.LHChannelParameter, multi int, legacyVolume bool) (insOut []*TransferInstruction, err error) {
+func convertInstructions(inssIn LHIVector, robot *LHProperties, carryvol wunit.Volume, channelprms *wtype.LHChannelParameter, multi int, legacyVolume bool, policy *wtype.LHPolicyRuleSet) (insOut []*TransferInstruction, err error) {
 	insOut = make([]*TransferInstruction, 0, 1)
 
 	// TODO --> iterator?
@@ -196,7 +197,7 @@ func convertInstructions(inssIn LHIVector, robot *LHProperties, carryvol wunit.V
 		}
 
 		for _, t := range parallelTransfers.Transfers {
-			transfers, err := makeTransfers(t, cmps, robot, inssToUse, carryvol)
+			transfers, err := makeTransfers(t, cmps, robot, inssToUse, carryvol, policy)
 
 			if err != nil {
 				return nil, err
@@ -210,7 +211,7 @@ func convertInstructions(inssIn LHIVector, robot *LHProperties, carryvol wunit.V
 	return insOut, nil
 }
 
-func makeTransfers(parallelTransfer ParallelTransfer, cmps []*wtype.LHComponent, robot *LHProperties, inssIn []*wtype.LHInstruction, carryvol wunit.Volume) ([]*TransferInstruction, error) {
+func makeTransfers(parallelTransfer ParallelTransfer, cmps []*wtype.LHComponent, robot *LHProperties, inssIn []*wtype.LHInstruction, carryvol wunit.Volume, policy *wtype.LHPolicyRuleSet) ([]*TransferInstruction, error) {
 	fromPlateIDs := parallelTransfer.PlateIDs
 	fromWells := parallelTransfer.WellCoords
 	vols := parallelTransfer.Vols
@@ -325,6 +326,24 @@ func makeTransfers(parallelTransfer ParallelTransfer, cmps []*wtype.LHComponent,
 
 		cnames[ci] = wellFrom.WContents.CName
 
+		// the policy for this transfer may restrict the tips used, which
+		// matters for how accurately it is done
+		var pol wtype.LHPolicy
+		if policy != nil {
+			pol = transferPolicy(policy, TransferParams{
+				What:       wh[ci],
+				PltFrom:    pf[ci],
+				PltTo:      pt[ci],
+				WellFrom:   wf[ci],
+				WellTo:     wt[ci],
+				Volume:     va[ci],
+				FPlateType: ptf[ci],
+				TPlateType: ptt[ci],
+				FVolume:    vf[ci],
+				TVolume:    vt[ci],
+			})
+		}
+
 		cmpFrom := wellFrom.Remove(va[ci])
 		// silently remove the carry
 		wellFrom.Remove(carryvol)
@@ -333,6 +352,20 @@ func makeTransfers(parallelTransfer ParallelTransfer, cmps []*wtype.LHComponent,
 			return insOut, wtype.LHError(wtype.LH_ERR_DIRE, "Planning inconsistency: src well does not contain sufficient volume - please report this error to the authors")
 		}
 
+		// the volume moved is only as good as the channel moving it and
+		// what's left behind is less certain for the same reason
+		if verr, ok := TransferError(va[ci], robot, pol); ok {
+			unit := cmpFrom.Vunit
+			if unit == "" {
+				unit = wellFrom.Vunit
+			}
+			e := verr.ConvertToString(unit)
+			cmpFrom.VolErr = e
+			wellFrom.WContents.VolErr = math.Hypot(wellFrom.WContents.VolErr, e)
+		} else {
+			cmpFrom.VolErr = 0.0
+		}
+
 		wellTo.Add(cmpFrom)
 
 		// make sure the cmp loc is set
@@ -0,0 +1,269 @@
+// /anthalib/driver/liquidhandling/decklayout.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package liquidhandling
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// deckDistance is the distance the head travels between two positions: it
+// is assumed to move across the deck at a fixed height so only x and y count
+func deckDistance(from, to wtype.Coordinates) float64 {
+	return math.Hypot(to.X-from.X, to.Y-from.Y)
+}
+
+type deckMove struct {
+	From string
+	To   string
+}
+
+// deckMoves counts how many times the head goes from one deck position to
+// another in ris. Where channels go to different positions the first one
+// is taken to be where the head is
+func deckMoves(ris []RobotInstruction) map[deckMove]int {
+	moves := make(map[deckMove]int)
+	last := ""
+
+	visit := func(pos []string) {
+		for _, p := range pos {
+			if p == "" {
+				continue
+			}
+			if last != "" && p != last {
+				moves[deckMove{From: last, To: p}] += 1
+			}
+			last = p
+			return
+		}
+	}
+
+	for _, ri := range ris {
+		switch ins := ri.(type) {
+		case *MoveInstruction:
+			visit(ins.Pos)
+		case *LoadTipsInstruction:
+			visit(ins.Pos)
+		case *UnloadTipsInstruction:
+			visit(ins.Pos)
+		case *MoveRawInstruction:
+			visit(ins.PltFrom)
+			visit(ins.PltTo)
+		}
+	}
+
+	return moves
+}
+
+// travelDistance is the total distance travelled making moves when the
+// contents of each position are moved to the position given by newpos
+func (lhp *LHProperties) travelDistance(moves map[deckMove]int, newpos map[string]string) float64 {
+	d := 0.0
+	for m, n := range moves {
+		from, ok1 := lhp.Layout[newpos[m.From]]
+		to, ok2 := lhp.Layout[newpos[m.To]]
+		if !ok1 || !ok2 {
+			continue
+		}
+		d += float64(n) * deckDistance(from, to)
+	}
+	return d
+}
+
+// allowedPositionsFor returns the positions the contents of pos may be moved
+// to: those permitted by any user constraint on a plate, otherwise the
+// preferences for that kind of thing. Anything which isn't in one of its
+// preferred positions already, or which we don't know how to move, stays put
+func (lhp *LHProperties) allowedPositionsFor(pos string) []string {
+	var allowed []string
+
+	if p, ok := lhp.Plates[pos]; ok {
+		if cons, isConstrained := p.IsConstrainedOn(lhp.Model); isConstrained {
+			allowed = cons
+		} else {
+			allowed = lhp.OrderedMergedPlatePrefs()
+		}
+	} else if _, ok := lhp.Tipboxes[pos]; ok {
+		allowed = lhp.Tip_preferences
+	} else if _, ok := lhp.Tipwastes[pos]; ok {
+		allowed = lhp.Tipwaste_preferences
+	} else if _, ok := lhp.Wastes[pos]; ok {
+		allowed = lhp.Waste_preferences
+	} else if _, ok := lhp.Washes[pos]; ok {
+		allowed = lhp.Wash_preferences
+	}
+
+	if !inStrArr(pos, allowed) {
+		return []string{pos}
+	}
+
+	return allowed
+}
+
+// OptimizeLayout rearranges the plates, tip boxes and wastes on the deck so
+// as to minimise the distance the head travels carrying out ris, keeping to
+// the preferred positions for each kind of thing and to any positions
+// plates are constrained to with SetConstrained.
+//
+// The result maps each position whose contents should move to its new
+// position, along with the time that is expected to save according to the
+// robot's timer. The instructions and properties are not changed: use
+// RelabelInstructionPositions and LHProperties.RelabelPositions to apply it
+func OptimizeLayout(ris []RobotInstruction, lhp *LHProperties) (map[string]string, time.Duration, error) {
+	timer := lhp.GetTimer()
+
+	if timer == nil || timer.HeadSpeed <= 0.0 {
+		return map[string]string{}, time.Duration(0), nil
+	}
+
+	moves := deckMoves(ris)
+
+	positions := make([]string, 0, len(lhp.Layout))
+	for pos := range lhp.Layout {
+		positions = append(positions, pos)
+	}
+	sort.Strings(positions)
+
+	for m := range moves {
+		if _, ok := lhp.Layout[m.From]; !ok {
+			return nil, time.Duration(0), wtype.LHError(wtype.LH_ERR_OTHER, fmt.Sprintf("cannot optimise layout: no coordinates for position %s", m.From))
+		}
+		if _, ok := lhp.Layout[m.To]; !ok {
+			return nil, time.Duration(0), wtype.LHError(wtype.LH_ERR_OTHER, fmt.Sprintf("cannot optimise layout: no coordinates for position %s", m.To))
+		}
+	}
+
+	// occupant[p] is the original position of whatever is now at p, newpos
+	// the inverse. Anything visited but not known to us stays where it is
+
+	occupant := make(map[string]string, len(positions))
+	newpos := make(map[string]string, len(positions))
+	allowed := make(map[string][]string, len(positions))
+
+	for _, pos := range positions {
+		if lhp.PosLookup[pos] != "" {
+			occupant[pos] = pos
+			newpos[pos] = pos
+			allowed[pos] = lhp.allowedPositionsFor(pos)
+		}
+	}
+
+	for m := range moves {
+		for _, pos := range []string{m.From, m.To} {
+			if _, ok := occupant[pos]; !ok {
+				occupant[pos] = pos
+				newpos[pos] = pos
+				allowed[pos] = []string{pos}
+			}
+		}
+	}
+
+	canGo := func(item, pos string) bool {
+		return item == "" || inStrArr(pos, allowed[item])
+	}
+
+	// swapping twice puts things back
+
+	swap := func(a, b string) {
+		ia, ib := occupant[a], occupant[b]
+		occupant[a], occupant[b] = ib, ia
+		if ia != "" {
+			newpos[ia] = b
+		}
+		if ib != "" {
+			newpos[ib] = a
+		}
+	}
+
+	before := lhp.travelDistance(moves, newpos)
+	best := before
+
+	// improve by swapping the contents of pairs of positions until no
+	// swap helps
+
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < len(positions); i++ {
+			for j := i + 1; j < len(positions); j++ {
+				a, b := positions[i], positions[j]
+				ia, ib := occupant[a], occupant[b]
+
+				if ia == "" && ib == "" || !canGo(ia, b) || !canGo(ib, a) {
+					continue
+				}
+
+				swap(a, b)
+
+				if d := lhp.travelDistance(moves, newpos); d < best-1e-6 {
+					best = d
+					improved = true
+				} else {
+					swap(a, b)
+				}
+			}
+		}
+	}
+
+	ret := make(map[string]string)
+	for from, to := range newpos {
+		if from != to {
+			ret[from] = to
+		}
+	}
+
+	return ret, timer.TimeForTravel(before - best), nil
+}
+
+// RelabelInstructionPositions changes the deck positions in ris according
+// to newpos, in place
+func RelabelInstructionPositions(ris []RobotInstruction, newpos map[string]string) {
+	relabel := func(pos []string) {
+		for i, p := range pos {
+			if np, ok := newpos[p]; ok {
+				pos[i] = np
+			}
+		}
+	}
+
+	for _, ri := range ris {
+		switch ins := ri.(type) {
+		case *MoveInstruction:
+			relabel(ins.Pos)
+		case *LoadTipsInstruction:
+			relabel(ins.Pos)
+		case *UnloadTipsInstruction:
+			relabel(ins.Pos)
+		case *MoveRawInstruction:
+			relabel(ins.PltFrom)
+			relabel(ins.PltTo)
+		case *AddPlateToInstruction:
+			if np, ok := newpos[ins.Position]; ok {
+				ins.Position = np
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package liquidhandling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/testinventory"
+)
+
+func makeLayoutRobot(ctx context.Context) (*LHProperties, error) {
+	robot, err := makeTestGilson(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pos := range []string{"position_9", "position_8"} {
+		p, err := inventory.NewPlate(ctx, "pcrplate_skirted_riser40")
+		if err != nil {
+			return nil, err
+		}
+
+		if err := robot.AddPlate(pos, p); err != nil {
+			return nil, err
+		}
+	}
+
+	return robot, nil
+}
+
+// tips from position_2, source plate at position_9, destination at
+// position_8 and tips into the waste at position_1
+func getLayoutInstructions(n int) []RobotInstruction {
+	move := func(pos string) RobotInstruction {
+		mov := NewMoveInstruction()
+		mov.Pos = append(mov.Pos, pos)
+		mov.Well = append(mov.Well, "A1")
+		return mov
+	}
+
+	ris := make([]RobotInstruction, 0, 4*n)
+	for i := 0; i < n; i++ {
+		lod := NewLoadTipsInstruction()
+		lod.Pos = append(lod.Pos, "position_2")
+		ris = append(ris, lod, move("position_9"), move("position_8"))
+		uld := NewUnloadTipsInstruction()
+		uld.Pos = append(uld.Pos, "position_1")
+		ris = append(ris, uld)
+	}
+
+	return ris
+}
+
+func TestOptimizeLayout(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	robot, err := makeLayoutRobot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ris := getLayoutInstructions(10)
+	moves := deckMoves(ris)
+	before := robot.travelDistance(moves, map[string]string{"position_1": "position_1", "position_2": "position_2", "position_8": "position_8", "position_9": "position_9"})
+
+	newpos, saving, err := OptimizeLayout(ris, robot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(newpos) == 0 || saving <= 0 {
+		t.Fatalf("expected layout to be improved, got %v saving %s", newpos, saving)
+	}
+
+	srcID := robot.PosLookup["position_9"]
+	tbID := robot.PosLookup["position_2"]
+
+	RelabelInstructionPositions(ris, newpos)
+	robot.RelabelPositions(newpos)
+
+	after := deckMoves(ris)
+	same := make(map[string]string)
+	for m := range after {
+		same[m.From] = m.From
+		same[m.To] = m.To
+	}
+
+	if d := robot.travelDistance(after, same); d >= before {
+		t.Errorf("expected less travel after relabelling: %f before %f after", before, d)
+	}
+
+	// things must have moved with their lookups and only to where they
+	// are allowed to go
+
+	for from, to := range newpos {
+		id := robot.PosLookup[to]
+		if robot.PlateIDLookup[id] != to {
+			t.Errorf("%s moved to %s but lookup says %s", from, to, robot.PlateIDLookup[id])
+		}
+	}
+
+	if _, ok := robot.Tipboxes[robot.PlateIDLookup[tbID]]; !ok || !inStrArr(robot.PlateIDLookup[tbID], robot.Tip_preferences) {
+		t.Errorf("tip box moved to %s, not a tip position", robot.PlateIDLookup[tbID])
+	}
+
+	if _, ok := robot.Plates[robot.PlateIDLookup[srcID]]; !ok {
+		t.Errorf("source plate lost in relabelling")
+	}
+}
+
+func TestOptimizeLayoutRespectsConstraints(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	robot, err := makeLayoutRobot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	robot.Plates["position_9"].SetConstrained(robot.Model, []string{"position_9"})
+	robot.Plates["position_8"].SetConstrained(robot.Model, []string{"position_8", "position_3"})
+
+	newpos, _, err := OptimizeLayout(getLayoutInstructions(10), robot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if to, ok := newpos["position_9"]; ok {
+		t.Errorf("pinned plate moved to %s", to)
+	}
+
+	if to, ok := newpos["position_8"]; ok && to != "position_3" {
+		t.Errorf("constrained plate moved to %s", to)
+	}
+}
+
+func TestOptimizeLayoutWithoutMovementModel(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	robot, err := makeLayoutRobot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	robot.Model = "Felix"
+	robot.Mnfr = "CyBio"
+
+	newpos, saving, err := OptimizeLayout(getLayoutInstructions(10), robot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(newpos) != 0 || saving != 0 {
+		t.Errorf("expected no change without head speed, got %v saving %s", newpos, saving)
+	}
+}
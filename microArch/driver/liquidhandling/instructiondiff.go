@@ -0,0 +1,184 @@
+package liquidhandling
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+// A Transfer is liquid moved by one channel from one well to another
+type Transfer struct {
+	Head     int
+	Channel  int
+	FromPos  string
+	FromWell string
+	ToPos    string
+	ToWell   string
+	What     string
+	Volume   wunit.Volume
+}
+
+func (t Transfer) String() string {
+	return fmt.Sprintf("%s of %s from %s:%s to %s:%s", t.Volume, t.What, t.FromPos, t.FromWell, t.ToPos, t.ToWell)
+}
+
+// the parts of a transfer which identify it between instruction sets
+func (t Transfer) key() string {
+	return strings.Join([]string{t.FromPos, t.FromWell, t.ToPos, t.ToWell, t.What}, "\x00")
+}
+
+type channelSource struct {
+	pos  string
+	well string
+	what string
+}
+
+func stringAt(a []string, i int) string {
+	if i < len(a) {
+		return a[i]
+	}
+	return ""
+}
+
+// Transfers follows the terminal instructions in ris and returns every
+// dispense as a transfer from wherever its channel last aspirated
+func Transfers(ris []RobotInstruction) []Transfer {
+	var ret []Transfer
+
+	src := make(map[[2]int]channelSource)
+
+	for _, ins := range mergeMovs(ris) {
+		switch ins := ins.(type) {
+		case MovAsp:
+			for i, v := range ins.Asp.Volume {
+				if v.IsNil() || v.RawValue() <= 0.0 {
+					continue
+				}
+				src[[2]int{ins.Asp.Head, i}] = channelSource{
+					pos:  stringAt(ins.Mov.Pos, i),
+					well: stringAt(ins.Mov.Well, i),
+					what: stringAt(ins.Asp.What, i),
+				}
+			}
+		case MovDsp:
+			for i, v := range ins.Dsp.Volume {
+				if v.IsNil() || v.RawValue() <= 0.0 {
+					continue
+				}
+				s := src[[2]int{ins.Dsp.Head, i}]
+				what := stringAt(ins.Dsp.What, i)
+				if what == "" {
+					what = s.what
+				}
+				ret = append(ret, Transfer{
+					Head:     ins.Dsp.Head,
+					Channel:  i,
+					FromPos:  s.pos,
+					FromWell: s.well,
+					ToPos:    stringAt(ins.Mov.Pos, i),
+					ToWell:   stringAt(ins.Mov.Well, i),
+					What:     what,
+					Volume:   wunit.CopyVolume(v),
+				})
+			}
+		case *UnloadTipsInstruction:
+			for _, i := range ins.Channels {
+				delete(src, [2]int{ins.Head, i})
+			}
+		}
+	}
+
+	return ret
+}
+
+// A TransferChange is a transfer made in both instruction sets compared but
+// in different volumes
+type TransferChange struct {
+	Old Transfer
+	New Transfer
+}
+
+func (tc TransferChange) String() string {
+	return fmt.Sprintf("%s now %s", tc.Old, tc.New.Volume)
+}
+
+// A TransferDiff is the difference between the transfers made by two sets of
+// instructions. Transfers are matched between the sets by source,
+// destination and liquid class, in the order they are made
+type TransferDiff struct {
+	Added   []Transfer
+	Removed []Transfer
+	Changed []TransferChange
+}
+
+// Empty is true if both sets of instructions make the same transfers
+func (td TransferDiff) Empty() bool {
+	return len(td.Added) == 0 && len(td.Removed) == 0 && len(td.Changed) == 0
+}
+
+func (td TransferDiff) String() string {
+	lines := make([]string, 0, len(td.Added)+len(td.Removed)+len(td.Changed))
+
+	for _, t := range td.Removed {
+		lines = append(lines, "- "+t.String())
+	}
+	for _, t := range td.Added {
+		lines = append(lines, "+ "+t.String())
+	}
+	for _, t := range td.Changed {
+		lines = append(lines, "~ "+t.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// volumes which differ by less than this many ul are the same
+const transferVolumeTolerance = 1.0e-6
+
+func sameVolume(a, b wunit.Volume) bool {
+	return math.Abs(a.ConvertToString("ul")-b.ConvertToString("ul")) < transferVolumeTolerance
+}
+
+// DiffTransfers compares the transfers made by two sets of instructions,
+// ignoring how they are grouped into instructions and which channels make
+// them
+func DiffTransfers(setA, setB []RobotInstruction) TransferDiff {
+	var td TransferDiff
+
+	ta := Transfers(setA)
+	tb := Transfers(setB)
+
+	inB := make(map[string][]int)
+	for i, t := range tb {
+		inB[t.key()] = append(inB[t.key()], i)
+	}
+
+	matched := make([]bool, len(tb))
+
+	for _, t := range ta {
+		k := t.key()
+		idx := inB[k]
+
+		if len(idx) == 0 {
+			td.Removed = append(td.Removed, t)
+			continue
+		}
+
+		inB[k] = idx[1:]
+		matched[idx[0]] = true
+
+		if n := tb[idx[0]]; !sameVolume(t.Volume, n.Volume) {
+			td.Changed = append(td.Changed, TransferChange{Old: t, New: n})
+		}
+	}
+
+	for i, t := range tb {
+		if !matched[i] {
+			td.Added = append(td.Added, t)
+		}
+	}
+
+	return td
+}
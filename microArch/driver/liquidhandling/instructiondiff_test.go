@@ -0,0 +1,95 @@
+package liquidhandling
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+func testTransfer(fromPos, fromWell, toPos, toWell, what string, vol float64) []RobotInstruction {
+	mov := func(pos, well string) *MoveInstruction {
+		ins := NewMoveInstruction()
+		ins.Pos = append(ins.Pos, pos)
+		ins.Well = append(ins.Well, well)
+		ins.Plt = append(ins.Plt, "pcrplate_skirted")
+		ins.Reference = append(ins.Reference, 0)
+		return ins
+	}
+
+	asp := NewAspirateInstruction()
+	asp.What = append(asp.What, what)
+	asp.Volume = append(asp.Volume, wunit.NewVolume(vol, "ul"))
+	asp.Multi = 1
+
+	dsp := NewDispenseInstruction()
+	dsp.What = append(dsp.What, what)
+	dsp.Volume = append(dsp.Volume, wunit.NewVolume(vol, "ul"))
+	dsp.Multi = 1
+
+	return []RobotInstruction{mov(fromPos, fromWell), asp, mov(toPos, toWell), dsp}
+}
+
+func testTransfers(vols ...float64) []RobotInstruction {
+	var ris []RobotInstruction
+	for i, v := range vols {
+		ris = append(ris, testTransfer("position_4", "A1", "position_7", []string{"A1", "B1", "C1", "D1"}[i], "water", v)...)
+	}
+	return ris
+}
+
+func TestParseInstructions(t *testing.T) {
+	ris := testTransfers(10.0, 20.0, 30.0)
+
+	set, err := json.Marshal(SetOfRobotInstructions{RobotInstructions: ris})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	arr, err := json.Marshal(ris)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, b := range [][]byte{set, arr} {
+		parsed, err := ParseInstructions(bytes.NewReader(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(parsed) != len(ris) {
+			t.Fatalf("expected %d instructions, got %d", len(ris), len(parsed))
+		}
+
+		if td := DiffTransfers(ris, parsed); !td.Empty() {
+			t.Errorf("expected parsed instructions to make the same transfers, got\n%s", td)
+		}
+	}
+
+	if _, err := ParseInstructions(bytes.NewReader([]byte(`{"Foo": []}`))); err == nil {
+		t.Error("expected error parsing JSON without instructions")
+	}
+}
+
+func TestDiffTransfers(t *testing.T) {
+	a := testTransfers(10.0, 20.0, 30.0)
+
+	// the second transfer changes, the third goes and one of dna is added
+	b := testTransfers(10.0, 25.0)
+	b = append(b, testTransfer("position_4", "B1", "position_7", "A1", "dna", 2.0)...)
+
+	td := DiffTransfers(a, b)
+
+	if len(td.Changed) != 1 || td.Changed[0].Old.ToWell != "B1" || td.Changed[0].New.Volume.ConvertToString("ul") != 25.0 {
+		t.Errorf("expected transfer to B1 to change to 25 ul, got %v", td.Changed)
+	}
+
+	if len(td.Removed) != 1 || td.Removed[0].ToWell != "C1" {
+		t.Errorf("expected transfer to C1 to be removed, got %v", td.Removed)
+	}
+
+	if len(td.Added) != 1 || td.Added[0].What != "dna" || td.Added[0].FromWell != "B1" {
+		t.Errorf("expected transfer of dna to be added, got %v", td.Added)
+	}
+}
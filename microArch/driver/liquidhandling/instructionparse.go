@@ -0,0 +1,46 @@
+package liquidhandling
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// ParseInstructions reads back robot instructions written as JSON, either as
+// a SetOfRobotInstructions or as a bare array of instructions
+func ParseInstructions(r io.Reader) ([]RobotInstruction, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	b = bytes.TrimSpace(b)
+
+	if len(b) == 0 {
+		return nil, fmt.Errorf("no instructions to parse")
+	}
+
+	if b[0] == '[' {
+		b = append(append([]byte(`{"RobotInstructions":`), b...), '}')
+	}
+
+	var objectMap map[string]*json.RawMessage
+
+	if err := json.Unmarshal(b, &objectMap); err != nil {
+		return nil, err
+	}
+
+	if m, ok := objectMap["RobotInstructions"]; !ok || m == nil {
+		return nil, fmt.Errorf("no RobotInstructions found")
+	}
+
+	var sori SetOfRobotInstructions
+
+	if err := json.Unmarshal(b, &sori); err != nil {
+		return nil, err
+	}
+
+	return sori.RobotInstructions, nil
+}
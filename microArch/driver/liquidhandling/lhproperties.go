@@ -74,6 +74,8 @@ type LHProperties struct {
 	Cnfvol               []*wtype.LHChannelParameter
 	Layout               map[string]wtype.Coordinates
 	MaterialType         material.MaterialType
+	// how to choose channels and tips, DefaultChannelScoreFunc if nil
+	ChannelScorer ChannelScoreFunc `gotopb:"-" json:"-"`
 }
 
 // utility print function
@@ -373,6 +375,8 @@ func (lhp *LHProperties) dup(keepIDs bool) *LHProperties {
 
 	r.MaterialType = lhp.MaterialType
 
+	r.ChannelScorer = lhp.ChannelScorer
+
 	// copy the driver
 
 	r.Driver = lhp.Driver
@@ -433,7 +437,7 @@ func (lhp *LHProperties) TipsLeftOfType(tiptype string) int {
 
 	for _, pref := range lhp.Tip_preferences {
 		tb := lhp.Tipboxes[pref]
-		if tb != nil {
+		if tb != nil && tb.Tiptype.Type == tiptype {
 			n += tb.N_clean_tips()
 		}
 	}
@@ -480,7 +484,7 @@ func (lhp *LHProperties) TipWastesMounted() int {
 	r := 0
 	for _, pref := range lhp.Tipwaste_preferences {
 		if lhp.PosLookup[pref] != "" {
-			_, ok := lhp.Tipwastes[lhp.PosLookup[pref]]
+			_, ok := lhp.Tipwastes[pref]
 
 			if !ok {
 				logger.Debug(fmt.Sprintf("Position %s claims to have a tipbox but is empty", pref))
@@ -499,7 +503,7 @@ func (lhp *LHProperties) TipSpacesLeft() int {
 	r := 0
 	for _, pref := range lhp.Tipwaste_preferences {
 		if lhp.PosLookup[pref] != "" {
-			bx, ok := lhp.Tipwastes[lhp.PosLookup[pref]]
+			bx, ok := lhp.Tipwastes[pref]
 
 			if !ok {
 				logger.Debug(fmt.Sprintf("Position %s claims to have a tipbox but is empty", pref))
@@ -513,6 +517,58 @@ func (lhp *LHProperties) TipSpacesLeft() int {
 	return r
 }
 
+// EmptyTipwastes empties every tip waste on the deck which has anything in
+// it, returning the positions of those emptied
+func (lhp *LHProperties) EmptyTipwastes() []string {
+	var ret []string
+	for _, pref := range lhp.Tipwaste_preferences {
+		tw, ok := lhp.Tipwastes[pref]
+		if !ok || tw.Contents == 0 {
+			continue
+		}
+		tw.Empty()
+		ret = append(ret, pref)
+	}
+
+	return ret
+}
+
+// ReplaceTipboxes refills boxes of tip type tiptype which cannot supply n
+// tips, as if the operator had swapped them for full ones, returning the
+// positions of the boxes replaced. If every box of this type has n tips
+// left, the emptiest is replaced
+func (lhp *LHProperties) ReplaceTipboxes(tiptype string, n int) []string {
+	var replace []string
+	emptiest := ""
+
+	for _, pref := range lhp.Tip_preferences {
+		bx, ok := lhp.Tipboxes[pref]
+		if !ok || bx.Tiptype.Type != tiptype {
+			continue
+		}
+
+		left := bx.N_clean_tips()
+
+		if left < n {
+			replace = append(replace, pref)
+		}
+
+		if left < bx.NTips && (emptiest == "" || left < lhp.Tipboxes[emptiest].N_clean_tips()) {
+			emptiest = pref
+		}
+	}
+
+	if len(replace) == 0 && emptiest != "" {
+		replace = append(replace, emptiest)
+	}
+
+	for _, pos := range replace {
+		lhp.Tipboxes[pos].Refresh()
+	}
+
+	return replace
+}
+
 func (lhp *LHProperties) AddTipWaste(tipwaste *wtype.LHTipwaste) error {
 	for _, pref := range lhp.Tipwaste_preferences {
 		if lhp.PosLookup[pref] != "" {
@@ -566,6 +622,63 @@ func (lhp *LHProperties) RemovePlateAtPosition(pos string) {
 	delete(lhp.Plates, pos)
 }
 
+// RelabelPositions moves whatever is at each position in newpos to the
+// position it maps to. Positions not mentioned stay where they are; the
+// caller must make sure no two things end up in the same place
+func (lhp *LHProperties) RelabelPositions(newpos map[string]string) {
+	to := func(pos string) string {
+		if np, ok := newpos[pos]; ok {
+			return np
+		}
+		return pos
+	}
+
+	posLookup := make(map[string]string, len(lhp.PosLookup))
+	for pos, id := range lhp.PosLookup {
+		if id == "" {
+			continue
+		}
+		posLookup[to(pos)] = id
+		lhp.PlateIDLookup[id] = to(pos)
+	}
+	for pos, id := range lhp.PosLookup {
+		if _, ok := posLookup[pos]; !ok && id == "" {
+			posLookup[pos] = ""
+		}
+	}
+	lhp.PosLookup = posLookup
+
+	plates := make(map[string]*wtype.LHPlate, len(lhp.Plates))
+	for pos, p := range lhp.Plates {
+		plates[to(pos)] = p
+	}
+	lhp.Plates = plates
+
+	tipboxes := make(map[string]*wtype.LHTipbox, len(lhp.Tipboxes))
+	for pos, tb := range lhp.Tipboxes {
+		tipboxes[to(pos)] = tb
+	}
+	lhp.Tipboxes = tipboxes
+
+	tipwastes := make(map[string]*wtype.LHTipwaste, len(lhp.Tipwastes))
+	for pos, tw := range lhp.Tipwastes {
+		tipwastes[to(pos)] = tw
+	}
+	lhp.Tipwastes = tipwastes
+
+	wastes := make(map[string]*wtype.LHPlate, len(lhp.Wastes))
+	for pos, w := range lhp.Wastes {
+		wastes[to(pos)] = w
+	}
+	lhp.Wastes = wastes
+
+	washes := make(map[string]*wtype.LHPlate, len(lhp.Washes))
+	for pos, w := range lhp.Washes {
+		washes[to(pos)] = w
+	}
+	lhp.Washes = washes
+}
+
 func (lhp *LHProperties) addWaste(waste *wtype.LHPlate) bool {
 	for _, pref := range lhp.Waste_preferences {
 		if lhp.PosLookup[pref] != "" {
@@ -1018,6 +1131,10 @@ func (lhp *LHProperties) GetTimer() *LHTimer {
 func (lhp *LHProperties) GetChannelScoreFunc() ChannelScoreFunc {
 	// this is to permit us to make this flexible
 
+	if lhp.ChannelScorer != nil {
+		return lhp.ChannelScorer
+	}
+
 	sc := DefaultChannelScoreFunc{}
 
 	return sc
@@ -3,9 +3,11 @@ package liquidhandling
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
 	"github.com/antha-lang/antha/inventory"
 	"github.com/antha-lang/antha/inventory/testinventory"
 )
@@ -80,3 +82,80 @@ func TestGetFirstDefined(t *testing.T) {
 		}
 	}
 }
+
+func countMessages(inss []RobotInstruction, what string) int {
+	n := 0
+	for _, ins := range inss {
+		if msg, ok := ins.(*MessageInstruction); ok && strings.Contains(msg.Message, what) {
+			n += 1
+		}
+	}
+	return n
+}
+
+func TestGetTipsReplenishes(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	params, err := makeTestGilson(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// no room for any more tip boxes
+	params.Tip_preferences = []string{}
+	for pos := range params.Tipboxes {
+		params.Tip_preferences = append(params.Tip_preferences, pos)
+	}
+
+	params.Tips = nil
+	for _, tb := range params.Tipboxes {
+		params.Tips = append(params.Tips, tb.Tips[0][0])
+	}
+
+	for _, tw := range params.Tipwastes {
+		tw.Capacity = 50
+	}
+
+	channel, tip := ChooseChannel(wunit.NewVolume(5.0, "ul"), params)
+	if tip == nil {
+		t.Fatal("no tip chosen for 5 ul")
+	}
+
+	var bx *wtype.LHTipbox
+	for _, tb := range params.Tipboxes {
+		if tb.Tiptype.Type == tip.Type {
+			bx = tb
+		}
+	}
+
+	var inss []RobotInstruction
+	for i := 0; i < bx.NTips+10; i++ {
+		tt, chanA := tipArrays(channel.Multi)
+		tt[0] = tip.Type
+		chanA[0] = channel
+
+		get, err := GetTips(ctx, tt, params, chanA, false)
+		if err != nil {
+			t.Fatalf("getting tip %d: %s", i+1, err)
+		}
+		inss = append(inss, get...)
+
+		drop, err := DropTips(tt, params, chanA)
+		if err != nil {
+			t.Fatalf("dropping tip %d: %s", i+1, err)
+		}
+		inss = append(inss, drop)
+	}
+
+	if n := countMessages(inss, "replace the tip box"); n != 1 {
+		t.Errorf("expected 1 tip box replacement, got %d", n)
+	}
+
+	if n := countMessages(inss, "empty the tip waste"); n != (bx.NTips+10)/50 {
+		t.Errorf("expected %d tip waste emptyings, got %d", (bx.NTips+10)/50, n)
+	}
+
+	if n := bx.N_clean_tips(); n != bx.NTips-10 {
+		t.Errorf("expected %d clean tips left after replacement, got %d", bx.NTips-10, n)
+	}
+}
@@ -1,6 +1,10 @@
 package liquidhandling
 
-import "time"
+import (
+	"time"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
 
 // records timing info
 // preliminary implementation assumes all instructions of a given
@@ -9,6 +13,9 @@ import "time"
 
 type LHTimer struct {
 	Times []time.Duration
+	// speed in mm/s at which the head travels across the deck, zero if
+	// travel time is taken to be included in the time for each move
+	HeadSpeed float64
 }
 
 func NewTimer() *LHTimer {
@@ -47,3 +54,41 @@ func (t *LHTimer) TimeFor(r RobotInstruction) time.Duration {
 	}
 	return d
 }
+
+// TimeSavedBy estimates the time saved by instructions which replace several
+// simpler ones, currently only multi-dispenses: each destination beyond the
+// first avoids a move and aspirate plus a move and blowout, at the cost of
+// disposing of the excess
+func (t *LHTimer) TimeSavedBy(r RobotInstruction) time.Duration {
+	var d time.Duration
+
+	mdi, ok := r.(*MultiDispenseInstruction)
+
+	if !ok || mdi.NDispenses() < 2 {
+		return d
+	}
+
+	per := t.Times[MOV] + t.Times[ASP] + t.Times[MOV] + t.Times[BLO]
+	d = time.Duration(int64(mdi.NDispenses()-1) * int64(per))
+
+	if !mdi.Excess.IsZero() {
+		d -= t.Times[MOV] + t.Times[DSP]
+	}
+
+	return d
+}
+
+// TimeForTravel estimates the time taken for the head to travel d mm
+func (t *LHTimer) TimeForTravel(d float64) time.Duration {
+	if t.HeadSpeed <= 0.0 || d <= 0.0 {
+		return time.Duration(0)
+	}
+
+	return time.Duration(d / t.HeadSpeed * float64(time.Second))
+}
+
+// TimeForMove estimates the time taken for the head to travel between two
+// deck positions
+func (t *LHTimer) TimeForMove(from, to wtype.Coordinates) time.Duration {
+	return t.TimeForTravel(deckDistance(from, to))
+}
@@ -0,0 +1,203 @@
+// /anthalib/driver/liquidhandling/liquidlevel.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package liquidhandling
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/microArch/logger"
+)
+
+// well references used in moves
+const (
+	WellBottom = iota
+	WellTop
+	LiquidLevel
+)
+
+// wellAt returns the well at the given deck position and address, or nil
+// if there is no such well
+func wellAt(prms *LHProperties, pos, address string) *wtype.LHWell {
+	if prms == nil || pos == "" || address == "" {
+		return nil
+	}
+
+	plate, ok := prms.Plates[pos]
+
+	if !ok || plate == nil {
+		return nil
+	}
+
+	return plate.Wellcoords[address]
+}
+
+// tipHeight converts a reference and z offset into a height in mm above the
+// bottom of the well given the height of the liquid surface
+func tipHeight(ref int, ofz float64, well *wtype.LHWell, liquidheight float64) float64 {
+	switch ref {
+	case WellTop:
+		return well.Zdim + ofz
+	case LiquidLevel:
+		return liquidheight + ofz
+	default:
+		return ofz
+	}
+}
+
+func heightAfter(well *wtype.LHWell, before, change wunit.Volume, add bool) (float64, float64, error) {
+	hb, err := well.HeightForVolume(before)
+
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+
+	after := wunit.CopyVolume(before)
+
+	if add {
+		after.Add(change)
+	} else {
+		after.Subtract(change)
+	}
+
+	ha, err := well.HeightForVolume(after)
+
+	if err != nil {
+		return 0.0, 0.0, err
+	}
+
+	return hb.ConvertToString("mm"), ha.ConvertToString("mm"), nil
+}
+
+// aspirateHeight works out where to put the tip to aspirate vol from a well
+// containing before when following the liquid level: LLF_BELOW_SURFACE mm
+// under the surface but no lower than ASPZOFFSET above the well bottom. The
+// tip must follow the liquid down if the surface will fall below it
+func aspirateHeight(pol wtype.LHPolicy, well *wtype.LHWell, before, vol wunit.Volume) (float64, bool, error) {
+	hb, ha, err := heightAfter(well, before, vol, false)
+
+	if err != nil {
+		return 0.0, false, err
+	}
+
+	z := math.Max(hb-SafeGetF64(pol, "LLF_BELOW_SURFACE"), SafeGetF64(pol, "ASPZOFFSET"))
+
+	return z, ha < z, nil
+}
+
+// dispenseHeight works out where to put the tip to dispense vol into a well
+// containing before: the dispense reference and offset are turned into a
+// height above the well bottom and the tip follows the liquid up if it
+// would otherwise end up below the surface
+func dispenseHeight(pol wtype.LHPolicy, well *wtype.LHWell, before, vol wunit.Volume) (float64, bool, error) {
+	hb, ha, err := heightAfter(well, before, vol, true)
+
+	if err != nil {
+		return 0.0, false, err
+	}
+
+	z := tipHeight(SafeGetInt(pol, "DSPREFERENCE"), SafeGetF64(pol, "DSPZOFFSET"), well, hb)
+
+	if z < 0.0 {
+		z = 0.0
+	}
+
+	return z, z < ha, nil
+}
+
+// applyLiquidLevel sets the move references and offsets and the LLF flags
+// for channels whose wells have known geometry. Channels where the height
+// can't be worked out are left as set by the policy
+func applyLiquidLevel(mov *MoveInstruction, llf []bool, prms *LHProperties, pos, wells []string, before, vols []wunit.Volume, f func(*wtype.LHWell, wunit.Volume, wunit.Volume) (float64, bool, error), ofzadj float64) {
+	for i := 0; i < len(mov.OffsetZ) && i < len(llf); i++ {
+		if i >= len(pos) || i >= len(wells) || i >= len(before) || i >= len(vols) {
+			break
+		}
+
+		well := wellAt(prms, pos[i], wells[i])
+
+		if well == nil {
+			continue
+		}
+
+		z, follow, err := f(well, before[i], vols[i])
+
+		if err != nil {
+			logger.Debug(fmt.Sprintf("not using liquid level for %s %s: %s", pos[i], wells[i], err.Error()))
+			continue
+		}
+
+		mov.Reference[i] = WellBottom
+		mov.OffsetZ[i] = z + ofzadj
+		llf[i] = follow
+	}
+}
+
+// CheckAspirateHeights flags every aspirate in ris for which the tip would
+// be above the surface of the liquid, working out where the tip is from the
+// move which precedes it
+func CheckAspirateHeights(ris []RobotInstruction, prms *LHProperties) []error {
+	errs := make([]error, 0)
+
+	var lastMove *MoveInstruction
+
+	for _, ri := range ris {
+		switch ins := ri.(type) {
+		case *MoveInstruction:
+			lastMove = ins
+		case *AspirateInstruction:
+			if lastMove == nil {
+				continue
+			}
+
+			for i := range lastMove.Pos {
+				if i >= len(lastMove.Well) || i >= len(lastMove.WVolume) || i >= len(lastMove.Reference) || i >= len(lastMove.OffsetZ) {
+					break
+				}
+
+				well := wellAt(prms, lastMove.Pos[i], lastMove.Well[i])
+
+				if well == nil {
+					continue
+				}
+
+				h, err := well.HeightForVolume(lastMove.WVolume[i])
+
+				if err != nil {
+					continue
+				}
+
+				lh := h.ConvertToString("mm")
+				z := tipHeight(lastMove.Reference[i], lastMove.OffsetZ[i], well, lh)
+
+				if z > lh {
+					errs = append(errs, wtype.LHError(wtype.LH_ERR_VOL, fmt.Sprintf("aspirate from %s %s: tip at %.2f mm is above the liquid surface at %.2f mm", lastMove.Pos[i], lastMove.Well[i], z, lh)))
+				}
+			}
+		}
+	}
+
+	return errs
+}
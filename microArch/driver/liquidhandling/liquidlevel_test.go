@@ -0,0 +1,138 @@
+package liquidhandling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/inventory/testinventory"
+)
+
+func getTestSuck(robot *LHProperties, what string, vol, wellvol wunit.Volume) *SuckInstruction {
+	ch, tip := ChooseChannel(vol, robot)
+	ins := NewSuckInstruction()
+	ins.AddTransferParams(TransferParams{
+		What:       what,
+		PltFrom:    "position_4",
+		WellFrom:   "A1",
+		Volume:     vol,
+		FPlateType: "pcrplate_skirted_riser40",
+		FVolume:    wellvol,
+		Channel:    ch.MergeWithTip(tip),
+		TipType:    tip.Type,
+	})
+	ins.Multi = 1
+	return ins
+}
+
+func useLLFPolicy(what string) (*wtype.LHPolicyRuleSet, error) {
+	pol, err := GetLHPolicyForTest()
+	if err != nil {
+		return nil, err
+	}
+
+	rule := wtype.NewLHPolicyRule("LLFRULE")
+	if err := rule.AddCategoryConditionOn("LIQUIDCLASS", what); err != nil {
+		return nil, err
+	}
+	pols := make(wtype.LHPolicy, 2)
+	pols["USE_LLF"] = true
+	pols["LLF_BELOW_SURFACE"] = 1.0
+	pol.AddRule(rule, pols)
+
+	return pol, nil
+}
+
+func TestAspirateFollowsLiquid(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	robot, err := makeMultiDispenseRobot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pol, err := useLLFPolicy("soup")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	well := robot.Plates["position_4"].Wellcoords["A1"]
+	before := wunit.NewVolume(150.0, "ul")
+	h, err := well.HeightForVolume(before)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// taking most of the liquid out means following it down
+
+	suck := getTestSuck(robot, "soup", wunit.NewVolume(100.0, "ul"), before)
+	ofzadj := SafeGetF64(GetPolicyFor(pol, suck), "OFFSETZADJUST")
+
+	ris, err := suck.Generate(ctx, pol, robot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mov := ris[0].(*MoveInstruction)
+	asp := ris[1].(*AspirateInstruction)
+
+	if mov.Reference[0] != WellBottom {
+		t.Errorf("expected move relative to well bottom, got reference %d", mov.Reference[0])
+	}
+
+	if expected := h.ConvertToString("mm") - 1.0 + ofzadj; mov.OffsetZ[0] != expected {
+		t.Errorf("expected tip 1mm below liquid surface at %f, got %f", expected, mov.OffsetZ[0])
+	}
+
+	if !asp.LLF[0] {
+		t.Errorf("expected aspirate to follow liquid level")
+	}
+
+	// a small volume doesn't need following
+
+	ris, err = getTestSuck(robot, "soup", wunit.NewVolume(1.0, "ul"), before).Generate(ctx, pol, robot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ris[1].(*AspirateInstruction).LLF[0] {
+		t.Errorf("expected small aspirate not to follow liquid level")
+	}
+}
+
+func TestCheckAspirateHeights(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	robot, err := makeMultiDispenseRobot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pol, err := GetLHPolicyForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// default policy aspirates 0.5mm above the well bottom
+
+	ris, err := getTestSuck(robot, "soup", wunit.NewVolume(10.0, "ul"), wunit.NewVolume(150.0, "ul")).Generate(ctx, pol, robot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if errs := CheckAspirateHeights(ris, robot); len(errs) != 0 {
+		t.Errorf("expected no problems aspirating from full well, got %v", errs)
+	}
+
+	// a tiny volume won't reach
+
+	ris, err = getTestSuck(robot, "soup", wunit.NewVolume(0.5, "ul"), wunit.NewVolume(0.5, "ul")).Generate(ctx, pol, robot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if errs := CheckAspirateHeights(ris, robot); len(errs) != 1 {
+		t.Errorf("expected tip above liquid to be flagged, got %d errors", len(errs))
+	}
+}
@@ -23,7 +23,6 @@
 package liquidhandling
 
 import (
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -35,7 +34,7 @@ import (
 	. "github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/doe"
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	"github.com/antha-lang/antha/antha/anthalib/wunit"
-	"github.com/ghodss/yaml"
+	"github.com/antha-lang/antha/microArch/logger"
 )
 
 type PolicyFile struct {
@@ -107,6 +106,7 @@ func MakePolicies() map[string]wtype.LHPolicy {
 	pols["loadwater"] = MakeLoadWaterPolicy()
 	pols["DispenseAboveLiquid"] = MakeDispenseAboveLiquidPolicy()
 	pols["DispenseAboveLiquidMulti"] = MakeDispenseAboveLiquidMultiPolicy()
+	pols["multidispense"] = MakeMultiDispensePolicy()
 	pols["PEG"] = MakePEGPolicy()
 	pols["Protoplasts"] = MakeProtoplastPolicy()
 	pols["dna_mix"] = MakeDNAMixPolicy()
@@ -449,6 +449,14 @@ func MakeDispenseAboveLiquidMultiPolicy() wtype.LHPolicy {
 	return policy
 }
 
+func MakeMultiDispensePolicy() wtype.LHPolicy {
+	policy := MakeDispenseAboveLiquidPolicy()
+	policy["CAN_MULTIDISPENSE"] = true
+	policy["MULTIDISPENSE_EXCESS"] = wunit.NewVolume(2.0, "ul")
+	policy["DESCRIPTION"] = "Dispense above the liquid, aspirating once for several destinations from the same source. An excess of 2ul is taken up and disposed of after the last dispense."
+	return policy
+}
+
 func MakeColonyPolicy() wtype.LHPolicy {
 	policy := make(wtype.LHPolicy, 12)
 	policy["DSPREFERENCE"] = 0
@@ -838,6 +846,8 @@ func MakeDefaultPolicy() wtype.LHPolicy {
 	defaultpolicy["DONT_BE_DIRTY"] = true
 	defaultpolicy["POST_MIX_Z"] = 0.5
 	defaultpolicy["PRE_MIX_Z"] = 0.5
+	defaultpolicy["USE_LLF"] = false
+	defaultpolicy["LLF_BELOW_SURFACE"] = 1.0
 	defaultpolicy["DESCRIPTION"] = "Default mix Policy. Blowout performed, no touch off, no mixing, tip reuse permitted for the same solution."
 
 	return defaultpolicy
@@ -1187,6 +1197,8 @@ func GetLHPolicyForTest() (*wtype.LHPolicyRuleSet, error) {
 
 }
 
+// LoadLHPoliciesFromFile reads policies from the JSON or YAML file named by
+// the environment variable ANTHA_LHPOLICIES_FILE, see ReadLHPolicies
 func LoadLHPoliciesFromFile() (*wtype.LHPolicyRuleSet, error) {
 	lhPoliciesFileName := os.Getenv("ANTHA_LHPOLICIES_FILE")
 	if lhPoliciesFileName == "" {
@@ -1196,27 +1208,12 @@ func LoadLHPoliciesFromFile() (*wtype.LHPolicyRuleSet, error) {
 	if err != nil {
 		return nil, err
 	}
-	lhprs := wtype.NewLHPolicyRuleSet()
-	lhprs.Policies = make(map[string]wtype.LHPolicy)
-	lhprs.Rules = make(map[string]wtype.LHPolicyRule)
-	//	err = readYAML(contents, lhprs)
-	err = readJSON(contents, lhprs)
+	lhprs, problems, err := ReadLHPolicies(lhPoliciesFileName, contents)
 	if err != nil {
 		return nil, err
 	}
-	return lhprs, nil
-}
-
-func readYAML(fileContents []byte, ruleSet *wtype.LHPolicyRuleSet) error {
-	if err := yaml.Unmarshal(fileContents, ruleSet); err != nil {
-		return err
-	}
-	return nil
-}
-
-func readJSON(fileContents []byte, ruleSet *wtype.LHPolicyRuleSet) error {
-	if err := json.Unmarshal(fileContents, ruleSet); err != nil {
-		return err
+	for _, p := range problems {
+		logger.Info(fmt.Sprintf("%s: %s", lhPoliciesFileName, p))
 	}
-	return nil
+	return lhprs, nil
 }
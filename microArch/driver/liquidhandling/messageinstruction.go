@@ -24,6 +24,22 @@ func NewMessageInstruction(lhi *wtype.LHInstruction) *MessageInstruction {
 	return &msi
 }
 
+// NewReplaceTipboxMessage makes a message pausing the run for the operator
+// to replace the tip box at pos with a full one of type boxtype
+func NewReplaceTipboxMessage(pos, boxtype string) *MessageInstruction {
+	msi := NewMessageInstruction(nil)
+	msi.Message = fmt.Sprintf("Run paused: please replace the tip box at position %s with a full box of %s", pos, boxtype)
+	return msi
+}
+
+// NewEmptyTipwasteMessage makes a message pausing the run for the operator
+// to empty the tip waste at pos
+func NewEmptyTipwasteMessage(pos string) *MessageInstruction {
+	msi := NewMessageInstruction(nil)
+	msi.Message = fmt.Sprintf("Run paused: please empty the tip waste at position %s", pos)
+	return msi
+}
+
 func (msi *MessageInstruction) Generate(ctx context.Context, policy *wtype.LHPolicyRuleSet, prms *LHProperties) ([]RobotInstruction, error) {
 	// use side effect to keep IDs straight
 
@@ -0,0 +1,505 @@
+// /anthalib/driver/liquidhandling/multidispense.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package liquidhandling
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+// MultiDispenseInstruction aspirates once from a single source then
+// dispenses into several destinations in turn. An excess volume set by the
+// MULTIDISPENSE_EXCESS policy is taken up in addition to what is dispensed;
+// this and anything left in the tip is disposed of at the end into a waste
+// plate, so there must be one mounted if the excess is not zero. The excess
+// is never returned to the source since the tip has been into every
+// destination by then
+type MultiDispenseInstruction struct {
+	GenericRobotInstruction
+	Type       int
+	What       string
+	PltFrom    string
+	WellFrom   string
+	FPlateType string
+	FVolume    wunit.Volume
+	PltTo      []string
+	WellTo     []string
+	TPlateType []string
+	TVolume    []wunit.Volume
+	Volume     []wunit.Volume
+	Excess     wunit.Volume
+	Prms       *wtype.LHChannelParameter
+	TipType    string
+}
+
+func NewMultiDispenseInstruction() *MultiDispenseInstruction {
+	var v MultiDispenseInstruction
+	v.Type = SMD
+	v.PltTo = make([]string, 0)
+	v.WellTo = make([]string, 0)
+	v.TPlateType = make([]string, 0)
+	v.TVolume = make([]wunit.Volume, 0)
+	v.Volume = make([]wunit.Volume, 0)
+	v.Excess = wunit.ZeroVolume()
+	v.GenericRobotInstruction.Ins = &v
+	return &v
+}
+
+func (ins *MultiDispenseInstruction) InstructionType() int {
+	return ins.Type
+}
+
+func (ins *MultiDispenseInstruction) GetParameter(name string) interface{} {
+	switch name {
+	case "LIQUIDCLASS":
+		return ins.What
+	case "VOLUME":
+		return ins.Volume
+	case "VOLUNT":
+		return nil
+	case "FROMPLATETYPE":
+		return ins.FPlateType
+	case "WELLFROMVOLUME":
+		return ins.FVolume
+	case "POSFROM":
+		return ins.PltFrom
+	case "POSTO":
+		return ins.PltTo
+	case "WELLFROM":
+		return ins.WellFrom
+	case "PARAMS":
+		return ins.Prms
+	case "PLATFORM":
+		if ins.Prms == nil {
+			return ""
+		}
+		return ins.Prms.Platform
+	case "WELLTO":
+		return ins.WellTo
+	case "WELLTOVOLUME":
+		return ins.TVolume
+	case "TOPLATETYPE":
+		return ins.TPlateType
+	case "INSTRUCTIONTYPE":
+		return ins.InstructionType()
+	case "TIPTYPE":
+		return ins.TipType
+	}
+	return nil
+}
+
+// AddDispense adds another destination to the instruction
+func (ins *MultiDispenseInstruction) AddDispense(tp TransferParams) {
+	ins.What = tp.What
+	ins.PltFrom = tp.PltFrom
+	ins.WellFrom = tp.WellFrom
+	ins.FPlateType = tp.FPlateType
+	if len(ins.Volume) == 0 {
+		ins.FVolume = wunit.CopyVolume(tp.FVolume)
+	}
+	ins.PltTo = append(ins.PltTo, tp.PltTo)
+	ins.WellTo = append(ins.WellTo, tp.WellTo)
+	ins.TPlateType = append(ins.TPlateType, tp.TPlateType)
+	ins.TVolume = append(ins.TVolume, wunit.CopyVolume(tp.TVolume))
+	ins.Volume = append(ins.Volume, wunit.CopyVolume(tp.Volume))
+	ins.Prms = tp.Channel
+	ins.TipType = tp.TipType
+}
+
+// NDispenses returns the number of destinations served by this instruction
+func (ins *MultiDispenseInstruction) NDispenses() int {
+	return len(ins.Volume)
+}
+
+// DispensedVolume is the total volume delivered to all destinations
+func (ins *MultiDispenseInstruction) DispensedVolume() wunit.Volume {
+	tot := wunit.ZeroVolume()
+	for _, v := range ins.Volume {
+		tot.Add(v)
+	}
+	return tot
+}
+
+// AspiratedVolume is the volume taken up from the source: what is
+// dispensed plus the excess
+func (ins *MultiDispenseInstruction) AspiratedVolume() wunit.Volume {
+	tot := ins.DispensedVolume()
+	tot.Add(ins.Excess)
+	return tot
+}
+
+func (ins *MultiDispenseInstruction) sourceParams() TransferParams {
+	return TransferParams{
+		What:       ins.What,
+		PltFrom:    ins.PltFrom,
+		WellFrom:   ins.WellFrom,
+		Volume:     ins.AspiratedVolume(),
+		FPlateType: ins.FPlateType,
+		FVolume:    wunit.CopyVolume(ins.FVolume),
+		Channel:    ins.Prms,
+		TipType:    ins.TipType,
+	}
+}
+
+func (ins *MultiDispenseInstruction) destParams(i int) TransferParams {
+	return TransferParams{
+		What:       ins.What,
+		PltFrom:    ins.PltFrom,
+		PltTo:      ins.PltTo[i],
+		WellFrom:   ins.WellFrom,
+		WellTo:     ins.WellTo[i],
+		Volume:     wunit.CopyVolume(ins.Volume[i]),
+		FPlateType: ins.FPlateType,
+		TPlateType: ins.TPlateType[i],
+		FVolume:    wunit.CopyVolume(ins.FVolume),
+		TVolume:    wunit.CopyVolume(ins.TVolume[i]),
+		Channel:    ins.Prms,
+		TipType:    ins.TipType,
+	}
+}
+
+// wasteFor finds the first waste plate mounted, if any
+func wasteFor(prms *LHProperties) (string, *wtype.LHPlate) {
+	for _, pos := range prms.Waste_preferences {
+		if waste, ok := prms.Wastes[pos]; ok && waste != nil {
+			return pos, waste
+		}
+	}
+	return "", nil
+}
+
+// disposalParams sends the excess to the first waste plate mounted
+func (ins *MultiDispenseInstruction) disposalParams(prms *LHProperties) (TransferParams, error) {
+	pos, waste := wasteFor(prms)
+	if waste == nil {
+		return TransferParams{}, wtype.LHError(wtype.LH_ERR_OTHER, fmt.Sprintf("multi-dispense of %s needs a waste for %s excess but none is mounted", ins.What, ins.Excess.ToString()))
+	}
+
+	tp := ins.sourceParams()
+	tp.Volume = wunit.CopyVolume(ins.Excess)
+	tp.PltTo = pos
+	tp.WellTo = "A1"
+	tp.TPlateType = waste.Type
+	tp.TVolume = wunit.ZeroVolume()
+	if w, ok := waste.Wellcoords["A1"]; ok {
+		tp.TVolume = w.CurrentVolume()
+	}
+	return tp, nil
+}
+
+func (ins *MultiDispenseInstruction) Generate(ctx context.Context, policy *wtype.LHPolicyRuleSet, prms *LHProperties) ([]RobotInstruction, error) {
+	ret := make([]RobotInstruction, 0)
+
+	if len(ins.Volume) == 0 {
+		return ret, nil
+	}
+
+	// one aspirate for everything
+
+	suck := NewSuckInstruction()
+	suck.AddTransferParams(ins.sourceParams())
+	suck.Multi = 1
+	ret = append(ret, suck)
+
+	// then a dispense per destination, with no reset in between; the last
+	// dispense, either to the last destination or of the excess into the
+	// waste, is a full one which blows out and resets like any other
+
+	last := ins.destParams(len(ins.Volume) - 1)
+	n := len(ins.Volume) - 1
+
+	if !ins.Excess.IsZero() {
+		disp, err := ins.disposalParams(prms)
+		if err != nil {
+			return ret, err
+		}
+		last = disp
+		n = len(ins.Volume)
+	}
+
+	for i := 0; i < n; i++ {
+		ret = append(ret, dispenseWithoutReset(policy, prms, ins.destParams(i))...)
+	}
+
+	blw := NewBlowInstruction()
+	blw.AddTransferParams(last)
+	blw.Multi = 1
+	ret = append(ret, blw)
+
+	return ret, nil
+}
+
+// dispenseWithoutReset is a cut-down version of BlowInstruction.Generate:
+// it moves to the destination and dispenses but does not blow out, post-mix
+// or reset since there is still liquid in the tip
+func dispenseWithoutReset(policy *wtype.LHPolicyRuleSet, prms *LHProperties, tp TransferParams) []RobotInstruction {
+	ret := make([]RobotInstruction, 0, 5)
+
+	blw := NewBlowInstruction()
+	blw.AddTransferParams(tp)
+	blw.Multi = 1
+	pol := GetPolicyFor(policy, blw)
+
+	ofx := SafeGetF64(pol, "DSPXOFFSET")
+	ofy := SafeGetF64(pol, "DSPYOFFSET")
+	ofz := SafeGetF64(pol, "DSPZOFFSET")
+	ofzadj := SafeGetF64(pol, "OFFSETZADJUST")
+	ofz += ofzadj
+	ref := SafeGetInt(pol, "DSPREFERENCE")
+
+	mov := NewMoveInstruction()
+	mov.Head = blw.Head
+	mov.Pos = blw.PltTo
+	mov.Plt = blw.TPlateType
+	mov.Well = blw.WellTo
+	mov.WVolume = blw.TVolume
+	mov.Reference = []int{ref}
+	mov.OffsetX = []float64{ofx}
+	mov.OffsetY = []float64{ofy}
+	mov.OffsetZ = []float64{ofz}
+
+	llf := []bool{false}
+
+	if SafeGetBool(pol, "USE_LLF") {
+		applyLiquidLevel(mov, llf, prms, blw.PltTo, blw.WellTo, blw.TVolume, blw.Volume, func(w *wtype.LHWell, before, vol wunit.Volume) (float64, bool, error) {
+			return dispenseHeight(pol, w, before, vol)
+		}, ofzadj)
+	}
+
+	ret = append(ret, mov)
+
+	pspeed := SafeGetF64(pol, "DEFAULTPIPETTESPEED")
+	dpspeed := SafeGetF64(pol, "DSPSPEED")
+	setpspeed := pspeed != dpspeed && dpspeed != 0.0
+
+	if setpspeed {
+		sps := NewSetPipetteSpeedInstruction()
+		sps.Head = blw.Head
+		sps.Channel = -1 // all channels
+		sps.Speed = dpspeed
+		ret = append(ret, sps)
+	}
+
+	dspins := NewDispenseInstruction()
+	dspins.Head = blw.Head
+	dspins.Volume = blw.Volume
+	dspins.Multi = 1
+	dspins.Plt = blw.TPlateType
+	dspins.What = blw.What
+	dspins.LLF = llf
+	ret = append(ret, dspins)
+
+	if setpspeed {
+		sps := NewSetPipetteSpeedInstruction()
+		sps.Head = blw.Head
+		sps.Channel = -1 // all channels
+		sps.Speed = pspeed
+		ret = append(ret, sps)
+	}
+
+	wait_time := SafeGetF64(pol, "DSP_WAIT")
+
+	if wait_time > 0.0 {
+		waitins := NewWaitInstruction()
+		waitins.Time = wait_time
+		ret = append(ret, waitins)
+	}
+
+	if SafeGetBool(pol, "TOUCHOFF") {
+		mov := NewMoveInstruction()
+		mov.Head = blw.Head
+		mov.Pos = blw.PltTo
+		mov.Plt = blw.TPlateType
+		mov.Well = blw.WellTo
+		mov.WVolume = blw.TVolume
+		mov.Reference = []int{0}
+		mov.OffsetX = []float64{0.0}
+		mov.OffsetY = []float64{0.0}
+		mov.OffsetZ = []float64{SafeGetF64(pol, "TOUCHOFFSET")}
+		ret = append(ret, mov)
+	}
+
+	return ret
+}
+
+// transferPolicy finds the policy which applies to the single transfer
+// with parameters tp
+func transferPolicy(policy *wtype.LHPolicyRuleSet, tp TransferParams) wtype.LHPolicy {
+	stci := NewSingleChannelTransferInstruction()
+	stci.What = tp.What
+	stci.PltFrom = tp.PltFrom
+	stci.PltTo = tp.PltTo
+	stci.WellFrom = tp.WellFrom
+	stci.WellTo = tp.WellTo
+	stci.Volume = tp.Volume
+	stci.FPlateType = tp.FPlateType
+	stci.TPlateType = tp.TPlateType
+	stci.FVolume = tp.FVolume
+	stci.TVolume = tp.TVolume
+	stci.Prms = tp.Channel
+	stci.TipType = tp.TipType
+	stci.GenericRobotInstruction.Ins = stci
+
+	return GetPolicyFor(policy, stci)
+}
+
+// canMultiDispense decides whether the transfer with parameters tp may be
+// one of the dispenses of a multi-dispense, returning the excess to be
+// disposed of and the additional volume taken up by the aspirate
+func canMultiDispense(policy *wtype.LHPolicyRuleSet, tp TransferParams) (bool, wunit.Volume, wunit.Volume) {
+	pol := transferPolicy(policy, tp)
+
+	if !SafeGetBool(pol, "CAN_MULTIDISPENSE") {
+		return false, wunit.ZeroVolume(), wunit.ZeroVolume()
+	}
+
+	// anything which puts the tip into the destination liquid or empties it
+	// prevents us carrying on
+
+	if SafeGetBool(pol, "JUSTBLOWOUT") || SafeGetInt(pol, "POST_MIX") > 0 {
+		return false, wunit.ZeroVolume(), wunit.ZeroVolume()
+	}
+
+	if SafeGetInt(pol, "DSPREFERENCE") == 0 && !tp.TVolume.IsZero() {
+		return false, wunit.ZeroVolume(), wunit.ZeroVolume()
+	}
+
+	return true, SafeGetVolume(pol, "MULTIDISPENSE_EXCESS"), SafeGetVolume(pol, "EXTRA_ASP_VOLUME")
+}
+
+// multiDispenseSetFrom finds the number of transfers starting at t which
+// can be merged into a single multi-dispense: they must share a source and
+// the channel chosen for the total volume, including any excess, must be
+// able to deliver each of them in a single movement. Returns 1 if
+// multi-dispensing is not possible, otherwise the excess and the channel
+// and tip to use
+func (ins *SingleChannelBlockInstruction) multiDispenseSetFrom(t int, policy *wtype.LHPolicyRuleSet, prms *LHProperties) (int, wunit.Volume, *wtype.LHChannelParameter, *wtype.LHTip) {
+	n := 0
+	excess := wunit.ZeroVolume()
+	total := wunit.ZeroVolume()
+	var channel *wtype.LHChannelParameter
+	var tip *wtype.LHTip
+
+	for j := t; j < len(ins.Volume); j++ {
+		if ins.What[j] != ins.What[t] || ins.PltFrom[j] != ins.PltFrom[t] || ins.WellFrom[j] != ins.WellFrom[t] {
+			break
+		}
+
+		ch, tp := ChooseChannelWithPolicy(ins.Volume[j], prms, ins.transferPolicy(j, ins.Volume[j], policy))
+
+		if tp == nil {
+			break
+		}
+
+		ok, exc, extra := canMultiDispense(policy, ins.transferParams(j, ch.MergeWithTip(tp), tp.Type))
+
+		if !ok {
+			break
+		}
+
+		// excess can only go to the waste, without one we do single
+		// transfers instead
+
+		if j == t && !exc.IsZero() {
+			if _, waste := wasteFor(prms); waste == nil {
+				break
+			}
+		}
+
+		if j == t {
+			excess = exc
+			total.Add(exc)
+			total.Add(extra)
+		}
+
+		total.Add(ins.Volume[j])
+
+		// don't take more than the source has
+
+		if !ins.FVolume[t].IsZero() && total.GreaterThan(ins.FVolume[t]) {
+			break
+		}
+
+		// the whole lot must fit in one tip and each dispense must be
+		// possible with it
+
+		ch, tp = ChooseChannelWithPolicy(total, prms, ins.transferPolicy(t, total, policy))
+
+		if tp == nil {
+			break
+		}
+
+		merged := ch.MergeWithTip(tp)
+
+		if total.GreaterThan(merged.Maxvol) || !ins.allAtLeast(t, j, merged.Minvol) {
+			break
+		}
+
+		n = j - t + 1
+		channel = ch
+		tip = tp
+	}
+
+	if n < 2 {
+		return 1, wunit.ZeroVolume(), nil, nil
+	}
+
+	return n, excess, channel, tip
+}
+
+func (ins *SingleChannelBlockInstruction) allAtLeast(from, to int, min wunit.Volume) bool {
+	for i := from; i <= to; i++ {
+		if ins.Volume[i].LessThan(min) {
+			return false
+		}
+	}
+	return true
+}
+
+func (ins *SingleChannelBlockInstruction) transferParams(t int, channel *wtype.LHChannelParameter, tiptype string) TransferParams {
+	return TransferParams{
+		What:       ins.What[t],
+		PltFrom:    ins.PltFrom[t],
+		PltTo:      ins.PltTo[t],
+		WellFrom:   ins.WellFrom[t],
+		WellTo:     ins.WellTo[t],
+		Volume:     wunit.CopyVolume(ins.Volume[t]),
+		FPlateType: ins.FPlateType[t],
+		TPlateType: ins.TPlateType[t],
+		FVolume:    wunit.CopyVolume(ins.FVolume[t]),
+		TVolume:    wunit.CopyVolume(ins.TVolume[t]),
+		Channel:    channel,
+		TipType:    tiptype,
+	}
+}
+
+// transferPolicy finds the policy for transfer t were it to move vol, this
+// is needed before a channel can be chosen for it
+func (ins *SingleChannelBlockInstruction) transferPolicy(t int, vol wunit.Volume, policy *wtype.LHPolicyRuleSet) wtype.LHPolicy {
+	tp := ins.transferParams(t, nil, "")
+	tp.Volume = wunit.CopyVolume(vol)
+	return transferPolicy(policy, tp)
+}
@@ -0,0 +1,409 @@
+package liquidhandling
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/testinventory"
+)
+
+func makeMultiDispenseRobot(ctx context.Context) (*LHProperties, error) {
+	robot, err := makeTestGilson(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := inventory.NewPlate(ctx, "pcrplate_skirted_riser40")
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := inventory.NewComponent(ctx, inventory.WaterType)
+	if err != nil {
+		return nil, err
+	}
+	c.Vol = 150.0
+	c.Vunit = "ul"
+	src.Wellcoords["A1"].Add(c)
+
+	if err := robot.AddPlate("position_4", src); err != nil {
+		return nil, err
+	}
+
+	dst, err := inventory.NewPlate(ctx, "pcrplate_skirted_riser40")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := robot.AddPlate("position_5", dst); err != nil {
+		return nil, err
+	}
+
+	return robot, nil
+}
+
+// addWaste mounts a waste for multi-dispense excess to go to
+func addWaste(ctx context.Context, robot *LHProperties) error {
+	waste, err := inventory.NewPlate(ctx, "DWR1")
+	if err != nil {
+		return err
+	}
+
+	if !robot.AddWasteTo("position_9", waste) {
+		return fmt.Errorf("cannot add waste")
+	}
+
+	return nil
+}
+
+func getMultiDispenseBlock(what string, n int, vol wunit.Volume) *SingleChannelBlockInstruction {
+	ins := NewSingleChannelBlockInstruction()
+	wells := []string{"A1", "B1", "C1", "D1", "E1", "F1", "G1", "H1"}
+	for i := 0; i < n; i++ {
+		ins.AddTransferParams(TransferParams{
+			What:       what,
+			PltFrom:    "position_4",
+			PltTo:      "position_5",
+			WellFrom:   "A1",
+			WellTo:     wells[i],
+			Volume:     wunit.CopyVolume(vol),
+			FPlateType: "pcrplate_skirted_riser40",
+			TPlateType: "pcrplate_skirted_riser40",
+			FVolume:    wunit.NewVolume(150.0, "ul"),
+			TVolume:    wunit.ZeroVolume(),
+		})
+	}
+	return ins
+}
+
+func countTypes(ris []RobotInstruction) map[int]int {
+	ret := make(map[int]int)
+	for _, ins := range ris {
+		ret[ins.InstructionType()] += 1
+	}
+	return ret
+}
+
+func TestMultiDispenseGrouping(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	robot, err := makeMultiDispenseRobot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := addWaste(ctx, robot); err != nil {
+		t.Fatal(err)
+	}
+
+	pol, err := GetLHPolicyForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ins := getMultiDispenseBlock("multidispense", 4, wunit.NewVolume(20.0, "ul"))
+
+	ris, err := ins.Generate(ctx, pol, robot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	types := countTypes(ris)
+
+	if types[SMD] != 1 || types[SCT] != 0 {
+		t.Fatalf("expected one multi-dispense and no single transfers, got %d and %d", types[SMD], types[SCT])
+	}
+
+	var mdi *MultiDispenseInstruction
+	for _, ri := range ris {
+		if ri.InstructionType() == SMD {
+			mdi = ri.(*MultiDispenseInstruction)
+		}
+	}
+
+	if mdi.NDispenses() != 4 {
+		t.Errorf("expected 4 dispenses, got %d", mdi.NDispenses())
+	}
+
+	if !mdi.Excess.EqualTo(wunit.NewVolume(2.0, "ul")) {
+		t.Errorf("expected excess of 2 ul, got %s", mdi.Excess.ToString())
+	}
+
+	if !mdi.AspiratedVolume().EqualTo(wunit.NewVolume(82.0, "ul")) {
+		t.Errorf("expected to aspirate 82 ul, got %s", mdi.AspiratedVolume().ToString())
+	}
+}
+
+func TestNoMultiDispenseWithoutWaste(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	robot, err := makeMultiDispenseRobot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pol, err := GetLHPolicyForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the excess has nowhere to go but the source, which the tip would
+	// contaminate
+
+	ins := getMultiDispenseBlock("multidispense", 4, wunit.NewVolume(20.0, "ul"))
+
+	ris, err := ins.Generate(ctx, pol, robot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	types := countTypes(ris)
+
+	if types[SMD] != 0 || types[SCT] != 4 {
+		t.Fatalf("expected 4 single transfers and no multi-dispense, got %d and %d", types[SCT], types[SMD])
+	}
+
+	mdi := NewMultiDispenseInstruction()
+	ch, tip := ChooseChannel(wunit.NewVolume(42.0, "ul"), robot)
+	for i := 0; i < 2; i++ {
+		mdi.AddDispense(ins.transferParams(i, ch.MergeWithTip(tip), tip.Type))
+	}
+	mdi.Excess = wunit.NewVolume(2.0, "ul")
+
+	if _, err := mdi.Generate(ctx, pol, robot); err == nil {
+		t.Error("expected error for excess with no waste")
+	}
+}
+
+func TestNoMultiDispenseWithoutPolicy(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	robot, err := makeMultiDispenseRobot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pol, err := GetLHPolicyForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ins := getMultiDispenseBlock("water", 4, wunit.NewVolume(20.0, "ul"))
+
+	ris, err := ins.Generate(ctx, pol, robot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	types := countTypes(ris)
+
+	if types[SMD] != 0 || types[SCT] != 4 {
+		t.Fatalf("expected 4 single transfers and no multi-dispense, got %d and %d", types[SCT], types[SMD])
+	}
+}
+
+// withSourceVolume sets the volume in the source of every transfer in ins
+func withSourceVolume(ins *SingleChannelBlockInstruction, vol wunit.Volume) *SingleChannelBlockInstruction {
+	for i := range ins.FVolume {
+		ins.FVolume[i] = wunit.CopyVolume(vol)
+	}
+	return ins
+}
+
+func aspirateVolumes(ris []RobotInstruction) []wunit.Volume {
+	var ret []wunit.Volume
+	for _, ri := range ris {
+		if ri.InstructionType() == ASP {
+			ret = append(ret, ri.(*AspirateInstruction).Volume...)
+		}
+	}
+	return ret
+}
+
+func TestMultiDispenseRespectsMaxvol(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	robot, err := makeMultiDispenseRobot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := addWaste(ctx, robot); err != nil {
+		t.Fatal(err)
+	}
+
+	pol, err := GetLHPolicyForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// with plenty in the source, 3 x 60ul + 2ul excess fits in a 200ul tip
+	// but 4 x 60ul does not so the last transfer is done on its own
+
+	ins := withSourceVolume(getMultiDispenseBlock("multidispense", 4, wunit.NewVolume(60.0, "ul")), wunit.NewVolume(1000.0, "ul"))
+
+	ris, err := NewRobotInstructionSet(ins).Generate(ctx, pol, robot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []wunit.Volume{wunit.NewVolume(182.0, "ul"), wunit.NewVolume(60.0, "ul")}
+	got := aspirateVolumes(ris)
+
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d aspirates, got %d: %v", len(expected), len(got), got)
+	}
+
+	for i := range expected {
+		if !got[i].EqualTo(expected[i]) {
+			t.Errorf("aspirate %d: expected %s, got %s", i, expected[i].ToString(), got[i].ToString())
+		}
+	}
+}
+
+func TestMultiDispenseExcessToWaste(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	robot, err := makeMultiDispenseRobot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := addWaste(ctx, robot); err != nil {
+		t.Fatal(err)
+	}
+
+	pol, err := GetLHPolicyForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ins := withSourceVolume(getMultiDispenseBlock("multidispense", 4, wunit.NewVolume(60.0, "ul")), wunit.NewVolume(1000.0, "ul"))
+
+	ris, err := ins.Generate(ctx, pol, robot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the transfer after the multi-dispense sees the source less the excess
+
+	var stci *SingleChannelTransferInstruction
+	for _, ri := range ris {
+		if s, ok := ri.(*SingleChannelTransferInstruction); ok {
+			stci = s
+		}
+	}
+
+	if stci == nil {
+		t.Fatal("expected a single transfer after the multi-dispense")
+	}
+
+	if e := wunit.NewVolume(998.0, "ul"); !stci.FVolume.EqualTo(e) {
+		t.Errorf("expected source volume %s, got %s", e.ToString(), stci.FVolume.ToString())
+	}
+}
+
+func TestMultiDispenseGenerate(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	robot, err := makeMultiDispenseRobot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := addWaste(ctx, robot); err != nil {
+		t.Fatal(err)
+	}
+
+	pol, err := GetLHPolicyForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block := getMultiDispenseBlock("multidispense", 3, wunit.NewVolume(20.0, "ul"))
+	ch, tip := ChooseChannel(wunit.NewVolume(62.0, "ul"), robot)
+
+	mdi := NewMultiDispenseInstruction()
+	for i := 0; i < 3; i++ {
+		mdi.AddDispense(block.transferParams(i, ch.MergeWithTip(tip), tip.Type))
+	}
+	mdi.Excess = wunit.NewVolume(2.0, "ul")
+
+	ris, err := NewRobotInstructionSet(mdi).Generate(ctx, pol, robot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedIns := []int{MOV, ASP, MOV, DSP, MOV, DSP, MOV, DSP, MOV, DSP, MOV, BLO}
+
+	if len(ris) != len(expectedIns) {
+		t.Fatalf("expected %d instructions, got %d", len(expectedIns), len(ris))
+	}
+
+	for i, ins := range ris {
+		if ins.InstructionType() != expectedIns[i] {
+			t.Fatalf("instruction %d: expected %s got %s", i, Robotinstructionnames[expectedIns[i]], Robotinstructionnames[ins.InstructionType()])
+		}
+	}
+
+	// the excess goes to the waste
+
+	disposal := ris[len(ris)-4].(*MoveInstruction)
+
+	if disposal.Pos[0] != "position_9" || disposal.Well[0] != "A1" {
+		t.Errorf("expected excess to go to the waste at position_9 A1, got %s %s", disposal.Pos[0], disposal.Well[0])
+	}
+
+	// with no excess the last destination gets the blowout and reset
+
+	mdi.Excess = wunit.ZeroVolume()
+
+	ris, err = NewRobotInstructionSet(mdi).Generate(ctx, pol, robot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedIns = []int{MOV, ASP, MOV, DSP, MOV, DSP, MOV, DSP, MOV, BLO}
+
+	if len(ris) != len(expectedIns) {
+		t.Fatalf("expected %d instructions, got %d", len(expectedIns), len(ris))
+	}
+
+	for i, ins := range ris {
+		if ins.InstructionType() != expectedIns[i] {
+			t.Fatalf("instruction %d: expected %s got %s", i, Robotinstructionnames[expectedIns[i]], Robotinstructionnames[ins.InstructionType()])
+		}
+	}
+
+	if blowout := ris[len(ris)-2].(*MoveInstruction); blowout.Well[0] != "C1" {
+		t.Errorf("expected blowout in the last destination C1, got %s", blowout.Well[0])
+	}
+}
+
+func TestMultiDispenseTimeSaving(t *testing.T) {
+	timer := GetTimerFor("Gilson", "Pipetmax")
+
+	mdi := NewMultiDispenseInstruction()
+	for i := 0; i < 4; i++ {
+		mdi.Volume = append(mdi.Volume, wunit.NewVolume(10.0, "ul"))
+	}
+
+	saved := timer.TimeSavedBy(mdi)
+
+	if saved <= 0 {
+		t.Errorf("expected multi-dispense to save time, got %s", saved)
+	}
+
+	mdi.Excess = wunit.NewVolume(2.0, "ul")
+
+	if s2 := timer.TimeSavedBy(mdi); s2 >= saved {
+		t.Errorf("disposing of excess should cost time: %s with vs %s without", s2, saved)
+	}
+
+	if s := timer.TimeSavedBy(NewSuckInstruction()); s != 0 {
+		t.Errorf("expected no saving for aspirate, got %s", s)
+	}
+}
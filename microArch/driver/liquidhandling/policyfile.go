@@ -0,0 +1,479 @@
+// /anthalib/driver/liquidhandling/policyfile.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package liquidhandling
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/ghodss/yaml"
+)
+
+// PolicyFileProblem is something wrong with a policy file. Path gives the
+// location of the offending item, e.g. Policies.water.ASPSPEED, and Line
+// the line it is on or zero if that couldn't be worked out. Warnings are
+// reported but don't stop the file being used
+type PolicyFileProblem struct {
+	Line    int
+	Path    string
+	Message string
+	Warning bool
+}
+
+func (p PolicyFileProblem) String() string {
+	s := p.Message
+
+	if p.Path != "" {
+		s = p.Path + ": " + s
+	}
+
+	if p.Line > 0 {
+		s = fmt.Sprintf("line %d: %s", p.Line, s)
+	}
+
+	if p.Warning {
+		s = "warning: " + s
+	}
+
+	return s
+}
+
+// policyFile holds the state of reading a policy file
+type policyFile struct {
+	contents  []byte
+	problems  []PolicyFileProblem
+	ruleNames map[string]bool
+}
+
+var policyFileSections = []string{"Policies", "Rules", "Options"}
+
+// lineOf finds the line on which the item at path is defined by looking for
+// each key in turn after the previous one. Where a key isn't found the line
+// of the last one which was is returned
+func (pf *policyFile) lineOf(path ...string) int {
+	offset := 0
+	line := 0
+
+	for _, key := range path {
+		re := regexp.MustCompile(`(^|[\s{,\[-])["']?` + regexp.QuoteMeta(key) + `["']?\s*:`)
+
+		loc := re.FindIndex(pf.contents[offset:])
+
+		if loc == nil {
+			break
+		}
+
+		offset += loc[1]
+		line = bytes.Count(pf.contents[:offset], []byte("\n")) + 1
+	}
+
+	return line
+}
+
+func (pf *policyFile) add(warning bool, path []string, format string, args ...interface{}) {
+	pf.problems = append(pf.problems, PolicyFileProblem{
+		Line:    pf.lineOf(path...),
+		Path:    strings.Join(path, "."),
+		Message: fmt.Sprintf(format, args...),
+		Warning: warning,
+	})
+}
+
+func (pf *policyFile) errorf(path []string, format string, args ...interface{}) {
+	pf.add(false, path, format, args...)
+}
+
+func (pf *policyFile) warnf(path []string, format string, args ...interface{}) {
+	pf.add(true, path, format, args...)
+}
+
+func (pf *policyFile) failed() bool {
+	for _, p := range pf.problems {
+		if !p.Warning {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	ks := make([]string, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+	return ks
+}
+
+// isYAML decides the format of a policy file from its extension or, failing
+// that, whether it looks like a JSON object
+func isYAML(fileName string, contents []byte) bool {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".yaml", ".yml":
+		return true
+	case ".json":
+		return false
+	}
+
+	return !bytes.HasPrefix(bytes.TrimSpace(contents), []byte("{"))
+}
+
+func lineAtOffset(contents []byte, offset int64) int {
+	if offset > int64(len(contents)) {
+		offset = int64(len(contents))
+	}
+	return bytes.Count(contents[:offset], []byte("\n")) + 1
+}
+
+// ReadLHPolicies reads a policy rule set from a JSON or YAML file, checking
+// it against the policy schema. Unknown items, values of the wrong type or
+// out of range and malformed rules are errors; unknown options, rules with
+// no policy and policies which no rule applies are warnings. All problems
+// found are returned along with an error if any of them are errors
+func ReadLHPolicies(fileName string, contents []byte) (*wtype.LHPolicyRuleSet, []PolicyFileProblem, error) {
+	pf := &policyFile{contents: contents, ruleNames: make(map[string]bool)}
+
+	js := contents
+
+	if isYAML(fileName, contents) {
+		var err error
+		js, err = yaml.YAMLToJSON(contents)
+		if err != nil {
+			return nil, nil, wtype.LHError(wtype.LH_ERR_POLICY, fmt.Sprintf("cannot read policy file %s: %s", fileName, err))
+		}
+	}
+
+	var top map[string]interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(js))
+	dec.UseNumber()
+
+	if err := dec.Decode(&top); err != nil {
+		if se, ok := err.(*json.SyntaxError); ok {
+			err = fmt.Errorf("line %d: %s", lineAtOffset(js, se.Offset), se)
+		}
+		return nil, nil, wtype.LHError(wtype.LH_ERR_POLICY, fmt.Sprintf("cannot read policy file %s: %s", fileName, err))
+	}
+
+	lhprs := wtype.NewLHPolicyRuleSet()
+	lhprs.Policies = make(map[string]wtype.LHPolicy)
+	lhprs.Rules = make(map[string]wtype.LHPolicyRule)
+
+	sections := make(map[string]interface{}, len(policyFileSections))
+
+	for _, k := range sortedKeys(top) {
+		known := false
+		for _, s := range policyFileSections {
+			if strings.EqualFold(k, s) {
+				sections[s] = top[k]
+				known = true
+			}
+		}
+		if !known {
+			pf.errorf([]string{k}, "unknown section, expected one of %s", strings.Join(policyFileSections, ", "))
+		}
+	}
+
+	pf.readPolicies(sections["Policies"], lhprs)
+	pf.readRules(sections["Rules"], lhprs)
+	pf.readOptions(sections["Options"], lhprs)
+
+	for name := range lhprs.Rules {
+		if _, ok := lhprs.Policies[name]; !ok {
+			pf.warnf([]string{"Rules", name}, "rule has no policy so does nothing")
+		}
+	}
+
+	for name := range lhprs.Policies {
+		if !pf.ruleNames[name] && name != "default" {
+			pf.warnf([]string{"Policies", name}, "no rule applies this policy")
+		}
+	}
+
+	sort.SliceStable(pf.problems, func(i, j int) bool {
+		return pf.problems[i].Line < pf.problems[j].Line
+	})
+
+	if pf.failed() {
+		var lines []string
+		for _, p := range pf.problems {
+			lines = append(lines, p.String())
+		}
+		return nil, pf.problems, wtype.LHError(wtype.LH_ERR_POLICY, fmt.Sprintf("invalid policy file %s:\n%s", fileName, strings.Join(lines, "\n")))
+	}
+
+	return lhprs, pf.problems, nil
+}
+
+func (pf *policyFile) readPolicies(section interface{}, lhprs *wtype.LHPolicyRuleSet) {
+	if section == nil {
+		return
+	}
+
+	policies, ok := section.(map[string]interface{})
+
+	if !ok {
+		pf.errorf([]string{"Policies"}, "should be a map from policy names to policies")
+		return
+	}
+
+	schema := wtype.GetPolicySchema()
+
+	for _, name := range sortedKeys(policies) {
+		items, ok := policies[name].(map[string]interface{})
+
+		if !ok {
+			pf.errorf([]string{"Policies", name}, "should be a map from policy items to values")
+			continue
+		}
+
+		policy := make(wtype.LHPolicy, len(items))
+
+		for _, k := range sortedKeys(items) {
+			path := []string{"Policies", name, k}
+			item, ok := schema[k]
+
+			if !ok {
+				pf.errorf(path, "unknown policy item, see antha list policyCommands")
+				continue
+			}
+
+			v, err := item.Convert(items[k])
+
+			if err != nil {
+				pf.errorf(path, "%s", err)
+				continue
+			}
+
+			policy[k] = v
+		}
+
+		lhprs.Policies[name] = policy
+	}
+}
+
+func (pf *policyFile) readRules(section interface{}, lhprs *wtype.LHPolicyRuleSet) {
+	if section == nil {
+		return
+	}
+
+	rules, ok := section.(map[string]interface{})
+
+	if !ok {
+		pf.errorf([]string{"Rules"}, "should be a map from rule names to rules")
+		return
+	}
+
+	for _, name := range sortedKeys(rules) {
+		pf.ruleNames[name] = true
+		path := []string{"Rules", name}
+		fields, ok := rules[name].(map[string]interface{})
+
+		if !ok {
+			pf.errorf(path, "should be a rule with Name, Conditions, Priority and Type")
+			continue
+		}
+
+		rule := wtype.NewLHPolicyRule(name)
+		valid := true
+
+		for _, k := range sortedKeys(fields) {
+			v := fields[k]
+			fpath := append(path, k)
+
+			switch k {
+			case "Name":
+				if s, ok := v.(string); !ok {
+					pf.errorf(fpath, "should be a string")
+					valid = false
+				} else if s != name {
+					pf.warnf(fpath, "rule is named %s but will be known as %s", s, name)
+				}
+			case "Priority":
+				n, ok := v.(json.Number)
+				i, err := n.Int64()
+				if !ok || err != nil {
+					pf.errorf(fpath, "should be a whole number")
+					valid = false
+				}
+				rule.Priority = int(i)
+			case "Type":
+				n, ok := v.(json.Number)
+				i, err := n.Int64()
+				if !ok || err != nil || (int(i) != wtype.LHP_AND && int(i) != wtype.LHP_OR) {
+					pf.errorf(fpath, "should be %d (all conditions) or %d (any condition)", wtype.LHP_AND, wtype.LHP_OR)
+					valid = false
+				}
+				rule.Type = int(i)
+			case "Conditions":
+				conds, ok := v.([]interface{})
+				if !ok {
+					pf.errorf(fpath, "should be a list of conditions")
+					valid = false
+					continue
+				}
+				for i, c := range conds {
+					cond, ok := pf.readCondition(fpath, i, c)
+					valid = valid && ok
+					rule.Conditions = append(rule.Conditions, cond)
+				}
+			default:
+				pf.errorf(fpath, "unknown rule field, expected Name, Conditions, Priority or Type")
+				valid = false
+			}
+		}
+
+		if valid {
+			lhprs.Rules[name] = rule
+		}
+	}
+}
+
+func (pf *policyFile) readCondition(rulePath []string, i int, c interface{}) (wtype.LHVariableCondition, bool) {
+	// locate the i'th condition by counting TestVariables
+	lookup := append([]string{}, rulePath...)
+	for j := 0; j <= i; j++ {
+		lookup = append(lookup, "TestVariable")
+	}
+
+	fail := func(format string, args ...interface{}) (wtype.LHVariableCondition, bool) {
+		pf.problems = append(pf.problems, PolicyFileProblem{
+			Line:    pf.lineOf(lookup...),
+			Path:    fmt.Sprintf("%s[%d]", strings.Join(rulePath, "."), i),
+			Message: fmt.Sprintf(format, args...),
+		})
+		return wtype.LHVariableCondition{}, false
+	}
+
+	fields, ok := c.(map[string]interface{})
+
+	if !ok {
+		return fail("should have TestVariable and Condition")
+	}
+
+	variable, ok := fields["TestVariable"].(string)
+
+	if !ok {
+		return fail("TestVariable should be a string")
+	}
+
+	param, ok := wtype.MakeInstructionParameters()[variable]
+
+	if !ok {
+		return fail("no instruction defines parameter %s", variable)
+	}
+
+	cond, ok := fields["Condition"].(map[string]interface{})
+
+	if !ok {
+		return fail("Condition should have either Category or Lower and Upper")
+	}
+
+	lhvc := wtype.NewLHVariableCondition(variable)
+
+	if cat, ok := cond["Category"]; ok {
+		s, ok := cat.(string)
+		if !ok {
+			return fail("Category should be a string")
+		}
+		if param.Type != reflect.TypeOf("") {
+			return fail("parameter %s is not categoric", variable)
+		}
+		if err := lhvc.SetCategoric(s); err != nil {
+			return fail("%s", err)
+		}
+		return lhvc, true
+	}
+
+	lo, lok := cond["Lower"].(json.Number)
+	up, uok := cond["Upper"].(json.Number)
+
+	if !lok || !uok {
+		return fail("Condition should have either Category or numeric Lower and Upper")
+	}
+
+	if param.Type != reflect.TypeOf(0.0) {
+		return fail("parameter %s is not numeric", variable)
+	}
+
+	lf, err := lo.Float64()
+	if err != nil {
+		return fail("%s", err)
+	}
+
+	uf, err := up.Float64()
+	if err != nil {
+		return fail("%s", err)
+	}
+
+	if lf > uf {
+		return fail("lower limit %g is greater than upper limit %g", lf, uf)
+	}
+
+	if err := lhvc.SetNumeric(lf, uf); err != nil {
+		return fail("%s", err)
+	}
+
+	return lhvc, true
+}
+
+func (pf *policyFile) readOptions(section interface{}, lhprs *wtype.LHPolicyRuleSet) {
+	if section == nil {
+		return
+	}
+
+	opts, ok := section.(map[string]interface{})
+
+	if !ok {
+		pf.errorf([]string{"Options"}, "should be a map from option names to values")
+		return
+	}
+
+	known := wtype.GetLHPolicyOptions()
+
+	for _, k := range sortedKeys(opts) {
+		path := []string{"Options", k}
+		opt, ok := known[k]
+
+		if !ok {
+			pf.warnf(path, "unknown option ignored")
+			continue
+		}
+
+		v := opts[k]
+
+		if reflect.TypeOf(v) != opt.Type {
+			pf.errorf(path, "wrong type: should be %s got %v", opt.TypeName(), v)
+			continue
+		}
+
+		lhprs.Options[k] = v
+	}
+}
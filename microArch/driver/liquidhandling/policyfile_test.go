@@ -0,0 +1,161 @@
+package liquidhandling
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+const testPolicyYAML = `Policies:
+  water:
+    ASPSPEED: 3.5
+    POST_MIX: 2
+  default:
+    TOUCHOFF: true
+Rules:
+  water:
+    Name: water
+    Priority: 1
+    Conditions:
+      - TestVariable: LIQUIDCLASS
+        Condition:
+          Category: water
+      - TestVariable: VOLUME
+        Condition:
+          Lower: 0
+          Upper: 20
+`
+
+func TestReadLHPoliciesYAML(t *testing.T) {
+	rs, problems, err := ReadLHPolicies("policies.yaml", []byte(testPolicyYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+
+	if v, ok := rs.Policies["water"]["POST_MIX"].(int); !ok || v != 2 {
+		t.Errorf("expected POST_MIX to be int 2, got %v (%T)", rs.Policies["water"]["POST_MIX"], rs.Policies["water"]["POST_MIX"])
+	}
+
+	if v := SafeGetF64(rs.Policies["water"], "ASPSPEED"); v != 3.5 {
+		t.Errorf("expected ASPSPEED 3.5, got %f", v)
+	}
+
+	rule := rs.Rules["water"]
+	if len(rule.Conditions) != 2 || rule.Priority != 1 {
+		t.Errorf("expected rule with 2 conditions and priority 1, got %v", rule)
+	}
+}
+
+func TestReadLHPoliciesReportsLines(t *testing.T) {
+	bad := strings.Replace(testPolicyYAML, "POST_MIX: 2", "POST_MIX: 2.5\n    ASPSPEEED: 1.0", 1)
+	bad = strings.Replace(bad, "TestVariable: VOLUME", "TestVariable: VOLUMEE", 1)
+	bad = strings.Replace(bad, "TOUCHOFF: true", "TOUCHOFF: true\n    DSPSPEED: -1.0", 1)
+
+	_, problems, err := ReadLHPolicies("policies.yaml", []byte(bad))
+
+	if err == nil {
+		t.Fatal("expected invalid policy file to be rejected")
+	}
+
+	expected := map[string]int{
+		"Policies.default.DSPSPEED": 8,
+		"Policies.water.ASPSPEEED":  5,
+		"Policies.water.POST_MIX":   4,
+		"Rules.water.Conditions[1]": 17,
+	}
+
+	if len(problems) != len(expected) {
+		t.Errorf("expected %d problems, got %d: %v", len(expected), len(problems), problems)
+	}
+
+	for _, p := range problems {
+		if line, ok := expected[p.Path]; !ok {
+			t.Errorf("unexpected problem %s", p)
+		} else if p.Line != line {
+			t.Errorf("expected %s on line %d, got %d", p.Path, line, p.Line)
+		} else if p.Warning {
+			t.Errorf("expected %s to be an error", p)
+		}
+	}
+}
+
+func TestReadLHPoliciesWarnings(t *testing.T) {
+	js := `{
+  "Policies": {"orphan": {"TOUCHOFF": false}},
+  "Rules": {"lonely": {"Name": "lonely", "Conditions": [], "Priority": 0, "Type": 0}},
+  "Options": {"NO_SUCH_OPTION": true}
+}`
+
+	rs, problems, err := ReadLHPolicies("policies.json", []byte(js))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := map[string]int{
+		"Policies.orphan":        2,
+		"Rules.lonely":           3,
+		"Options.NO_SUCH_OPTION": 4,
+	}
+
+	if len(problems) != len(lines) {
+		t.Errorf("expected %d warnings, got %v", len(lines), problems)
+	}
+
+	for _, p := range problems {
+		if !p.Warning {
+			t.Errorf("expected %s to be a warning", p)
+		}
+		if lines[p.Path] != p.Line {
+			t.Errorf("expected %s on line %d, got %d", p.Path, lines[p.Path], p.Line)
+		}
+	}
+
+	if _, ok := rs.Options["NO_SUCH_OPTION"]; ok {
+		t.Errorf("unknown option should not be set")
+	}
+}
+
+func TestReadLHPoliciesRoundTrip(t *testing.T) {
+	builtin, err := GetLHPolicyForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(builtin); err != nil {
+		t.Fatal(err)
+	}
+
+	rs, problems, err := ReadLHPolicies("", buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range problems {
+		if !p.Warning {
+			t.Errorf("unexpected problem with built-in policies: %s", p)
+		}
+	}
+
+	// some built-in policies give whole numbers as ints where the schema
+	// says float64, reading back converts them so do the same here
+	schema := wtype.GetPolicySchema()
+	for _, p := range builtin.Policies {
+		for k, v := range p {
+			if c, err := schema[k].Convert(v); err == nil {
+				p[k] = c
+			}
+		}
+	}
+
+	if !rs.IsEqualTo(builtin) {
+		t.Errorf("built-in policies not the same after reading back")
+	}
+}
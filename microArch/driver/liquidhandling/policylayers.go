@@ -0,0 +1,299 @@
+// /anthalib/driver/liquidhandling/policylayers.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package liquidhandling
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// policy layers, in increasing order of precedence
+const (
+	BuiltInPolicies = iota
+	SitePolicies
+	WorkflowPolicies
+	ComponentPolicies
+)
+
+var PolicyLayerNames = []string{"built-in", "site", "workflow", "component"}
+
+// rule priorities are offset by this much per layer so that later layers
+// take precedence whatever priorities the rules within them have
+const policyLayerPriority = 1000
+
+// PolicyLayer is a set of policy rules from one source
+type PolicyLayer struct {
+	Level  int
+	Source string
+	Rules  *wtype.LHPolicyRuleSet
+}
+
+func (pl PolicyLayer) Name() string {
+	if pl.Level < 0 || pl.Level >= len(PolicyLayerNames) {
+		return fmt.Sprintf("layer %d", pl.Level)
+	}
+	return PolicyLayerNames[pl.Level]
+}
+
+// PolicyResolver combines policies from built-in, site, workflow and
+// component layers, keeping track of where each setting came from
+type PolicyResolver struct {
+	Layers []PolicyLayer
+
+	// component policies added so far by component name
+	components map[string][]string
+}
+
+func NewPolicyResolver() *PolicyResolver {
+	return &PolicyResolver{Layers: make([]PolicyLayer, 0, 4)}
+}
+
+// DefaultPolicyResolver has the built-in policies and, if the environment
+// variable ANTHA_LHPOLICIES_FILE is set, site policies read from that file
+func DefaultPolicyResolver() (*PolicyResolver, error) {
+	pr := NewPolicyResolver()
+
+	builtin, err := GetLHPolicyForTest()
+
+	if err != nil {
+		return nil, err
+	}
+
+	pr.AddLayer(BuiltInPolicies, "MakePolicies", builtin)
+
+	if fn := os.Getenv("ANTHA_LHPOLICIES_FILE"); fn != "" {
+		site, err := LoadLHPoliciesFromFile()
+
+		if err != nil {
+			return nil, err
+		}
+
+		pr.AddLayer(SitePolicies, fn, site)
+	}
+
+	return pr, nil
+}
+
+// AddLayer adds a set of rules at the given level; layers at the same level
+// take precedence in the order they are added
+func (pr *PolicyResolver) AddLayer(level int, source string, rules *wtype.LHPolicyRuleSet) {
+	if rules == nil {
+		return
+	}
+
+	pr.Layers = append(pr.Layers, PolicyLayer{Level: level, Source: source, Rules: rules})
+
+	sort.SliceStable(pr.Layers, func(i, j int) bool {
+		return pr.Layers[i].Level < pr.Layers[j].Level
+	})
+}
+
+// AddComponentPolicies adds any policies set on cmp with SetPolicies. The
+// same component is usually used in many mixes so each distinct set of
+// policies for a component name is only added once
+func (pr *PolicyResolver) AddComponentPolicies(cmp *wtype.LHComponent) error {
+	rules, err := cmp.GetPolicies()
+
+	if err != nil {
+		return err
+	}
+
+	if len(rules.Rules) == 0 {
+		return nil
+	}
+
+	// GetPolicies has checked this is a string
+	key := cmp.Extra["Policies"].(string)
+
+	if pr.components == nil {
+		pr.components = make(map[string][]string)
+	}
+
+	added := pr.components[cmp.CName]
+	for _, k := range added {
+		if k == key {
+			return nil
+		}
+	}
+	pr.components[cmp.CName] = append(added, key)
+
+	// a component of the same name with different policies needs a layer
+	// of its own so its rules don't clash with the others'
+	source := cmp.CName
+	if len(added) != 0 {
+		source = fmt.Sprintf("%s#%d", cmp.CName, len(added)+1)
+	}
+
+	pr.AddLayer(ComponentPolicies, source, rules)
+	return nil
+}
+
+// combine merges the layers into one rule set. The default policy is built
+// up from any default policies in each layer; other rules are renamed where
+// names clash between layers and have their priorities offset by layer.
+// Also returns where each setting in the default policy and each rule in
+// the result came from
+func (pr *PolicyResolver) combine() (*wtype.LHPolicyRuleSet, map[string]PolicySource, map[string]PolicySource) {
+	ret := wtype.NewLHPolicyRuleSet()
+	def := make(wtype.LHPolicy)
+	defSources := make(map[string]PolicySource)
+	origins := make(map[string]PolicySource)
+
+	for _, layer := range pr.Layers {
+		for k, v := range layer.Rules.Options {
+			ret.Options[k] = v
+		}
+
+		src := PolicySource{Layer: layer.Name(), Source: layer.Source, Rule: "default"}
+
+		for k, v := range layer.Rules.Policies["default"] {
+			def[k] = v
+			defSources[k] = src
+		}
+
+		names := make([]string, 0, len(layer.Rules.Rules))
+		for name := range layer.Rules.Rules {
+			if name != "default" {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			rule := layer.Rules.Rules[name]
+			if _, clash := ret.Rules[rule.Name]; clash || rule.Name == "default" {
+				rule.Name = fmt.Sprintf("%s:%s", layer.Source, name)
+			}
+			rule.Priority += layer.Level * policyLayerPriority
+
+			ret.AddRule(rule, layer.Rules.Policies[name])
+			origins[rule.Name] = PolicySource{Layer: layer.Name(), Source: layer.Source, Rule: name}
+		}
+	}
+
+	rule := wtype.NewLHPolicyRule("default")
+	if err := rule.AddCategoryConditionOn("LIQUIDCLASS", "default"); err == nil {
+		ret.AddRule(rule, def)
+	}
+
+	return ret, defSources, origins
+}
+
+// RuleSet combines all the layers into a single rule set for use with
+// GetPolicyFor, which then gives the same policies as Resolve
+func (pr *PolicyResolver) RuleSet() *wtype.LHPolicyRuleSet {
+	rs, _, _ := pr.combine()
+	return rs
+}
+
+// PolicySource records where a policy setting came from
+type PolicySource struct {
+	Layer  string
+	Source string
+	Rule   string
+}
+
+func (ps PolicySource) String() string {
+	return fmt.Sprintf("%s (%s) rule %s", ps.Layer, ps.Source, ps.Rule)
+}
+
+// PolicyMatch is a rule which matched an instruction
+type PolicyMatch struct {
+	PolicySource
+	Priority   int
+	Conditions []wtype.LHVariableCondition
+	Policy     wtype.LHPolicy
+}
+
+// ResolvedPolicy is the policy in effect for an instruction along with
+// where each setting came from and the rules which matched, in the order
+// they were applied
+type ResolvedPolicy struct {
+	Policy  wtype.LHPolicy
+	Sources map[string]PolicySource
+	Matches []PolicyMatch
+}
+
+// Resolve works out the policy for ins as GetPolicyFor does with the
+// combined rule set: the default policy with each matching rule merged in
+// order of priority
+func (pr *PolicyResolver) Resolve(ins RobotInstruction) ResolvedPolicy {
+	rs, defSources, origins := pr.combine()
+
+	names := make([]string, 0, len(rs.Rules))
+	for name := range rs.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	gri := GenericRobotInstruction{Ins: ins}
+
+	matched := make([]wtype.LHPolicyRule, 0, len(names))
+	for _, name := range names {
+		if gri.Check(rs.Rules[name]) {
+			matched = append(matched, rs.Rules[name])
+		}
+	}
+
+	sort.Stable(wtype.SortableRules(matched))
+
+	ret := ResolvedPolicy{
+		Policy:  wtype.DupLHPolicy(rs.Policies["default"]),
+		Sources: make(map[string]PolicySource, len(defSources)),
+		Matches: make([]PolicyMatch, 0, len(matched)),
+	}
+
+	for k, src := range defSources {
+		ret.Sources[k] = src
+	}
+
+	for _, rule := range matched {
+		policy := rs.Policies[rule.Name]
+		src, ok := origins[rule.Name]
+
+		for k, v := range policy {
+			ret.Policy[k] = v
+			if ok {
+				ret.Sources[k] = src
+			} else {
+				ret.Sources[k] = defSources[k]
+			}
+		}
+
+		if !ok {
+			src = PolicySource{Layer: "combined", Source: "all layers", Rule: rule.Name}
+		}
+
+		ret.Matches = append(ret.Matches, PolicyMatch{
+			PolicySource: src,
+			Priority:     rule.Priority,
+			Conditions:   rule.Conditions,
+			Policy:       policy,
+		})
+	}
+
+	return ret
+}
@@ -0,0 +1,174 @@
+package liquidhandling
+
+import (
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+func getTestSuckFor(what string, vol wunit.Volume) *SuckInstruction {
+	ins := NewSuckInstruction()
+	ins.What = []string{what}
+	ins.Volume = []wunit.Volume{vol}
+	return ins
+}
+
+func makeTestLayer(ruleName, what string, policy wtype.LHPolicy) *wtype.LHPolicyRuleSet {
+	rs := wtype.NewLHPolicyRuleSet()
+	rule := wtype.NewLHPolicyRule(ruleName)
+	rule.AddCategoryConditionOn("LIQUIDCLASS", what)
+	rs.AddRule(rule, policy)
+	return rs
+}
+
+func makeTestResolver(t *testing.T) *PolicyResolver {
+	builtin, err := GetLHPolicyForTest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pr := NewPolicyResolver()
+	pr.AddLayer(BuiltInPolicies, "MakePolicies", builtin)
+	return pr
+}
+
+func TestPolicyResolverSiteOverridesBuiltIn(t *testing.T) {
+	pr := makeTestResolver(t)
+
+	site := makeTestLayer("water", "water", wtype.LHPolicy{"ASPSPEED": 1.5})
+	site.Policies["default"] = wtype.LHPolicy{"TOUCHOFF": true}
+	pr.AddLayer(SitePolicies, "site.json", site)
+
+	res := pr.Resolve(getTestSuckFor("water", wunit.NewVolume(50.0, "ul")))
+
+	if v := SafeGetF64(res.Policy, "ASPSPEED"); v != 1.5 {
+		t.Errorf("expected site ASPSPEED 1.5, got %f", v)
+	}
+
+	if src := res.Sources["ASPSPEED"]; src.Layer != "site" || src.Source != "site.json" || src.Rule != "water" {
+		t.Errorf("expected ASPSPEED to come from site.json rule water, got %s", src)
+	}
+
+	if !SafeGetBool(res.Policy, "TOUCHOFF") {
+		t.Errorf("expected site default TOUCHOFF to be set")
+	}
+
+	if src := res.Sources["TOUCHOFF"]; src.Layer != "site" || src.Rule != "default" {
+		t.Errorf("expected TOUCHOFF to come from site default, got %s", src)
+	}
+}
+
+func TestPolicyResolverComponentOverridesWorkflow(t *testing.T) {
+	pr := makeTestResolver(t)
+
+	// added in reverse order: levels not order of addition decide precedence
+	pr.AddLayer(ComponentPolicies, "soup", makeTestLayer("soup", "soup", wtype.LHPolicy{"DSPSPEED": 2.0}))
+	pr.AddLayer(WorkflowPolicies, "workflow", makeTestLayer("soup", "soup", wtype.LHPolicy{"DSPSPEED": 1.0, "DSPREFERENCE": 1}))
+
+	res := pr.Resolve(getTestSuckFor("soup", wunit.NewVolume(10.0, "ul")))
+
+	if v := SafeGetF64(res.Policy, "DSPSPEED"); v != 2.0 {
+		t.Errorf("expected component DSPSPEED 2.0, got %f", v)
+	}
+
+	if src := res.Sources["DSPSPEED"]; src.Layer != "component" {
+		t.Errorf("expected DSPSPEED from component layer, got %s", src)
+	}
+
+	if src := res.Sources["DSPREFERENCE"]; src.Layer != "workflow" {
+		t.Errorf("expected DSPREFERENCE from workflow layer, got %s", src)
+	}
+
+	if n := len(res.Matches); n < 2 || res.Matches[n-1].Layer != "component" {
+		t.Errorf("expected component rule to be applied last, got %v", res.Matches)
+	}
+}
+
+func TestPolicyResolverMatchesGetPolicyFor(t *testing.T) {
+	// the built-in policies have rules which tie on priority, which
+	// GetPolicyFor applies in no particular order, so use our own here
+	builtin := makeTestLayer("dna", "dna", wtype.LHPolicy{"POST_MIX": 1, "ASPSPEED": 2.0})
+	builtin.Policies["default"] = wtype.LHPolicy{"POST_MIX": 0, "ASPSPEED": 3.0, "TOUCHOFF": false}
+	lv := wtype.NewLHPolicyRule("lowvolume")
+	lv.AddNumericConditionOn("VOLUME", 0.0, 1.0)
+	builtin.AddRule(lv, wtype.LHPolicy{"TOUCHOFF": true, "ASPSPEED": 1.0})
+
+	pr := NewPolicyResolver()
+	pr.AddLayer(BuiltInPolicies, "MakePolicies", builtin)
+	pr.AddLayer(SitePolicies, "site", makeTestLayer("water", "water", wtype.LHPolicy{"ASPSPEED": 4.0}))
+	pr.AddLayer(WorkflowPolicies, "workflow", makeTestLayer("dna", "dna", wtype.LHPolicy{"POST_MIX": 3}))
+
+	for _, what := range []string{"water", "dna", "culture", "nosuchliquid"} {
+		for _, v := range []float64{0.5, 5.0, 50.0} {
+			ins := getTestSuckFor(what, wunit.NewVolume(v, "ul"))
+
+			expected := GetPolicyFor(pr.RuleSet(), ins)
+			got := pr.Resolve(ins).Policy
+
+			if len(expected) != len(got) {
+				t.Errorf("%s %.1ful: expected %d settings, got %d", what, v, len(expected), len(got))
+				continue
+			}
+
+			for k, ev := range expected {
+				if gv, ok := got[k]; !ok || gv != ev {
+					t.Errorf("%s %.1ful: expected %s = %v, got %v", what, v, k, ev, gv)
+				}
+			}
+		}
+	}
+}
+
+func TestAddComponentPoliciesOnce(t *testing.T) {
+	pr := makeTestResolver(t)
+
+	soup := wtype.NewLHComponent()
+	soup.CName = "soup"
+	if err := soup.SetPolicies(makeTestLayer("soup", "soup", wtype.LHPolicy{"DSPSPEED": 2.0})); err != nil {
+		t.Fatal(err)
+	}
+
+	// the same component in several mixes adds its policies once
+
+	for i := 0; i < 3; i++ {
+		if err := pr.AddComponentPolicies(soup.Dup()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	count := func() int {
+		n := 0
+		for _, l := range pr.Layers {
+			if l.Level == ComponentPolicies {
+				n++
+			}
+		}
+		return n
+	}
+
+	if n := count(); n != 1 {
+		t.Fatalf("expected 1 component layer, got %d", n)
+	}
+
+	// but a different set under the same name is kept apart
+
+	other := soup.Dup()
+	if err := other.SetPolicies(makeTestLayer("soup", "soup", wtype.LHPolicy{"ASPSPEED": 1.0})); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pr.AddComponentPolicies(other); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := count(); n != 2 {
+		t.Fatalf("expected 2 component layers, got %d", n)
+	}
+
+	res := pr.Resolve(getTestSuckFor("soup", wunit.NewVolume(10.0, "ul")))
+
+	if SafeGetF64(res.Policy, "DSPSPEED") != 2.0 || SafeGetF64(res.Policy, "ASPSPEED") != 1.0 {
+		t.Errorf("expected the rules from both sets of soup policies, got %v", res.Policy)
+	}
+}
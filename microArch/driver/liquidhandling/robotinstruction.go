@@ -86,13 +86,14 @@ const (
 	MBL            // MOV BLO	    ""       ""
 	RAP            // RemoveAllPlates
 	APT            // AddPlateTo
+	SMD            // Single channel multi-dispense
 )
 
 func InstructionTypeName(ins RobotInstruction) string {
 	return Robotinstructionnames[ins.InstructionType()]
 }
 
-var Robotinstructionnames = []string{"TFR", "TFB", "SCB", "MCB", "SCT", "MCT", "CCC", "LDT", "UDT", "RST", "CHA", "ASP", "DSP", "BLO", "PTZ", "MOV", "MRW", "LOD", "ULD", "SUK", "BLW", "SPS", "SDS", "INI", "FIN", "WAI", "LON", "LOF", "OPN", "CLS", "LAD", "UAD", "MMX", "MIX", "MSG", "MOVASP", "MOVDSP", "MOVMIX", "MOVBLO", "RAP", "APT"}
+var Robotinstructionnames = []string{"TFR", "TFB", "SCB", "MCB", "SCT", "MCT", "CCC", "LDT", "UDT", "RST", "CHA", "ASP", "DSP", "BLO", "PTZ", "MOV", "MRW", "LOD", "ULD", "SUK", "BLW", "SPS", "SDS", "INI", "FIN", "WAI", "LON", "LOF", "OPN", "CLS", "LAD", "UAD", "MMX", "MIX", "MSG", "MOVASP", "MOVDSP", "MOVMIX", "MOVBLO", "RAP", "APT", "SMD"}
 
 var RobotParameters = []string{"HEAD", "CHANNEL", "LIQUIDCLASS", "POSTO", "WELLFROM", "WELLTO", "REFERENCE", "VOLUME", "VOLUNT", "FROMPLATETYPE", "WELLFROMVOLUME", "POSFROM", "WELLTOVOLUME", "TOPLATETYPE", "MULTI", "WHAT", "LLF", "PLT", "TOWELLVOLUME", "OFFSETX", "OFFSETY", "OFFSETZ", "TIME", "SPEED", "MESSAGE", "COMPONENT"}
 
@@ -364,6 +365,20 @@ func (sori *SetOfRobotInstructions) UnmarshalJSON(b []byte) error {
 			ins = NewWaitInstruction()
 		case FIN:
 			ins = NewFinalizeInstruction()
+		case MRW:
+			ins = NewMoveRawInstruction()
+		case LON:
+			ins = NewLightsOnInstruction()
+		case LOF:
+			ins = NewLightsOffInstruction()
+		case OPN:
+			ins = NewOpenInstruction()
+		case CLS:
+			ins = NewCloseInstruction()
+		case LAD:
+			ins = NewLoadAdaptorInstruction()
+		case UAD:
+			ins = NewUnloadAdaptorInstruction()
 		default:
 			return fmt.Errorf("Unknown instruction type: %d", t)
 		}
@@ -46,6 +46,17 @@ func (ri *RobotInstructionSet) Add(ins RobotInstruction) {
 	ri.instructions = append(ri.instructions, ris)
 }
 
+// Walk calls f on every instruction in the set, parents before children
+func (ri *RobotInstructionSet) Walk(f func(RobotInstruction)) {
+	if ri.parent != nil {
+		f(ri.parent)
+	}
+
+	for _, ins := range ri.instructions {
+		ins.Walk(f)
+	}
+}
+
 func (ri *RobotInstructionSet) Generate(ctx context.Context, lhpr *wtype.LHPolicyRuleSet, lhpm *LHProperties) ([]RobotInstruction, error) {
 	ret := make([]RobotInstruction, 0, 1)
 
@@ -52,6 +52,8 @@ func makeGilsonPipetmaxTimer() *LHTimer {
 	t.Times[18], _ = time.ParseDuration("8s")   // UNLOAD
 	t.Times[32], _ = time.ParseDuration("6s")   // MIX
 
+	t.HeadSpeed = 150.0 // mm/s
+
 	return t
 }
 
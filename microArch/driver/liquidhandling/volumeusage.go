@@ -0,0 +1,181 @@
+// /anthalib/driver/liquidhandling/volumeusage.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package liquidhandling
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+// WellUsage describes what a stream of instructions does to the liquid in
+// one well. Volumes are those actually moved by the robot so include
+// anything the liquid handling policies add, such as extra aspirate
+// volumes, multi-dispense excess and pre- and post-mixing
+type WellUsage struct {
+	Position  string
+	Well      string
+	Component string
+	Initial   wunit.Volume
+	Residual  wunit.Volume
+	Aspirated wunit.Volume
+	Aspirates int
+	// Required is the least the well must hold at the start for every
+	// aspirate and mix to leave at least the residual volume behind
+	Required wunit.Volume
+}
+
+// Shortfall returns how much more the well needs to hold at the start, or
+// zero if it holds enough
+func (wu WellUsage) Shortfall() wunit.Volume {
+	s := wu.Required.ConvertToString("ul") - wu.Initial.ConvertToString("ul")
+
+	if s <= 0.0 {
+		return wunit.ZeroVolume()
+	}
+
+	return wunit.NewVolume(s, "ul")
+}
+
+func (wu WellUsage) String() string {
+	return fmt.Sprintf("%s %s (%s): holds %s, needs %s for %d aspirate(s) of %s in total", wu.Position, wu.Well, wu.Component, wu.Initial, wu.Required, wu.Aspirates, wu.Aspirated)
+}
+
+// running totals in ul for one well
+type wellState struct {
+	usage     *WellUsage
+	residual  float64
+	net       float64
+	aspirated float64
+	required  float64
+	used      bool
+}
+
+// SimulateWellUsage follows the liquid in every well on prms through ris,
+// working out where each aspirate, dispense and mix happens from the move
+// which precedes it. It returns the usage of each well which is aspirated
+// from or mixed in, in the order they are first used; prms should be the
+// robot as it is before ris are carried out and isn't changed
+func SimulateWellUsage(ris []RobotInstruction, prms *LHProperties) []WellUsage {
+	states := make(map[string]*wellState)
+	order := make([]string, 0)
+
+	get := func(pos, address string) *wellState {
+		key := pos + ":" + address
+
+		if s, ok := states[key]; ok {
+			return s
+		}
+
+		well := wellAt(prms, pos, address)
+
+		if well == nil {
+			return nil
+		}
+
+		s := &wellState{
+			usage: &WellUsage{
+				Position:  pos,
+				Well:      address,
+				Component: well.Contents().CName,
+				Initial:   well.CurrentVolume(),
+				Residual:  well.ResidualVolume(),
+			},
+			residual: well.ResidualVolume().ConvertToString("ul"),
+		}
+
+		states[key] = s
+		order = append(order, key)
+
+		return s
+	}
+
+	// channels calls f with the well and volume for each channel used
+	channels := func(mov *MoveInstruction, vols []wunit.Volume, f func(*wellState, float64)) {
+		if mov == nil {
+			return
+		}
+
+		for i := range mov.Pos {
+			if i >= len(mov.Well) || i >= len(vols) {
+				break
+			}
+
+			if mov.Pos[i] == "" || mov.Well[i] == "" || vols[i].IsNil() {
+				continue
+			}
+
+			if s := get(mov.Pos[i], mov.Well[i]); s != nil {
+				f(s, vols[i].ConvertToString("ul"))
+			}
+		}
+	}
+
+	// the well must hold v more than its residual volume whether v is
+	// taken out or just mixed
+	need := func(s *wellState, v float64) {
+		s.required = math.Max(s.required, s.residual+v-s.net)
+		s.used = true
+	}
+
+	var lastMove *MoveInstruction
+
+	for _, ri := range ris {
+		switch ins := ri.(type) {
+		case *MoveInstruction:
+			lastMove = ins
+		case *AspirateInstruction:
+			channels(lastMove, ins.Volume, func(s *wellState, v float64) {
+				need(s, v)
+				s.net -= v
+				s.aspirated += v
+				s.usage.Aspirates += 1
+			})
+		case *MixInstruction:
+			channels(lastMove, ins.Volume, need)
+		case *DispenseInstruction:
+			channels(lastMove, ins.Volume, func(s *wellState, v float64) {
+				s.net += v
+			})
+		}
+	}
+
+	ret := make([]WellUsage, 0, len(order))
+
+	for _, key := range order {
+		s := states[key]
+
+		if !s.used {
+			// only dispensed into
+			continue
+		}
+
+		s.usage.Aspirated = wunit.NewVolume(s.aspirated, "ul")
+		s.usage.Required = wunit.NewVolume(s.required, "ul")
+
+		ret = append(ret, *s.usage)
+	}
+
+	return ret
+}
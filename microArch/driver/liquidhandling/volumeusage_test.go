@@ -0,0 +1,82 @@
+package liquidhandling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/inventory/testinventory"
+)
+
+func useExtraAspiratePolicy(what string, extra wunit.Volume) (*wtype.LHPolicyRuleSet, error) {
+	pol, err := GetLHPolicyForTest()
+	if err != nil {
+		return nil, err
+	}
+
+	rule := wtype.NewLHPolicyRule("EXTRARULE")
+	if err := rule.AddCategoryConditionOn("LIQUIDCLASS", what); err != nil {
+		return nil, err
+	}
+	pols := make(wtype.LHPolicy, 1)
+	pols["EXTRA_ASP_VOLUME"] = extra
+	pol.AddRule(rule, pols)
+
+	return pol, nil
+}
+
+func TestSimulateWellUsage(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	robot, err := makeMultiDispenseRobot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pol, err := useExtraAspiratePolicy("soup", wunit.NewVolume(10.0, "ul"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	well := robot.Plates["position_4"].Wellcoords["A1"]
+	residual := well.ResidualVolume().ConvertToString("ul")
+
+	for _, tc := range []struct {
+		vol      float64
+		required float64
+		short    float64
+	}{
+		{vol: 100.0, required: 110.0 + residual, short: 0.0},
+		{vol: 145.0, required: 155.0 + residual, short: 5.0 + residual},
+	} {
+		ris, err := getTestSuck(robot, "soup", wunit.NewVolume(tc.vol, "ul"), well.CurrentVolume()).Generate(ctx, pol, robot)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		usage := SimulateWellUsage(ris, robot)
+
+		if len(usage) != 1 {
+			t.Fatalf("expected usage of one well, got %v", usage)
+		}
+
+		u := usage[0]
+
+		if u.Position != "position_4" || u.Well != "A1" || u.Aspirates != 1 {
+			t.Errorf("expected one aspirate from position_4 A1, got %s", u)
+		}
+
+		if r := u.Required.ConvertToString("ul"); r != tc.required {
+			t.Errorf("aspirating %g ul: expected %g ul required, got %g", tc.vol, tc.required, r)
+		}
+
+		if s := u.Shortfall().ConvertToString("ul"); s != tc.short {
+			t.Errorf("aspirating %g ul: expected shortfall of %g ul, got %g", tc.vol, tc.short, s)
+		}
+	}
+
+	if v := well.CurrentVolume().ConvertToString("ul"); v != 150.0 {
+		t.Errorf("expected simulation to leave well alone, but it now holds %g ul", v)
+	}
+}
@@ -0,0 +1,461 @@
+// microArch/planner/stocks.go: Part of the Antha language
+// Copyright (C) 2017 the Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+// Package planner works out how to prepare what a protocol needs before it
+// is run
+package planner
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+const (
+	// DefaultMaxDilution is the largest dilution made in a single step
+	// unless a request says otherwise
+	DefaultMaxDilution = 100.0
+	// DefaultDiluent is what stocks are diluted with unless a request says
+	// otherwise
+	DefaultDiluent = "water"
+
+	tolerance = 1e-9
+)
+
+// WellTarget is what a well should end up containing
+type WellTarget struct {
+	Well           string
+	TotalVolume    wunit.Volume
+	Concentrations map[string]wunit.Concentration
+}
+
+// StockRequest asks for a set of stocks from which the wells can be made
+type StockRequest struct {
+	Wells []WellTarget
+	// MinVolume is the smallest volume the robot can transfer
+	MinVolume wunit.Volume
+	// MaxSolubility limits how concentrated each stock can be, any
+	// component not listed is taken to be infinitely soluble
+	MaxSolubility map[string]wunit.Concentration
+	// MaxDilution is the largest dilution to make in one step,
+	// DefaultMaxDilution if zero
+	MaxDilution float64
+	// Diluent is what stocks and wells are made up with, DefaultDiluent
+	// if empty
+	Diluent string
+}
+
+// Stock is a solution to be prepared before the run
+type Stock struct {
+	Component     string
+	Concentration wunit.Concentration
+	// Volume is the least that is needed, including for any dilutions
+	Volume wunit.Volume
+}
+
+// Dilution is an intermediate made from a stock or another intermediate so
+// that wells needing very little of a component get at least the minimum
+// transfer volume
+type Dilution struct {
+	Name          string
+	Component     string
+	From          string
+	Factor        float64
+	Concentration wunit.Concentration
+	Volume        wunit.Volume
+	SourceVolume  wunit.Volume
+	DiluentVolume wunit.Volume
+}
+
+// Transfer moves Volume of Source into a well
+type Transfer struct {
+	Well   string
+	Source string
+	Volume wunit.Volume
+}
+
+// StockDesign is the answer to a StockRequest: the stocks to prepare, the
+// intermediates to make from them, what goes into each well and why
+type StockDesign struct {
+	Stocks    []Stock
+	Dilutions []Dilution
+	Transfers []Transfer
+	Reasoning []string
+}
+
+func (sd *StockDesign) explain(format string, args ...interface{}) {
+	sd.Reasoning = append(sd.Reasoning, fmt.Sprintf(format, args...))
+}
+
+// a use of a component in one well, concentrations are in SI units and
+// volumes in ul
+type use struct {
+	well   int
+	conc   float64
+	volume float64
+}
+
+type component struct {
+	name    string
+	unit    string
+	base    string
+	uses    []use
+	maxConc float64
+	// the most concentrated stock every well can be made from
+	directConc float64
+}
+
+// convert an SI value back to the unit the component was asked for in
+func (c component) concentration(si float64) wunit.Concentration {
+	return wunit.NewConcentration(si/wunit.NewConcentration(1.0, c.unit).SIValue(), c.unit)
+}
+
+func ul(v float64) wunit.Volume {
+	return wunit.NewVolume(v, "ul")
+}
+
+func collectComponents(req StockRequest) ([]*component, error) {
+	byName := make(map[string]*component)
+
+	for i, w := range req.Wells {
+		if w.TotalVolume.IsNil() || w.TotalVolume.RawValue() <= 0.0 {
+			return nil, fmt.Errorf("well %s has no total volume", w.Well)
+		}
+
+		tv := w.TotalVolume.ConvertToString("ul")
+
+		for name, conc := range w.Concentrations {
+			if conc.IsNil() || conc.RawValue() <= 0.0 {
+				continue
+			}
+
+			c, ok := byName[name]
+			if !ok {
+				c = &component{name: name, unit: conc.Unit().PrefixedSymbol(), base: conc.Unit().BaseSISymbol()}
+				byName[name] = c
+			} else if c.base != conc.Unit().BaseSISymbol() {
+				return nil, fmt.Errorf("component %s is wanted in both %s and %s in well %s", name, c.unit, conc.Unit().PrefixedSymbol(), w.Well)
+			}
+
+			c.uses = append(c.uses, use{well: i, conc: conc.SIValue(), volume: tv})
+		}
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ret := make([]*component, 0, len(names))
+	for _, name := range names {
+		ret = append(ret, byName[name])
+	}
+
+	return ret, nil
+}
+
+// Requirement is how a set of wells wants a component, with all
+// concentrations in the same unit
+type Requirement struct {
+	// MaxConc is the highest concentration any of the wells wants
+	MaxConc float64
+	// DirectConc is the most concentrated stock from which every well
+	// still needs at least the minimum transfer volume
+	DirectConc float64
+	// MaxSolubility is the most concentrated the stock can be made, zero
+	// if there is no limit
+	MaxSolubility float64
+}
+
+// ChooseStockConcentrations picks a stock concentration for each component,
+// in the unit of its requirement. Each stock is made as concentrated as it
+// can be while every well still needs at least the minimum volume of it,
+// subject to solubility, provided that the stocks together fit into the
+// wells which need the most of them. When they do not fit the stocks are
+// concentrated towards their solubility limits until they do, and some
+// wells will then need less than the minimum volume.
+//
+// It also returns the fraction of the fullest wells the stocks would make up
+// before any such concentration
+func ChooseStockConcentrations(reqs map[string]Requirement) (map[string]float64, float64, error) {
+	names := make([]string, 0, len(reqs))
+	for name := range reqs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	direct := make(map[string]float64, len(reqs))
+	minFraction := make(map[string]float64, len(reqs))
+
+	sumDirect := 0.0
+	sumMin := 0.0
+
+	for _, name := range names {
+		r := reqs[name]
+
+		if r.MaxConc <= 0.0 || r.DirectConc <= 0.0 {
+			return nil, 0.0, fmt.Errorf("no concentration wanted for %s", name)
+		}
+
+		direct[name] = r.DirectConc
+
+		if r.MaxSolubility > 0.0 {
+			minFraction[name] = r.MaxConc / r.MaxSolubility
+
+			if minFraction[name] > 1.0 {
+				return nil, 0.0, wtype.LHError(wtype.LH_ERR_CONC, fmt.Sprintf("%s is wanted %.3g times more concentrated than it is soluble", name, minFraction[name]))
+			}
+
+			direct[name] = math.Min(direct[name], r.MaxSolubility)
+		}
+
+		sumDirect += r.MaxConc / direct[name]
+		sumMin += minFraction[name]
+	}
+
+	if sumMin > 1.0+tolerance {
+		return nil, 0.0, wtype.LHError(wtype.LH_ERR_CONC, fmt.Sprintf("even at their solubility limits the stocks would make up %.0f%% of the most concentrated wells", sumMin*100.0))
+	}
+
+	scale := 1.0
+	if sumDirect > 1.0+tolerance {
+		scale = (1.0 - sumMin) / (sumDirect - sumMin)
+	}
+
+	concs := make(map[string]float64, len(reqs))
+
+	for _, name := range names {
+		r := reqs[name]
+		fraction := minFraction[name] + scale*(r.MaxConc/direct[name]-minFraction[name])
+		concs[name] = r.MaxConc / fraction
+	}
+
+	return concs, sumDirect, nil
+}
+
+// DesignStocks proposes stock concentrations from which the wells in the
+// request can be made without transferring less than the minimum volume.
+//
+// Stocks are chosen by ChooseStockConcentrations, as in the scheduler's
+// solution setup, and wells which would then need less than the minimum
+// volume are made from intermediate dilutions of no more than MaxDilution
+// per step instead
+func DesignStocks(req StockRequest) (*StockDesign, error) {
+	if req.MinVolume.IsNil() || req.MinVolume.RawValue() <= 0.0 {
+		return nil, fmt.Errorf("no minimum transfer volume given")
+	}
+
+	vmin := req.MinVolume.ConvertToString("ul")
+
+	maxDilution := req.MaxDilution
+	if maxDilution == 0.0 {
+		maxDilution = DefaultMaxDilution
+	} else if maxDilution <= 1.0 {
+		return nil, fmt.Errorf("maximum dilution must be greater than 1, got %g", maxDilution)
+	}
+
+	diluent := req.Diluent
+	if diluent == "" {
+		diluent = DefaultDiluent
+	}
+
+	cmps, err := collectComponents(req)
+	if err != nil {
+		return nil, err
+	}
+
+	design := &StockDesign{}
+
+	// first the most concentrated stock each component can have without
+	// any well needing less than the minimum volume
+
+	reqs := make(map[string]Requirement, len(cmps))
+
+	for _, c := range cmps {
+		minConc := math.Inf(1)
+		c.directConc = math.Inf(1)
+
+		for _, u := range c.uses {
+			c.maxConc = math.Max(c.maxConc, u.conc)
+			minConc = math.Min(minConc, u.conc)
+			c.directConc = math.Min(c.directConc, u.conc*u.volume/vmin)
+		}
+
+		design.explain("%s: wanted between %s and %s; with a minimum transfer of %s the stock can be at most %s", c.name, c.concentration(minConc), c.concentration(c.maxConc), req.MinVolume, c.concentration(c.directConc))
+
+		r := Requirement{MaxConc: c.maxConc, DirectConc: c.directConc}
+
+		if smax, ok := req.MaxSolubility[c.name]; ok && !smax.IsNil() && smax.RawValue() > 0.0 {
+			if smax.Unit().BaseSISymbol() != c.base {
+				return nil, fmt.Errorf("solubility of %s is in %s but it is wanted in %s", c.name, smax.Unit().PrefixedSymbol(), c.unit)
+			}
+
+			r.MaxSolubility = smax.SIValue()
+
+			if r.MaxSolubility < c.maxConc {
+				return nil, wtype.LHError(wtype.LH_ERR_CONC, fmt.Sprintf("%s is wanted at %s but is only soluble to %s", c.name, c.concentration(c.maxConc), smax))
+			}
+
+			if r.MaxSolubility < c.directConc {
+				design.explain("%s: limited by its solubility to %s", c.name, smax)
+			}
+		}
+
+		reqs[c.name] = r
+	}
+
+	stockConcs, fraction, err := ChooseStockConcentrations(reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	if fraction > 1.0+tolerance {
+		design.explain("stocks at these concentrations would make up %.0f%% of the most concentrated wells, so they are concentrated until they make up at most 100%%", fraction*100.0)
+	} else {
+		design.explain("stocks at these concentrations make up at most %.0f%% of the most concentrated wells", fraction*100.0)
+	}
+
+	wellVolumes := make([]float64, len(req.Wells))
+
+	for _, c := range cmps {
+		if err := design.addComponent(c, stockConcs[c.name], vmin, maxDilution, diluent, req.Wells, wellVolumes); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, w := range req.Wells {
+		tv := w.TotalVolume.ConvertToString("ul")
+		remaining := tv - wellVolumes[i]
+
+		if remaining < -tolerance {
+			return nil, wtype.LHError(wtype.LH_ERR_CONC, fmt.Sprintf("well %s needs %s of stocks but only holds %s", w.Well, ul(wellVolumes[i]), w.TotalVolume))
+		}
+
+		if remaining > tolerance {
+			if remaining < vmin {
+				design.explain("well %s: only %s of %s is left to add, below the minimum transfer", w.Well, ul(remaining), diluent)
+			}
+			design.Transfers = append(design.Transfers, Transfer{Well: w.Well, Source: diluent, Volume: ul(remaining)})
+		}
+	}
+
+	return design, nil
+}
+
+// addComponent decides which wells are made from the stock and which from
+// intermediate dilutions of it, and works out how much of each is needed
+func (sd *StockDesign) addComponent(c *component, stockConc, vmin, maxDilution float64, diluent string, wells []WellTarget, wellVolumes []float64) error {
+	stock := Stock{Component: c.name, Concentration: c.concentration(stockConc)}
+
+	// the most dilute source needed is the one from which the well needing
+	// least of the component still gets the minimum volume
+
+	lowest := stockConc
+	minWellVolume := math.Inf(1)
+	for _, u := range c.uses {
+		lowest = math.Min(lowest, u.conc*u.volume/vmin)
+		minWellVolume = math.Min(minWellVolume, u.volume)
+	}
+
+	// dilute in equal steps no larger than the wells can take: a well
+	// served by one source needs less than the minimum from the one before
+	// so at most the step factor times the minimum from its own
+
+	stepLimit := math.Min(maxDilution, minWellVolume/vmin)
+
+	steps := 0
+	factor := 1.0
+
+	if stockConc/lowest > 1.0+tolerance {
+		if stepLimit <= 1.0 {
+			return wtype.LHError(wtype.LH_ERR_CONC, fmt.Sprintf("%s: wells need less than the minimum transfer of its stock and are too small to be made from a dilution", c.name))
+		}
+		steps = int(math.Ceil(math.Log(stockConc/lowest)/math.Log(stepLimit) - tolerance))
+		factor = math.Pow(stockConc/lowest, 1.0/float64(steps))
+	}
+
+	names := make([]string, steps+1)
+	concs := make([]float64, steps+1)
+	demand := make([]float64, steps+1)
+
+	names[0] = c.name
+	concs[0] = stockConc
+	for i := 1; i <= steps; i++ {
+		concs[i] = concs[i-1] / factor
+		names[i] = fmt.Sprintf("%s 1:%.4g", c.name, stockConc/concs[i])
+	}
+
+	counts := make([]int, steps+1)
+
+	for _, u := range c.uses {
+		level := 0
+		for level < steps && u.conc*u.volume/concs[level] < vmin*(1.0-tolerance) {
+			level++
+		}
+
+		v := u.conc * u.volume / concs[level]
+		demand[level] += v
+		counts[level]++
+		wellVolumes[u.well] += v
+
+		sd.Transfers = append(sd.Transfers, Transfer{Well: wells[u.well].Well, Source: names[level], Volume: ul(v)})
+	}
+
+	if steps > 0 {
+		sd.explain("%s: %d well(s) would need less than %s of the %s stock, so it is diluted %d time(s) by 1:%.3g", c.name, len(c.uses)-counts[0], ul(vmin), stock.Concentration, steps, factor)
+	}
+
+	// work back from the most dilute: each intermediate must be made with
+	// at least the minimum volume of its source
+
+	dilutions := make([]Dilution, steps)
+	needed := 0.0
+
+	for i := steps; i > 0; i-- {
+		total := math.Max(demand[i]+needed, vmin*factor)
+		needed = total / factor
+
+		dilutions[i-1] = Dilution{
+			Name:          names[i],
+			Component:     c.name,
+			From:          names[i-1],
+			Factor:        factor,
+			Concentration: c.concentration(concs[i]),
+			Volume:        ul(total),
+			SourceVolume:  ul(needed),
+			DiluentVolume: ul(total - needed),
+		}
+
+		sd.explain("%s: make %s by adding %s of %s to %s of %s for %d well(s)", c.name, dilutions[i-1].Volume, dilutions[i-1].SourceVolume, names[i-1], dilutions[i-1].DiluentVolume, diluent, counts[i])
+	}
+
+	stock.Volume = ul(demand[0] + needed)
+
+	sd.Stocks = append(sd.Stocks, stock)
+	sd.Dilutions = append(sd.Dilutions, dilutions...)
+
+	return nil
+}
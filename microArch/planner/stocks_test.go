@@ -0,0 +1,186 @@
+package planner
+
+import (
+	"math"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+func makeTestWell(name string, concs map[string]float64) WellTarget {
+	wt := WellTarget{
+		Well:           name,
+		TotalVolume:    wunit.NewVolume(100.0, "ul"),
+		Concentrations: make(map[string]wunit.Concentration, len(concs)),
+	}
+	for cmp, c := range concs {
+		wt.Concentrations[cmp] = wunit.NewConcentration(c, "mM")
+	}
+	return wt
+}
+
+// check every transfer is at least the minimum and that each well gets what
+// it asked for in the volume it asked for
+func checkDesign(t *testing.T, req StockRequest, design *StockDesign) {
+	type source struct {
+		cmp  string
+		conc float64
+	}
+
+	sources := make(map[string]source)
+
+	for _, s := range design.Stocks {
+		sources[s.Component] = source{s.Component, s.Concentration.ConvertToString("mM")}
+	}
+
+	for _, d := range design.Dilutions {
+		sources[d.Name] = source{d.Component, d.Concentration.ConvertToString("mM")}
+
+		if d.SourceVolume.LessThan(req.MinVolume) {
+			t.Errorf("%s made with %s of %s, less than the minimum", d.Name, d.SourceVolume, d.From)
+		}
+	}
+
+	volumes := make(map[string]float64)
+	concs := make(map[string]map[string]float64)
+
+	for _, tr := range design.Transfers {
+		v := tr.Volume.ConvertToString("ul")
+		if v < req.MinVolume.ConvertToString("ul")-1e-9 {
+			t.Errorf("%s of %s into %s is less than the minimum", tr.Volume, tr.Source, tr.Well)
+		}
+		volumes[tr.Well] += v
+
+		if src, ok := sources[tr.Source]; ok {
+			if concs[tr.Well] == nil {
+				concs[tr.Well] = make(map[string]float64)
+			}
+			concs[tr.Well][src.cmp] += src.conc * v
+		}
+	}
+
+	for _, w := range req.Wells {
+		tv := w.TotalVolume.ConvertToString("ul")
+		if math.Abs(volumes[w.Well]-tv) > 1e-6 {
+			t.Errorf("well %s: expected %f ul got %f", w.Well, tv, volumes[w.Well])
+		}
+
+		for cmp, c := range w.Concentrations {
+			if got := concs[w.Well][cmp] / tv; math.Abs(got-c.ConvertToString("mM")) > 1e-6 {
+				t.Errorf("well %s: expected %s of %s got %f mM", w.Well, c, cmp, got)
+			}
+		}
+	}
+}
+
+func TestDesignStocksDirect(t *testing.T) {
+	req := StockRequest{
+		Wells: []WellTarget{
+			makeTestWell("A1", map[string]float64{"dna": 1.0, "salt": 5.0}),
+			makeTestWell("B1", map[string]float64{"dna": 10.0, "salt": 5.0}),
+		},
+		MinVolume: wunit.NewVolume(1.0, "ul"),
+	}
+
+	design, err := DesignStocks(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkDesign(t, req, design)
+
+	if len(design.Dilutions) != 0 {
+		t.Errorf("expected no dilutions, got %v", design.Dilutions)
+	}
+
+	if len(design.Stocks) != 2 || design.Stocks[0].Component != "dna" || math.Abs(design.Stocks[0].Concentration.ConvertToString("mM")-100.0) > 1e-9 {
+		t.Errorf("expected 100 mM dna stock, got %v", design.Stocks)
+	}
+
+	if len(design.Reasoning) == 0 {
+		t.Errorf("expected some reasoning")
+	}
+}
+
+func TestDesignStocksIntermediate(t *testing.T) {
+	req := StockRequest{
+		Wells: []WellTarget{
+			makeTestWell("A1", map[string]float64{"dna": 0.01, "salt": 100.0}),
+			makeTestWell("B1", map[string]float64{"dna": 0.5, "salt": 100.0}),
+			makeTestWell("C1", map[string]float64{"dna": 50.0, "salt": 100.0}),
+		},
+		MinVolume: wunit.NewVolume(1.0, "ul"),
+		MaxSolubility: map[string]wunit.Concentration{
+			"dna":  wunit.NewConcentration(1000.0, "mM"),
+			"salt": wunit.NewConcentration(1000.0, "mM"),
+		},
+	}
+
+	design, err := DesignStocks(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkDesign(t, req, design)
+
+	if len(design.Dilutions) != 1 || design.Dilutions[0].Component != "dna" {
+		t.Errorf("expected one dilution of dna, got %v", design.Dilutions)
+	}
+}
+
+func TestDesignStocksInsoluble(t *testing.T) {
+	req := StockRequest{
+		Wells: []WellTarget{
+			makeTestWell("A1", map[string]float64{"dna": 60.0, "salt": 60.0}),
+		},
+		MinVolume: wunit.NewVolume(1.0, "ul"),
+		MaxSolubility: map[string]wunit.Concentration{
+			"dna":  wunit.NewConcentration(100.0, "mM"),
+			"salt": wunit.NewConcentration(100.0, "mM"),
+		},
+	}
+
+	if _, err := DesignStocks(req); err == nil {
+		t.Errorf("expected an error when stocks cannot fit")
+	}
+}
+
+func TestChooseStockConcentrations(t *testing.T) {
+	reqs := map[string]Requirement{
+		// fits as is
+		"a": {MaxConc: 10.0, DirectConc: 50.0},
+		// limited by solubility
+		"b": {MaxConc: 10.0, DirectConc: 100.0, MaxSolubility: 40.0},
+	}
+
+	concs, fraction, err := ChooseStockConcentrations(reqs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if math.Abs(fraction-0.45) > 1e-9 {
+		t.Errorf("expected stocks to make up 0.45 of the wells, got %f", fraction)
+	}
+
+	if math.Abs(concs["a"]-50.0) > 1e-9 || math.Abs(concs["b"]-40.0) > 1e-9 {
+		t.Errorf("expected stocks of 50 and 40, got %v", concs)
+	}
+
+	// too much to fit: concentrate both towards their solubility limits
+	reqs["a"] = Requirement{MaxConc: 10.0, DirectConc: 10.0, MaxSolubility: 100.0}
+
+	concs, _, err = ChooseStockConcentrations(reqs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f := 10.0/concs["a"] + 10.0/concs["b"]; math.Abs(f-1.0) > 1e-9 {
+		t.Errorf("expected stocks to fill the wells exactly, got %f", f)
+	}
+
+	reqs["a"] = Requirement{MaxConc: 10.0, DirectConc: 10.0, MaxSolubility: 5.0}
+
+	if _, _, err := ChooseStockConcentrations(reqs); err == nil {
+		t.Errorf("expected an error when a component is wanted above its solubility")
+	}
+}
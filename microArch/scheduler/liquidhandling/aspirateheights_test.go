@@ -0,0 +1,56 @@
+package liquidhandling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/testinventory"
+	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+func aspirateFrom(wellvol wunit.Volume, offsetZ float64) []liquidhandling.TerminalRobotInstruction {
+	mov := liquidhandling.NewMoveInstruction()
+	mov.Pos = []string{"position_4"}
+	mov.Plt = []string{"pcrplate_skirted_riser40"}
+	mov.Well = []string{"A1"}
+	mov.WVolume = []wunit.Volume{wellvol}
+	mov.Reference = []int{liquidhandling.WellBottom}
+	mov.OffsetX = []float64{0.0}
+	mov.OffsetY = []float64{0.0}
+	mov.OffsetZ = []float64{offsetZ}
+
+	asp := liquidhandling.NewAspirateInstruction()
+	asp.Volume = []wunit.Volume{wunit.NewVolume(1.0, "ul")}
+	asp.Multi = 1
+
+	return []liquidhandling.TerminalRobotInstruction{mov, asp}
+}
+
+func TestCheckAspirateHeightsRecordsWarnings(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	robot := makeGilson(ctx)
+	p, err := inventory.NewPlate(ctx, "pcrplate_skirted_riser40")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := robot.AddPlate("position_4", p); err != nil {
+		t.Fatal(err)
+	}
+
+	rq := NewLHRequest()
+
+	rq.Instructions = aspirateFrom(wunit.NewVolume(1.0, "ul"), 5.0)
+	checkAspirateHeights(rq, robot)
+	if len(rq.AspirateWarnings) != 1 {
+		t.Fatalf("expecting tip above liquid to be recorded, got %v", rq.AspirateWarnings)
+	}
+
+	rq.Instructions = aspirateFrom(wunit.NewVolume(150.0, "ul"), 0.5)
+	checkAspirateHeights(rq, robot)
+	if len(rq.AspirateWarnings) != 0 {
+		t.Errorf("expecting no warnings aspirating from full well, got %v", rq.AspirateWarnings)
+	}
+}
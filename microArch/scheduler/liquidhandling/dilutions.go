@@ -0,0 +1,301 @@
+// anthalib//liquidhandling/dilutions.go: Part of the Antha language
+// Copyright (C) 2017 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package liquidhandling
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/microArch/logger"
+)
+
+// DilutionPlateName is the name given to the plate intermediate dilutions
+// are made in
+const DilutionPlateName = "intermediate_dilutions"
+
+// DilutionStep records an intermediate dilution inserted by the planner so
+// that transfers below the robot's minimum volume can be made
+type DilutionStep struct {
+	InstructionID string
+	Component     string
+	Diluent       string
+	Factor        float64
+	Platetype     string
+	Volume        wunit.Volume
+	UsedBy        []string
+}
+
+func (ds DilutionStep) String() string {
+	return fmt.Sprintf("%s diluted 1:%g in %s (%s in %s) for %d mix(es)", ds.Component, ds.Factor, ds.Diluent, ds.Volume, ds.Platetype, len(ds.UsedBy))
+}
+
+// a transfer too small to be made directly, along with the component in the
+// same mix which it can be diluted with
+type lowVolumeUse struct {
+	ins     *wtype.LHInstruction
+	cmp     *wtype.LHComponent
+	diluent *wtype.LHComponent
+}
+
+const dilutionTolerance = 1e-6
+
+// insertDilutions finds components in mixes which are below the minimum
+// volume the robot can move and replaces them with larger volumes of an
+// intermediate dilution, made with another component of the same mix which
+// is reduced to match, so the final concentrations are unchanged. The
+// dilutions are made on a scratch plate of one of the input plate types.
+// Anything which can't be dealt with this way is left alone
+func insertDilutions(request *LHRequest, vmin wunit.Volume) ([]DilutionStep, error) {
+	if vmin.IsZero() {
+		return nil, nil
+	}
+
+	groups := make(map[string][]lowVolumeUse)
+	var keys []string
+
+	for _, insID := range request.Output_order {
+		ins := request.LHInstructions[insID]
+
+		if ins.Type != wtype.LHIMIX {
+			continue
+		}
+
+		for ix, cmp := range ins.Components {
+			if ix == 0 && ins.IsMixInPlace() {
+				continue
+			}
+
+			if cmp.IsInstance() || cmp.Vol <= 0.0 || !cmp.Volume().LessThan(vmin) {
+				continue
+			}
+
+			diluent := findDiluent(ins, cmp)
+
+			if diluent == nil {
+				logger.Info(fmt.Sprintf("cannot dilute %s for mix %s: nothing to dilute it with", cmp.Volume(), ins.ID))
+				continue
+			}
+
+			key := cmp.CName + "\x00" + diluent.CName
+
+			if _, ok := groups[key]; !ok {
+				keys = append(keys, key)
+			}
+
+			groups[key] = append(groups[key], lowVolumeUse{ins: ins, cmp: cmp, diluent: diluent})
+		}
+	}
+
+	var steps []DilutionStep
+
+	for _, key := range keys {
+		step, ins, ok := makeDilution(request, groups[key], vmin)
+
+		if !ok {
+			continue
+		}
+
+		request.Add_instruction(ins)
+		steps = append(steps, step)
+		logger.Info(fmt.Sprintf("inserted intermediate dilution: %s", step))
+	}
+
+	if len(steps) == 0 {
+		return nil, nil
+	}
+
+	// the new mixes need to be ordered ahead of the ones they feed
+	return steps, set_output_order(request)
+}
+
+// findDiluent chooses the component of a mix which is present in the
+// largest volume, other than cmp itself, to dilute cmp with
+func findDiluent(ins *wtype.LHInstruction, cmp *wtype.LHComponent) *wtype.LHComponent {
+	var diluent *wtype.LHComponent
+
+	for ix, c := range ins.Components {
+		if c == cmp || c.CName == cmp.CName || c.IsInstance() || (ix == 0 && ins.IsMixInPlace()) {
+			continue
+		}
+
+		if diluent == nil || diluent.Volume().LessThan(c.Volume()) {
+			diluent = c
+		}
+	}
+
+	return diluent
+}
+
+func containsComponent(ins *wtype.LHInstruction, cmp *wtype.LHComponent) bool {
+	for _, c := range ins.Components {
+		if c == cmp {
+			return true
+		}
+	}
+	return false
+}
+
+// dilutionPlatetype chooses the first input plate type whose wells will take
+// vol, or nil if there is none
+func dilutionPlatetype(request *LHRequest, vol wunit.Volume) *wtype.LHPlate {
+	for _, p := range request.Input_platetypes {
+		if p == nil || p.Nwells == 0 {
+			continue
+		}
+
+		if !p.Rows[0][0].MaxVolume().LessThan(vol) {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// makeDilution works out how much to dilute the component used in uses, makes
+// the mix for it and rewrites the mixes using it
+func makeDilution(request *LHRequest, uses []lowVolumeUse, vmin wunit.Volume) (DilutionStep, *wtype.LHInstruction, bool) {
+	min := vmin.ConvertToString("ul")
+
+	smallest := math.Inf(1)
+	for _, u := range uses {
+		smallest = math.Min(smallest, u.cmp.Volume().ConvertToString("ul"))
+	}
+
+	factor := math.Ceil(min/smallest - dilutionTolerance)
+
+	// the diluent left in each mix must either be used up or still be
+	// possible to move
+
+	carry := 0.0
+	if !request.CarryVolume.IsNil() {
+		carry = request.CarryVolume.ConvertToString("ul")
+	}
+
+	var ok []lowVolumeUse
+	need := 0.0
+
+	for _, u := range uses {
+		// an earlier dilution may have used up the diluent
+		if !containsComponent(u.ins, u.diluent) {
+			continue
+		}
+
+		v := u.cmp.Volume().ConvertToString("ul")
+		left := u.diluent.Volume().ConvertToString("ul") - v*(factor-1.0)
+
+		if left < -dilutionTolerance || (left > dilutionTolerance && left < min) {
+			logger.Info(fmt.Sprintf("cannot dilute %s for mix %s: not enough %s", u.cmp.Volume(), u.ins.ID, u.diluent.CName))
+			continue
+		}
+
+		ok = append(ok, u)
+		need += v*factor + carry
+	}
+
+	if len(ok) == 0 {
+		return DilutionStep{}, nil, false
+	}
+
+	// make enough for every transfer out, including what is carried over
+	// each time, plus the residual volume, and enough that the dilution
+	// itself doesn't need too small a volume of the source
+
+	plate := dilutionPlatetype(request, wunit.NewVolume(need, "ul"))
+
+	if plate == nil {
+		logger.Info(fmt.Sprintf("cannot dilute %s: no input plate type holds %s", ok[0].cmp.CName, wunit.NewVolume(need, "ul")))
+		return DilutionStep{}, nil, false
+	}
+
+	total := need + plate.Rows[0][0].ResidualVolume().ConvertToString("ul")
+	total = math.Max(total, min*factor)
+
+	if plate.Rows[0][0].MaxVolume().LessThan(wunit.NewVolume(total, "ul")) {
+		logger.Info(fmt.Sprintf("cannot dilute %s: %s is too much for %s", ok[0].cmp.CName, wunit.NewVolume(total, "ul"), plate.Type))
+		return DilutionStep{}, nil, false
+	}
+
+	src := ok[0].cmp.Cp()
+	src.SetVolume(wunit.NewVolume(total/factor, "ul"))
+	dil := ok[0].diluent.Cp()
+	dil.SetVolume(wunit.NewVolume(total-total/factor, "ul"))
+
+	result := src.Cp()
+	result.Mix(dil)
+	result.Loc = ""
+	result.Destination = ""
+	result.DeclareInstance()
+
+	ins := wtype.NewLHMixInstruction()
+	ins.AddComponent(src)
+	ins.AddComponent(dil)
+	ins.AddProduct(result)
+	ins.Platetype = plate.Type
+	ins.PlateName = DilutionPlateName
+	ins.BlockID = ok[0].ins.BlockID
+
+	step := DilutionStep{
+		InstructionID: ins.ID,
+		Component:     src.CName,
+		Diluent:       dil.CName,
+		Factor:        factor,
+		Platetype:     plate.Type,
+		Volume:        result.Volume(),
+	}
+
+	for _, u := range ok {
+		v := u.cmp.Volume().ConvertToString("ul")
+
+		smp := result.Cp()
+		smp.ParentID = result.ID
+		smp.SetSample(true)
+		smp.SetVolume(wunit.NewVolume(v*factor, "ul"))
+
+		left := u.diluent.Volume().ConvertToString("ul") - v*(factor-1.0)
+
+		cmps := make([]*wtype.LHComponent, 0, len(u.ins.Components))
+		for _, c := range u.ins.Components {
+			switch {
+			case c == u.cmp:
+				cmps = append(cmps, smp)
+			case c == u.diluent:
+				if left > dilutionTolerance {
+					c.SetVolume(wunit.NewVolume(left, "ul"))
+					cmps = append(cmps, c)
+				}
+			default:
+				cmps = append(cmps, c)
+			}
+		}
+		u.ins.Components = cmps
+
+		step.UsedBy = append(step.UsedBy, u.ins.ID)
+	}
+
+	sort.Strings(step.UsedBy)
+
+	return step, ins, true
+}
@@ -0,0 +1,85 @@
+package liquidhandling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/mixer"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/testinventory"
+)
+
+func TestInsertDilutions(t *testing.T) {
+	testInsertDilutions(t, func(rq *LHRequest) {
+		rq.Input_platetypes = append(rq.Input_platetypes, GetPlateForTest())
+	})
+}
+
+// dilutions need an input plate type before the inputs are laid out, so
+// one must be found if the plate types are to be searched for
+func TestInsertDilutionsWithInputPlateQuery(t *testing.T) {
+	testInsertDilutions(t, func(rq *LHRequest) {
+		rq.InputPlateQuery = inventory.PlateQuery{Wells: 96, Skirt: wtype.SkirtSemi}
+	})
+}
+
+func testInsertDilutions(t *testing.T, setInputPlates func(*LHRequest)) {
+	ctx := testinventory.NewContext(context.Background())
+
+	lh := GetLiquidHandlerForTest(ctx)
+	rq := GetLHRequestForTest()
+	rq.Options.InsertDilutions = true
+
+	water := GetComponentForTest(ctx, "water", wunit.NewVolume(1000.0, "ul"))
+	part := GetComponentForTest(ctx, "dna", wunit.NewVolume(50.0, "ul"))
+
+	// 0.1ul is below the 0.5ul the test robot can move
+	var mixes []*wtype.LHInstruction
+	for k := 0; k < 4; k++ {
+		ins := wtype.NewLHMixInstruction()
+		ins.AddComponent(mixer.Sample(water, wunit.NewVolume(49.9, "ul")))
+		ins.AddComponent(mixer.Sample(part, wunit.NewVolume(0.1, "ul")))
+		ins.AddProduct(GetComponentForTest(ctx, "water", wunit.NewVolume(50.0, "ul")))
+		rq.Add_instruction(ins)
+		mixes = append(mixes, ins)
+	}
+
+	setInputPlates(rq)
+	rq.Output_platetypes = append(rq.Output_platetypes, GetPlateForTest())
+	rq.ConfigureYourself()
+
+	if err := lh.Plan(ctx, rq); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rq.Dilutions) != 1 {
+		t.Fatalf("expected one dilution, got %v", rq.Dilutions)
+	}
+
+	step := rq.Dilutions[0]
+
+	if step.Component != "dna" || step.Diluent != "water" || step.Factor != 5.0 || len(step.UsedBy) != 4 {
+		t.Errorf("expected dna diluted 1:5 in water for 4 mixes, got %s", step)
+	}
+
+	if _, ok := rq.LHInstructions[step.InstructionID]; !ok {
+		t.Errorf("dilution mix %s not in request", step.InstructionID)
+	}
+
+	// concentrations are the same: 0.5ul of a 1:5 dilution and 0.4ul less water
+	for _, ins := range mixes {
+		total := wunit.NewVolume(0.0, "ul")
+		for _, c := range ins.Components {
+			total.Add(c.Volume())
+			if c.Volume().LessThan(wunit.NewVolume(0.5, "ul")) {
+				t.Errorf("%s of %s is still below the minimum", c.Volume(), c.CName)
+			}
+		}
+
+		if !total.EqualTo(wunit.NewVolume(50.0, "ul")) {
+			t.Errorf("expected mix to total 50 ul, got %s", total)
+		}
+	}
+}
@@ -25,9 +25,11 @@ package liquidhandling
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+	"github.com/antha-lang/antha/microArch/logger"
 )
 
 // robot here should be a copy... this routine will be destructive of state
@@ -76,6 +78,19 @@ func ExecutionPlanner3(ctx context.Context, request *LHRequest, robot *liquidhan
 	}
 	request.Instructions = instrx
 
+	// credit any time saved by multi-dispensing
+
+	if timer := robot.GetTimer(); timer != nil {
+		var saved time.Duration
+		request.InstructionSet.Walk(func(ins liquidhandling.RobotInstruction) {
+			saved += timer.TimeSavedBy(ins)
+		})
+		request.MultiDispenseSaving = saved.Seconds()
+		if saved > 0 {
+			logger.Info(fmt.Sprintf("Time saved by multi-dispensing: %s", saved.String()))
+		}
+	}
+
 	// TODO -- pass evaporation info back up to request
 
 	return request, nil
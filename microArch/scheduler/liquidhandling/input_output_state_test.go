@@ -153,6 +153,72 @@ func TestBeforeVsAfterUserPlateDest(t *testing.T) {
 
 	compareInitFinalStates(t, lh, expected)
 }
+func TestOutputWells(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+	rq := makeRequest()
+	lh := makeLiquidhandler(ctx)
+
+	cmp1, cmp2 := getComponents(ctx, t)
+
+	cmp1.Vol = 100.0
+	cmp2.Vol = 50.0
+	cmp2.Conc = 70.0
+	cmp2.Cunit = "ng/ul"
+
+	s1 := mixer.Sample(cmp1, wunit.NewVolume(25.0, "ul"))
+	s2 := mixer.Sample(cmp2, wunit.NewVolume(10.0, "ul"))
+
+	mo := mixer.MixOptions{
+		Components: []*wtype.LHComponent{s1, s2},
+		PlateType:  "pcrplate_skirted_riser20",
+		Address:    "C1",
+		PlateName:  "output",
+	}
+
+	ins := mixer.GenericMix(mo)
+
+	rq.LHInstructions[ins.ID] = ins
+
+	pl, err := inventory.NewPlate(ctx, "pcrplate_skirted_riser20")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rq.Input_platetypes = append(rq.Input_platetypes, pl)
+
+	rq.ConfigureYourself()
+
+	if err := lh.Plan(ctx, rq); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rq.OutputWells) != 1 {
+		t.Fatalf("expected 1 output well, got %d", len(rq.OutputWells))
+	}
+
+	ow := rq.OutputWells[0]
+
+	if ow.Well != "C1" || ow.PlateName != "output" || ow.Component != "water+dna_part" {
+		t.Errorf("expected water+dna_part in output C1, got %s in %s %s", ow.Component, ow.PlateName, ow.Well)
+	}
+
+	if !ow.Volume.EqualTo(wunit.NewVolume(35.0, "ul")) {
+		t.Errorf("expected 35 ul, got %s", ow.Volume.ToString())
+	}
+
+	// 10 of 35ul at 70ng/ul, which is the same as mg/l
+
+	sc, ok := ow.Solutes["dna_part"]
+
+	if !ok {
+		t.Fatalf("expected dna_part among the solutes, got %v", ow.Solutes)
+	}
+
+	if sc.Conc < 19.99 || sc.Conc > 20.01 || sc.Unit != "mg/l" {
+		t.Errorf("expected 20 mg/l dna_part, got %s", sc)
+	}
+}
+
 func TestBeforeVsAfterUserPlateAutoDest(t *testing.T) {
 	ctx := testinventory.NewContext(context.Background())
 	rq := makeRequest()
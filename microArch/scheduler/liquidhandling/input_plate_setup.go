@@ -71,6 +71,24 @@ func (is InputSorter) Less(i, j int) bool {
 	return ss.Less(i, j)
 }
 
+// resolveInputPlatetypes searches the inventory for the input plate types
+// meeting the request's constraints, unless types were given
+func resolveInputPlatetypes(ctx context.Context, request *LHRequest) error {
+	if len(request.Input_platetypes) != 0 {
+		return nil
+	}
+
+	platetypes, err := inventory.Plates(ctx, request.InputPlateQuery)
+	if err != nil {
+		return err
+	}
+	if len(platetypes) == 0 {
+		return wtype.LHError(wtype.LH_ERR_OTHER, fmt.Sprintf("no input plate types match %q", request.InputPlateQuery))
+	}
+	request.Input_platetypes = platetypes
+	return nil
+}
+
 //  TASK: 	Map inputs to input plates
 // INPUT: 	"input_platetype", "inputs"
 //OUTPUT: 	"input_plates"      -- these each have components in wells
@@ -78,17 +96,10 @@ func (is InputSorter) Less(i, j int) bool {
 func input_plate_setup(ctx context.Context, request *LHRequest) (*LHRequest, error) {
 	st := sampletracker.GetSampleTracker()
 	// I think this might need moving too
-	input_platetypes := (*request).Input_platetypes
-	if input_platetypes == nil || len(input_platetypes) == 0 {
-		// XXX this is dangerous... until input_plate_linear is replaced we will hit big problems here
-		// this configuration needs to happen outside but for now...
-		input_platetypes, err := inventory.XXXNewPlates(ctx)
-		if err != nil {
-			return nil, err
-		}
-		(*request).Input_platetypes = input_platetypes
-		//debug
+	if err := resolveInputPlatetypes(ctx, request); err != nil {
+		return nil, err
 	}
+	input_platetypes := (*request).Input_platetypes
 
 	// we assume that input_plates is set if any locs are set
 	input_plates := (*request).Input_plates
@@ -225,6 +236,12 @@ func input_plate_setup(ctx context.Context, request *LHRequest) (*LHRequest, err
 					newcomponent.Vunit = curr_well.Vunit
 					newcomponent.Loc = location
 					volume.Subtract(curr_well.WorkingVolume())
+
+					// hold back whatever the robot has been found
+					// to need beyond what is planned
+					if r, ok := request.Input_vols_reserved[cname]; ok {
+						curr_well.Rvol += r.ConvertToString(curr_well.Vunit)
+					}
 				}
 
 				st.SetLocationOf(component.ID, location)
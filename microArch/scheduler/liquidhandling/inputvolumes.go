@@ -0,0 +1,208 @@
+package liquidhandling
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+	"github.com/antha-lang/antha/microArch/logger"
+)
+
+// maxInputSetupAttempts limits how many times the input plates are set up
+// again to cover what the instructions turn out to take from them
+const maxInputSetupAttempts = 5
+
+// inputShortfall is how much more an input well needs to hold for the
+// instructions generated to be carried out
+type inputShortfall struct {
+	usage         liquidhandling.WellUsage
+	volume        wunit.Volume
+	autoallocated bool
+}
+
+func (is inputShortfall) String() string {
+	return fmt.Sprintf("%s short by %s", is.usage, is.volume)
+}
+
+// checkInputVolumes follows the instructions generated for request through
+// the robot as it was before they were run, and returns how short each input
+// well is of what is really taken out of it. The volume deliberately held
+// back in autoallocated wells counts towards what they hold
+func checkInputVolumes(request *LHRequest, before *liquidhandling.LHProperties) []inputShortfall {
+	inputs := make(map[string]bool, len(request.Input_plates))
+	for id := range request.Input_plates {
+		if pos, ok := request.Plate_lookup[id]; ok {
+			inputs[pos] = true
+		}
+	}
+
+	ris := make([]liquidhandling.RobotInstruction, 0, len(request.Instructions))
+	for _, ins := range request.Instructions {
+		ris = append(ris, ins)
+	}
+
+	var short []inputShortfall
+
+	for _, u := range liquidhandling.SimulateWellUsage(ris, before) {
+		if !inputs[u.Position] {
+			continue
+		}
+
+		s := u.Shortfall()
+
+		well := before.Plates[u.Position].Wellcoords[u.Well]
+		auto := well.IsAutoallocated() && !well.IsUserAllocated()
+
+		if auto {
+			if r, ok := request.Input_vols_reserved[u.Component]; ok {
+				s.Subtract(r)
+			}
+		}
+
+		if s.RawValue() <= 0.0 {
+			continue
+		}
+
+		short = append(short, inputShortfall{usage: u, volume: s, autoallocated: auto})
+	}
+
+	return short
+}
+
+// reserveInputVolumes holds back more in every autoallocated well of each
+// component which runs short, so that less is planned to come out of each,
+// and asks for more of it to make up the difference
+func reserveInputVolumes(request *LHRequest, wanting map[string]wunit.Volume, short []inputShortfall) {
+	more := make(map[string]wunit.Volume, len(short))
+
+	for _, s := range short {
+		cname := s.usage.Component
+
+		if m, ok := more[cname]; !ok || m.LessThan(s.volume) {
+			more[cname] = s.volume
+		}
+
+		w, ok := wanting[cname]
+
+		if !ok {
+			w = wunit.ZeroVolume()
+		}
+
+		w = wunit.CopyVolume(w)
+		w.Add(s.volume)
+		wanting[cname] = w
+	}
+
+	for cname, m := range more {
+		r, ok := request.Input_vols_reserved[cname]
+
+		if !ok {
+			r = wunit.ZeroVolume()
+		}
+
+		r = wunit.CopyVolume(r)
+		r.Add(m)
+		request.Input_vols_reserved[cname] = r
+	}
+}
+
+func dupVolumes(vols map[string]wunit.Volume) map[string]wunit.Volume {
+	r := make(map[string]wunit.Volume, len(vols))
+	for k, v := range vols {
+		r[k] = wunit.CopyVolume(v)
+	}
+	return r
+}
+
+func dupPlates(plates map[string]*wtype.LHPlate) map[string]*wtype.LHPlate {
+	r := make(map[string]*wtype.LHPlate, len(plates))
+	for k, v := range plates {
+		r[k] = v.DupKeepIDs()
+	}
+	return r
+}
+
+// setupInputs defines the input plates, sets up the deck and generates the
+// instructions for request. The instructions are then checked against what
+// the input wells hold: the liquid handling policies can take more out than
+// was planned for, through extra aspirate volumes, multi-dispense excess and
+// mixing. If any autoallocated inputs run short the setup is done again with
+// more held back in each of their wells, until either every input suffices or
+// the shortfall can't be fixed, which is an error
+func (this *Liquidhandler) setupInputs(ctx context.Context, request *LHRequest) (*LHRequest, error) {
+	// everything which follows changes these, so keep them to start again
+	robot := this.Properties.DupKeepIDs()
+	inputPlates := dupPlates(request.Input_plates)
+	outputPlates := dupPlates(request.Output_plates)
+	inputOrder := append([]string{}, request.Input_plate_order...)
+	outputOrder := append([]string{}, request.Output_plate_order...)
+	wanting := dupVolumes(request.Input_vols_wanting)
+
+	if request.Input_vols_reserved == nil {
+		request.Input_vols_reserved = make(map[string]wunit.Volume)
+	}
+
+	for attempt := 1; ; attempt++ {
+		var err error
+
+		// define the input plates
+		// should be merged with the above
+		request, err = input_plate_setup(ctx, request)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// next we need to determine the liquid handler setup
+		request, err = this.Setup(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		// remove dummy mix-in-place instructions
+
+		request = removeDummyInstructions(request)
+
+		// now make instructions
+		request, err = this.ExecutionPlan(ctx, request)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// the robot before the instructions were run
+		short := checkInputVolumes(request, this.FinalProperties)
+
+		if len(short) == 0 {
+			return request, nil
+		}
+
+		fixable := attempt < maxInputSetupAttempts
+		msgs := make([]string, 0, len(short))
+
+		for _, s := range short {
+			msgs = append(msgs, s.String())
+			fixable = fixable && s.autoallocated
+		}
+
+		if !fixable {
+			return nil, wtype.LHError(wtype.LH_ERR_VOL, fmt.Sprintf("not enough liquid in input wells after %d attempt(s) at setup: %s", attempt, strings.Join(msgs, "; ")))
+		}
+
+		logger.Info(fmt.Sprintf("setting up inputs again to allow for: %s", strings.Join(msgs, "; ")))
+
+		reserveInputVolumes(request, wanting, short)
+
+		this.Properties = robot.DupKeepIDs()
+		request.Input_plates = dupPlates(inputPlates)
+		request.Output_plates = dupPlates(outputPlates)
+		request.Input_plate_order = append([]string{}, inputOrder...)
+		request.Output_plate_order = append([]string{}, outputOrder...)
+		request.Input_vols_wanting = dupVolumes(wanting)
+		request.InstructionSet = liquidhandling.NewRobotInstructionSet(nil)
+		request.Instructions = nil
+	}
+}
@@ -0,0 +1,65 @@
+package liquidhandling
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/mixer"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/inventory/testinventory"
+)
+
+func TestSetupInputsAllowsForPolicy(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	lh := GetLiquidHandlerForTest(ctx)
+	rq := GetLHRequestForTest()
+
+	// every aspirate of water takes 20ul more than is dispensed, which
+	// planning doesn't know about
+	rule := wtype.NewLHPolicyRule("EXTRAWATER")
+	if err := rule.AddCategoryConditionOn("LIQUIDCLASS", "water"); err != nil {
+		t.Fatal(err)
+	}
+	pol := make(wtype.LHPolicy, 1)
+	pol["EXTRA_ASP_VOLUME"] = wunit.NewVolume(20.0, "ul")
+	rq.Policies.AddRule(rule, pol)
+
+	water := GetComponentForTest(ctx, "water", wunit.NewVolume(2000.0, "ul"))
+
+	for k := 0; k < 4; k++ {
+		ins := wtype.NewLHMixInstruction()
+		ins.AddComponent(mixer.Sample(water, wunit.NewVolume(40.0, "ul")))
+		ins.AddProduct(GetComponentForTest(ctx, "water", wunit.NewVolume(40.0, "ul")))
+		rq.Add_instruction(ins)
+	}
+
+	rq.Input_platetypes = append(rq.Input_platetypes, GetPlateForTest())
+	rq.Output_platetypes = append(rq.Output_platetypes, GetPlateForTest())
+	rq.ConfigureYourself()
+
+	if err := lh.Plan(ctx, rq); err != nil {
+		t.Fatal(err)
+	}
+
+	// planning would take all four from one well, which isn't enough
+	r, ok := rq.Input_vols_reserved["water"]
+	if !ok || r.IsZero() {
+		t.Fatalf("expected some water to be held back in each well, got %v", rq.Input_vols_reserved)
+	}
+
+	if len(rq.Input_assignments["water"]) == 0 {
+		t.Fatal("expected water to be assigned to input wells")
+	}
+
+	for _, loc := range rq.Input_assignments["water"] {
+		tx := strings.Split(loc, ":")
+		w := rq.Input_plates[tx[0]].Wellcoords[tx[1]]
+
+		if w.ResidualVolume().LessThan(r) {
+			t.Errorf("expected at least %s held back in %s, got %s", r, loc, w.ResidualVolume())
+		}
+	}
+}
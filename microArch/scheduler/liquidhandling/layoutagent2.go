@@ -176,7 +176,7 @@ func LayoutStage(ctx context.Context, request *LHRequest, params *liquidhandling
 
 	// find existing assignments and copy into the plate_choices structure
 	// this may be because 1) the user has set the assignment 2) the assignment derives from a component
-	plate_choices, mapchoices, err := get_and_complete_assignments(request, chain.ValueIDs(), plate_choices, mapchoices)
+	plate_choices, mapchoices, err := get_and_complete_assignments(ctx, request, chain.ValueIDs(), plate_choices, mapchoices)
 
 	// map choices maps layout groups to (temp)plate IDs
 
@@ -312,7 +312,7 @@ type PlateChoice struct {
 	Output    []bool
 }
 
-func get_and_complete_assignments(request *LHRequest, order []string, s []PlateChoice, m map[string]string) ([]PlateChoice, map[string]string, error) {
+func get_and_complete_assignments(ctx context.Context, request *LHRequest, order []string, s []PlateChoice, m map[string]string) ([]PlateChoice, map[string]string, error) {
 	//s := make([]PlateChoice, 0, 3)
 	//m := make(map[int]string)
 
@@ -453,7 +453,15 @@ func get_and_complete_assignments(request *LHRequest, order []string, s []PlateC
 
 	for i, _ := range s {
 		if s[i].Platetype == "" {
-			s[i].Platetype = request.Output_platetypes[0].Type
+			var ins *wtype.LHInstruction
+			if len(s[i].Assigned) != 0 {
+				ins = request.LHInstructions[s[i].Assigned[0]]
+			}
+			pt, err := chooseAPlate(ctx, request, ins)
+			if err != nil {
+				return s, m, err
+			}
+			s[i].Platetype = pt
 		}
 	}
 
@@ -499,7 +507,11 @@ func choose_plates(ctx context.Context, request *LHRequest, pc []PlateChoice, or
 			if ass == -1 {
 				// make a new plate
 				ass = len(pc)
-				pc = append(pc, PlateChoice{Platetype: chooseAPlate(request, v), Assigned: []string{v.ID}, ID: wtype.GetUUID(), Wells: []string{""}, Name: "Output_plate_" + v.ID[0:6], Output: []bool{true}})
+				pt, err := chooseAPlate(ctx, request, v)
+				if err != nil {
+					return nil, err
+				}
+				pc = append(pc, PlateChoice{Platetype: pt, Assigned: []string{v.ID}, ID: wtype.GetUUID(), Wells: []string{""}, Name: "Output_plate_" + v.ID[0:6], Output: []bool{true}})
 				continue
 			}
 
@@ -616,9 +628,40 @@ func assignmentWithType(pt string, pc []PlateChoice) int {
 	return r
 }
 
-func chooseAPlate(request *LHRequest, ins *wtype.LHInstruction) string {
-	// for now we ignore ins and just choose the First Output Platetype
-	return request.Output_platetypes[0].Type
+// chooseAPlate returns the first of the request's output plate types or, if
+// there are none, the type meeting the request's output plate constraints
+// whose wells are the smallest that will hold the result of ins
+func chooseAPlate(ctx context.Context, request *LHRequest, ins *wtype.LHInstruction) (string, error) {
+	if len(request.Output_platetypes) != 0 {
+		return request.Output_platetypes[0].Type, nil
+	}
+
+	plates, err := inventory.Plates(ctx, request.OutputPlateQuery)
+	if err != nil {
+		return "", err
+	}
+
+	vol := wunit.ZeroVolume()
+	if ins != nil && ins.Result != nil {
+		vol = ins.Result.Volume()
+	}
+
+	var best *wtype.LHPlate
+	for _, p := range plates {
+		max := p.Welltype.MaxVolume()
+		if max.LessThan(vol) {
+			continue
+		}
+		if best == nil || max.LessThan(best.Welltype.MaxVolume()) {
+			best = p
+		}
+	}
+
+	if best == nil {
+		return "", wtype.LHError(wtype.LH_ERR_OTHER, fmt.Sprintf("no output plate types match %q and hold %s", request.OutputPlateQuery, vol))
+	}
+
+	return best.Type, nil
 }
 func stringinarray(s string, array []string) int {
 	r := -1
@@ -437,3 +437,39 @@ func testReqBig(t *testing.T, req *LHRequest) {
 		}
 	}
 }
+
+func TestChooseAPlate(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	req := NewLHRequest()
+	req.OutputPlateQuery = inventory.PlateQuery{Wells: 96, Skirt: wtype.SkirtFull}
+
+	ins := wtype.NewLHMixInstruction()
+	ins.Result = wtype.NewLHComponent()
+	ins.Result.Vol = 150
+	ins.Result.Vunit = "ul"
+
+	if pt, err := chooseAPlate(ctx, req, ins); err != nil {
+		t.Fatal(err)
+	} else if pt != "pcrplate_skirted" {
+		t.Errorf("expecting pcrplate_skirted but got %s", pt)
+	}
+
+	// no skirted plate holds this much
+	ins.Result.Vol = 500
+	if pt, err := chooseAPlate(ctx, req, ins); err == nil {
+		t.Errorf("expecting no plate but got %s", pt)
+	}
+
+	// listed types take precedence
+	dsw, err := inventory.NewPlate(ctx, "DSW96")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Output_platetypes = append(req.Output_platetypes, dsw)
+	if pt, err := chooseAPlate(ctx, req, ins); err != nil {
+		t.Fatal(err)
+	} else if pt != "DSW96" {
+		t.Errorf("expecting DSW96 but got %s", pt)
+	}
+}
@@ -7,6 +7,8 @@ type LHOptions struct {
 	PrintInstructions       bool
 	LegacyVolume            bool
 	FixVolumes              bool
+	OptimizeLayout          bool
+	InsertDilutions         bool
 }
 
 func NewLHOptions() LHOptions {
@@ -28,9 +28,31 @@ import (
 
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/inventory"
 	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
 )
 
+// TipUsage records the number of tips of one type a plan consumes and the
+// number of boxes they come from
+type TipUsage struct {
+	Type  string
+	Tips  int
+	Boxes int
+}
+
+// OutputWell is what a plan expects to end up in a well it mixes into: the
+// volume and the concentration of each solute, each with its standard
+// uncertainty
+type OutputWell struct {
+	PlateID     string
+	PlateName   string
+	Well        string
+	Component   string
+	Volume      wunit.Volume
+	VolumeError wunit.Volume
+	Solutes     map[string]wtype.SoluteConcentration
+}
+
 // structure for defining a request to the liquid handler
 type LHRequest struct {
 	ID                    string
@@ -52,6 +74,8 @@ type LHRequest struct {
 	Input_setup_weights   map[string]float64
 	Output_platetypes     []*wtype.LHPlate
 	Output_plate_order    []string
+	InputPlateQuery       inventory.PlateQuery // searched when there are no Input_platetypes
+	OutputPlateQuery      inventory.PlateQuery // searched when there are no Output_platetypes
 	Plate_lookup          map[string]string
 	Stockconcs            map[string]wunit.Concentration
 	Policies              *wtype.LHPolicyRuleSet
@@ -62,7 +86,14 @@ type LHRequest struct {
 	Input_vols_supplied   map[string]wunit.Volume
 	Input_vols_required   map[string]wunit.Volume
 	Input_vols_wanting    map[string]wunit.Volume
+	Input_vols_reserved   map[string]wunit.Volume
 	TimeEstimate          float64
+	MultiDispenseSaving   float64
+	LayoutSaving          float64
+	TipsUsed              []TipUsage
+	Dilutions             []DilutionStep
+	AspirateWarnings      []string // aspirates whose tip would be above the liquid surface
+	OutputWells           []OutputWell
 	CarryVolume           wunit.Volume
 	InstructionSets       [][]*wtype.LHInstruction
 	Evaps                 []wtype.VolumeCorrection
@@ -135,14 +166,6 @@ func (req *LHRequest) ConfigureYourself() error {
 // this function checks requests so we can see early on whether or not they
 // are going to cause problems
 func ValidateLHRequest(rq *LHRequest) (bool, string) {
-	if rq.Output_platetypes == nil || len(rq.Output_platetypes) == 0 {
-		return false, "No output plate type specified"
-	}
-
-	if len(rq.Input_platetypes) == 0 {
-		return false, "No input plate types specified"
-	}
-
 	if rq.Policies == nil {
 		return false, "No policies specified"
 	}
@@ -175,6 +198,7 @@ func NewLHRequest() *LHRequest {
 	lhr.Input_vols_required = make(map[string]wunit.Volume)
 	lhr.Input_vols_supplied = make(map[string]wunit.Volume)
 	lhr.Input_vols_wanting = make(map[string]wunit.Volume)
+	lhr.Input_vols_reserved = make(map[string]wunit.Volume)
 	lhr.CarryVolume = wunit.NewVolume(0.5, "ul")
 	lhr.Input_setup_weights["MAX_N_PLATES"] = 2
 	lhr.Input_setup_weights["MAX_N_WELLS"] = 96
@@ -26,6 +26,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -622,9 +623,17 @@ func forceSanity(request *LHRequest) {
 }
 
 func (this *Liquidhandler) Plan(ctx context.Context, request *LHRequest) error {
+	// splits are planned as sets of mixes from a common source
+
+	err := expandSplitInstructions(request)
+
+	if err != nil {
+		return err
+	}
+
 	// figure out the output order
 
-	err := set_output_order(request)
+	err = set_output_order(request)
 
 	if err != nil {
 		return err
@@ -667,6 +676,22 @@ func (this *Liquidhandler) Plan(ctx context.Context, request *LHRequest) error {
 	request.LHInstructions = instructions
 	request.Stockconcs = stockconcs
 
+	if request.Options.InsertDilutions {
+		// dilutions are made in input plates, so their types are needed
+		// now rather than when the inputs are laid out
+		if err := resolveInputPlatetypes(ctx, request); err != nil {
+			return err
+		}
+
+		// replace transfers too small to make with larger ones from
+		// intermediate dilutions
+		request.Dilutions, err = insertDilutions(request, this.Properties.MinPossibleVolume())
+
+		if err != nil {
+			return err
+		}
+	}
+
 	// set up the mapping of the outputs
 	// tried moving here to see if we can use results in fixVolumes
 	request, err = this.Layout(ctx, request)
@@ -711,31 +736,33 @@ func (this *Liquidhandler) Plan(ctx context.Context, request *LHRequest) error {
 	if err != nil {
 		return err
 	}
-	// define the input plates
-	// should be merged with the above
-	request, err = input_plate_setup(ctx, request)
+	// define the input plates, set up the deck and make the instructions,
+	// making sure the inputs hold enough for what they really do
+	request, err = this.setupInputs(ctx, request)
 
 	if err != nil {
 		return err
 	}
 
-	// next we need to determine the liquid handler setup
-	request, err = this.Setup(ctx, request)
+	// count up the tips used of each type
+	request, err = this.Tip_box_setup(ctx, request)
+
 	if err != nil {
 		return err
 	}
 
-	// remove dummy mix-in-place instructions
-
-	request = removeDummyInstructions(request)
-
-	// now make instructions
-	request, err = this.ExecutionPlan(ctx, request)
+	// rearrange the deck to cut down on travel
+	if request.Options.OptimizeLayout {
+		err = this.optimizeLayout(request)
 
-	if err != nil {
-		return err
+		if err != nil {
+			return err
+		}
 	}
 
+	// flag any aspirates which won't reach the liquid
+	checkAspirateHeights(request, this.Properties)
+
 	// sorts out tip boxes etc.
 	this.Refresh_tipboxes_tipwastes(request)
 
@@ -753,6 +780,69 @@ func (this *Liquidhandler) Plan(ctx context.Context, request *LHRequest) error {
 		return err
 	}
 
+	request.OutputWells, err = this.outputWells(request)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkAspirateHeights records in the request every aspirate for which the
+// tip would be above the surface of the liquid
+func checkAspirateHeights(request *LHRequest, robot *liquidhandling.LHProperties) {
+	ris := make([]liquidhandling.RobotInstruction, 0, len(request.Instructions))
+	for _, ins := range request.Instructions {
+		ris = append(ris, ins)
+	}
+
+	request.AspirateWarnings = nil
+	for _, err := range liquidhandling.CheckAspirateHeights(ris, robot) {
+		logger.Warning(err.Error())
+		request.AspirateWarnings = append(request.AspirateWarnings, err.Error())
+	}
+}
+
+// optimizeLayout moves plates, tip boxes and wastes to where the head has
+// least far to travel between them and updates the instructions to match
+func (this *Liquidhandler) optimizeLayout(request *LHRequest) error {
+	ris := make([]liquidhandling.RobotInstruction, 0, len(request.Instructions))
+	for _, ins := range request.Instructions {
+		ris = append(ris, ins)
+	}
+
+	newpos, saving, err := liquidhandling.OptimizeLayout(ris, this.Properties)
+
+	if err != nil {
+		return err
+	}
+
+	if len(newpos) == 0 {
+		logger.Debug("Deck layout already optimal")
+		return nil
+	}
+
+	liquidhandling.RelabelInstructionPositions(ris, newpos)
+	this.Properties.RelabelPositions(newpos)
+	this.FinalProperties.RelabelPositions(newpos)
+
+	for id, pos := range request.Plate_lookup {
+		if np, ok := newpos[pos]; ok {
+			request.Plate_lookup[id] = np
+		}
+	}
+
+	request.LayoutSaving = saving.Seconds()
+
+	moves := make([]string, 0, len(newpos))
+	for from, to := range newpos {
+		moves = append(moves, fmt.Sprintf("%s -> %s", from, to))
+	}
+	sort.Strings(moves)
+
+	logger.Info(fmt.Sprintf("Deck layout rearranged (%s), expected to save %s", strings.Join(moves, ", "), saving))
+
 	return nil
 }
 
@@ -1057,7 +1147,21 @@ func (lh *Liquidhandler) fix_post_ids() {
 }
 
 func (lh *Liquidhandler) fix_post_names(rq *LHRequest) error {
-	// Instructions updating a well
+	assignment, err := lh.finalAssignments(rq)
+	if err != nil {
+		return err
+	}
+
+	for well, inst := range assignment {
+		well.WContents.CName = inst.Result.CName
+	}
+
+	return nil
+}
+
+// finalAssignments finds the well in the final state each mix ends up in,
+// keeping only the last mix into each well
+func (lh *Liquidhandler) finalAssignments(rq *LHRequest) (map[*wtype.LHWell]*wtype.LHInstruction, error) {
 	assignment := make(map[*wtype.LHWell]*wtype.LHInstruction)
 	for _, inst := range rq.LHInstructions {
 		// ignore non -mix instructions
@@ -1068,22 +1172,22 @@ func (lh *Liquidhandler) fix_post_names(rq *LHRequest) error {
 		tx := strings.Split(inst.Result.Loc, ":")
 		newid, ok := lh.plateIDMap[tx[0]]
 		if !ok {
-			return wtype.LHError(wtype.LH_ERR_DIRE, fmt.Sprintf("No output plate mapped to %s", tx[0]))
+			return nil, wtype.LHError(wtype.LH_ERR_DIRE, fmt.Sprintf("No output plate mapped to %s", tx[0]))
 		}
 
 		ip, ok := lh.FinalProperties.PlateLookup[newid]
 		if !ok {
-			return wtype.LHError(wtype.LH_ERR_DIRE, fmt.Sprintf("No output plate %s", newid))
+			return nil, wtype.LHError(wtype.LH_ERR_DIRE, fmt.Sprintf("No output plate %s", newid))
 		}
 
 		p, ok := ip.(*wtype.LHPlate)
 		if !ok {
-			return wtype.LHError(wtype.LH_ERR_DIRE, fmt.Sprintf("Got %s, should have *wtype.LHPlate", reflect.TypeOf(ip)))
+			return nil, wtype.LHError(wtype.LH_ERR_DIRE, fmt.Sprintf("Got %s, should have *wtype.LHPlate", reflect.TypeOf(ip)))
 		}
 
 		well, ok := p.Wellcoords[tx[1]]
 		if !ok {
-			return wtype.LHError(wtype.LH_ERR_DIRE, fmt.Sprintf("No well %s on plate %s", tx[1], tx[0]))
+			return nil, wtype.LHError(wtype.LH_ERR_DIRE, fmt.Sprintf("No well %s on plate %s", tx[1], tx[0]))
 		}
 
 		oldInst := assignment[well]
@@ -1094,11 +1198,44 @@ func (lh *Liquidhandler) fix_post_names(rq *LHRequest) error {
 		}
 	}
 
-	for well, inst := range assignment {
-		well.WContents.CName = inst.Result.CName
+	return assignment, nil
+}
+
+// outputWells reports what the plan expects to end up in each well mixed
+// into, in the order of the plates and wells in the final state
+func (lh *Liquidhandler) outputWells(rq *LHRequest) ([]OutputWell, error) {
+	assignment, err := lh.finalAssignments(rq)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	ret := make([]OutputWell, 0, len(assignment))
+	for well := range assignment {
+		name := ""
+		if p, ok := lh.FinalProperties.PlateLookup[well.Plateid].(*wtype.LHPlate); ok {
+			name = p.PlateName
+		}
+
+		cmp := well.WContents
+		ret = append(ret, OutputWell{
+			PlateID:     well.Plateid,
+			PlateName:   name,
+			Well:        well.Crds,
+			Component:   cmp.CName,
+			Volume:      well.CurrentVolume(),
+			VolumeError: cmp.VolumeError(),
+			Solutes:     cmp.SoluteConcentrations(),
+		})
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].PlateID != ret[j].PlateID {
+			return ret[i].PlateID < ret[j].PlateID
+		}
+		return wtype.CompareStringWellCoordsCol(ret[i].Well, ret[j].Well) < 0
+	})
+
+	return ret, nil
 }
 
 func dummy(ins *wtype.LHInstruction) bool {
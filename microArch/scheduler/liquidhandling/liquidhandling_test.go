@@ -37,7 +37,7 @@ import (
 	"github.com/antha-lang/antha/inventory/testinventory"
 )
 
-func TestStockConcs(*testing.T) {
+func TestStockConcs(t *testing.T) {
 	names := []string{"tea", "milk", "sugar"}
 
 	minrequired := make(map[string]float64, len(names))
@@ -57,7 +57,25 @@ func TestStockConcs(*testing.T) {
 		T[name] = wunit.NewVolume(100.0, "ul")
 	}
 
-	choose_stock_concentrations(minrequired, maxrequired, Smax, vmin, T)
+	cncs, err := choose_stock_concentrations(minrequired, maxrequired, Smax, vmin, T)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the stocks must fit into the most concentrated wells and none can be
+	// more concentrated than it is soluble
+
+	fraction := 0.0
+	for _, name := range names {
+		fraction += maxrequired[name] / cncs[name]
+		if cncs[name] > Smax[name]*(1.0+1e-9) {
+			t.Errorf("%s: stock of %f is above its solubility of %f", name, cncs[name], Smax[name])
+		}
+	}
+
+	if fraction > 1.0+1e-9 {
+		t.Errorf("stocks make up %f of the most concentrated wells", fraction)
+	}
 	/*for k, v := range cncs {
 		logger.Debug(fmt.Sprintln(k, " ", minrequired[k], " ", maxrequired[k], " ", T[k], " ", v))
 	}*/
@@ -154,6 +172,53 @@ func TestTipOverrideNegative(t *testing.T) {
 	}
 }
 
+func TestMixedTipTypes(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	lh := GetLiquidHandlerForTest(ctx)
+	rq := GetLHRequestForTest()
+
+	water := GetComponentForTest(ctx, "water", wunit.NewVolume(1000.0, "ul"))
+	part := GetComponentForTest(ctx, "dna", wunit.NewVolume(50.0, "ul"))
+
+	for k := 0; k < 4; k++ {
+		ins := wtype.NewLHMixInstruction()
+		ins.AddComponent(mixer.Sample(water, wunit.NewVolume(50.0, "ul")))
+		ins.AddComponent(mixer.Sample(part, wunit.NewVolume(5.0, "ul")))
+		ins.AddProduct(GetComponentForTest(ctx, "water", wunit.NewVolume(55.0, "ul")))
+		rq.Add_instruction(ins)
+	}
+
+	rq.Input_platetypes = append(rq.Input_platetypes, GetPlateForTest())
+	rq.Output_platetypes = append(rq.Output_platetypes, GetPlateForTest())
+
+	for _, tt := range []string{"Gilson20", "Gilson200"} {
+		tb, err := inventory.NewTipbox(ctx, tt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rq.Tips = append(rq.Tips, tb)
+	}
+
+	rq.ConfigureYourself()
+
+	if err := lh.Plan(ctx, rq); err != nil {
+		t.Fatal(err)
+	}
+
+	used := make(map[string]int)
+	for _, tu := range rq.TipsUsed {
+		used[tu.Type] = tu.Tips
+		if tu.Boxes < 1 {
+			t.Errorf("expected at least one box of %s, got %d", tu.Type, tu.Boxes)
+		}
+	}
+
+	if len(used) != 2 || used["Gilson20"] == 0 || used["Gilson200"] == 0 {
+		t.Errorf("expected both Gilson20 and Gilson200 tips to be used, got %v", rq.TipsUsed)
+	}
+}
+
 func TestPlateReuse(t *testing.T) {
 	ctx := testinventory.NewContext(context.Background())
 
@@ -134,17 +134,17 @@ func BasicSetupAgent(ctx context.Context, request *LHRequest, params *liquidhand
 	// tips
 	tips := request.Tips
 
-	// just need to set the tip types
-	// these should be distinct... we should check really
-	// ...eventually
+	// just need to set the tip types, each of which is a candidate
+	// for every transfer
 	if len(tips) != 0 {
-		tipz := make([]*wtype.LHTip, len(tips))
-		for i, tb := range tips {
-			if tb == nil {
+		tipz := make([]*wtype.LHTip, 0, len(tips))
+		seen := make(map[string]bool, len(tips))
+		for _, tb := range tips {
+			if tb == nil || seen[tb.Tiptype.Type] {
 				continue
 			}
-			//	params.Tips = append(params.Tips, tb.Tips[0][0])
-			tipz[i] = tb.Tips[0][0]
+			seen[tb.Tiptype.Type] = true
+			tipz = append(tipz, tb.Tips[0][0])
 		}
 		params.Tips = tipz
 	}
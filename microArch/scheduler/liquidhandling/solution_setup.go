@@ -207,9 +207,11 @@ func solution_setup(request *LHRequest, prms *liquidhandling.LHProperties) (map[
 		return nil, nil, fmt.Errorf("min unit %s not equal to max unit %s ", minUnit, maxUnit)
 	}
 
-	stockconcs := choose_stock_concentrations(minSIRequired, maxSIRequired, Smax, vmin.RawValue(), hshTVol)
+	stockconcs, err := choose_stock_concentrations(minSIRequired, maxSIRequired, Smax, vmin.ConvertToString("ul"), hshTVol)
 
-	// handle any errors here
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// add the fixed concentrations into stockconcs
 
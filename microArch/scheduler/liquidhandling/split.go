@@ -0,0 +1,31 @@
+package liquidhandling
+
+import (
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// expandSplitInstructions replaces every split instruction in the request
+// with the equivalent set of single component mixes; since these all share
+// the same source and generation they end up in the same transfer block
+// where they can be merged into multi-dispenses
+func expandSplitInstructions(request *LHRequest) error {
+	for id, ins := range request.LHInstructions {
+		if ins.Type != wtype.LHISPL {
+			continue
+		}
+
+		mixes, err := ins.SplitToMixes()
+
+		if err != nil {
+			return wtype.LHError(wtype.LH_ERR_DIRE, err.Error())
+		}
+
+		delete(request.LHInstructions, id)
+
+		for _, mix := range mixes {
+			request.Add_instruction(mix)
+		}
+	}
+
+	return nil
+}
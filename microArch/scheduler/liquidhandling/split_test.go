@@ -0,0 +1,80 @@
+package liquidhandling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/mixer"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/inventory/testinventory"
+)
+
+func configure_request_split(ctx context.Context, rq *LHRequest, n int) *wtype.LHInstruction {
+	water := GetComponentForTest(ctx, "water", wunit.NewVolume(1000.0, "ul"))
+
+	ins := wtype.NewLHSplitInstruction()
+
+	for k := 0; k < n; k++ {
+		smp := mixer.Sample(water, wunit.NewVolume(20.0, "ul"))
+		res := wtype.NewLHComponent()
+		res.Mix(smp)
+		ins.AddSplitProduct(smp, res)
+	}
+
+	rq.Add_instruction(ins)
+
+	return ins
+}
+
+func TestExpandSplitInstructions(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	rq := GetLHRequestForTest()
+	split := configure_request_split(ctx, rq, 8)
+
+	if err := expandSplitInstructions(rq); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rq.LHInstructions) != 8 {
+		t.Fatalf("expected 8 instructions after expanding split, got %d", len(rq.LHInstructions))
+	}
+
+	products := make(map[string]bool, len(split.Results))
+	for _, res := range split.Results {
+		products[res.ID] = true
+	}
+
+	for _, ins := range rq.LHInstructions {
+		if ins.Type != wtype.LHIMIX {
+			t.Errorf("expected MIX instruction, got %s", ins.InsType())
+		}
+
+		if !products[ins.ProductID] {
+			t.Errorf("instruction %s does not make any of the split products", ins.ID)
+		}
+
+		delete(products, ins.ProductID)
+	}
+}
+
+func TestPlanWithSplit(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	lh := GetLiquidHandlerForTest(ctx)
+	rq := GetLHRequestForTest()
+	configure_request_split(ctx, rq, 8)
+	rq.Input_platetypes = append(rq.Input_platetypes, GetPlateForTest())
+	rq.Output_platetypes = append(rq.Output_platetypes, GetPlateForTest())
+
+	rq.ConfigureYourself()
+
+	if err := lh.Plan(ctx, rq); err != nil {
+		t.Fatalf("Got an error planning split: %s", err)
+	}
+
+	if v := rq.Input_vols_required["water"]; v.RawValue() < 160.0 {
+		t.Errorf("expected at least 160 ul water to be required, got %s", v.ToString())
+	}
+}
@@ -25,125 +25,79 @@ package liquidhandling
 import (
 	"context"
 	"fmt"
-	"strings"
+	"sort"
 
-	"github.com/antha-lang/antha/antha/anthalib/wtype"
-	"github.com/antha-lang/antha/inventory"
 	lhdriver "github.com/antha-lang/antha/microArch/driver/liquidhandling"
 	"github.com/antha-lang/antha/microArch/logger"
 )
 
-//  TASK: 	Determine number of tip boxes of each type
+//  TASK: 	Determine number of tips and tip boxes of each type used
 // INPUT: 	instructions
-//OUTPUT: 	arrays of tip boxes
+//OUTPUT: 	summary of tips consumed by type
 func (lh *Liquidhandler) Tip_box_setup(ctx context.Context, request *LHRequest) (*LHRequest, error) {
-	tip_boxes := make([]*wtype.LHTipbox, 0)
-
 	// the instructions have been generated at this point so we just need to go through and count the tips used
 	// of each type
-	instrx := request.Instructions
-	ntips := make(map[string]int)
-	tiplocs := make(map[string]map[string]int)
-
-	// aide memoire: ultimately these tip types derive from the LHProperties object which was passed into
-	// the call to generating concrete instructions
-	// there is a "tips" field which is just an array of LHTip objects. The Name field from one of these
-	// is passed through to TIPTYPE
-	// tips are ultimately chosen based on which one has a minimum volume which is closest to the required minimum volume
-	// now this behaviour must be a lot more complicated in general since there are cases in which
-	// it is permissible to use more than one tip type for the same request and in fact it would only be a case of
-	// generating more or fewer transfers
-	// this can be supported but it's a problem for the scheduler to optimize against preferences for number of operations,
-	// time limits, space usage etc. as well as hard constraints on the above
-	//
-
-	for _, ins := range instrx {
-		if ins.InstructionType() == lhdriver.LOD {
-			ttype := ins.GetParameter("TIPTYPE").([]string)[0]
-			ntips[ttype] += ins.GetParameter("MULTI").(int)
-			hs, ok := tiplocs[ttype]
+	// tip types are chosen per transfer so several may be in use at once: the type
+	// is that of the box at the position each tip was loaded from, since the names of
+	// tip boxes in the instructions need not match those of the tips they hold
 
-			if !ok {
-				hs = make(map[string]int, 2)
-				tiplocs[ttype] = hs
-			}
+	ntips := make(map[string]int)
+	tiplocs := make(map[string]map[string]bool)
+	boxsize := make(map[string]int)
 
-			hs[ins.GetParameter("POS").([]string)[0]] += ins.GetParameter("MULTI").(int)
+	for _, ins := range request.Instructions {
+		if ins.InstructionType() != lhdriver.LOD {
+			continue
 		}
-	}
 
-	h := make(map[string]int, 3)
+		pos, _ := ins.GetParameter("POS").([]string)
+		wells, _ := ins.GetParameter("WELL").([]string)
+		boxtypes, _ := ins.GetParameter("TIPTYPE").([]string)
 
-	for tiptype, ntip := range ntips {
-		// need to make sure the names match up here
-		tx := strings.Split(tiptype, "_")
-		actualtiptype := tx[0]
-		h[actualtiptype] += ntip
-
-		ar, ok := tiplocs[actualtiptype]
-
-		if !ok {
-			tiplocs[actualtiptype] = tiplocs[tiptype]
-		} else {
-			for k, _ := range tiplocs[tiptype] {
-				ar[k] += 1
+		for i, p := range pos {
+			if p == "" || i >= len(wells) || wells[i] == "" {
+				continue
 			}
-			tiplocs[actualtiptype] = ar
-		}
-	}
-
-	tiplocs2 := make([]string, 0, 1)
-
-	for actualtiptype, ntip := range h {
-		ar := tiplocs[actualtiptype]
-		ar2 := make([]string, 0, 1)
-		for k, _ := range ar {
-			ar2 = append(ar2, k)
-		}
-
-		logger.Debug(fmt.Sprintln("TIPS OF TYPE ", actualtiptype, " USED: ", ntip))
-
-		logger.Info(fmt.Sprintf("Block %s Tips of type %s used: %d", request.BlockID, actualtiptype, ntip))
 
-		// how many tips remain on the platform?
-
-		fmt.Println(ntip, " ", lh.Properties.TipsLeftOfType(actualtiptype))
+			tiptype := ""
+			if tb, ok := lh.Properties.Tipboxes[p]; ok && tb.Tiptype != nil {
+				tiptype = tb.Tiptype.Type
+				boxsize[tiptype] = tb.NTips
+			} else if i < len(boxtypes) {
+				tiptype = boxtypes[i]
+			}
 
-		newtips_needed := ntip - lh.Properties.TipsLeftOfType(actualtiptype)
+			ntips[tiptype] += 1
 
-		if newtips_needed < 1 {
-			continue
-		}
-
-		tbt, err := inventory.NewTipbox(ctx, actualtiptype)
-		if err != nil {
-			return nil, err
+			hs, ok := tiplocs[tiptype]
+			if !ok {
+				hs = make(map[string]bool, 2)
+				tiplocs[tiptype] = hs
+			}
+			hs[p] = true
 		}
+	}
 
-		ntbx := (newtips_needed-1)/tbt.NTips + 1
-
-		fmt.Println("newtips needed: ", newtips_needed, " : ", tbt.NTips, " NTBX: ", ntbx)
+	used := make([]TipUsage, 0, len(ntips))
 
-		for i := 0; i < ntbx; i++ {
-			tbt2, err := inventory.NewTipbox(ctx, actualtiptype)
-			if err != nil {
-				return nil, err
+	for tiptype, ntip := range ntips {
+		// boxes may be replaced when the driver tracks tips, so
+		// more can be used than there are positions
+		nbox := len(tiplocs[tiptype])
+		if boxsize[tiptype] > 0 {
+			if n := (ntip-1)/boxsize[tiptype] + 1; n > nbox {
+				nbox = n
 			}
-			tip_boxes = append(tip_boxes, tbt2)
-			tiplocs2 = append(tiplocs2, ar2[i])
 		}
-	}
 
-	(*request).Tips = tip_boxes
+		used = append(used, TipUsage{Type: tiptype, Tips: ntip, Boxes: nbox})
 
-	// need to fix the tip situation in the properties structure
-
-	lh.Properties.RemoveTipBoxes()
-	for i, tb := range tip_boxes {
-		fmt.Println("ADDING TIP BOXES to : ", tiplocs2[i])
-		lh.Properties.AddTipBoxTo(tiplocs2[i], tb)
-		lh.FinalProperties.AddTipBoxTo(tiplocs2[i], tb)
+		logger.Info(fmt.Sprintf("Block %s Tips of type %s used: %d from %d box(es)", request.BlockID, tiptype, ntip, nbox))
 	}
 
+	sort.Slice(used, func(i, j int) bool { return used[i].Type < used[j].Type })
+
+	request.TipsUsed = used
+
 	return request, nil
 }
@@ -20,6 +20,12 @@ var (
 type Endpoint struct {
 	URI string
 	Arg interface{}
+	// Writer, if set, is the name of a driver which writes up the
+	// instructions for the liquid handler at URI rather than sending them
+	// to it, see Writers
+	Writer string
+	// Format is the format the writer should use, where it has a choice
+	Format string
 }
 
 // An Opt are options for connecting to a set of device plugins (drivers)
@@ -79,6 +85,9 @@ func New(opt Opt) (ret *Auto, err error) {
 		}
 		ret.Conns = append(ret.Conns, conn)
 
+		tryer.Writer = ep.Writer
+		tryer.Format = ep.Format
+
 		if err = tryer.Try(ctx, conn, ep.Arg); err != nil {
 			return
 		}
@@ -8,6 +8,7 @@ import (
 
 	runner "github.com/antha-lang/antha/driver/antha_runner_v1"
 	"github.com/antha-lang/antha/target"
+	"github.com/antha-lang/antha/target/mixer"
 	"google.golang.org/grpc"
 )
 
@@ -63,6 +64,13 @@ func (a *Auto) executeMix(ctx context.Context, inst *target.Mix) error {
 		return fmt.Errorf("no runner for %s", inst.Files.Type)
 	}
 	r := rs[0]
+
+	if m, ok := inst.Dev.(*mixer.Mixer); ok {
+		if err := m.VerifyBarcodes(inst); err != nil {
+			return err
+		}
+	}
+
 	reply, err := r.Run(ctx, &runner.RunRequest{
 		Type: inst.Files.Type,
 		Data: inst.Files.Tarball,
@@ -3,6 +3,7 @@ package auto
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/antha-lang/antha/target"
 )
@@ -16,6 +17,8 @@ func (a *Auto) Pretty(inst target.Inst) string {
 		return prettyRun(inst)
 	case *target.Manual:
 		return prettyManual(inst)
+	case *target.Order:
+		return prettyOrder(inst)
 	case *target.Wait:
 		return "Wait"
 	case *target.Prompt:
@@ -31,8 +34,70 @@ func prettyManual(inst *target.Manual) string {
 	return fmt.Sprintf("[%s] %s", inst.Label, strings.Replace(inst.Details, "\n", "; ", -1))
 }
 
+func prettyOrder(inst *target.Order) string {
+	if len(inst.Shortfalls) == 0 {
+		return "[ord] nothing to order"
+	}
+
+	var short []string
+	for _, s := range inst.Shortfalls {
+		short = append(short, s.String())
+	}
+
+	return fmt.Sprintf("[ord] %s", strings.Join(short, "; "))
+}
+
 func prettyMix(inst *target.Mix) string {
-	return fmt.Sprintf("[mix] (size: %d)", len(inst.Files.Tarball))
+	s := fmt.Sprintf("[mix] (size: %d)", len(inst.Files.Tarball))
+
+	if inst.Request == nil {
+		return s
+	}
+
+	if len(inst.Request.TipsUsed) != 0 {
+		var tips []string
+		for _, tu := range inst.Request.TipsUsed {
+			tips = append(tips, fmt.Sprintf("%d %s in %d box(es)", tu.Tips, tu.Type, tu.Boxes))
+		}
+
+		s = fmt.Sprintf("%s tips: %s", s, strings.Join(tips, ", "))
+	}
+
+	if inst.Request.TimeEstimate > 0 {
+		s = fmt.Sprintf("%s time: %s", s, seconds(inst.Request.TimeEstimate))
+	}
+
+	if inst.Request.MultiDispenseSaving > 0 {
+		s = fmt.Sprintf("%s saved by multi-dispensing: %s", s, seconds(inst.Request.MultiDispenseSaving))
+	}
+
+	if len(inst.Request.Dilutions) != 0 {
+		var dils []string
+		for _, ds := range inst.Request.Dilutions {
+			dils = append(dils, ds.String())
+		}
+
+		s = fmt.Sprintf("%s dilutions: %s", s, strings.Join(dils, "; "))
+	}
+
+	if len(inst.Request.AspirateWarnings) != 0 {
+		s = fmt.Sprintf("%s warnings: %s", s, strings.Join(inst.Request.AspirateWarnings, "; "))
+	}
+
+	if len(inst.Reservations) != 0 {
+		var rs []string
+		for _, r := range inst.Reservations {
+			rs = append(rs, r.String())
+		}
+
+		s = fmt.Sprintf("%s stock held by %s: %s", s, inst.Reservations[0].Holder, strings.Join(rs, "; "))
+	}
+
+	return s
+}
+
+func seconds(s float64) string {
+	return time.Duration(s * float64(time.Second)).Round(time.Second).String()
 }
 
 func prettyRun(inst *target.Run) string {
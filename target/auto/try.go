@@ -8,6 +8,7 @@ import (
 	runner "github.com/antha-lang/antha/driver/antha_runner_v1"
 	lhclient "github.com/antha-lang/antha/driver/lh"
 	"github.com/antha-lang/antha/driver/pb/lh"
+	lhdriver "github.com/antha-lang/antha/microArch/driver/liquidhandling"
 	"github.com/antha-lang/antha/target/handler"
 	"github.com/antha-lang/antha/target/human"
 	"github.com/antha-lang/antha/target/mixer"
@@ -20,6 +21,9 @@ type tryer struct {
 	Auto      *Auto
 	MaybeArgs []interface{}
 	HumanOpt  human.Opt
+	// Writer and Format of the endpoint being tried
+	Writer string
+	Format string
 }
 
 // AddDriver queries a driver and adds the corresponding device to the target
@@ -76,8 +80,17 @@ func (a *tryer) AddMixer(ctx context.Context, conn *grpc.ClientConn, arg interfa
 	candidates = append(candidates, arg)
 	candidates = append(candidates, a.MaybeArgs...)
 
+	var lhd lhdriver.ExtendedLiquidhandlingDriver = &lhclient.Driver{C: c}
+	if a.Writer != "" {
+		w, err := newWriter(a.Writer, a.Format, lhd)
+		if err != nil {
+			return err
+		}
+		lhd = w
+	}
+
 	a.HumanOpt.CanMix = false
-	d, err := mixer.New(getMixerOpt(candidates), &lhclient.Driver{C: c})
+	d, err := mixer.New(getMixerOpt(candidates), lhd)
 	if err != nil {
 		return err
 	}
@@ -96,7 +109,12 @@ func getMixerOpt(maybeArgs []interface{}) (ret mixer.Opt) {
 
 func (a *tryer) Try(ctx context.Context, conn *grpc.ClientConn, arg interface{}) error {
 	var tries []func(context.Context, *grpc.ClientConn, interface{}) error
-	tries = append(tries, a.AddDriver, a.AddMixer)
+	if a.Writer != "" {
+		// only liquid handlers can be written up
+		tries = append(tries, a.AddMixer)
+	} else {
+		tries = append(tries, a.AddDriver, a.AddMixer)
+	}
 
 	for _, t := range tries {
 		if err := t(ctx, conn, arg); err == nil {
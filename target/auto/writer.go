@@ -0,0 +1,62 @@
+package auto
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antha-lang/antha/driver/bench"
+	"github.com/antha-lang/antha/driver/worklist"
+	lhdriver "github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+// Writers are the drivers which can write up the instructions for a liquid
+// handler instead of sending them to it. Each is given the properties of
+// the liquid handler, which are what the instructions are planned for, and
+// the format asked for
+var Writers = map[string]func(props *lhdriver.LHProperties, format string) (lhdriver.ExtendedLiquidhandlingDriver, error){
+	"worklist": func(props *lhdriver.LHProperties, format string) (lhdriver.ExtendedLiquidhandlingDriver, error) {
+		var opt worklist.Opt
+		switch format {
+		case "", "csv":
+		case "tsv":
+			opt.Separator = '\t'
+		default:
+			return nil, fmt.Errorf("unknown worklist format %q", format)
+		}
+		return worklist.NewDriver(props, opt), nil
+	},
+	"bench": func(props *lhdriver.LHProperties, format string) (lhdriver.ExtendedLiquidhandlingDriver, error) {
+		switch format {
+		case "", bench.Markdown, bench.HTML:
+		default:
+			return nil, fmt.Errorf("unknown bench protocol format %q", format)
+		}
+		return bench.NewDriver(props, bench.Opt{Format: format}), nil
+	},
+}
+
+// WriterNames returns the names of the Writers, in order
+func WriterNames() []string {
+	var names []string
+	for name := range Writers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newWriter makes the named writer stand in for the liquid handler d
+func newWriter(name, format string, d lhdriver.ExtendedLiquidhandlingDriver) (lhdriver.ExtendedLiquidhandlingDriver, error) {
+	mk, ok := Writers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown writer %q, expected one of %s", name, strings.Join(WriterNames(), ", "))
+	}
+
+	props, status := d.GetCapabilities()
+	if !status.OK {
+		return nil, fmt.Errorf("cannot get capabilities: %s", status.Msg)
+	}
+
+	return mk(&props, format)
+}
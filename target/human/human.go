@@ -89,6 +89,14 @@ func (a *Human) generate(cmd interface{}) ([]target.Inst, error) {
 	switch cmd := cmd.(type) {
 
 	case *wtype.LHInstruction:
+		if cmd.Type == wtype.LHISPL {
+			insts = append(insts, &target.Manual{
+				Dev:     a,
+				Label:   "aliquot",
+				Details: prettySplitDetails(cmd),
+			})
+			break
+		}
 		insts = append(insts, &target.Manual{
 			Dev:     a,
 			Label:   "mix",
@@ -131,3 +139,10 @@ func prettyMixDetails(inst *wtype.LHInstruction) string {
 	}
 	return "mix"
 }
+
+func prettySplitDetails(inst *wtype.LHInstruction) string {
+	if len(inst.Components) == 0 {
+		return "aliquot"
+	}
+	return fmt.Sprintf("aliquot %s into %d x %s", inst.Components[0].CName, len(inst.Results), inst.Components[0].Volume().ToString())
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	"github.com/antha-lang/antha/ast"
 	"github.com/antha-lang/antha/driver"
+	"github.com/antha-lang/antha/inventory/stock"
 	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
 	lh "github.com/antha-lang/antha/microArch/scheduler/liquidhandling"
 )
@@ -61,6 +62,8 @@ func (a noDeviceMixin) Device() Device {
 type Order struct {
 	Manual
 	Mixes []*Mix
+	// Stock the mixes need more of than there is
+	Shortfalls stock.Shortfalls
 }
 
 // A PlatePrep is a task to setup plates
@@ -98,6 +101,8 @@ type Mix struct {
 	FinalProperties *liquidhandling.LHProperties
 	Final           map[string]string // Map from ids in Properties to FinalProperties
 	Files           Files
+	Reservations    []*stock.Reservation // Stock held for the inputs of the mix
+	Shortfalls      stock.Shortfalls     // Stock the inputs need more of than there is
 	Initializers    []Inst
 }
 
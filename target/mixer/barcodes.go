@@ -0,0 +1,45 @@
+package mixer
+
+import (
+	"fmt"
+
+	anthadriver "github.com/antha-lang/antha/microArch/driver"
+	driver "github.com/antha-lang/antha/microArch/driver/liquidhandling"
+	"github.com/antha-lang/antha/target"
+)
+
+// VerifyBarcodes checks the barcodes at each deck position are those of the
+// plates and tipboxes mix plans to be there, returning
+// driver.BarcodeMismatches if not. Barcodes come from the driver if it can
+// scan them, with those the operator scanned filling any positions it could
+// not read. If neither reports any barcodes there is nothing to check.
+func (a *Mixer) VerifyBarcodes(mix *target.Mix) error {
+	if mix.Properties == nil {
+		return nil
+	}
+
+	scanned := make(map[string]string)
+	for pos, barcode := range a.opt.ScannedBarcodes {
+		scanned[pos] = barcode
+	}
+
+	scanner, ok := a.driver.(driver.BarcodeScanner)
+	if ok {
+		barcodes, stat := scanner.ScanBarcodes()
+		switch stat.Errorcode {
+		case anthadriver.ERR:
+			return fmt.Errorf("cannot scan barcodes: %s", stat.Msg)
+		case anthadriver.NIM:
+			ok = false
+		}
+		for pos, barcode := range barcodes {
+			scanned[pos] = barcode
+		}
+	}
+
+	if !ok && len(scanned) == 0 {
+		return nil
+	}
+
+	return driver.VerifyBarcodes(mix.Properties.Barcodes(), scanned)
+}
@@ -0,0 +1,75 @@
+package mixer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/testinventory"
+	anthadriver "github.com/antha-lang/antha/microArch/driver"
+	driver "github.com/antha-lang/antha/microArch/driver/liquidhandling"
+	"github.com/antha-lang/antha/target"
+)
+
+type scanningDriver struct {
+	driver.ExtendedLiquidhandlingDriver
+	barcodes map[string]string
+	status   int
+}
+
+func (d *scanningDriver) ScanBarcodes() (map[string]string, anthadriver.CommandStatus) {
+	return d.barcodes, anthadriver.CommandStatus{OK: d.status != anthadriver.ERR, Errorcode: d.status}
+}
+
+func TestVerifyBarcodes(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	p, err := inventory.NewPlate(ctx, "pcrplate_skirted_riser40")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Barcode = "P000123"
+
+	mix := &target.Mix{
+		Properties: &driver.LHProperties{
+			PosLookup:   map[string]string{"position_4": p.ID},
+			PlateLookup: map[string]interface{}{p.ID: p},
+		},
+	}
+
+	m := &Mixer{driver: &scanningDriver{status: anthadriver.NIM}}
+
+	// nothing scanned, nothing to check
+	if err := m.VerifyBarcodes(mix); err != nil {
+		t.Error(err)
+	}
+
+	m.opt.ScannedBarcodes = map[string]string{"position_4": "P000123"}
+	if err := m.VerifyBarcodes(mix); err != nil {
+		t.Error(err)
+	}
+
+	m.opt.ScannedBarcodes = map[string]string{"position_5": "P000123"}
+	if err := m.VerifyBarcodes(mix); err == nil {
+		t.Error("expecting error for plate in the wrong place")
+	}
+
+	// what the driver scans takes precedence
+	m.driver = &scanningDriver{barcodes: map[string]string{"position_4": "P000999"}}
+	m.opt.ScannedBarcodes = map[string]string{"position_4": "P000123"}
+	if err := m.VerifyBarcodes(mix); err == nil {
+		t.Error("expecting error for the wrong plate")
+	}
+
+	// a driver which scans but reads nothing means the plate is missing
+	m.driver = &scanningDriver{}
+	m.opt.ScannedBarcodes = nil
+	if err := m.VerifyBarcodes(mix); err == nil {
+		t.Error("expecting error for a missing plate")
+	}
+
+	m.driver = &scanningDriver{status: anthadriver.ERR}
+	if err := m.VerifyBarcodes(mix); err == nil {
+		t.Error("expecting error when the scan fails")
+	}
+}
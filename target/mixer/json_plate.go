@@ -0,0 +1,161 @@
+package mixer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/meta"
+)
+
+// plateJSON is the JSON form of a plate's contents, e.g.,
+//
+//   {
+//     "type": "pcrplate_skirted",
+//     "name": "input",
+//     "barcode": "P000123",
+//     "wells": {
+//       "A1": {"component": "water", "type": "water", "volume": "50ul"},
+//       "B1": {
+//         "component": "mastermix", "volume": "20ul",
+//         "subComponents": {"dNTPs": "0.2mM", "MgCl2": "1.5mM"}
+//       }
+//     }
+//   }
+type plateJSON struct {
+	Type    string              `json:"type"`
+	Name    string              `json:"name,omitempty"`
+	Barcode string              `json:"barcode,omitempty"`
+	Wells   map[string]wellJSON `json:"wells"`
+}
+
+type wellJSON struct {
+	Component     string            `json:"component"`
+	Type          string            `json:"type,omitempty"`
+	Volume        string            `json:"volume,omitempty"`
+	Concentration string            `json:"concentration,omitempty"`
+	SubComponents map[string]string `json:"subComponents,omitempty"` // concentration by name
+}
+
+// ParsePlateJSON parses a plate from its JSON form
+func ParsePlateJSON(ctx context.Context, data []byte) (*ParsePlateResult, error) {
+	return ParsePlateJSONWithValidationConfig(ctx, data, DefaultValidationConfig())
+}
+
+// ParsePlateJSONWithValidationConfig parses a plate from its JSON form: the
+// plate type, name and barcode and, by well, the component, its type, volume and
+// concentration, each with units, and the concentrations of the
+// sub-components it contains.
+func ParsePlateJSONWithValidationConfig(ctx context.Context, data []byte, vc ValidationConfig) (*ParsePlateResult, error) {
+	var pj plateJSON
+	if err := meta.UnmarshalJSONStrict(data, &pj); err != nil {
+		return nil, err
+	}
+
+	plate, err := newInputPlate(ctx, pj.Type, pj.Name)
+	if err != nil {
+		return nil, err
+	}
+	plate.Barcode = pj.Barcode
+
+	var addrs []string
+	for addr := range pj.Wells {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	var warnings []string
+	for _, addr := range addrs {
+		w := pj.Wells[addr]
+		vol, vunit := splitQuantity(w.Volume)
+		conc, cunit := splitQuantity(w.Concentration)
+		solutes, sws := parseSubComponents(w.SubComponents)
+
+		ws, err := addRecord(plate, wellRecord{
+			Well:    addr,
+			CName:   w.Component,
+			CType:   w.Type,
+			Vol:     vol,
+			Vunit:   vunit,
+			Conc:    conc,
+			Cunit:   cunit,
+			Solutes: solutes,
+		}, vc)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("well %s skipped: %s", addr, err))
+			continue
+		}
+		for _, w := range append(ws, sws...) {
+			warnings = append(warnings, fmt.Sprintf("well %s: %s", addr, w))
+		}
+	}
+
+	return &ParsePlateResult{
+		Plate:    plate,
+		Warnings: warnings,
+	}, nil
+}
+
+// parseSubComponents parses the concentrations of sub-components by name,
+// skipping any that cannot be parsed
+func parseSubComponents(subs map[string]string) (map[string]wtype.SoluteConcentration, []string) {
+	if len(subs) == 0 {
+		return nil, nil
+	}
+
+	var names []string
+	for name := range subs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	solutes := make(map[string]wtype.SoluteConcentration, len(subs))
+	var warnings []string
+	for _, name := range names {
+		value, unit := splitQuantity(subs[name])
+		c, err := strconv.ParseFloat(value, 64)
+		if err == nil && unit == "" {
+			err = fmt.Errorf("no unit")
+		}
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("sub-component %q skipped: cannot parse concentration %q: %s", name, subs[name], err))
+			continue
+		}
+		solutes[name] = wtype.SoluteConcentration{Conc: c, Unit: unit}
+	}
+	return solutes, warnings
+}
+
+// MarshalPlateJSON writes a plate in JSON form
+func MarshalPlateJSON(plate *wtype.LHPlate) ([]byte, error) {
+	pj := plateJSON{
+		Type:    plate.Type,
+		Name:    plate.PlateName,
+		Barcode: plate.Barcode,
+		Wells:   make(map[string]wellJSON),
+	}
+
+	for _, well := range plate.AllNonEmptyWells() {
+		comp := well.WContents
+		w := wellJSON{
+			Component: comp.CName,
+			Type:      comp.TypeName(),
+			Volume:    fmt.Sprintf("%g%s", comp.Vol, comp.Vunit),
+		}
+		if comp.Cunit != "" {
+			w.Concentration = fmt.Sprintf("%g%s", comp.Conc, comp.Cunit)
+		}
+		if len(comp.Solutes) != 0 {
+			w.SubComponents = make(map[string]string, len(comp.Solutes))
+			for name, sc := range comp.Solutes {
+				w.SubComponents[name] = fmt.Sprintf("%g%s", sc.Conc, sc.Unit)
+			}
+		}
+		pj.Wells[well.Crds] = w
+	}
+
+	return json.MarshalIndent(pj, "", "  ")
+}
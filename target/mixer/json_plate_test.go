@@ -0,0 +1,80 @@
+package mixer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/inventory/testinventory"
+)
+
+func TestParsePlateJSON(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	file := []byte(`
+{
+  "type": "pcrplate_skirted",
+  "name": "input",
+  "barcode": "P000123",
+  "wells": {
+    "A1": {"component": "water", "type": "water", "volume": "50ul"},
+    "B1": {
+      "component": "mastermix", "type": "water", "volume": "20ul", "concentration": "2X",
+      "subComponents": {"dNTPs": "0.2mM", "MgCl2": "1.5mM", "polymerase": "lots"}
+    },
+    "Z1": {"component": "water", "volume": "50ul"}
+  }
+}`)
+
+	r, err := ParsePlate(ctx, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Plate.Type != "pcrplate_skirted" || r.Plate.PlateName != "input" || r.Plate.Barcode != "P000123" {
+		t.Errorf("unexpected plate %s %s with barcode %q", r.Plate.Type, r.Plate.PlateName, r.Plate.Barcode)
+	}
+
+	var warnings []string
+	for _, w := range r.Warnings {
+		// A1 has no concentration
+		if !strings.HasPrefix(w, "well A1:") {
+			warnings = append(warnings, w)
+		}
+	}
+	if len(warnings) != 2 || !strings.Contains(warnings[0], "polymerase") || !strings.HasPrefix(warnings[1], "well Z1 skipped") {
+		t.Errorf("unexpected warnings %q", warnings)
+	}
+
+	mm := r.Plate.WellAt(wtype.MakeWellCoords("B1")).WContents
+	if mm.CName != "mastermix" || mm.Vol != 20 || mm.Conc != 2 || mm.Cunit != "X" {
+		t.Errorf("unexpected component %s %g%s %g%s", mm.CName, mm.Vol, mm.Vunit, mm.Conc, mm.Cunit)
+	}
+	if sc := mm.Solutes["MgCl2"]; len(mm.Solutes) != 2 || sc.Conc != 1.5 || sc.Unit != "mM" {
+		t.Errorf("unexpected sub-components %v", mm.Solutes)
+	}
+
+	// plates read what they write
+	bs, err := MarshalPlateJSON(r.Plate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2, err := ParsePlateJSON(ctx, bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := samePlate(r.Plate, r2.Plate); err != nil {
+		t.Error(err)
+	}
+	if r2.Plate.Barcode != r.Plate.Barcode {
+		t.Errorf("expecting barcode %q but found %q", r.Plate.Barcode, r2.Plate.Barcode)
+	}
+	if mm2 := r2.Plate.WellAt(wtype.MakeWellCoords("B1")).WContents; len(mm2.Solutes) != 2 {
+		t.Errorf("unexpected sub-components %v", mm2.Solutes)
+	}
+
+	if _, err := ParsePlate(ctx, []byte(`{"type": "pcrplate_skirted", "colour": "red"}`)); err == nil {
+		t.Error("expecting error for unknown key")
+	}
+}
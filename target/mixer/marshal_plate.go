@@ -3,13 +3,31 @@ package mixer
 import (
 	"bytes"
 	"encoding/csv"
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 )
 
 // MarshalPlateCSV writes a plate to a CSV file
 func MarshalPlateCSV(plate *wtype.LHPlate) ([]byte, error) {
+	var out bytes.Buffer
+	w := csv.NewWriter(&out)
+	err := w.WriteAll(plateRecords(plate))
+	return out.Bytes(), err
+}
+
+// MarshalPlateCSVGrid writes a plate to a CSV file in grid layout
+func MarshalPlateCSVGrid(plate *wtype.LHPlate) ([]byte, error) {
+	var out bytes.Buffer
+	w := csv.NewWriter(&out)
+	err := w.WriteAll(plateGrid(plate))
+	return out.Bytes(), err
+}
+
+// plateRecords returns the contents of a plate in list layout
+func plateRecords(plate *wtype.LHPlate) [][]string {
 	var records [][]string
 	records = append(records, []string{
 		plate.Type,
@@ -32,9 +50,38 @@ func MarshalPlateCSV(plate *wtype.LHPlate) ([]byte, error) {
 			comp.Cunit,
 		})
 	}
+	return records
+}
+
+// plateGrid returns the contents of a plate in grid layout
+func plateGrid(plate *wtype.LHPlate) [][]string {
+	records := [][]string{{plate.Type, plate.PlateName}}
+
+	columns := []string{""}
+	for x := 0; x < plate.WellsX(); x++ {
+		columns = append(columns, wtype.WellCoords{X: x}.ColNumString())
+	}
+	records = append(records, columns)
 
-	var out bytes.Buffer
-	w := csv.NewWriter(&out)
-	err := w.WriteAll(records)
-	return out.Bytes(), err
+	for y := 0; y < plate.WellsY(); y++ {
+		rec := []string{wtype.WellCoords{Y: y}.RowLettString()}
+		for x := 0; x < plate.WellsX(); x++ {
+			rec = append(rec, gridCell(plate.WellAt(wtype.WellCoords{X: x, Y: y})))
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+// gridCell returns the contents of a well as a grid layout cell
+func gridCell(well *wtype.LHWell) string {
+	if well == nil || well.Empty() {
+		return ""
+	}
+	comp := well.WContents
+	parts := []string{comp.CName, comp.TypeName(), fmt.Sprintf("%g%s", comp.Vol, comp.Vunit)}
+	if comp.Cunit != "" {
+		parts = append(parts, fmt.Sprintf("%g%s", comp.Conc, comp.Cunit))
+	}
+	return strings.Join(parts, ";")
 }
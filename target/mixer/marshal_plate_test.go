@@ -18,7 +18,10 @@ func makeTestPlate(ctx context.Context, in *wtype.LHPlate) *wtype.LHPlate {
 
 	out.PlateName = in.PlateName
 	for coord, well := range in.Wellcoords {
-		out.WellAt(wtype.MakeWellCoordsA1(coord)).Add(well.WContents)
+		w := out.WellAt(wtype.MakeWellCoordsA1(coord))
+		w.Add(well.WContents)
+		w.WContents.Conc = well.WContents.Conc
+		w.WContents.Cunit = well.WContents.Cunit
 	}
 	return out
 }
@@ -69,6 +69,7 @@ type lhreq struct {
 	*planner.LHRequest     // A request
 	*driver.LHProperties   // ... its state
 	*planner.Liquidhandler // ... and its associated planner
+	policies               *driver.PolicyResolver
 }
 
 func (a *Mixer) makeLhreq(ctx context.Context) (*lhreq, error) {
@@ -86,10 +87,15 @@ func (a *Mixer) makeLhreq(ctx context.Context) (*lhreq, error) {
 
 	/// TODO --> a.opt.Destination isn't being passed through, this makes MixInto redundant
 
-	if err := req.Policies.SetOption("USE_DRIVER_TIP_TRACKING", a.opt.UseDriverTipTracking); err != nil {
+	// component policies are added once we know the mixes
+
+	policies, err := driver.DefaultPolicyResolver()
+	if err != nil {
 		return nil, err
 	}
 
+	policies.AddLayer(driver.WorkflowPolicies, "workflow", a.opt.Policies)
+
 	prop := a.properties.Dup()
 	prop.Driver = a.properties.Driver
 	plan := planner.Init(prop)
@@ -108,7 +114,10 @@ func (a *Mixer) makeLhreq(ctx context.Context) (*lhreq, error) {
 
 	// TODO -- error check here to prevent nil values
 
-	if p := a.opt.InputPlateType; len(p) != 0 {
+	// searching for plate types takes precedence over listing them
+	if q := a.opt.InputPlateQuery; q != nil {
+		req.InputPlateQuery = *q
+	} else if p := a.opt.InputPlateType; len(p) != 0 {
 		for _, v := range p {
 			p, err := inventory.NewPlate(ctx, v)
 			if err != nil {
@@ -119,7 +128,9 @@ func (a *Mixer) makeLhreq(ctx context.Context) (*lhreq, error) {
 		}
 	}
 
-	if p := a.opt.OutputPlateType; len(p) != 0 {
+	if q := a.opt.OutputPlateQuery; q != nil {
+		req.OutputPlateQuery = *q
+	} else if p := a.opt.OutputPlateType; len(p) != 0 {
 		for _, v := range p {
 			p, err := inventory.NewPlate(ctx, v)
 			if err != nil {
@@ -141,8 +152,7 @@ func (a *Mixer) makeLhreq(ctx context.Context) (*lhreq, error) {
 
 	if p := a.opt.InputPlateData; len(p) != 0 {
 		for idx, bs := range p {
-			buf := bytes.NewBuffer(bs)
-			r, err := ParsePlateCSV(ctx, buf)
+			r, err := ParsePlate(ctx, bs)
 			if err != nil {
 				return nil, fmt.Errorf("cannot parse data at idx %d: %s", idx, err)
 			}
@@ -202,10 +212,19 @@ func (a *Mixer) makeLhreq(ctx context.Context) (*lhreq, error) {
 
 	req.Options.FixVolumes = a.opt.FixVolumes
 
+	// deck layout
+
+	req.Options.OptimizeLayout = a.opt.OptimizeLayout
+
+	// intermediate dilutions
+
+	req.Options.InsertDilutions = a.opt.InsertDilutions
+
 	return &lhreq{
 		LHRequest:     req,
 		LHProperties:  prop,
 		Liquidhandler: plan,
+		policies:      policies,
 	}, nil
 }
 
@@ -230,6 +249,12 @@ func (a *Mixer) Compile(ctx context.Context, nodes []ast.Node) ([]target.Inst, e
 	return target.SequentialOrder(mix), nil
 }
 
+// A namedOutputDriver is a driver which knows what its output file should
+// be called
+type namedOutputDriver interface {
+	OutputFileName() string
+}
+
 func (a *Mixer) saveFile(name string) ([]byte, error) {
 	data, status := a.driver.GetOutputFile()
 	if !status.OK {
@@ -301,7 +326,9 @@ func (a *Mixer) makeMix(ctx context.Context, mixes []*wtype.LHInstruction) (*tar
 	r.LHRequest.BlockID = getID(mixes)
 
 	for _, mix := range mixes {
-		if len(mix.Platetype) != 0 && !hasPlate(r.LHRequest.Output_platetypes, mix.Platetype, mix.PlateID) {
+		// the output plate types are the defaults for mixes which don't
+		// ask for one, so leave them empty if they are to be searched for
+		if a.opt.OutputPlateQuery == nil && len(mix.Platetype) != 0 && !hasPlate(r.LHRequest.Output_platetypes, mix.Platetype, mix.PlateID) {
 			p, err := inventory.NewPlate(ctx, mix.Platetype)
 			if err != nil {
 				return nil, err
@@ -310,6 +337,18 @@ func (a *Mixer) makeMix(ctx context.Context, mixes []*wtype.LHInstruction) (*tar
 			r.LHRequest.Output_platetypes = append(r.LHRequest.Output_platetypes, p)
 		}
 		r.LHRequest.Add_instruction(mix)
+
+		for _, cmp := range mix.Components {
+			if err := r.policies.AddComponentPolicies(cmp); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	r.LHRequest.Policies = r.policies.RuleSet()
+
+	if err := r.LHRequest.Policies.SetOption("USE_DRIVER_TIP_TRACKING", a.opt.UseDriverTipTracking); err != nil {
+		return nil, err
 	}
 
 	err = r.Liquidhandler.MakeSolutions(ctx, r.LHRequest)
@@ -321,7 +360,15 @@ func (a *Mixer) makeMix(ctx context.Context, mixes []*wtype.LHInstruction) (*tar
 		return nil, err
 	}
 
+	reservations, shortfalls, err := a.reserveStock(ctx, r.LHRequest.ID, r.LHProperties)
+	if err != nil {
+		return nil, err
+	}
+
 	name := a.opt.DriverOutputFileName
+	if n, ok := a.driver.(namedOutputDriver); ok && len(name) == 0 {
+		name = n.OutputFileName()
+	}
 	if len(name) == 0 {
 		// TODO: Desired filename not exposed in current driver interface, so pick
 		// a name. So far, at least Gilson software cares what the filename is, so
@@ -340,6 +387,8 @@ func (a *Mixer) makeMix(ctx context.Context, mixes []*wtype.LHInstruction) (*tar
 		Properties:      r.LHProperties,
 		FinalProperties: r.Liquidhandler.FinalProperties,
 		Final:           r.Liquidhandler.PlateIDMap(),
+		Reservations:    reservations,
+		Shortfalls:      shortfalls,
 		Files: target.Files{
 			Tarball: tarball,
 			Type:    a.FileType(),
@@ -377,6 +426,14 @@ func New(opt Opt, d driver.ExtendedLiquidhandlingDriver) (*Mixer, error) {
 	if len(opt.DriverSpecificWashPreferences) != 0 && p.CheckPreferenceCompatibility(opt.DriverSpecificWashPreferences) {
 		update(&p.Wash_preferences, opt.DriverSpecificWashPreferences)
 	}
+	cs, err := driver.ChannelScoreFuncFor(opt.ChannelScorer, opt.MaxTransferError)
+	if err != nil {
+		return nil, err
+	}
+	if cs != nil {
+		p.ChannelScorer = cs
+	}
+
 	p.Driver = d
 	return &Mixer{driver: d, properties: &p, opt: opt}, nil
 }
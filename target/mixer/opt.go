@@ -2,6 +2,7 @@ package mixer
 
 import (
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/inventory"
 	"github.com/antha-lang/antha/meta"
 )
 
@@ -32,6 +33,8 @@ type Opt struct {
 	ResidualVolumeWeight *float64
 	InputPlateType       []string
 	OutputPlateType      []string
+	InputPlateQuery      *inventory.PlateQuery // search for input plate types instead of using InputPlateType
+	OutputPlateQuery     *inventory.PlateQuery // search for output plate types instead of using OutputPlateType
 	TipType              []string
 	PlanningVersion      string
 
@@ -59,6 +62,25 @@ type Opt struct {
 	UseDriverTipTracking bool
 	LegacyVolume         bool // don't track volumes for intermediates
 	FixVolumes           bool // aim to revise requested volumes to service requirements
+	OptimizeLayout       bool // rearrange the deck to minimise head travel
+	InsertDilutions      bool // make intermediate dilutions for volumes too small to move, off unless asked for
+	OrderShortfalls      bool // order stock the plan needs more of than there is, rather than failing
+
+	// How to choose channels and tips: default, accuracy or errorbudget.
+	// The last takes the quickest combination whose expected error is no
+	// more than MaxTransferError percent
+	ChannelScorer    string
+	MaxTransferError float64
+
+	// Barcodes an operator has scanned at each deck position, checked
+	// against the plan before each mix is run where the driver cannot scan
+	// them itself
+	ScannedBarcodes map[string]string
+
+	// Liquid handling policies from the workflow configuration. These take
+	// precedence over built-in and site policies but not over those set on
+	// components
+	Policies *wtype.LHPolicyRuleSet
 }
 
 // Merge two configs together and return the result. Values in the argument
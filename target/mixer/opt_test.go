@@ -0,0 +1,17 @@
+package mixer
+
+import "testing"
+
+func TestMergeInsertDilutions(t *testing.T) {
+	if DefaultOpt.InsertDilutions {
+		t.Fatal("expected dilutions to be off by default")
+	}
+
+	if opt := DefaultOpt.Merge(&Opt{InsertDilutions: false}); opt.InsertDilutions {
+		t.Error("expected dilutions to stay off when not asked for")
+	}
+
+	if opt := DefaultOpt.Merge(&Opt{InsertDilutions: true}); !opt.InsertDilutions {
+		t.Error("expected dilutions when asked for")
+	}
+}
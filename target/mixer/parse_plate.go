@@ -1,12 +1,13 @@
 package mixer
 
 import (
+	"bytes"
 	"context"
 	"encoding/csv"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
-	"os"
 	"strconv"
 	"strings"
 
@@ -100,132 +101,273 @@ func ParsePlateCSV(ctx context.Context, inData io.Reader) (*ParsePlateResult, er
 //   <well1> , <component name1> , <component type1 ?> , <volume1 ?> , <volume unit1 ?>, <conc1 ?> , <conc unit1 ?>
 //   ...
 //
+// or, in grid layout, with a row of column numbers after the first line and
+// a row letter at the start of each following line:
+//
+//   <plate type> , <plate name ?>
+//                , 1      , 2      , ...
+//   A            , <cell> , <cell> , ...
+//   B            , <cell> , <cell> , ...
+//   ...
+//
+// where each non-empty cell is
+//
+//   <component name> ; <component type ?> ; <volume with unit ?> ; <conc with unit ?>
+//
 // TODO: refactor if/when Opt loses raw []byte and file as InputPlate options
 func ParsePlateCSVWithValidationConfig(ctx context.Context, inData io.Reader, vc ValidationConfig) (*ParsePlateResult, error) {
-	// Get returning "" if idx >= len(xs)
-	get := func(xs []string, idx int) string {
-		if len(xs) <= idx {
-			return ""
-		}
-		return strings.TrimSpace(xs[idx])
-	}
+	csvr := csv.NewReader(inData)
+	csvr.FieldsPerRecord = -1
 
-	parseUnit := func(value, unit, defaultUnit string) (float64, string, error) {
-		v, err := strconv.ParseFloat(value, 64)
-		if err != nil {
-			err = fmt.Errorf("cannot parse value %q: %s", value, err)
-		}
+	recs, err := csvr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
 
-		if len(unit) == 0 {
-			unit = defaultUnit
-			err = fmt.Errorf("cannot parse unit %q", unit)
-		}
+	return parsePlateRecords(ctx, recs, vc)
+}
 
-		return v, unit, err
+// field returns xs[idx] without surrounding whitespace or "" if idx >=
+// len(xs)
+func field(xs []string, idx int) string {
+	if len(xs) <= idx {
+		return ""
 	}
+	return strings.TrimSpace(xs[idx])
+}
 
-	csvr := csv.NewReader(inData)
-	csvr.FieldsPerRecord = -1
+// isGrid returns whether records are in grid layout, i.e., whether the
+// second record is a row of column numbers
+func isGrid(recs [][]string) bool {
+	return len(recs) > 1 && field(recs[1], 0) == "" && field(recs[1], 1) == "1"
+}
+
+// parsePlateRecords parses the records of a CSV file or the rows of a
+// spreadsheet in either list or grid layout
+func parsePlateRecords(ctx context.Context, recs [][]string, vc ValidationConfig) (*ParsePlateResult, error) {
+	if len(recs) == 0 || len(recs[0]) == 0 {
+		return nil, fmt.Errorf("empty file")
+	}
 
-	rec, err := csvr.Read()
+	plate, err := newInputPlate(ctx, field(recs[0], 0), field(recs[0], 1))
 	if err != nil {
 		return nil, err
 	}
 
-	if len(rec) == 0 {
-		return nil, fmt.Errorf("empty file")
+	var warnings []string
+	if isGrid(recs) {
+		warnings = parseGrid(plate, recs[1], recs[2:], vc)
+	} else {
+		warnings = parseList(plate, recs[1:], vc)
 	}
 
-	plateType := rec[0]
+	return &ParsePlateResult{
+		Plate:    plate,
+		Warnings: warnings,
+	}, nil
+}
+
+func newInputPlate(ctx context.Context, plateType, plateName string) (*wtype.LHPlate, error) {
 	plate, err := inventory.NewPlate(ctx, plateType)
 	if err != nil {
 		return nil, fmt.Errorf("cannot make plate %s: %s", plateType, err)
 	}
 
-	plate.PlateName = get(rec, 1)
+	plate.PlateName = plateName
 	if len(plate.PlateName) == 0 {
 		plate.PlateName = fmt.Sprint("input_plate_", plate.ID)
 	}
 
-	var warnings []string
-	for lineNo := 1; true; lineNo++ {
-		rec, err := csvr.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, err
-		}
-
-		wellField := get(rec, 0)
-		cname := get(rec, 1)
-		ctypeField := get(rec, 2)
+	return plate, nil
+}
 
-		well, err := validWellCoord(wellField)
+func parseList(plate *wtype.LHPlate, recs [][]string, vc ValidationConfig) []string {
+	var warnings []string
+	for idx, rec := range recs {
+		lineNo := idx + 1
+		ws, err := addRecord(plate, wellRecord{
+			Well:  field(rec, 0),
+			CName: field(rec, 1),
+			CType: field(rec, 2),
+			Vol:   field(rec, 3),
+			Vunit: field(rec, 4),
+			Conc:  field(rec, 5),
+			Cunit: field(rec, 6),
+		}, vc)
 		if err != nil {
 			warnings = append(warnings, fmt.Sprintf("line %d skipped: %s", lineNo, err))
 			continue
-		} else if err := validWell(well, plate); err != nil {
-			warnings = append(warnings, fmt.Sprintf("line %d skipped: %s", lineNo, err))
-			continue
 		}
-
-		if err := validName(cname, vc); err != nil {
-			warnings = append(warnings, fmt.Sprintf("line %d skipped: %s", lineNo, err))
-			continue
+		for _, w := range ws {
+			warnings = append(warnings, fmt.Sprintf("line %d: %s", lineNo, w))
 		}
+	}
+	return warnings
+}
 
-		ctype, err := wtype.LiquidTypeFromString(wtype.PolicyName(ctypeField))
-		if err != nil {
-			warnings = append(warnings, fmt.Sprintf("line %d: unknown component type %q, defaulting to %q: %s", lineNo, ctypeField, wtype.LiquidTypeName(ctype), err))
+func parseGrid(plate *wtype.LHPlate, columns []string, recs [][]string, vc ValidationConfig) []string {
+	var warnings []string
+	for _, rec := range recs {
+		row := field(rec, 0)
+		for col := 1; col < len(rec); col++ {
+			cell := field(rec, col)
+			if cell == "" {
+				continue
+			}
+
+			wellField := row + field(columns, col)
+			parts := strings.Split(cell, ";")
+			vol, vunit := splitQuantity(field(parts, 2))
+			conc, cunit := splitQuantity(field(parts, 3))
+
+			ws, err := addRecord(plate, wellRecord{
+				Well:  wellField,
+				CName: field(parts, 0),
+				CType: field(parts, 1),
+				Vol:   vol,
+				Vunit: vunit,
+				Conc:  conc,
+				Cunit: cunit,
+			}, vc)
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("well %s skipped: %s", wellField, err))
+				continue
+			}
+			for _, w := range ws {
+				warnings = append(warnings, fmt.Sprintf("well %s: %s", wellField, w))
+			}
 		}
+	}
+	return warnings
+}
+
+// splitQuantity splits a value with a unit, e.g., "50ul", into its value and
+// unit
+func splitQuantity(s string) (value, unit string) {
+	s = strings.TrimSpace(s)
+	idx := strings.IndexFunc(s, func(r rune) bool {
+		return !strings.ContainsRune("0123456789.+-", r)
+	})
+	if idx < 0 {
+		return s, ""
+	}
+	return strings.TrimSpace(s[:idx]), strings.TrimSpace(s[idx:])
+}
 
-		vol, vunit, err := parseUnit(get(rec, 3), get(rec, 4), "ul")
+// wellRecord is the contents of a well as written in a plate file
+type wellRecord struct {
+	Well  string
+	CName string
+	CType string
+	Vol   string
+	Vunit string
+	Conc  string
+	Cunit string
+
+	Solutes map[string]wtype.SoluteConcentration // sub-components
+}
+
+// addRecord adds the component described by a record to its well. An error
+// means the record was skipped, otherwise warnings describe the defaults
+// used for any fields that could not be parsed.
+func addRecord(plate *wtype.LHPlate, rec wellRecord, vc ValidationConfig) (warnings []string, err error) {
+	parseUnit := func(value, unit, defaultUnit string) (float64, string, error) {
+		v, err := strconv.ParseFloat(value, 64)
 		if err != nil {
-			warnings = append(warnings, fmt.Sprintf("line %d: unknown volume %q, defaulting to \"%f%s\": %s", lineNo, get(rec, 3)+get(rec, 4), vol, vunit, err))
+			err = fmt.Errorf("cannot parse value %q: %s", value, err)
 		}
-		volume := wunit.NewVolume(vol, vunit)
 
-		conc, cunit, err := parseUnit(get(rec, 5), get(rec, 6), "ng/ul")
-		if err != nil {
-			warnings = append(warnings, fmt.Sprintf("line %d: unknown concentration %q, defaulting to \"%f%s\": %s", lineNo, get(rec, 5)+get(rec, 6), conc, cunit, err))
+		if len(unit) == 0 {
+			unit = defaultUnit
+			err = fmt.Errorf("cannot parse unit %q", unit)
 		}
-		concentration := wunit.NewConcentration(conc, cunit)
 
-		// Make component
-		cmp := wtype.NewLHComponent()
+		return v, unit, err
+	}
+
+	well, err := validWellCoord(rec.Well)
+	if err != nil {
+		return nil, err
+	} else if err := validWell(well, plate); err != nil {
+		return nil, err
+	}
 
-		cmp.Vol = volume.RawValue()
-		cmp.Vunit = volume.Unit().PrefixedSymbol()
-		cmp.CName = cname
-		cmp.Type = ctype
+	if err := validName(rec.CName, vc); err != nil {
+		return nil, err
+	}
 
-		cmp.Conc = concentration.RawValue()
-		cmp.Cunit = concentration.Unit().PrefixedSymbol()
+	ctype, err := wtype.LiquidTypeFromString(wtype.PolicyName(rec.CType))
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("unknown component type %q, defaulting to %q: %s", rec.CType, wtype.LiquidTypeName(ctype), err))
+	}
 
-		plate.WellAt(well).Add(cmp)
+	vol, vunit, err := parseUnit(rec.Vol, rec.Vunit, "ul")
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("unknown volume %q, defaulting to \"%f%s\": %s", rec.Vol+rec.Vunit, vol, vunit, err))
 	}
+	volume := wunit.NewVolume(vol, vunit)
 
-	return &ParsePlateResult{
-		Plate:    plate,
-		Warnings: warnings,
-	}, nil
+	conc, cunit, err := parseUnit(rec.Conc, rec.Cunit, "ng/ul")
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("unknown concentration %q, defaulting to \"%f%s\": %s", rec.Conc+rec.Cunit, conc, cunit, err))
+	}
+	concentration := wunit.NewConcentration(conc, cunit)
+
+	// Make component
+	cmp := wtype.NewLHComponent()
+
+	cmp.Vol = volume.RawValue()
+	cmp.Vunit = volume.Unit().PrefixedSymbol()
+	cmp.CName = rec.CName
+	cmp.Type = ctype
+
+	cmp.Conc = concentration.RawValue()
+	cmp.Cunit = concentration.Unit().PrefixedSymbol()
+	cmp.Solutes = rec.Solutes
+
+	// mixing into a well does not keep the concentration of what is added
+	w := plate.WellAt(well)
+	wasEmpty := w.Empty()
+	w.Add(cmp)
+	if wasEmpty {
+		w.WContents.Conc = cmp.Conc
+		w.WContents.Cunit = cmp.Cunit
+	}
+
+	return warnings, nil
 }
 
-func parsePlateFile(ctx context.Context, filename string) (*ParsePlateResult, error) {
-	f, err := os.Open(filename)
+// ParsePlate parses a plate from the contents of a file in any of the forms
+// read by ParsePlateCSV, ParsePlateXLSX or ParsePlateJSON, recognising XLSX
+// and JSON by their contents.
+func ParsePlate(ctx context.Context, data []byte) (*ParsePlateResult, error) {
+	return ParsePlateWithValidationConfig(ctx, data, DefaultValidationConfig())
+}
+
+// ParsePlateWithValidationConfig is ParsePlate with a given validation
+// config
+func ParsePlateWithValidationConfig(ctx context.Context, data []byte, vc ValidationConfig) (*ParsePlateResult, error) {
+	switch {
+	case bytes.HasPrefix(data, xlsxMagic):
+		return ParsePlateXLSXWithValidationConfig(ctx, data, vc)
+	case bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")):
+		return ParsePlateJSONWithValidationConfig(ctx, data, vc)
+	default:
+		return ParsePlateCSVWithValidationConfig(ctx, bytes.NewReader(data), vc)
+	}
+}
 
+func parsePlateFile(ctx context.Context, filename string) (*ParsePlateResult, error) {
+	data, err := ioutil.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
 
-	defer f.Close() // nolint: errcheck
-
-	return ParsePlateCSV(ctx, f)
+	return ParsePlate(ctx, data)
 }
 
-// ParseInputPlateFile is convenience function for parsing a plate from file.
-// Will splat out warnings to stdout.
+// ParseInputPlateFile is convenience function for parsing a plate from file
+// in any of the forms ParsePlate reads. Will splat out warnings to stdout.
 func ParseInputPlateFile(ctx context.Context, filename string) (*wtype.LHPlate, error) {
 	r, err := parsePlateFile(ctx, filename)
 	if err != nil {
@@ -0,0 +1,48 @@
+package mixer
+
+import (
+	"context"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/inventory/stock"
+	driver "github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+// stockNeeded returns the volume of each component in the input wells the
+// planner allocated itself, which must come from stock rather than from
+// plates the user supplied
+func stockNeeded(props *driver.LHProperties) map[string]wunit.Volume {
+	needs := make(map[string]wunit.Volume)
+	for _, plate := range props.Plates {
+		for _, well := range plate.Wellcoords {
+			if !well.IsAutoallocated() || well.IsUserAllocated() || well.Empty() {
+				continue
+			}
+			c := well.WContents
+			v, ok := needs[c.CName]
+			if !ok {
+				v = wunit.NewVolume(0.0, "ul")
+			}
+			v.Add(c.Volume())
+			needs[c.CName] = v
+		}
+	}
+	return needs
+}
+
+// reserveStock reserves what the input wells in props need from the stock
+// ledger in ctx, if there is one. Unless shortfalls are to be ordered, a
+// shortfall is an error and nothing is reserved
+func (a *Mixer) reserveStock(ctx context.Context, holder string, props *driver.LHProperties) ([]*stock.Reservation, stock.Shortfalls, error) {
+	l, ok := stock.GetLedger(ctx)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	rs, short := l.Reserve(holder, stockNeeded(props))
+	if len(short) != 0 && !a.opt.OrderShortfalls {
+		l.Release(holder)
+		return nil, nil, short
+	}
+	return rs, short, nil
+}
@@ -0,0 +1,80 @@
+package mixer
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/stock"
+	"github.com/antha-lang/antha/inventory/testinventory"
+	driver "github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+func TestReserveStock(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	plate, err := inventory.NewPlate(ctx, "pcrplate_skirted")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fill := func(crds, cname string, vol float64, auto, user bool) {
+		c, err := inventory.NewComponent(ctx, cname)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Vol = vol
+		c.Vunit = "ul"
+		w := plate.Wellcoords[crds]
+		w.Add(c)
+		if auto {
+			w.DeclareAutoallocated()
+		}
+		if user {
+			w.SetUserAllocated()
+		}
+	}
+
+	fill("A1", "water", 150, true, false)
+	fill("B1", "water", 100, true, false)
+	fill("C1", "water", 100, false, true)
+	fill("A2", "PBS", 80, true, false)
+
+	props := &driver.LHProperties{
+		Plates: map[string]*wtype.LHPlate{"position_4": plate},
+	}
+
+	needs := stockNeeded(props)
+	if v := needs["water"].ConvertToString("ul"); math.Abs(v-250) > 1e-6 {
+		t.Errorf("expecting 250ul of water needed but found %gul", v)
+	}
+	if n := len(needs); n != 2 {
+		t.Errorf("expecting 2 components needed but found %v", needs)
+	}
+
+	water := wunit.NewVolume(200, "ul")
+	l, err := stock.NewLedger(&stock.Lot{ID: "w1", Component: "water", Volume: &water})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = stock.NewContext(ctx, l)
+
+	a := &Mixer{}
+	if _, _, err := a.reserveStock(ctx, "plan", props); err == nil {
+		t.Error("expecting error for too little water")
+	} else if n := len(l.Reservations("")); n != 0 {
+		t.Errorf("expecting nothing reserved but found %d reservations", n)
+	}
+
+	a.opt.OrderShortfalls = true
+	rs, short, err := a.reserveStock(ctx, "plan", props)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rs) != 1 || len(short) != 1 || short[0].Component != "water" {
+		t.Errorf("expecting all of lot w1 reserved and water short but found %v and %v", rs, short)
+	}
+}
@@ -0,0 +1,91 @@
+package mixer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/spreadsheet"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/tealeg/xlsx"
+)
+
+// xlsxMagic begins every XLSX file, which is a zip archive
+var xlsxMagic = []byte("PK\x03\x04")
+
+// ParsePlateXLSX parses a plate from the first sheet of an XLSX file
+func ParsePlateXLSX(ctx context.Context, data []byte) (*ParsePlateResult, error) {
+	return ParsePlateXLSXWithValidationConfig(ctx, data, DefaultValidationConfig())
+}
+
+// ParsePlateXLSXWithValidationConfig parses a plate from the first sheet of
+// an XLSX file. The sheet is laid out, one cell per field, in either the
+// list or grid layout of ParsePlateCSVWithValidationConfig.
+func ParsePlateXLSXWithValidationConfig(ctx context.Context, data []byte, vc ValidationConfig) (*ParsePlateResult, error) {
+	file, err := spreadsheet.OpenBinary(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Sheets) == 0 {
+		return nil, fmt.Errorf("empty file")
+	}
+
+	return parsePlateRecords(ctx, sheetRecords(spreadsheet.Sheet(file, 0)), vc)
+}
+
+// sheetRecords returns the values of the cells of a sheet row by row
+func sheetRecords(sheet *xlsx.Sheet) [][]string {
+	var recs [][]string
+	for _, row := range sheet.Rows {
+		var rec []string
+		if row != nil {
+			for _, cell := range row.Cells {
+				var value string
+				if cell != nil {
+					value = cell.String()
+				}
+				rec = append(rec, value)
+			}
+		}
+		recs = append(recs, rec)
+	}
+	return recs
+}
+
+// MarshalPlateXLSX writes a plate to an XLSX file in list layout
+func MarshalPlateXLSX(plate *wtype.LHPlate) ([]byte, error) {
+	return marshalXLSX(plateRecords(plate))
+}
+
+// MarshalPlateXLSXGrid writes a plate to an XLSX file in grid layout
+func MarshalPlateXLSXGrid(plate *wtype.LHPlate) ([]byte, error) {
+	return marshalXLSX(plateGrid(plate))
+}
+
+func marshalXLSX(recs [][]string) ([]byte, error) {
+	file, err := recordsFile(recs)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	err = file.Write(&out)
+	return out.Bytes(), err
+}
+
+// recordsFile returns an XLSX file with one sheet holding the records
+func recordsFile(recs [][]string) (*xlsx.File, error) {
+	file := xlsx.NewFile()
+	sheet, err := file.AddSheet("Plate")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rec := range recs {
+		row := sheet.AddRow()
+		for _, value := range rec {
+			row.AddCell().SetString(value)
+		}
+	}
+	return file, nil
+}
@@ -0,0 +1,138 @@
+package mixer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/inventory/testinventory"
+)
+
+func makeConcentrationTestPlate(ctx context.Context) *wtype.LHPlate {
+	return makeTestPlate(ctx, &wtype.LHPlate{
+		PlateName: "Input_plate_1",
+		Type:      "pcrplate_skirted",
+		Wellcoords: map[string]*wtype.LHWell{
+			"A1": &wtype.LHWell{
+				WContents: &wtype.LHComponent{
+					CName: "water",
+					Type:  wtype.LTWater,
+					Vol:   50.0,
+					Vunit: "ul",
+					Conc:  0,
+					Cunit: "g/l",
+				},
+			},
+			"C12": &wtype.LHWell{
+				WContents: &wtype.LHComponent{
+					CName: "dna",
+					Type:  wtype.LTDNA,
+					Vol:   20.5,
+					Vunit: "ul",
+					Conc:  5,
+					Cunit: "mg/l", // ng/ul
+				},
+			},
+		},
+	})
+}
+
+func TestXLSXPlate(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	plate := makeConcentrationTestPlate(ctx)
+
+	for name, recs := range map[string][][]string{
+		"list": plateRecords(plate),
+		"grid": plateGrid(plate),
+	} {
+		file, err := recordsFile(recs)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := parsePlateRecords(ctx, sheetRecords(file.Sheets[0]), DefaultValidationConfig())
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+		if err := samePlate(plate, r.Plate); err != nil {
+			t.Errorf("%s: %s", name, err)
+		}
+		if len(r.Warnings) != 0 {
+			t.Errorf("%s: found warnings: %s", name, r.Warnings)
+		}
+		if r.Plate.PlateName != plate.PlateName {
+			t.Errorf("%s: expecting plate name %q but found %q", name, plate.PlateName, r.Plate.PlateName)
+		}
+	}
+}
+
+func TestParsePlateGrid(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	file := []byte(
+		`
+pcrplate_skirted,
+,1,2,3
+A,water;water;50ul;0g/l,,
+B,,tea+milk,
+C,,,dna;dna;20.5ul;5ng/ul
+I,water,,
+`)
+	r, err := ParsePlate(ctx, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &wtype.LHPlate{
+		Type: "pcrplate_skirted",
+		Wellcoords: map[string]*wtype.LHWell{
+			"A1": &wtype.LHWell{
+				WContents: &wtype.LHComponent{
+					CName: "water",
+					Type:  wtype.LTWater,
+					Vol:   50.0,
+					Vunit: "ul",
+				},
+			},
+			"C3": &wtype.LHWell{
+				WContents: &wtype.LHComponent{
+					CName: "dna",
+					Type:  wtype.LTDNA,
+					Vol:   20.5,
+					Vunit: "ul",
+					Conc:  5,
+					Cunit: "mg/l", // ng/ul
+				},
+			},
+		},
+	}
+	if err := samePlate(expected, r.Plate); err != nil {
+		t.Error(err)
+	}
+
+	// tea+milk has an invalid name and there is no row I on a 96 well plate
+	skipped := 0
+	for _, w := range r.Warnings {
+		if strings.Contains(w, "skipped") {
+			skipped++
+		}
+	}
+	if skipped != 2 {
+		t.Errorf("expecting 2 wells skipped, found warnings: %s", r.Warnings)
+	}
+
+	bs, err := MarshalPlateCSVGrid(r.Plate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2, err := ParsePlate(ctx, bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := samePlate(r.Plate, r2.Plate); err != nil {
+		t.Error(err)
+	}
+}